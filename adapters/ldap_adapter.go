@@ -0,0 +1,111 @@
+package adapters
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"crypto/tls"
+	"crypto/x509"
+	"github.com/go-ldap/ldap/v3"
+	pkgerrors "github.com/pkg/errors"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const ldapTimeout = time.Second * 10
+
+// LdapAdapter 서비스 계정으로 멤버를 검색한 뒤 멤버의 DN 과 비밀번호로 바인드하여 인증한다.
+type LdapAdapter struct {
+}
+
+func (adapter LdapAdapter) Authenticate(setting dtos.LdapLoginSetting, username, password string) (dtos.LdapMember, error) {
+	// 비밀번호 없이 바인드하면 인증되지 않은 바인드(RFC 4513 5.1.2)로 성공할 수 있으므로 거부한다.
+	if len(username) == 0 || len(password) == 0 {
+		return dtos.LdapMember{}, errors.ErrAuthentication
+	}
+
+	ldapConn, err := adapter.connect(setting)
+	if err != nil {
+		return dtos.LdapMember{}, err
+	}
+
+	defer ldapConn.Close()
+
+	if len(setting.BindDn) > 0 {
+		if err := ldapConn.Bind(setting.BindDn, setting.BindPassword); err != nil {
+			return dtos.LdapMember{}, pkgerrors.Wrap(err, "ldap service account bind error")
+		}
+	}
+
+	searchRequest := ldap.NewSearchRequest(setting.SearchBase, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		2, int(ldapTimeout.Seconds()), false, setting.GetUserFilter(username),
+		[]string{setting.GetNameAttribute(), setting.GetEmailAttribute(), setting.GetGroupsAttribute()}, nil)
+
+	searchResult, err := ldapConn.Search(searchRequest)
+	if err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultSizeLimitExceeded) {
+			return dtos.LdapMember{}, errors.ErrAuthentication
+		}
+		return dtos.LdapMember{}, pkgerrors.Wrap(err, "ldap search error")
+	}
+
+	// 검색 결과가 없거나 여러 멤버가 검색되는 경우 어떤 멤버인지 알 수 없다.
+	if len(searchResult.Entries) != 1 {
+		return dtos.LdapMember{}, errors.ErrAuthentication
+	}
+
+	entry := searchResult.Entries[0]
+	if err := ldapConn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.ErrorNetwork) || err.Error() == "ldap: connection timed out" {
+			return dtos.LdapMember{}, pkgerrors.Wrap(err, "ldap network error")
+		}
+
+		return dtos.LdapMember{}, errors.ErrAuthentication
+	}
+
+	return dtos.LdapMember{
+		UserId: strings.ToLower(username),
+		Dn:     entry.DN,
+		Name:   entry.GetAttributeValue(setting.GetNameAttribute()),
+		Email:  entry.GetAttributeValue(setting.GetEmailAttribute()),
+		Groups: entry.GetAttributeValues(setting.GetGroupsAttribute()),
+	}, nil
+}
+
+func (LdapAdapter) connect(setting dtos.LdapLoginSetting) (*ldap.Conn, error) {
+	ldapUrl, err := url.Parse(setting.Url)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "ldap url error")
+	}
+
+	tlsConfig := &tls.Config{
+		ServerName: ldapUrl.Hostname(),
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if len(setting.CaCertificate) > 0 {
+		certPool := x509.NewCertPool()
+		if !certPool.AppendCertsFromPEM([]byte(setting.CaCertificate)) {
+			return nil, pkgerrors.New("ldap ca certificate error")
+		}
+		tlsConfig.RootCAs = certPool
+	}
+
+	ldapConn, err := ldap.DialURL(setting.Url, ldap.DialWithTLSConfig(tlsConfig),
+		ldap.DialWithDialer(&net.Dialer{Timeout: ldapTimeout}))
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "ldap conn error")
+	}
+
+	ldapConn.SetTimeout(ldapTimeout)
+
+	if setting.StartTls {
+		if err := ldapConn.StartTLS(tlsConfig); err != nil {
+			ldapConn.Close()
+			return nil, pkgerrors.Wrap(err, "ldap start tls error")
+		}
+	}
+
+	return ldapConn, nil
+}
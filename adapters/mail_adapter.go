@@ -0,0 +1,60 @@
+package adapters
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"fmt"
+	pkgerrors "github.com/pkg/errors"
+	"mime"
+	"net/smtp"
+	"strings"
+)
+
+// MailSender 메일 발송 방식을 교체할 수 있도록(테스트에서는 가짜 발송기 사용) 인터페이스로 분리한다.
+type MailSender interface {
+	Send(mail dtos.Mail) error
+}
+
+var mailSender MailSender = SmtpMailSender{}
+
+func MailAdapter() MailSender {
+	return mailSender
+}
+
+func SetMailSender(sender MailSender) {
+	mailSender = sender
+}
+
+// SmtpMailSender 설정 파일(Mail)의 SMTP 서버로 메일을 발송한다.
+type SmtpMailSender struct {
+}
+
+func (SmtpMailSender) Send(mail dtos.Mail) error {
+	mailConfig := config.Config.Mail
+	if len(mailConfig.SmtpHost) == 0 {
+		return errors.ErrMailNotConfigured
+	}
+
+	var auth smtp.Auth
+	if len(mailConfig.Username) > 0 {
+		auth = smtp.PlainAuth("", mailConfig.Username, mailConfig.Password, mailConfig.SmtpHost)
+	}
+
+	message := strings.Join([]string{
+		fmt.Sprintf("From: %s", mailConfig.From),
+		fmt.Sprintf("To: %s", strings.Join(mail.To, ", ")),
+		fmt.Sprintf("Subject: %s", mime.QEncoding.Encode("UTF-8", mail.Subject)),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+		"",
+		mail.Body,
+	}, "\r\n")
+
+	addr := fmt.Sprintf("%s:%d", mailConfig.SmtpHost, mailConfig.SmtpPort)
+	if err := smtp.SendMail(addr, auth, mailConfig.From, mail.To, []byte(message)); err != nil {
+		return pkgerrors.Wrap(err, "send mail error")
+	}
+
+	return nil
+}
@@ -0,0 +1,236 @@
+package adapters
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/security"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"github.com/golang-jwt/jwt"
+	pkgerrors "github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// OidcAdapter OpenID Connect 인가 코드 흐름(PKCE)으로 IdP 에 인증한다.
+// https://openid.net/specs/openid-connect-core-1_0.html
+type OidcAdapter struct {
+}
+
+// https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderMetadata
+type oidcProviderMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JwksUri               string `json:"jwks_uri"`
+}
+
+var oidcHttpClient = &http.Client{Timeout: time.Second * 10}
+
+func (adapter OidcAdapter) GetAuthorizationUri(setting dtos.OidcLoginSetting, state, nonce, codeChallenge string) (string, error) {
+	metadata, err := adapter.discover(setting.Issuer)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", setting.ClientId)
+	query.Set("redirect_uri", setting.RedirectUri)
+	query.Set("scope", strings.Join(setting.GetScopes(), " "))
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(metadata.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+
+	return metadata.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+func (adapter OidcAdapter) Authenticate(code, codeVerifier, nonce string, setting dtos.OidcLoginSetting) (dtos.OidcMember, error) {
+	metadata, err := adapter.discover(setting.Issuer)
+	if err != nil {
+		return dtos.OidcMember{}, err
+	}
+
+	idToken, err := adapter.exchangeCode(metadata, code, codeVerifier, setting)
+	if err != nil {
+		return dtos.OidcMember{}, err
+	}
+
+	claims, err := adapter.validateIdToken(metadata, idToken, nonce, setting)
+	if err != nil {
+		return dtos.OidcMember{}, err
+	}
+
+	oidcMember := dtos.OidcMember{
+		Issuer: metadata.Issuer,
+	}
+	oidcMember.Subject, _ = claims["sub"].(string)
+	oidcMember.Name, _ = claims[setting.GetNameClaim()].(string)
+	oidcMember.Email, _ = claims[setting.GetEmailClaim()].(string)
+	oidcMember.Picture, _ = claims[setting.GetPictureClaim()].(string)
+	oidcMember.EmailVerified, _ = claims["email_verified"].(bool)
+
+	return oidcMember, nil
+}
+
+func (adapter OidcAdapter) discover(issuer string) (oidcProviderMetadata, error) {
+	var metadata oidcProviderMetadata
+	discoveryUri := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	if err := adapter.getJson(discoveryUri, &metadata); err != nil {
+		return metadata, pkgerrors.Wrap(err, "oidc discovery error")
+	}
+
+	// 디스커버리 문서의 issuer 는 설정한 issuer 와 같아야 한다(IdP 위장 방지).
+	if strings.TrimSuffix(metadata.Issuer, "/") != strings.TrimSuffix(issuer, "/") {
+		return metadata, pkgerrors.New(fmt.Sprintf("oidc discovery error: issuer mismatch(%v)", metadata.Issuer))
+	}
+
+	if len(metadata.AuthorizationEndpoint) == 0 || len(metadata.TokenEndpoint) == 0 || len(metadata.JwksUri) == 0 {
+		return metadata, pkgerrors.New("oidc discovery error: missing endpoint")
+	}
+
+	return metadata, nil
+}
+
+func (adapter OidcAdapter) exchangeCode(metadata oidcProviderMetadata, code, codeVerifier string, setting dtos.OidcLoginSetting) (string, error) {
+	data := url.Values{}
+	data.Set("grant_type", "authorization_code")
+	data.Set("code", code)
+	data.Set("redirect_uri", setting.RedirectUri)
+	data.Set("client_id", setting.ClientId)
+	data.Set("code_verifier", codeVerifier)
+	if len(setting.ClientSecret) > 0 {
+		data.Set("client_secret", setting.ClientSecret)
+	}
+
+	r, err := http.NewRequest(http.MethodPost, metadata.TokenEndpoint, strings.NewReader(data.Encode()))
+	if err != nil {
+		return "", pkgerrors.Wrap(err, "oidc token error")
+	}
+	r.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Add("Accept", "application/json")
+
+	res, err := oidcHttpClient.Do(r)
+	if err != nil {
+		return "", pkgerrors.Wrap(err, "oidc token error")
+	}
+
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return "", pkgerrors.Wrap(err, "oidc token error")
+	}
+
+	if res.StatusCode != http.StatusOK {
+		// 유효하지 않은(이미 사용했거나 code_verifier 가 맞지 않는) 인가 코드
+		log.Error(fmt.Sprintf("oidc token error: %v %v", res.StatusCode, string(body)))
+		return "", errors.ErrAuthentication
+	}
+
+	responseBody := map[string]interface{}{}
+	if err = json.Unmarshal(body, &responseBody); err != nil {
+		return "", pkgerrors.Wrap(err, "oidc token error")
+	}
+
+	idToken, ok := responseBody["id_token"].(string)
+	if !ok || len(idToken) == 0 {
+		return "", errors.ErrInvalidOidcIdToken
+	}
+
+	return idToken, nil
+}
+
+// validateIdToken https://openid.net/specs/openid-connect-core-1_0.html#IDTokenValidation
+func (adapter OidcAdapter) validateIdToken(metadata oidcProviderMetadata, idToken, nonce string, setting dtos.OidcLoginSetting) (jwt.MapClaims, error) {
+	var jwks security.Jwks
+	if err := adapter.getJson(metadata.JwksUri, &jwks); err != nil {
+		return nil, pkgerrors.Wrap(err, "oidc jwks error")
+	}
+
+	claims := jwt.MapClaims{}
+	token, err := jwt.ParseWithClaims(idToken, claims, func(token *jwt.Token) (interface{}, error) {
+		// 대칭 키(HS256)와 none 알고리즘은 허용하지 않는다.
+		switch token.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS, *jwt.SigningMethodECDSA, *jwt.SigningMethodEd25519:
+		default:
+			return nil, pkgerrors.New(fmt.Sprintf("not supported id token signing method: %v", token.Method.Alg()))
+		}
+
+		keyId, _ := token.Header["kid"].(string)
+		for _, jwk := range jwks.Keys {
+			if len(keyId) > 0 && jwk.Kid != keyId {
+				continue
+			}
+
+			if jwk.Use == "enc" || (len(jwk.Alg) > 0 && jwk.Alg != token.Method.Alg()) {
+				continue
+			}
+
+			return jwk.GetPublicKey()
+		}
+
+		return nil, pkgerrors.New(fmt.Sprintf("id token signing key not found: %v", keyId))
+	})
+	if err != nil || !token.Valid {
+		log.Error(fmt.Sprintf("oidc id token error: %v", err))
+		return nil, errors.ErrInvalidOidcIdToken
+	}
+
+	if claims["iss"] != metadata.Issuer {
+		return nil, errors.ErrInvalidOidcIdToken
+	}
+
+	if !claims.VerifyAudience(setting.ClientId, true) {
+		return nil, errors.ErrInvalidOidcIdToken
+	}
+
+	// aud 가 여러 개인 경우 azp 가 client_id 여야 한다.
+	if azp, ok := claims["azp"]; ok && azp != setting.ClientId {
+		return nil, errors.ErrInvalidOidcIdToken
+	}
+
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, errors.ErrInvalidOidcIdToken
+	}
+
+	tokenNonce, _ := claims["nonce"].(string)
+	if subtle.ConstantTimeCompare([]byte(tokenNonce), []byte(nonce)) != 1 {
+		return nil, errors.ErrInvalidOidcIdToken
+	}
+
+	if subject, _ := claims["sub"].(string); len(subject) == 0 {
+		return nil, errors.ErrInvalidOidcIdToken
+	}
+
+	return claims, nil
+}
+
+func (OidcAdapter) getJson(uri string, result interface{}) error {
+	res, err := oidcHttpClient.Get(uri)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return pkgerrors.New(fmt.Sprintf("unexpected status code: %v", res.StatusCode))
+	}
+
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(body, result)
+}
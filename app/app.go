@@ -2,7 +2,10 @@ package app
 
 import (
 	"better-admin-backend-service/app/db"
+	"better-admin-backend-service/app/middlewares"
 	"better-admin-backend-service/app/routes"
+	"better-admin-backend-service/http/scim"
+	"better-admin-backend-service/http/wellknown"
 	"better-admin-backend-service/http/ws"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
@@ -44,11 +47,18 @@ func (a *App) SetUp() error {
 
 	a.gin.GET("/ws/:id", ws.WebSocketHandler(a.webSocketUpgrader))
 
+	// 다른 서비스, 내부 앱에서 토큰을 검증할 수 있도록 서명 공개키와 OpenID Connect 디스커버리 문서를 제공한다.
+	a.gin.GET("/.well-known/jwks.json", wellknown.JwksHandler())
+	a.gin.GET("/.well-known/openid-configuration", wellknown.OpenIdConfigurationHandler())
+
 	// Liveness Probe
 	a.gin.GET("/health", func(c *gin.Context) {
 		c.Status(http.StatusNoContent)
 	})
 
+	// SCIM API 는 멤버의 JWT 가 아닌 SCIM 토큰으로 인증하므로 REST API 의 인증/인가 미들웨어를 적용하지 않는다.
+	scim.Router{}.MapRoutes(a.gin.Group("/scim/v2", middlewares.ErrorHandler, middlewares.GORMDb(a.gormDB), middlewares.ScimToken()))
+
 	a.addGinMiddlewares()
 	a.router.MapRoutes(a.gin.Group("/api"))
 	return nil
@@ -1,6 +1,7 @@
 package app
 
 import (
+	authDomain "better-admin-backend-service/auth/domain"
 	memberDomain "better-admin-backend-service/member/domain"
 	organizationDomain "better-admin-backend-service/organization/domain"
 	rbacDomain "better-admin-backend-service/rbac/domain"
@@ -15,7 +16,15 @@ func (a *App) migrateDatabase() error {
 	// 테이블 생성
 	if err := a.gormDB.AutoMigrate(&memberDomain.MemberEntity{}, &siteDomain.SettingEntity{}, &rbacDomain.PermissionEntity{},
 		&rbacDomain.RoleEntity{}, &organizationDomain.OrganizationEntity{},
-		&webhookDomain.WebHookEntity{}, &webhookDomain.WebHookMessageEntity{}); err != nil {
+		&webhookDomain.WebHookEntity{}, &webhookDomain.WebHookMessageEntity{},
+		&authDomain.RefreshTokenEntity{}, &authDomain.RevokedAccessTokenEntity{},
+		&memberDomain.WebAuthnCredentialEntity{}, &authDomain.WebAuthnSessionEntity{},
+		&authDomain.OidcAuthSessionEntity{}, &authDomain.LoginFailureEntity{}, &memberDomain.MemberAccessLogEntity{},
+		&authDomain.SessionEntity{}, &authDomain.PersonalAccessTokenEntity{}, &authDomain.ImpersonationEntity{},
+		&memberDomain.MemberInvitationEntity{}, &memberDomain.EmailVerificationTokenEntity{},
+		&memberDomain.MemberIdentityEntity{}, &memberDomain.MemberIdentityLinkTokenEntity{}, &authDomain.ScimTokenEntity{},
+		&authDomain.OAuthClientEntity{}, &authDomain.OAuthAuthorizationCodeEntity{}, &authDomain.OAuthConsentEntity{},
+		&authDomain.OAuthAccessTokenEntity{}, &authDomain.LoginCodeEntity{}, &memberDomain.MemberStatusHistoryEntity{}); err != nil {
 		return err
 	}
 
@@ -177,6 +186,13 @@ func (a *App) migrateDatabase() error {
 		}
 	}
 
+	// 처음 설치한 뒤에 추가된 사전 정의 권한은 이미 설치된 경우에도 추가한다.
+	for _, permission := range addedPreDefinedPermissions {
+		if err := a.addPreDefinedPermission(permission); err != nil {
+			return err
+		}
+	}
+
 	// siteadm 계정 만들기
 	var signId string
 	a.gormDB.Raw("SELECT sign_id FROM members WHERE sign_id = ?", "siteadm").Scan(&signId)
@@ -195,3 +211,61 @@ func (a *App) migrateDatabase() error {
 
 	return nil
 }
+
+type preDefinedPermission struct {
+	name        string
+	description string
+	// 시스템 관리자 역할에 할당한다.
+	systemAdmin bool
+}
+
+var addedPreDefinedPermissions = []preDefinedPermission{
+	{name: "member.impersonate", description: "멤버 대리 로그인", systemAdmin: true},
+	{name: "member.merge", description: "멤버 병합", systemAdmin: true},
+	{name: "member-invitation.all", description: "멤버 초대에 관한 모든 권한", systemAdmin: true},
+	{name: "member-invitation.create", description: "멤버 초대"},
+	{name: "member-invitation.read", description: "멤버 초대 조회"},
+	{name: "member-invitation.delete", description: "멤버 초대 취소"},
+	{name: "oauth-client.all", description: "내부 앱에 관한 모든 권한", systemAdmin: true},
+	{name: "oauth-client.create", description: "내부 앱 등록"},
+	{name: "oauth-client.read", description: "내부 앱 조회"},
+	{name: "oauth-client.update", description: "내부 앱 수정"},
+	{name: "oauth-client.delete", description: "내부 앱 삭제"},
+}
+
+// addPreDefinedPermission 권한이 없는 경우에만 추가하므로 관리자가 시스템 관리자 역할에서 뺀 권한은 다시 할당하지 않는다.
+func (a *App) addPreDefinedPermission(permission preDefinedPermission) error {
+	var permissionCount int64
+	if err := a.gormDB.Raw("SELECT count(*) FROM permissions WHERE name = ?", permission.name).Scan(&permissionCount).Error; err != nil {
+		return err
+	}
+
+	if permissionCount > 0 {
+		return nil
+	}
+
+	if err := a.gormDB.Exec("INSERT INTO permissions(type, name, description, created_at, updated_at, created_by, updated_by) values(?, ?, ?, ?, ?, 1, 1)",
+		"pre-define", permission.name, permission.description, time.Now(), time.Now()).Error; err != nil {
+		return err
+	}
+
+	if permission.systemAdmin == false {
+		return nil
+	}
+
+	var permissionId uint
+	if err := a.gormDB.Raw("SELECT id FROM permissions WHERE name = ?", permission.name).Scan(&permissionId).Error; err != nil {
+		return err
+	}
+
+	var roleCount int64
+	if err := a.gormDB.Raw("SELECT count(*) FROM roles WHERE id = 1 AND type = 'pre-define'").Scan(&roleCount).Error; err != nil {
+		return err
+	}
+
+	if roleCount == 0 {
+		return nil
+	}
+
+	return a.gormDB.Exec("INSERT INTO role_permissions(role_entity_id, permission_entity_id) values(1, ?)", permissionId).Error
+}
@@ -0,0 +1,113 @@
+package app
+
+import (
+	"better-admin-backend-service/constants"
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"os"
+	"testing"
+)
+
+func newMigrationTestApp(t *testing.T) *App {
+	gormDB, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 메모리 DB 는 커넥션마다 따로 만들어지므로 하나의 커넥션만 사용한다.
+	sqlDB, err := gormDB.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	return &App{gormDB: gormDB}
+}
+
+func getPolicyPermissions(t *testing.T) []string {
+	data, err := os.ReadFile("../authorization/rest/data.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var policy struct {
+		Api map[string]map[string][]string `json:"api"`
+	}
+	if err := json.Unmarshal(data, &policy); err != nil {
+		t.Fatal(err)
+	}
+
+	// 인증된 모든 멤버, 본인 정보 관리 범위는 역할로 부여하지 않는다. web-hook-note.create 는 웹훅 토큰에만 부여한다.
+	excluded := map[string]bool{
+		"all-authenticated-members":         true,
+		constants.PermissionScopeMemberSelf: true,
+		"web-hook-note.create":              true,
+	}
+
+	permissions := make([]string, 0)
+	exists := map[string]bool{}
+	for _, methods := range policy.Api {
+		for _, requiredPermissions := range methods {
+			for _, permission := range requiredPermissions {
+				if excluded[permission] || exists[permission] {
+					continue
+				}
+				exists[permission] = true
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+	return permissions
+}
+
+func TestApp_migrateDatabase_권한_정책의_모든_권한이_있다(t *testing.T) {
+	// given
+	app := newMigrationTestApp(t)
+
+	// when
+	err := app.migrateDatabase()
+
+	// then
+	assert.NoError(t, err)
+	for _, permission := range getPolicyPermissions(t) {
+		var count int64
+		app.gormDB.Raw("SELECT count(*) FROM permissions WHERE name = ?", permission).Scan(&count)
+		assert.Equal(t, int64(1), count, permission)
+	}
+
+	var systemAdminPermissions []string
+	app.gormDB.Raw("SELECT p.name FROM role_permissions rp JOIN permissions p ON p.id = rp.permission_entity_id WHERE rp.role_entity_id = 1").
+		Scan(&systemAdminPermissions)
+	assert.Subset(t, systemAdminPermissions, []string{"member.impersonate", "member.merge", "member-invitation.all", "oauth-client.all"})
+}
+
+func TestApp_migrateDatabase_이미_설치된_경우(t *testing.T) {
+	// given
+	// 대리 로그인 권한이 추가되기 전에 설치된 경우
+	app := newMigrationTestApp(t)
+	if err := app.migrateDatabase(); err != nil {
+		t.Fatal(err)
+	}
+	app.gormDB.Exec("DELETE FROM role_permissions WHERE permission_entity_id IN (SELECT id FROM permissions WHERE name = ?)", "member.impersonate")
+	app.gormDB.Exec("DELETE FROM permissions WHERE name = ?", "member.impersonate")
+
+	// when
+	err := app.migrateDatabase()
+
+	// then
+	assert.NoError(t, err)
+
+	var count int64
+	app.gormDB.Raw("SELECT count(*) FROM permissions WHERE name = ?", "member.impersonate").Scan(&count)
+	assert.Equal(t, int64(1), count)
+
+	app.gormDB.Raw("SELECT count(*) FROM role_permissions rp JOIN permissions p ON p.id = rp.permission_entity_id WHERE rp.role_entity_id = 1 AND p.name = ?",
+		"member.impersonate").Scan(&count)
+	assert.Equal(t, int64(1), count)
+
+	// 다시 실행해도 권한이 중복되지 않는다.
+	app.gormDB.Raw("SELECT count(*) FROM permissions WHERE name = ?", "member.merge").Scan(&count)
+	assert.Equal(t, int64(1), count)
+}
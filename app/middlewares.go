@@ -2,6 +2,7 @@ package app
 
 import (
 	"better-admin-backend-service/app/middlewares"
+	"better-admin-backend-service/helpers"
 	xss "github.com/bettercode-oss/gin-middleware-xss"
 	"github.com/gin-contrib/cors"
 	"net/http"
@@ -14,21 +15,31 @@ func (a *App) addGinMiddlewares() {
 	a.gin.Use(cors.New(a.newCorsConfig()))
 	a.gin.Use(middlewares.NoRoute(a.gin))
 	a.gin.Use(middlewares.ErrorHandler)
-	a.gin.Use(middlewares.JwtToken())
-	a.gin.Use(middlewares.RestAuthorizer(a.regoQuery))
+	a.gin.Use(middlewares.ClientInfo())
+	// 토큰 폐기 여부를 DB 에서 확인해야 하기 때문에 JwtToken 보다 먼저 DB를 설정한다.
 	a.gin.Use(middlewares.GORMDb(a.gormDB))
+	a.gin.Use(a.router.JwtToken())
+	// 액세스 토큰으로 인증된 요청인지 확인해야 하기 때문에 JwtToken 다음에 CSRF 토큰을 확인한다.
+	a.gin.Use(middlewares.CsrfToken())
+	a.gin.Use(middlewares.RestAuthorizer(a.regoQuery))
+	// 비밀번호, LDAP 필터((&(...)) 등), 리다이렉트 URI 처럼 원문 그대로 사용해야 하는 값을 받는 API 는 제외한다.
 	a.gin.Use(xss.Sanitizer(xss.Config{
-		UrlsToExclude:     []string{"/api/auth", "/api/auth/dooray"},
+		UrlsToExclude: []string{"/api/auth", "/api/auth/password", "/api/auth/dooray", "/api/auth/ldap",
+			"/api/members/my/password", "/api/site/settings/ldap-login", "/api/oauth/clients", "/api/oauth/consent"},
 		TargetHttpMethods: []string{http.MethodPost, http.MethodPut}}))
 }
 
 func (a *App) newCorsConfig() cors.Config {
 	corsConfig := cors.DefaultConfig()
 	corsConfig.AllowCredentials = true
+	// 쿠키 세션 모드에서는 브라우저가 리프레시 토큰 쿠키를 함께 전송하므로 허용한 웹 화면 주소에서의 요청만 허용한다.
 	corsConfig.AllowOriginFunc = func(origin string) bool {
+		if helpers.SessionCookieHelper().IsCookieMode() {
+			return helpers.SessionCookieHelper().IsAllowedOrigin(origin)
+		}
 		return true
 	}
-	corsConfig.AllowHeaders = []string{"Origin", "Content-Length", "Content-Type", "Authorization"}
+	corsConfig.AllowHeaders = []string{"Origin", "Content-Length", "Content-Type", "Authorization", helpers.CsrfTokenHeaderName}
 	corsConfig.MaxAge = AccessControlMaxAgeLimitHours * time.Hour
 	return corsConfig
 }
@@ -0,0 +1,16 @@
+package middlewares
+
+import (
+	"better-admin-backend-service/helpers"
+	"github.com/gin-gonic/gin"
+)
+
+// ClientInfo 로그인 제한, 접근 기록 등 서비스에서 클라이언트 IP, User-Agent 를 사용할 수 있도록 컨텍스트에 설정한다.
+func ClientInfo() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := helpers.ContextHelper().SetClientIp(c.Request.Context(), c.ClientIP())
+		ctx = helpers.ContextHelper().SetClientUserAgent(ctx, c.Request.UserAgent())
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,60 @@
+package middlewares
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"crypto/subtle"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"time"
+)
+
+// refreshTokenCookieUrls 리프레시 토큰 쿠키로 인증하는 API 는 Authorization 헤더가 있어도 항상 CSRF 토큰을 확인한다.
+var refreshTokenCookieUrls = []string{"/api/auth/token/refresh", "/api/auth/logout"}
+
+// CsrfToken 브라우저가 자동으로 전송하는 리프레시 토큰 쿠키가 있는 변경 요청은 CSRF 토큰 쿠키와 같은 값을 헤더로 전송해야 한다.(double-submit)
+// 액세스 토큰은 브라우저가 자동으로 전송하지 않으므로 JwtToken 에서 인증된 요청(bearer 모드, API 클라이언트)은 확인하지 않는다.
+// 따라서 JwtToken 다음에 사용해야 한다.
+func CsrfToken() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if len(helpers.SessionCookieHelper().GetRefreshToken(c.Request)) == 0 || isAuthenticatedByAccessToken(c) {
+			c.Next()
+			return
+		}
+
+		csrfToken := ""
+		if csrfTokenCookie, err := c.Request.Cookie(helpers.CsrfTokenCookieName); err == nil {
+			csrfToken = csrfTokenCookie.Value
+		} else {
+			// CSRF 토큰 쿠키가 만료되었거나 없는 경우 다음 요청부터 사용할 수 있도록 다시 발급한다.
+			if err := helpers.SessionCookieHelper().SetCsrfTokenCookie(c.Writer, time.Time{}); err != nil {
+				helpers.ErrorHelper().InternalServerError(c, err)
+				c.Abort()
+				return
+			}
+		}
+
+		switch c.Request.Method {
+		case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+			headerCsrfToken := c.Request.Header.Get(helpers.CsrfTokenHeaderName)
+			if len(csrfToken) == 0 || subtle.ConstantTimeCompare([]byte(csrfToken), []byte(headerCsrfToken)) != 1 {
+				c.AbortWithStatusJSON(http.StatusForbidden, dtos.ErrorMessage{Message: errors.ErrInvalidCsrfToken.Error()})
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+func isAuthenticatedByAccessToken(c *gin.Context) bool {
+	for _, refreshTokenCookieUrl := range refreshTokenCookieUrls {
+		if c.FullPath() == refreshTokenCookieUrl {
+			return false
+		}
+	}
+
+	_, err := helpers.ContextHelper().GetUserClaim(c.Request.Context())
+	return err == nil
+}
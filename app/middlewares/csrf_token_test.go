@@ -0,0 +1,166 @@
+package middlewares
+
+import (
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/security"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newCsrfTokenTestRouter() *gin.Engine {
+	router := gin.Default()
+	// JwtToken 대신 유효한 액세스 토큰(test-access-token)인 경우에만 UserClaim 을 설정한다.
+	router.Use(func(c *gin.Context) {
+		if c.Request.Header.Get("Authorization") == "Bearer test-access-token" {
+			c.Request = c.Request.WithContext(helpers.ContextHelper().SetUserClaim(c.Request.Context(), &security.UserClaim{Id: 1}))
+		}
+		c.Next()
+	})
+	router.Use(CsrfToken())
+	router.GET("/api/test", func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, nil)
+	})
+	router.POST("/api/test", func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, nil)
+	})
+	router.POST("/api/auth/token/refresh", func(ctx *gin.Context) {
+		ctx.JSON(http.StatusOK, nil)
+	})
+	return router
+}
+
+func TestCsrfToken_리프레시_토큰_쿠키가_있는_변경_요청은_CSRF_토큰_헤더가_필요하다(t *testing.T) {
+	// given
+	router := newCsrfTokenTestRouter()
+	req := httptest.NewRequest(http.MethodPost, "/api/test", nil)
+	req.AddCookie(&http.Cookie{Name: "refreshToken", Value: "test-refresh-token"})
+	req.AddCookie(&http.Cookie{Name: "csrfToken", Value: "test-csrf-token"})
+	rec := httptest.NewRecorder()
+
+	// when
+	router.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestCsrfToken_CSRF_토큰_헤더가_쿠키와_다른_경우(t *testing.T) {
+	// given
+	router := newCsrfTokenTestRouter()
+	req := httptest.NewRequest(http.MethodPost, "/api/test", nil)
+	req.AddCookie(&http.Cookie{Name: "refreshToken", Value: "test-refresh-token"})
+	req.AddCookie(&http.Cookie{Name: "csrfToken", Value: "test-csrf-token"})
+	req.Header.Set("X-CSRF-Token", "other-csrf-token")
+	rec := httptest.NewRecorder()
+
+	// when
+	router.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestCsrfToken_CSRF_토큰_헤더가_쿠키와_같은_경우(t *testing.T) {
+	// given
+	router := newCsrfTokenTestRouter()
+	req := httptest.NewRequest(http.MethodPost, "/api/test", nil)
+	req.AddCookie(&http.Cookie{Name: "refreshToken", Value: "test-refresh-token"})
+	req.AddCookie(&http.Cookie{Name: "csrfToken", Value: "test-csrf-token"})
+	req.Header.Set("X-CSRF-Token", "test-csrf-token")
+	rec := httptest.NewRecorder()
+
+	// when
+	router.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestCsrfToken_CSRF_토큰_쿠키가_없는_경우_다시_발급한다(t *testing.T) {
+	// given
+	router := newCsrfTokenTestRouter()
+	req := httptest.NewRequest(http.MethodPost, "/api/test", nil)
+	req.AddCookie(&http.Cookie{Name: "refreshToken", Value: "test-refresh-token"})
+	req.Header.Set("X-CSRF-Token", "")
+	rec := httptest.NewRecorder()
+
+	// when
+	router.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	cookies := rec.Result().Cookies()
+	assert.Equal(t, 1, len(cookies))
+	assert.Equal(t, "csrfToken", cookies[0].Name)
+	assert.NotEmpty(t, cookies[0].Value)
+	assert.False(t, cookies[0].HttpOnly)
+}
+
+func TestCsrfToken_Authorization_헤더로_인증하는_요청은_확인하지_않는다(t *testing.T) {
+	// given
+	router := newCsrfTokenTestRouter()
+	req := httptest.NewRequest(http.MethodPost, "/api/test", nil)
+	req.AddCookie(&http.Cookie{Name: "refreshToken", Value: "test-refresh-token"})
+	req.Header.Set("Authorization", "Bearer test-access-token")
+	rec := httptest.NewRecorder()
+
+	// when
+	router.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestCsrfToken_액세스_토큰으로_인증되지_않은_Authorization_헤더는_확인한다(t *testing.T) {
+	// given
+	router := newCsrfTokenTestRouter()
+	req := httptest.NewRequest(http.MethodPost, "/api/test", nil)
+	req.AddCookie(&http.Cookie{Name: "refreshToken", Value: "test-refresh-token"})
+	req.AddCookie(&http.Cookie{Name: "csrfToken", Value: "test-csrf-token"})
+	req.Header.Set("Authorization", "Basic eA==")
+	rec := httptest.NewRecorder()
+
+	// when
+	router.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestCsrfToken_리프레시_토큰_쿠키로_인증하는_API_는_액세스_토큰이_있어도_확인한다(t *testing.T) {
+	// given
+	router := newCsrfTokenTestRouter()
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/token/refresh", nil)
+	req.AddCookie(&http.Cookie{Name: "refreshToken", Value: "test-refresh-token"})
+	req.AddCookie(&http.Cookie{Name: "csrfToken", Value: "test-csrf-token"})
+	req.Header.Set("Authorization", "Bearer test-access-token")
+	rec := httptest.NewRecorder()
+
+	// when
+	router.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestCsrfToken_조회_요청과_쿠키가_없는_요청은_확인하지_않는다(t *testing.T) {
+	// given
+	router := newCsrfTokenTestRouter()
+	getReq := httptest.NewRequest(http.MethodGet, "/api/test", nil)
+	getReq.AddCookie(&http.Cookie{Name: "refreshToken", Value: "test-refresh-token"})
+	getReq.AddCookie(&http.Cookie{Name: "csrfToken", Value: "test-csrf-token"})
+	postReq := httptest.NewRequest(http.MethodPost, "/api/test", nil)
+
+	for _, req := range []*http.Request{getReq, postReq} {
+		rec := httptest.NewRecorder()
+
+		// when
+		router.ServeHTTP(rec, req)
+
+		// then
+		assert.Equal(t, http.StatusOK, rec.Code)
+	}
+}
@@ -2,19 +2,19 @@ package middlewares
 
 import (
 	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
 	"better-admin-backend-service/helpers"
 	"better-admin-backend-service/security"
+	"better-admin-backend-service/services"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strings"
 )
 
-var (
-	jwtAuthentication security.JwtAuthentication
-)
-
-func JwtToken() gin.HandlerFunc {
-	jwtAuthentication = security.JwtAuthentication{}
+// JwtToken 라우트와 같은 서비스로 토큰 폐기 여부와 개인 액세스 토큰을 확인하기 위해 서비스를 전달받는다.
+func JwtToken(tokenRevocationService *services.TokenRevocationService,
+	personalAccessTokenService *services.PersonalAccessTokenService) gin.HandlerFunc {
+	jwtAuthentication := security.JwtAuthentication{}
 
 	return func(c *gin.Context) {
 		accessToken := c.Request.Header.Get("Authorization")
@@ -23,6 +23,12 @@ func JwtToken() gin.HandlerFunc {
 			return
 		}
 
+		// 토큰 엔드포인트는 내부 앱이 Basic 인증(client_secret_basic)으로 클라이언트를 인증한다.
+		if strings.HasPrefix(accessToken, "Basic ") {
+			c.Next()
+			return
+		}
+
 		index := strings.Index(accessToken, "Bearer")
 		if index < 0 {
 			index = strings.Index(accessToken, "Bearer")
@@ -32,6 +38,31 @@ func JwtToken() gin.HandlerFunc {
 			accessToken = strings.Trim(accessToken, " ")
 		}
 
+		// 내부 앱의 액세스 토큰은 멤버의 토큰이 아니므로 /userinfo 에서만 확인한다.
+		if security.IsOAuthAccessToken(accessToken) {
+			c.Next()
+			return
+		}
+
+		if security.IsPersonalAccessToken(accessToken) {
+			userClaim, err := personalAccessTokenService.Authenticate(c.Request.Context(), accessToken)
+			if err != nil {
+				if err == security.InvalidAccessToken || err == security.AccessTokenExpired || err == security.AccessTokenRevoked {
+					c.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Message: err.Error()})
+					c.Abort()
+					return
+				}
+
+				helpers.ErrorHelper().InternalServerError(c, err)
+				c.Abort()
+				return
+			}
+
+			c.Request = c.Request.WithContext(helpers.ContextHelper().SetUserClaim(c.Request.Context(), userClaim))
+			c.Next()
+			return
+		}
+
 		userClaim, err := jwtAuthentication.ConvertTokenUserClaim(accessToken)
 		if err != nil {
 			c.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Message: err.Error()})
@@ -39,7 +70,30 @@ func JwtToken() gin.HandlerFunc {
 			return
 		}
 
+		if err := tokenRevocationService.ValidateToken(c.Request.Context(), *userClaim); err != nil {
+			if err == security.AccessTokenRevoked || err == security.AccessTokenPermissionChanged {
+				c.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Message: err.Error()})
+				c.Abort()
+				return
+			}
+
+			helpers.ErrorHelper().InternalServerError(c, err)
+			c.Abort()
+			return
+		}
+
+		// 대리 로그인 토큰은 멤버의 권한을 그대로 가지므로 조회와 대리 로그인 종료만 허용한다.
+		if userClaim.IsImpersonation() && c.Request.Method != http.MethodGet && isEndImpersonation(c) == false {
+			c.JSON(http.StatusForbidden, dtos.ErrorMessage{Message: errors.ErrImpersonation.Error()})
+			c.Abort()
+			return
+		}
+
 		c.Request = c.Request.WithContext(helpers.ContextHelper().SetUserClaim(c.Request.Context(), userClaim))
 		c.Next()
 	}
 }
+
+func isEndImpersonation(c *gin.Context) bool {
+	return c.Request.Method == http.MethodDelete && c.FullPath() == "/api/members/my/impersonation"
+}
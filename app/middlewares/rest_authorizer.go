@@ -21,8 +21,9 @@ func RestAuthorizer(regoQuery *rego.PreparedEvalQuery) gin.HandlerFunc {
 		userClaim, err := helpers.ContextHelper().GetUserClaim(c.Request.Context())
 		if err == nil {
 			input["member"] = map[string]any{
-				"id":          userClaim.Id,
-				"permissions": userClaim.Permissions,
+				"id":                  userClaim.Id,
+				"permissions":         userClaim.Permissions,
+				"personalAccessToken": userClaim.IsPersonalAccessToken(),
 			}
 		}
 		url := c.FullPath()
@@ -23,7 +23,7 @@ func TestRestAuthorizer_권한이_있는_API_접근할_수_있다(t *testing.T)
 		t.Error(err)
 	}
 
-	router.Use(JwtToken())
+	router.Use(JwtToken(nil, nil))
 	router.Use(RestAuthorizer(&opaRego))
 	router.GET("/api/access-control/permissions", func(ctx *gin.Context) {
 		ctx.JSON(http.StatusOK, nil)
@@ -54,7 +54,7 @@ func TestRestAuthorizer_권한이_없는_API에_접근할_수_없다(t *testing.
 		t.Error(err)
 	}
 
-	router.Use(JwtToken())
+	router.Use(JwtToken(nil, nil))
 	router.Use(RestAuthorizer(&opaRego))
 	router.GET("/api/access-control/permissions", func(ctx *gin.Context) {
 		ctx.JSON(http.StatusOK, nil)
@@ -0,0 +1,43 @@
+package middlewares
+
+import (
+	authRepository "better-admin-backend-service/auth/repository"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/security"
+	"better-admin-backend-service/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ScimToken SCIM API 는 사이트 관리자가 발급한 SCIM 토큰으로만 호출할 수 있다.
+func ScimToken() gin.HandlerFunc {
+	scimTokenService := services.NewScimTokenService(&authRepository.ScimTokenRepository{})
+
+	return func(c *gin.Context) {
+		token := strings.TrimSpace(strings.TrimPrefix(c.Request.Header.Get("Authorization"), "Bearer"))
+
+		userClaim, err := scimTokenService.Authenticate(c.Request.Context(), token)
+		if err != nil {
+			if err == security.InvalidAccessToken {
+				c.Header("Content-Type", constants.ScimContentType)
+				c.AbortWithStatusJSON(http.StatusUnauthorized, dtos.ScimError{
+					Schemas: []string{constants.ScimSchemaError},
+					Status:  strconv.Itoa(http.StatusUnauthorized),
+					Detail:  err.Error(),
+				})
+				return
+			}
+
+			helpers.ErrorHelper().InternalServerError(c, err)
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(helpers.ContextHelper().SetUserClaim(c.Request.Context(), userClaim))
+		c.Next()
+	}
+}
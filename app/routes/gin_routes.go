@@ -4,4 +4,6 @@ import "github.com/gin-gonic/gin"
 
 type GinRoute interface {
 	MapRoutes(routerGroup *gin.RouterGroup)
+	// JwtToken 라우트와 같은 서비스로 액세스 토큰을 확인하는 미들웨어를 반환한다.
+	JwtToken() gin.HandlerFunc
 }
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"better-admin-backend-service/helpers"
+	"context"
+	"gorm.io/gorm"
+	"time"
+)
+
+// ImpersonationEntity 관리자가 다른 멤버로 대리 로그인한 기록. 대리 로그인 토큰의 jti 로 토큰과 연결되며,
+// 대리 로그인을 종료하면 EndedAt 이 기록된다.(종료하지 않은 경우 ExpiresAt 에 토큰이 만료된다.)
+type ImpersonationEntity struct {
+	gorm.Model
+	ImpersonatorId uint      `gorm:"not null;index"`
+	MemberId       uint      `gorm:"not null;index"`
+	Jti            string    `gorm:"type:varchar(32);not null;uniqueIndex"`
+	Reason         string    `gorm:"type:varchar(200)"`
+	IpAddress      string    `gorm:"type:varchar(50)"`
+	UserAgent      string    `gorm:"type:varchar(255)"`
+	ExpiresAt      time.Time `gorm:"not null"`
+	EndedAt        *time.Time
+}
+
+func (ImpersonationEntity) TableName() string {
+	return "member_impersonations"
+}
+
+func (e ImpersonationEntity) IsEnded() bool {
+	return e.EndedAt != nil
+}
+
+func (e *ImpersonationEntity) End() {
+	if e.EndedAt != nil {
+		return
+	}
+
+	now := time.Now()
+	e.EndedAt = &now
+}
+
+func NewImpersonationEntity(ctx context.Context, impersonatorId, memberId uint, jti, reason string, expiresAt time.Time) ImpersonationEntity {
+	return ImpersonationEntity{
+		ImpersonatorId: impersonatorId,
+		MemberId:       memberId,
+		Jti:            jti,
+		Reason:         reason,
+		IpAddress:      helpers.ContextHelper().GetClientIp(ctx),
+		UserAgent:      truncateUserAgent(helpers.ContextHelper().GetClientUserAgent(ctx)),
+		ExpiresAt:      expiresAt,
+	}
+}
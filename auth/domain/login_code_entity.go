@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"better-admin-backend-service/security"
+	"gorm.io/gorm"
+	"time"
+)
+
+// 로그인 코드는 웹 화면으로 리다이렉트된 직후 바로 교환하므로 짧게 유지한다.
+const loginCodeTimeout = time.Minute
+
+// LoginCodeEntity 외부 IdP 로그인 후 리다이렉트 URL 에 액세스 토큰을 노출하지 않도록 일회용 코드로 교환할 때까지 토큰을 보관한다.
+// 코드는 한 번만 교환할 수 있고, 교환하면 바로 삭제한다.
+type LoginCodeEntity struct {
+	gorm.Model
+	CodeHash             string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	AccessToken          string    `gorm:"type:varchar(2000);not null"`
+	AccessTokenExpiresAt int64     `gorm:"not null"`
+	ExpiresAt            time.Time `gorm:"not null"`
+}
+
+func (LoginCodeEntity) TableName() string {
+	return "login_codes"
+}
+
+func (e LoginCodeEntity) IsExpired() bool {
+	return time.Now().After(e.ExpiresAt)
+}
+
+func (e LoginCodeEntity) GetJwtToken() security.JwtToken {
+	return security.JwtToken{
+		AccessToken: e.AccessToken,
+		ExpiresAt:   e.AccessTokenExpiresAt,
+	}
+}
+
+func NewLoginCodeEntity(code string, token security.JwtToken) LoginCodeEntity {
+	return LoginCodeEntity{
+		CodeHash:             security.HashToken(code),
+		AccessToken:          token.AccessToken,
+		AccessTokenExpiresAt: token.ExpiresAt,
+		ExpiresAt:            time.Now().Add(loginCodeTimeout),
+	}
+}
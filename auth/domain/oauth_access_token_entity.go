@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"better-admin-backend-service/security"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+const OAuthAccessTokenExpiration = time.Hour
+
+// OAuthAccessTokenEntity 내부 앱이 /userinfo 를 호출할 때 사용하는 토큰. 멤버의 REST API 는 호출할 수 없다.
+type OAuthAccessTokenEntity struct {
+	gorm.Model
+	TokenHash string `gorm:"type:varchar(64);not null;uniqueIndex"`
+	ClientId  string `gorm:"type:varchar(64);not null;index"`
+	MemberId  uint   `gorm:"not null"`
+	// 공백으로 구분한 scope
+	Scope string `gorm:"type:varchar(200);not null"`
+	// 인가 코드가 재사용되면 이 코드로 발급한 토큰을 폐기한다.(RFC 6749 4.1.2)
+	AuthorizationCodeId uint      `gorm:"not null;index"`
+	ExpiresAt           time.Time `gorm:"not null"`
+}
+
+func (OAuthAccessTokenEntity) TableName() string {
+	return "oauth_access_tokens"
+}
+
+func (e OAuthAccessTokenEntity) IsExpired() bool {
+	return time.Now().After(e.ExpiresAt)
+}
+
+func (e OAuthAccessTokenEntity) GetScopes() []string {
+	return strings.Fields(e.Scope)
+}
+
+func NewOAuthAccessTokenEntity(token string, authorizationCode OAuthAuthorizationCodeEntity) OAuthAccessTokenEntity {
+	return OAuthAccessTokenEntity{
+		TokenHash:           security.HashToken(token),
+		ClientId:            authorizationCode.ClientId,
+		MemberId:            authorizationCode.MemberId,
+		Scope:               authorizationCode.Scope,
+		AuthorizationCodeId: authorizationCode.ID,
+		ExpiresAt:           time.Now().Add(OAuthAccessTokenExpiration),
+	}
+}
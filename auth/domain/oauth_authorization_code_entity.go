@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"better-admin-backend-service/security"
+	"crypto/subtle"
+	"gorm.io/gorm"
+	"time"
+)
+
+// 인가 코드는 리다이렉트 직후 바로 교환하므로 짧게 유지한다.(RFC 6749 4.1.2 권장 최대 10분)
+const oauthAuthorizationCodeTimeout = time.Minute * 5
+
+// OAuthAuthorizationCodeEntity 멤버가 동의한 인가 요청. 코드는 한 번만 토큰으로 교환할 수 있다.
+type OAuthAuthorizationCodeEntity struct {
+	gorm.Model
+	CodeHash    string `gorm:"type:varchar(64);not null;uniqueIndex"`
+	ClientId    string `gorm:"type:varchar(64);not null"`
+	MemberId    uint   `gorm:"not null"`
+	RedirectUri string `gorm:"type:varchar(1000);not null"`
+	// 공백으로 구분한 scope
+	Scope         string    `gorm:"type:varchar(200);not null"`
+	Nonce         string    `gorm:"type:varchar(200)"`
+	CodeChallenge string    `gorm:"type:varchar(128);not null"`
+	ExpiresAt     time.Time `gorm:"not null"`
+	UsedAt        *time.Time
+}
+
+func (OAuthAuthorizationCodeEntity) TableName() string {
+	return "oauth_authorization_codes"
+}
+
+func (e OAuthAuthorizationCodeEntity) IsExpired() bool {
+	return time.Now().After(e.ExpiresAt)
+}
+
+func (e OAuthAuthorizationCodeEntity) IsUsed() bool {
+	return e.UsedAt != nil
+}
+
+func (e *OAuthAuthorizationCodeEntity) Use() {
+	now := time.Now()
+	e.UsedAt = &now
+}
+
+// VerifyCodeVerifier 인가 요청은 S256 방식만 허용하므로 code_verifier 의 S256 해시를 비교한다.
+func (e OAuthAuthorizationCodeEntity) VerifyCodeVerifier(codeVerifier string) bool {
+	if len(codeVerifier) == 0 {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(e.CodeChallenge), []byte(security.GetPkceS256CodeChallenge(codeVerifier))) == 1
+}
+
+func NewOAuthAuthorizationCodeEntity(code, clientId string, memberId uint, redirectUri, scope, nonce, codeChallenge string) OAuthAuthorizationCodeEntity {
+	return OAuthAuthorizationCodeEntity{
+		CodeHash:      security.HashToken(code),
+		ClientId:      clientId,
+		MemberId:      memberId,
+		RedirectUri:   redirectUri,
+		Scope:         scope,
+		Nonce:         nonce,
+		CodeChallenge: codeChallenge,
+		ExpiresAt:     time.Now().Add(oauthAuthorizationCodeTimeout),
+	}
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/security"
+	"context"
+	"crypto/subtle"
+	"gorm.io/gorm"
+	"strings"
+)
+
+// OAuthClientEntity 멤버 계정으로 로그인(OpenID Connect)하는 내부 앱.
+type OAuthClientEntity struct {
+	gorm.Model
+	ClientId string `gorm:"type:varchar(64);not null;uniqueIndex"`
+	// 비어 있으면 시크릿을 안전하게 보관할 수 없는 공개 클라이언트(SPA, 모바일 앱)로 PKCE 로만 인가 코드를 교환한다.
+	ClientSecretHash string `gorm:"type:varchar(64)"`
+	Name             string `gorm:"type:varchar(100);not null"`
+	// 공백으로 구분한 리다이렉트 URI
+	RedirectUris string `gorm:"type:varchar(2000);not null"`
+	// 사내에서 직접 운영하는 앱은 동의 화면 없이 인가 코드를 발급한다.
+	SkipConsent bool
+	CreatedBy   uint
+	UpdatedBy   uint
+}
+
+func (OAuthClientEntity) TableName() string {
+	return "oauth_clients"
+}
+
+func (e OAuthClientEntity) IsPublic() bool {
+	return len(e.ClientSecretHash) == 0
+}
+
+func (e OAuthClientEntity) GetRedirectUris() []string {
+	return strings.Fields(e.RedirectUris)
+}
+
+// HasRedirectUri 등록된 리다이렉트 URI 와 정확히 일치해야 한다.(RFC 6749 3.1.2.3)
+func (e OAuthClientEntity) HasRedirectUri(redirectUri string) bool {
+	for _, registered := range e.GetRedirectUris() {
+		if registered == redirectUri {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (e OAuthClientEntity) MatchClientSecret(clientSecret string) bool {
+	if e.IsPublic() || len(clientSecret) == 0 {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(e.ClientSecretHash), []byte(security.HashToken(clientSecret))) == 1
+}
+
+func (e *OAuthClientEntity) ChangeClientSecret(ctx context.Context, clientSecret string) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	e.ClientSecretHash = security.HashToken(clientSecret)
+	e.UpdatedBy = userClaim.Id
+
+	return nil
+}
+
+// Update 클라이언트 유형(공개, 기밀)은 등록한 뒤 변경할 수 없다.
+func (e *OAuthClientEntity) Update(ctx context.Context, information dtos.OAuthClientInformation) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	e.Name = information.Name
+	e.RedirectUris = strings.Join(information.RedirectUris, " ")
+	e.SkipConsent = information.SkipConsent
+	e.UpdatedBy = userClaim.Id
+
+	return nil
+}
+
+// NewOAuthClientEntity clientSecret 이 비어 있으면 공개 클라이언트로 등록한다.
+func NewOAuthClientEntity(ctx context.Context, clientId, clientSecret string, information dtos.OAuthClientInformation) (OAuthClientEntity, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return OAuthClientEntity{}, err
+	}
+
+	entity := OAuthClientEntity{
+		ClientId:     clientId,
+		Name:         information.Name,
+		RedirectUris: strings.Join(information.RedirectUris, " "),
+		SkipConsent:  information.SkipConsent,
+		CreatedBy:    userClaim.Id,
+		UpdatedBy:    userClaim.Id,
+	}
+
+	if len(clientSecret) > 0 {
+		entity.ClientSecretHash = security.HashToken(clientSecret)
+	}
+
+	return entity, nil
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"gorm.io/gorm"
+	"strings"
+)
+
+// OAuthConsentEntity 멤버가 내부 앱에 제공하기로 동의한 scope. 동의한 scope 안에서는 다시 묻지 않는다.
+type OAuthConsentEntity struct {
+	gorm.Model
+	MemberId uint   `gorm:"not null;uniqueIndex:idx_oauth_consents_member_client"`
+	ClientId string `gorm:"type:varchar(64);not null;uniqueIndex:idx_oauth_consents_member_client"`
+	// 공백으로 구분한 scope
+	Scope string `gorm:"type:varchar(200);not null"`
+}
+
+func (OAuthConsentEntity) TableName() string {
+	return "oauth_consents"
+}
+
+func (e OAuthConsentEntity) GetScopes() []string {
+	return strings.Fields(e.Scope)
+}
+
+// Covers 요청한 scope 를 모두 동의했는지 확인한다.
+func (e OAuthConsentEntity) Covers(scopes []string) bool {
+	consented := map[string]bool{}
+	for _, scope := range e.GetScopes() {
+		consented[scope] = true
+	}
+
+	for _, scope := range scopes {
+		if !consented[scope] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Grant 이전에 동의한 scope 는 유지하고 새로 동의한 scope 를 추가한다.
+func (e *OAuthConsentEntity) Grant(scopes []string) {
+	granted := e.GetScopes()
+	for _, scope := range scopes {
+		if !e.Covers([]string{scope}) {
+			granted = append(granted, scope)
+			e.Scope = strings.Join(granted, " ")
+		}
+	}
+}
+
+func NewOAuthConsentEntity(memberId uint, clientId string, scopes []string) OAuthConsentEntity {
+	return OAuthConsentEntity{
+		MemberId: memberId,
+		ClientId: clientId,
+		Scope:    strings.Join(scopes, " "),
+	}
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"gorm.io/gorm"
+	"time"
+)
+
+const oidcAuthSessionTimeout = time.Minute * 10
+
+// OidcAuthSessionEntity 인가 요청을 보낸 뒤 IdP 에서 돌아올 때까지 state, nonce, PKCE code verifier 를 보관한다. 세션은 한 번만 사용할 수 있다.
+type OidcAuthSessionEntity struct {
+	gorm.Model
+	State        string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	Nonce        string    `gorm:"type:varchar(64);not null"`
+	CodeVerifier string    `gorm:"type:varchar(128);not null"`
+	Redirect     string    `gorm:"type:varchar(1000)"`
+	ExpiresAt    time.Time `gorm:"not null"`
+}
+
+func (OidcAuthSessionEntity) TableName() string {
+	return "oidc_auth_sessions"
+}
+
+func (e OidcAuthSessionEntity) IsExpired() bool {
+	return time.Now().After(e.ExpiresAt)
+}
+
+func NewOidcAuthSessionEntity(state, nonce, codeVerifier, redirect string) OidcAuthSessionEntity {
+	return OidcAuthSessionEntity{
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		Redirect:     redirect,
+		ExpiresAt:    time.Now().Add(oidcAuthSessionTimeout),
+	}
+}
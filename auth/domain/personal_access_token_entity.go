@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"better-admin-backend-service/security"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+// 목록에서 토큰을 구분할 수 있도록 보관하는 원문의 앞부분 길이(접두사 포함)
+const personalAccessTokenDisplayLength = 12
+
+// PersonalAccessTokenEntity 스크립트, CI 등에서 사용하기 위해 멤버가 발급한 토큰. 멤버가 가진 권한 중 일부만 사용할 수 있다.
+type PersonalAccessTokenEntity struct {
+	gorm.Model
+	MemberId     uint   `gorm:"not null;index"`
+	Name         string `gorm:"type:varchar(100);not null"`
+	TokenHash    string `gorm:"type:varchar(64);not null;uniqueIndex"`
+	DisplayToken string `gorm:"type:varchar(20);not null"`
+	// 쉼표로 구분한 권한
+	Permissions string    `gorm:"type:varchar(1000)"`
+	TokenEpoch  uint      `gorm:"not null;default:0"`
+	ExpiresAt   time.Time `gorm:"not null"`
+	LastUsedAt  *time.Time
+}
+
+func (PersonalAccessTokenEntity) TableName() string {
+	return "personal_access_tokens"
+}
+
+func (e PersonalAccessTokenEntity) GetPermissions() []string {
+	if len(e.Permissions) == 0 {
+		return []string{}
+	}
+
+	return strings.Split(e.Permissions, ",")
+}
+
+func (e PersonalAccessTokenEntity) IsExpired() bool {
+	return time.Now().After(e.ExpiresAt)
+}
+
+func (e *PersonalAccessTokenEntity) Use() {
+	now := time.Now()
+	e.LastUsedAt = &now
+}
+
+// NewPersonalAccessTokenEntity tokenEpoch 는 발급할 때 멤버의 토큰 에포크로, 멤버의 모든 토큰을 폐기하면 함께 사용할 수 없게 된다.
+func NewPersonalAccessTokenEntity(memberId, tokenEpoch uint, name, token string, permissions []string, expiresAt time.Time) PersonalAccessTokenEntity {
+	return PersonalAccessTokenEntity{
+		MemberId:     memberId,
+		Name:         name,
+		TokenHash:    security.HashToken(token),
+		DisplayToken: token[:personalAccessTokenDisplayLength],
+		Permissions:  strings.Join(permissions, ","),
+		TokenEpoch:   tokenEpoch,
+		ExpiresAt:    expiresAt,
+	}
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"better-admin-backend-service/security"
+	"gorm.io/gorm"
+	"time"
+)
+
+type RefreshTokenEntity struct {
+	gorm.Model
+	MemberId  uint      `gorm:"not null;index"`
+	TokenHash string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	FamilyId  string    `gorm:"type:varchar(32);not null;index"`
+	ExpiresAt time.Time `gorm:"not null"`
+	RotatedAt *time.Time
+	RevokedAt *time.Time
+}
+
+func (RefreshTokenEntity) TableName() string {
+	return "refresh_tokens"
+}
+
+func (r RefreshTokenEntity) IsRotated() bool {
+	return r.RotatedAt != nil
+}
+
+func (r RefreshTokenEntity) IsRevoked() bool {
+	return r.RevokedAt != nil
+}
+
+func (r RefreshTokenEntity) IsExpired() bool {
+	return time.Now().After(r.ExpiresAt)
+}
+
+func (r *RefreshTokenEntity) Rotate() {
+	now := time.Now()
+	r.RotatedAt = &now
+}
+
+func (r *RefreshTokenEntity) Revoke() {
+	if r.RevokedAt != nil {
+		return
+	}
+
+	now := time.Now()
+	r.RevokedAt = &now
+}
+
+func NewRefreshTokenEntity(memberId uint, familyId string, jwtToken security.JwtToken) RefreshTokenEntity {
+	return RefreshTokenEntity{
+		MemberId:  memberId,
+		TokenHash: security.HashToken(jwtToken.RefreshToken),
+		FamilyId:  familyId,
+		ExpiresAt: jwtToken.RefreshTokenExpires,
+	}
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"better-admin-backend-service/security"
+	"gorm.io/gorm"
+	"time"
+)
+
+type RevokedAccessTokenEntity struct {
+	gorm.Model
+	Jti       string    `gorm:"type:varchar(32);not null;uniqueIndex"`
+	MemberId  uint      `gorm:"not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+}
+
+func (RevokedAccessTokenEntity) TableName() string {
+	return "revoked_access_tokens"
+}
+
+func NewRevokedAccessTokenEntity(userClaim security.UserClaim) RevokedAccessTokenEntity {
+	return RevokedAccessTokenEntity{
+		Jti:       userClaim.Jti,
+		MemberId:  userClaim.Id,
+		ExpiresAt: time.Unix(userClaim.ExpiresAt, 0),
+	}
+}
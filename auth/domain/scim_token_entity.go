@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/security"
+	"context"
+	"gorm.io/gorm"
+	"time"
+)
+
+// 목록에서 토큰을 구분할 수 있도록 보관하는 원문의 앞부분 길이(접두사 포함)
+const scimTokenDisplayLength = 14
+
+// ScimTokenEntity 인사 시스템, IdP 가 SCIM API 로 멤버와 그룹(조직)을 프로비저닝할 때 사용하는 토큰.
+// 멤버의 권한과 관계없이 SCIM API 만 호출할 수 있다.
+type ScimTokenEntity struct {
+	gorm.Model
+	Name         string `gorm:"type:varchar(100);not null"`
+	TokenHash    string `gorm:"type:varchar(64);not null;uniqueIndex"`
+	DisplayToken string `gorm:"type:varchar(20);not null"`
+	LastUsedAt   *time.Time
+	CreatedBy    uint
+}
+
+func (ScimTokenEntity) TableName() string {
+	return "scim_tokens"
+}
+
+func (e *ScimTokenEntity) Use() {
+	now := time.Now()
+	e.LastUsedAt = &now
+}
+
+func NewScimTokenEntity(ctx context.Context, name, token string) (ScimTokenEntity, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return ScimTokenEntity{}, err
+	}
+
+	return ScimTokenEntity{
+		Name:         name,
+		TokenHash:    security.HashToken(token),
+		DisplayToken: token[:scimTokenDisplayLength],
+		CreatedBy:    userClaim.Id,
+	}, nil
+}
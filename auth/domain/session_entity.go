@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"better-admin-backend-service/helpers"
+	"context"
+	"gorm.io/gorm"
+	"time"
+)
+
+const maxSessionUserAgentLength = 255
+
+// SessionEntity 로그인할 때마다 만들어지는 세션. 로그인할 때 시작된 리프레시 토큰 패밀리와 같은 아이디(SessionId)를 사용하며
+// 토큰을 갱신할 때마다 마지막 접근 정보를 기록한다.
+type SessionEntity struct {
+	gorm.Model
+	MemberId       uint      `gorm:"not null;index"`
+	SessionId      string    `gorm:"type:varchar(32);not null;uniqueIndex"`
+	AuthType       string    `gorm:"type:varchar(20);not null"`
+	IpAddress      string    `gorm:"type:varchar(50)"`
+	UserAgent      string    `gorm:"type:varchar(255)"`
+	LastAccessedAt time.Time `gorm:"not null"`
+	ExpiresAt      time.Time `gorm:"not null"`
+	RevokedAt      *time.Time
+}
+
+func (SessionEntity) TableName() string {
+	return "member_sessions"
+}
+
+func (e SessionEntity) IsRevoked() bool {
+	return e.RevokedAt != nil
+}
+
+func (e SessionEntity) IsActive() bool {
+	return e.IsRevoked() == false && time.Now().Before(e.ExpiresAt)
+}
+
+// Touch 토큰을 갱신한 클라이언트 정보로 마지막 접근 정보를 변경한다.
+func (e *SessionEntity) Touch(ctx context.Context, expiresAt time.Time) {
+	e.IpAddress = helpers.ContextHelper().GetClientIp(ctx)
+	e.UserAgent = truncateUserAgent(helpers.ContextHelper().GetClientUserAgent(ctx))
+	e.LastAccessedAt = time.Now()
+	e.ExpiresAt = expiresAt
+}
+
+func (e *SessionEntity) Revoke() {
+	if e.RevokedAt != nil {
+		return
+	}
+
+	now := time.Now()
+	e.RevokedAt = &now
+}
+
+func NewSessionEntity(ctx context.Context, memberId uint, sessionId, authType string, expiresAt time.Time) SessionEntity {
+	entity := SessionEntity{
+		MemberId:  memberId,
+		SessionId: sessionId,
+		AuthType:  authType,
+	}
+	entity.Touch(ctx, expiresAt)
+
+	return entity
+}
+
+func truncateUserAgent(userAgent string) string {
+	if runes := []rune(userAgent); len(runes) > maxSessionUserAgentLength {
+		return string(runes[:maxSessionUserAgentLength])
+	}
+	return userAgent
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"encoding/json"
+	"github.com/go-webauthn/webauthn/webauthn"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+const (
+	WebAuthnCeremonyRegistration = "registration"
+	WebAuthnCeremonyLogin        = "login"
+)
+
+// WebAuthnSessionEntity 등록/로그인 세리머니의 챌린지를 세리머니가 완료될 때까지 보관한다. 세션은 한 번만 사용할 수 있다.
+type WebAuthnSessionEntity struct {
+	gorm.Model
+	SessionId string    `gorm:"type:varchar(32);not null;uniqueIndex"`
+	MemberId  uint      `gorm:"not null;default:0"` // 로그인 세리머니는 멤버를 알 수 없으므로 0
+	Ceremony  string    `gorm:"type:varchar(20);not null"`
+	Data      string    `gorm:"type:text;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+}
+
+func (WebAuthnSessionEntity) TableName() string {
+	return "webauthn_sessions"
+}
+
+func (e WebAuthnSessionEntity) IsExpired() bool {
+	return time.Now().After(e.ExpiresAt)
+}
+
+func (e WebAuthnSessionEntity) GetSessionData() (webauthn.SessionData, error) {
+	var sessionData webauthn.SessionData
+	if err := json.Unmarshal([]byte(e.Data), &sessionData); err != nil {
+		return webauthn.SessionData{}, pkgerrors.Wrap(err, "JSON Unmarshal error")
+	}
+
+	return sessionData, nil
+}
+
+func NewWebAuthnSessionEntity(sessionId string, memberId uint, ceremony string, sessionData webauthn.SessionData) (WebAuthnSessionEntity, error) {
+	data, err := json.Marshal(sessionData)
+	if err != nil {
+		return WebAuthnSessionEntity{}, pkgerrors.Wrap(err, "JSON Marshal error")
+	}
+
+	return WebAuthnSessionEntity{
+		SessionId: sessionId,
+		MemberId:  memberId,
+		Ceremony:  ceremony,
+		Data:      string(data),
+		ExpiresAt: sessionData.Expires,
+	}, nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"better-admin-backend-service/auth/domain"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type ImpersonationRepository struct {
+}
+
+func (ImpersonationRepository) Create(ctx context.Context, entity *domain.ImpersonationEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (ImpersonationRepository) FindByJti(ctx context.Context, jti string) (domain.ImpersonationEntity, error) {
+	var entity domain.ImpersonationEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.ImpersonationEntity{Jti: jti}).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (ImpersonationRepository) FindAll(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.ImpersonationEntity, int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.ImpersonationEntity{})
+
+	if filters != nil {
+		for key, value := range filters {
+			if key == "memberId" {
+				db.Where("member_id = ?", value)
+			}
+
+			if key == "impersonatorId" {
+				db.Where("impersonator_id = ?", value)
+			}
+		}
+	}
+
+	var entities = make([]domain.ImpersonationEntity, 0)
+	var totalCount int64
+
+	if err := db.Count(&totalCount).Scopes(helpers.GormHelper().Pageable(pageable)).
+		Order("id desc").Find(&entities).Error; err != nil {
+		return entities, totalCount, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, totalCount, nil
+}
+
+func (ImpersonationRepository) Save(ctx context.Context, entity *domain.ImpersonationEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
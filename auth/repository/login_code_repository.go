@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"better-admin-backend-service/auth/domain"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type LoginCodeRepository struct {
+}
+
+func (LoginCodeRepository) Create(ctx context.Context, entity *domain.LoginCodeEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (LoginCodeRepository) FindByCodeHash(ctx context.Context, codeHash string) (domain.LoginCodeEntity, error) {
+	var entity domain.LoginCodeEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.LoginCodeEntity{CodeHash: codeHash}).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+// Delete 같은 코드로 동시에 교환하면 하나의 요청만 삭제할 수 있고, 나머지 요청은 errors.ErrNotFound 를 반환한다.
+func (LoginCodeRepository) Delete(ctx context.Context, entity domain.LoginCodeEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	// 교환한 코드는 다시 사용할 수 없도록 완전히 삭제한다.
+	result := db.Unscoped().Delete(&entity)
+	if result.Error != nil {
+		return pkgerrors.Wrap(result.Error, "db error")
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"better-admin-backend-service/auth/domain"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type LoginFailureRepository struct {
+}
+
+func (LoginFailureRepository) FindByLogin(ctx context.Context, loginType, loginId string) (domain.LoginFailureEntity, error) {
+	var entity domain.LoginFailureEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.LoginFailureEntity{LoginType: loginType, LoginId: loginId}).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (LoginFailureRepository) Save(ctx context.Context, entity *domain.LoginFailureEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (LoginFailureRepository) DeleteByLogin(ctx context.Context, loginType, loginId string) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	// 고유 인덱스(로그인 유형, 아이디)로 다시 기록할 수 있도록 완전히 삭제한다.
+	if err := db.Unscoped().Where(&domain.LoginFailureEntity{LoginType: loginType, LoginId: loginId}).
+		Delete(&domain.LoginFailureEntity{}).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"better-admin-backend-service/auth/domain"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type OAuthAccessTokenRepository struct {
+}
+
+func (OAuthAccessTokenRepository) Create(ctx context.Context, entity *domain.OAuthAccessTokenEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (OAuthAccessTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (domain.OAuthAccessTokenEntity, error) {
+	var entity domain.OAuthAccessTokenEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.OAuthAccessTokenEntity{TokenHash: tokenHash}).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (OAuthAccessTokenRepository) DeleteAllByAuthorizationCodeId(ctx context.Context, authorizationCodeId uint) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.OAuthAccessTokenEntity{AuthorizationCodeId: authorizationCodeId}).
+		Delete(&domain.OAuthAccessTokenEntity{}).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (OAuthAccessTokenRepository) DeleteAllByClientId(ctx context.Context, clientId string) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.OAuthAccessTokenEntity{ClientId: clientId}).
+		Delete(&domain.OAuthAccessTokenEntity{}).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"better-admin-backend-service/auth/domain"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type OAuthAuthorizationCodeRepository struct {
+}
+
+func (OAuthAuthorizationCodeRepository) Create(ctx context.Context, entity *domain.OAuthAuthorizationCodeEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (OAuthAuthorizationCodeRepository) FindByCodeHash(ctx context.Context, codeHash string) (domain.OAuthAuthorizationCodeEntity, error) {
+	var entity domain.OAuthAuthorizationCodeEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.OAuthAuthorizationCodeEntity{CodeHash: codeHash}).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+// Use 아직 사용하지 않은 인가 코드인 경우에만 사용 시각을 기록한다.
+// 같은 인가 코드로 동시에 토큰을 요청하면 하나의 요청만 사용할 수 있고, 나머지 요청은 false 를 반환한다.
+func (OAuthAuthorizationCodeRepository) Use(ctx context.Context, entity *domain.OAuthAuthorizationCodeEntity) (bool, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	entity.Use()
+	result := db.Model(&domain.OAuthAuthorizationCodeEntity{}).
+		Where("id = ? AND used_at IS NULL", entity.ID).
+		Update("used_at", entity.UsedAt)
+	if result.Error != nil {
+		return false, pkgerrors.Wrap(result.Error, "db error")
+	}
+
+	return result.RowsAffected == 1, nil
+}
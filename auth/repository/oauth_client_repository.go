@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"better-admin-backend-service/auth/domain"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type OAuthClientRepository struct {
+}
+
+func (OAuthClientRepository) Create(ctx context.Context, entity *domain.OAuthClientEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (OAuthClientRepository) FindByClientId(ctx context.Context, clientId string) (domain.OAuthClientEntity, error) {
+	var entity domain.OAuthClientEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.OAuthClientEntity{ClientId: clientId}).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (OAuthClientRepository) FindAll(ctx context.Context) ([]domain.OAuthClientEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.OAuthClientEntity, 0)
+	if err := db.Order("id").Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (OAuthClientRepository) Save(ctx context.Context, entity *domain.OAuthClientEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (OAuthClientRepository) Delete(ctx context.Context, entity domain.OAuthClientEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	// 삭제한 클라이언트 아이디로 다시 등록할 수 있도록 완전히 삭제한다.
+	if err := db.Unscoped().Delete(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
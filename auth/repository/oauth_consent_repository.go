@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"better-admin-backend-service/auth/domain"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type OAuthConsentRepository struct {
+}
+
+func (OAuthConsentRepository) FindByMemberIdAndClientId(ctx context.Context, memberId uint, clientId string) (domain.OAuthConsentEntity, error) {
+	var entity domain.OAuthConsentEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.OAuthConsentEntity{MemberId: memberId, ClientId: clientId}).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (OAuthConsentRepository) Save(ctx context.Context, entity *domain.OAuthConsentEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (OAuthConsentRepository) DeleteAllByClientId(ctx context.Context, clientId string) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	// 고유 인덱스(멤버, 클라이언트)로 다시 동의할 수 있도록 완전히 삭제한다.
+	if err := db.Unscoped().Where(&domain.OAuthConsentEntity{ClientId: clientId}).
+		Delete(&domain.OAuthConsentEntity{}).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
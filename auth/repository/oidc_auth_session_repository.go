@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"better-admin-backend-service/auth/domain"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type OidcAuthSessionRepository struct {
+}
+
+func (OidcAuthSessionRepository) Create(ctx context.Context, entity *domain.OidcAuthSessionEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (OidcAuthSessionRepository) FindByState(ctx context.Context, state string) (domain.OidcAuthSessionEntity, error) {
+	var entity domain.OidcAuthSessionEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.OidcAuthSessionEntity{State: state}).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+// Delete 같은 세션으로 동시에 로그인하면 하나의 요청만 삭제할 수 있고, 나머지 요청은 errors.ErrNotFound 를 반환한다.
+func (OidcAuthSessionRepository) Delete(ctx context.Context, entity domain.OidcAuthSessionEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	// 사용된 세션은 다시 사용할 수 없도록 완전히 삭제한다.
+	result := db.Unscoped().Delete(&entity)
+	if result.Error != nil {
+		return pkgerrors.Wrap(result.Error, "db error")
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.ErrNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"better-admin-backend-service/auth/domain"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type PersonalAccessTokenRepository struct {
+}
+
+func (PersonalAccessTokenRepository) Create(ctx context.Context, entity *domain.PersonalAccessTokenEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (PersonalAccessTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (domain.PersonalAccessTokenEntity, error) {
+	var entity domain.PersonalAccessTokenEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.PersonalAccessTokenEntity{TokenHash: tokenHash}).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (PersonalAccessTokenRepository) FindByIdAndMemberId(ctx context.Context, id, memberId uint) (domain.PersonalAccessTokenEntity, error) {
+	var entity domain.PersonalAccessTokenEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.PersonalAccessTokenEntity{MemberId: memberId}).First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (PersonalAccessTokenRepository) FindAllByMemberId(ctx context.Context, memberId uint) ([]domain.PersonalAccessTokenEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.PersonalAccessTokenEntity, 0)
+	if err := db.Where(&domain.PersonalAccessTokenEntity{MemberId: memberId}).Order("id").Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (PersonalAccessTokenRepository) Save(ctx context.Context, entity *domain.PersonalAccessTokenEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (PersonalAccessTokenRepository) Delete(ctx context.Context, entity domain.PersonalAccessTokenEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Delete(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
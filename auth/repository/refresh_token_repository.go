@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"better-admin-backend-service/auth/domain"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepository struct {
+}
+
+func (RefreshTokenRepository) Create(ctx context.Context, entity *domain.RefreshTokenEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (RefreshTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (domain.RefreshTokenEntity, error) {
+	var entity domain.RefreshTokenEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.RefreshTokenEntity{TokenHash: tokenHash}).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (RefreshTokenRepository) FindAllByFamilyId(ctx context.Context, familyId string) ([]domain.RefreshTokenEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.RefreshTokenEntity, 0)
+	if err := db.Where(&domain.RefreshTokenEntity{FamilyId: familyId}).Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (RefreshTokenRepository) FindAllByMemberId(ctx context.Context, memberId uint) ([]domain.RefreshTokenEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.RefreshTokenEntity, 0)
+	if err := db.Where(&domain.RefreshTokenEntity{MemberId: memberId}).Where("revoked_at IS NULL").Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (RefreshTokenRepository) Save(ctx context.Context, entity *domain.RefreshTokenEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+// Rotate 아직 회전되지 않은 토큰인 경우에만 회전 시각을 기록한다.
+// 같은 토큰으로 동시에 갱신하면 하나의 요청만 회전할 수 있고, 나머지 요청은 false 를 반환한다.
+func (RefreshTokenRepository) Rotate(ctx context.Context, entity *domain.RefreshTokenEntity) (bool, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	entity.Rotate()
+	result := db.Model(&domain.RefreshTokenEntity{}).
+		Where("id = ? AND rotated_at IS NULL", entity.ID).
+		Update("rotated_at", entity.RotatedAt)
+	if result.Error != nil {
+		return false, pkgerrors.Wrap(result.Error, "db error")
+	}
+
+	return result.RowsAffected == 1, nil
+}
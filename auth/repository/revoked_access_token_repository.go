@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"better-admin-backend-service/auth/domain"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+)
+
+type RevokedAccessTokenRepository struct {
+}
+
+func (RevokedAccessTokenRepository) Create(ctx context.Context, entity *domain.RevokedAccessTokenEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (RevokedAccessTokenRepository) ExistsByJti(ctx context.Context, jti string) (bool, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var count int64
+	if err := db.Model(&domain.RevokedAccessTokenEntity{}).Where("jti = ?", jti).Count(&count).Error; err != nil {
+		return false, pkgerrors.Wrap(err, "db error")
+	}
+
+	if count > 0 {
+		return true, nil
+	}
+
+	return false, nil
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"better-admin-backend-service/auth/domain"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+type SessionRepository struct {
+}
+
+func (SessionRepository) Create(ctx context.Context, entity *domain.SessionEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (SessionRepository) FindBySessionId(ctx context.Context, sessionId string) (domain.SessionEntity, error) {
+	var entity domain.SessionEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.SessionEntity{SessionId: sessionId}).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (SessionRepository) FindByIdAndMemberId(ctx context.Context, id, memberId uint) (domain.SessionEntity, error) {
+	var entity domain.SessionEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.SessionEntity{MemberId: memberId}).First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+// FindAllActiveByMemberId 폐기되거나 만료되지 않은 세션을 최근에 접근한 순서로 조회한다.
+func (SessionRepository) FindAllActiveByMemberId(ctx context.Context, memberId uint) ([]domain.SessionEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.SessionEntity, 0)
+	if err := db.Where(&domain.SessionEntity{MemberId: memberId}).
+		Where("revoked_at IS NULL AND expires_at > ?", time.Now()).
+		Order("last_accessed_at desc").Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (SessionRepository) Save(ctx context.Context, entity *domain.SessionEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"better-admin-backend-service/auth/domain"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type WebAuthnSessionRepository struct {
+}
+
+func (WebAuthnSessionRepository) Create(ctx context.Context, entity *domain.WebAuthnSessionEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (WebAuthnSessionRepository) FindBySessionId(ctx context.Context, sessionId string) (domain.WebAuthnSessionEntity, error) {
+	var entity domain.WebAuthnSessionEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.WebAuthnSessionEntity{SessionId: sessionId}).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (WebAuthnSessionRepository) Delete(ctx context.Context, entity domain.WebAuthnSessionEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	// 사용된 세션은 다시 사용할 수 없도록 완전히 삭제한다.
+	if err := db.Unscoped().Delete(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
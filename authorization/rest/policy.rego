@@ -11,14 +11,39 @@ allowed {
 # 인증된 모든 멤버에게 허용 정책
 allowed {
     input.member.id > 0 # 인증된 사용자 체크
+    not input.member.personalAccessToken
 
     required_permissions := data.api[input.api.url][input.api.method]
     some p
     required_permissions[p] == "all-authenticated-members"
 }
 
+# 개인 액세스 토큰은 조회(GET)만 허용 정책
+# 패스키, 2단계 인증, 세션 등 계정 보안 정보는 개인 액세스 토큰으로 변경할 수 없다.
+allowed {
+    input.member.id > 0 # 인증된 사용자 체크
+    input.member.personalAccessToken
+    input.api.method == "GET"
+
+    required_permissions := data.api[input.api.url][input.api.method]
+    some p
+    required_permissions[p] == "all-authenticated-members"
+}
+
+# 본인 정보 관리 범위(member.self)는 인증된 모든 멤버에게 허용 정책
+# 단, 개인 액세스 토큰은 member.self 범위를 지정한 경우에만 허용한다(권한이 있는 멤버에게만 허용 정책).
+allowed {
+    input.member.id > 0 # 인증된 사용자 체크
+    not input.member.personalAccessToken
+
+    required_permissions := data.api[input.api.url][input.api.method]
+    some p
+    required_permissions[p] == "member.self"
+}
+
 # 권한이 있는 멤버에게만 허용 정책
 allowed {
+    not personal_access_token_denied
     member_permissions := input.member.permissions
     required_permissions := data.api[input.api.url][input.api.method]
 
@@ -28,6 +53,19 @@ allowed {
     count(satisfied_permissions) == count(required_permissions)
 }
 
+# 개인 액세스 토큰으로는 권한이 있더라도 토큰, 클라이언트 시크릿 등 인증 정보를 발급할 수 없다.
+personal_access_token_denied_apis := {
+    "/api/site/settings/scim/tokens",
+    "/api/oauth/clients",
+    "/api/oauth/clients/:clientId/secret"
+}
+
+personal_access_token_denied {
+    input.member.personalAccessToken
+    input.api.method != "GET"
+    personal_access_token_denied_apis[input.api.url]
+}
+
 permissionmatch(permission, req_permission, delim) = true {
     permission == req_permission
 } else = result { # else문으로 여러 규칙 바디를 연결하면 첫 번째 바디의 조건이 만족하지 않았을 때 다음 바디의 조건을 체크하도록 규칙을 작성한다.
@@ -18,6 +18,15 @@ test_auth_login_with_dooray_allowed {
     }
 }
 
+test_auth_login_with_ldap_allowed {
+    allowed with input as {
+        "api": {
+            "url": "/api/auth/ldap",
+            "method": "POST"
+        }
+    }
+}
+
 test_auth_login_with_google_workspace_allowed {
     allowed with input as {
         "api": {
@@ -27,6 +36,42 @@ test_auth_login_with_google_workspace_allowed {
     }
 }
 
+test_auth_google_workspace_authorization_allowed {
+    allowed with input as {
+        "api": {
+            "url": "/api/auth/google-workspace/authorization",
+            "method": "GET"
+        }
+    }
+}
+
+test_auth_login_code_allowed {
+    allowed with input as {
+        "api": {
+            "url": "/api/auth/login-code",
+            "method": "POST"
+        }
+    }
+}
+
+test_auth_oidc_authorization_allowed {
+    allowed with input as {
+        "api": {
+            "url": "/api/auth/oidc/authorization",
+            "method": "GET"
+        }
+    }
+}
+
+test_auth_login_with_oidc_allowed {
+    allowed with input as {
+        "api": {
+            "url": "/api/auth/oidc",
+            "method": "GET"
+        }
+    }
+}
+
 test_auth_token_refresh_allowed {
     allowed with input as {
         "api": {
@@ -36,6 +81,73 @@ test_auth_token_refresh_allowed {
     }
 }
 
+test_auth_password_change_allowed {
+    allowed with input as {
+        "api": {
+            "url": "/api/auth/password",
+            "method": "POST"
+        }
+    }
+}
+
+test_auth_two_factor_allowed {
+    allowed with input as {
+        "api": {
+            "url": "/api/auth/two-factor",
+            "method": "POST"
+        }
+    }
+}
+
+test_auth_two_factor_enrollment_allowed {
+    allowed with input as {
+        "api": {
+            "url": "/api/auth/two-factor/enrollment",
+            "method": "POST"
+        }
+    }
+}
+
+test_auth_passkey_options_allowed {
+    allowed with input as {
+        "api": {
+            "url": "/api/auth/passkey/options",
+            "method": "POST"
+        }
+    }
+}
+
+test_auth_login_with_passkey_allowed {
+    allowed with input as {
+        "api": {
+            "url": "/api/auth/passkey",
+            "method": "POST"
+        }
+    }
+}
+
+test_auth_logout_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/auth/logout",
+            "method": "POST"
+        }
+    }
+}
+
+test_auth_logout_not_allowed {
+    not allowed with input as {
+        "api": {
+            "url": "/api/auth/logout",
+            "method": "POST"
+        }
+    }
+}
+
 test_access_control_permissions_read_allowed {
     allowed with input as {
         "member": {
@@ -392,453 +504,2309 @@ test_member_my_information_read_not_allowed {
     }
 }
 
-test_member_read_allowed {
+test_member_my_profile_read_allowed {
     allowed with input as {
         "member": {
             "id": 1,
-            "permissions": ["member.read"]
+            "permissions": []
         },
         "api": {
-            "url": "/api/members/:id",
+            "url": "/api/members/my/profile",
             "method": "GET"
         }
     }
 }
 
-test_member_read_not_allowed {
+test_member_my_profile_read_not_allowed {
     not allowed with input as {
-        "member": {
-            "id": 1,
-            "permissions": ["member.update"]
-        },
         "api": {
-            "url": "/api/members/:id",
+            "url": "/api/members/my/profile",
             "method": "GET"
         }
     }
 }
 
-test_member_assgin_roles_update_allowed {
+test_member_my_profile_update_allowed {
     allowed with input as {
         "member": {
             "id": 1,
-            "permissions": ["member.update"]
+            "permissions": []
         },
         "api": {
-            "url": "/api/members/:id/assign-roles",
+            "url": "/api/members/my/profile",
             "method": "PUT"
         }
     }
 }
 
-test_member_assgin_roles_update_not_allowed {
+test_member_my_profile_update_not_allowed {
     not allowed with input as {
-        "member": {
-            "id": 1,
-            "permissions": ["member.read"]
-        },
         "api": {
-            "url": "/api/members/:id/assign-roles",
+            "url": "/api/members/my/profile",
             "method": "PUT"
         }
     }
 }
 
-test_member_approved_update_allowed {
+test_member_my_profile_update_with_personal_access_token_allowed {
     allowed with input as {
         "member": {
             "id": 1,
-            "permissions": ["member.update"]
+            "permissions": ["member.self"],
+            "personalAccessToken": true
         },
         "api": {
-            "url": "/api/members/:id/approved",
+            "url": "/api/members/my/profile",
             "method": "PUT"
         }
     }
 }
 
-test_member_approved_update_not_allowed {
+test_member_my_profile_update_with_personal_access_token_not_allowed {
     not allowed with input as {
         "member": {
             "id": 1,
-            "permissions": ["member.read"]
+            "permissions": ["member.read"],
+            "personalAccessToken": true
         },
         "api": {
-            "url": "/api/members/:id/approved",
+            "url": "/api/members/my/profile",
             "method": "PUT"
         }
     }
 }
 
-test_member_rejected_update_allowed {
+test_member_my_with_personal_access_token_allowed {
     allowed with input as {
         "member": {
             "id": 1,
-            "permissions": ["member.update"]
+            "permissions": [],
+            "personalAccessToken": true
         },
         "api": {
-            "url": "/api/members/:id/rejected",
-            "method": "PUT"
+            "url": "/api/members/my",
+            "method": "GET"
         }
     }
 }
 
-test_member_rejected_update_not_allowed {
+test_member_my_passkeys_options_with_personal_access_token_not_allowed {
     not allowed with input as {
         "member": {
             "id": 1,
-            "permissions": ["member.read"]
+            "permissions": ["member.read"],
+            "personalAccessToken": true
         },
         "api": {
-            "url": "/api/members/:id/rejected",
-            "method": "PUT"
+            "url": "/api/members/my/passkeys/options",
+            "method": "POST"
         }
     }
 }
 
-test_members_search_filters_read_allowed {
-    allowed with input as {
+test_member_my_passkeys_create_with_personal_access_token_not_allowed {
+    not allowed with input as {
         "member": {
             "id": 1,
-            "permissions": []
+            "permissions": ["member.read"],
+            "personalAccessToken": true
         },
         "api": {
-            "url": "/api/members/search-filters",
-            "method": "GET"
+            "url": "/api/members/my/passkeys",
+            "method": "POST"
         }
     }
 }
 
-test_members_search_filters_read_not_allowed {
+test_member_my_two_factor_with_personal_access_token_not_allowed {
     not allowed with input as {
-        "api": {
-            "url": "/api/members/search-filters",
-            "method": "GET"
-        }
-    }
-}
-
-test_organization_create_allowed {
-    allowed with input as {
         "member": {
             "id": 1,
-            "permissions": ["organization.create"]
+            "permissions": ["member.read"],
+            "personalAccessToken": true
         },
         "api": {
-            "url": "/api/organizations",
+            "url": "/api/members/my/two-factor",
             "method": "POST"
         }
     }
 }
 
-test_organization_create_not_allowed {
+test_member_my_sessions_delete_with_personal_access_token_not_allowed {
     not allowed with input as {
         "member": {
             "id": 1,
-            "permissions": []
+            "permissions": ["member.read"],
+            "personalAccessToken": true
         },
         "api": {
-            "url": "/api/organizations",
-            "method": "POST"
+            "url": "/api/members/my/sessions/:sessionId",
+            "method": "DELETE"
         }
     }
 }
 
-test_organizations_read_allowed {
+test_member_read_allowed {
     allowed with input as {
         "member": {
             "id": 1,
-            "permissions": ["organization.read"]
+            "permissions": ["member.read"]
         },
         "api": {
-            "url": "/api/organizations",
+            "url": "/api/members/:id",
             "method": "GET"
         }
     }
 }
 
-test_organization_create_not_allowed {
+test_member_read_not_allowed {
     not allowed with input as {
         "member": {
             "id": 1,
-            "permissions": ["organization.create"]
+            "permissions": ["member.update"]
         },
         "api": {
-            "url": "/api/organizations",
+            "url": "/api/members/:id",
             "method": "GET"
         }
     }
 }
 
-test_organization_read_allowed {
+test_member_assgin_roles_update_allowed {
     allowed with input as {
         "member": {
             "id": 1,
-            "permissions": ["organization.read"]
+            "permissions": ["member.update"]
         },
         "api": {
-            "url": "/api/organizations/:organizationId",
-            "method": "GET"
+            "url": "/api/members/:id/assign-roles",
+            "method": "PUT"
         }
     }
 }
 
-test_organization_read_not_allowed {
+test_member_assgin_roles_update_not_allowed {
     not allowed with input as {
         "member": {
             "id": 1,
-            "permissions": ["organization.create"]
+            "permissions": ["member.read"]
         },
         "api": {
-            "url": "/api/organizations/:organizationId",
-            "method": "GET"
+            "url": "/api/members/:id/assign-roles",
+            "method": "PUT"
         }
     }
 }
 
-test_organization_delete_allowed {
+test_member_approved_update_allowed {
     allowed with input as {
         "member": {
             "id": 1,
-            "permissions": ["organization.delete"]
+            "permissions": ["member.update"]
         },
         "api": {
-            "url": "/api/organizations/:organizationId",
-            "method": "DELETE"
+            "url": "/api/members/:id/approved",
+            "method": "PUT"
         }
     }
 }
 
-test_organization_delete_not_allowed {
+test_member_approved_update_not_allowed {
     not allowed with input as {
         "member": {
             "id": 1,
-            "permissions": ["organization.read"]
+            "permissions": ["member.read"]
         },
         "api": {
-            "url": "/api/organizations/:organizationId",
-            "method": "DELETE"
+            "url": "/api/members/:id/approved",
+            "method": "PUT"
         }
     }
 }
 
-test_organization_change_name_allowed {
+test_member_rejected_update_allowed {
     allowed with input as {
         "member": {
             "id": 1,
-            "permissions": ["organization.update"]
+            "permissions": ["member.update"]
         },
         "api": {
-            "url": "/api/organizations/:organizationId/name",
+            "url": "/api/members/:id/rejected",
             "method": "PUT"
         }
     }
 }
 
-test_organization_change_name_not_allowed {
+test_member_rejected_update_not_allowed {
     not allowed with input as {
         "member": {
             "id": 1,
-            "permissions": ["organization.read"]
+            "permissions": ["member.read"]
         },
         "api": {
-            "url": "/api/organizations/:organizationId/name",
+            "url": "/api/members/:id/rejected",
             "method": "PUT"
         }
     }
 }
 
-test_organization_change_position_allowed {
+test_member_suspended_update_allowed {
     allowed with input as {
         "member": {
             "id": 1,
-            "permissions": ["organization.update"]
+            "permissions": ["member.update"]
         },
         "api": {
-            "url": "/api/organizations/:organizationId/change-position",
+            "url": "/api/members/:id/suspended",
             "method": "PUT"
         }
     }
 }
 
-test_organization_change_position_not_allowed {
+test_member_suspended_update_not_allowed {
     not allowed with input as {
         "member": {
             "id": 1,
-            "permissions": ["organization.read"]
+            "permissions": ["member.read"]
         },
         "api": {
-            "url": "/api/organizations/:organizationId/change-position",
+            "url": "/api/members/:id/suspended",
             "method": "PUT"
         }
     }
 }
 
-test_organization_assign_roles_allowed {
+test_member_locked_update_allowed {
     allowed with input as {
         "member": {
             "id": 1,
-            "permissions": ["organization.update"]
+            "permissions": ["member.update"]
         },
         "api": {
-            "url": "/api/organizations/:organizationId/assign-roles",
+            "url": "/api/members/:id/locked",
             "method": "PUT"
         }
     }
 }
 
-test_organization_assign_roles_not_allowed {
+test_member_locked_update_not_allowed {
     not allowed with input as {
         "member": {
             "id": 1,
-            "permissions": ["organization.read"]
+            "permissions": ["member.read"]
         },
         "api": {
-            "url": "/api/organizations/:organizationId/assign-roles",
+            "url": "/api/members/:id/locked",
             "method": "PUT"
         }
     }
 }
 
-test_organization_assign_members_allowed {
+test_member_withdrawn_update_allowed {
     allowed with input as {
         "member": {
             "id": 1,
-            "permissions": ["organization.update"]
+            "permissions": ["member.update"]
         },
         "api": {
-            "url": "/api/organizations/:organizationId/assign-members",
+            "url": "/api/members/:id/withdrawn",
             "method": "PUT"
         }
     }
 }
 
-test_organization_assign_members_not_allowed {
+test_member_withdrawn_update_not_allowed {
     not allowed with input as {
         "member": {
             "id": 1,
-            "permissions": ["organization.read"]
+            "permissions": ["member.read"]
         },
         "api": {
-            "url": "/api/organizations/:organizationId/assign-members",
+            "url": "/api/members/:id/withdrawn",
             "method": "PUT"
         }
     }
 }
 
-test_site_settings_read_allowed {
-    allowed with input as {
-        "api": {
-            "url": "/api/site/settings",
-            "method": "GET"
-        }
-    }
-}
-
-test_site_settings_dooray_login_read_allowed {
+test_member_expired_update_allowed {
     allowed with input as {
         "member": {
             "id": 1,
-            "permissions": ["site-settings.read"]
+            "permissions": ["member.update"]
         },
         "api": {
-            "url": "/api/site/settings/dooray-login",
-            "method": "GET"
+            "url": "/api/members/:id/expired",
+            "method": "PUT"
         }
     }
 }
 
-test_site_settings_dooray_login_read_not_allowed {
+test_member_expired_update_not_allowed {
     not allowed with input as {
         "member": {
             "id": 1,
-            "permissions": []
+            "permissions": ["member.read"]
         },
         "api": {
-            "url": "/api/site/settings/dooray-login",
-            "method": "GET"
+            "url": "/api/members/:id/expired",
+            "method": "PUT"
         }
     }
 }
 
-test_site_settings_dooray_login_update_allowed {
+test_member_reactivated_update_allowed {
     allowed with input as {
         "member": {
             "id": 1,
-            "permissions": ["site-settings.update"]
+            "permissions": ["member.update"]
         },
         "api": {
-            "url": "/api/site/settings/dooray-login",
+            "url": "/api/members/:id/reactivated",
             "method": "PUT"
         }
     }
 }
 
-test_site_settings_dooray_login_update_not_allowed {
+test_member_reactivated_update_not_allowed {
     not allowed with input as {
         "member": {
             "id": 1,
-            "permissions": ["site-settings.read"]
+            "permissions": ["member.read"]
+        },
+        "api": {
+            "url": "/api/members/:id/reactivated",
+            "method": "PUT"
+        }
+    }
+}
+
+test_member_status_histories_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.read"]
+        },
+        "api": {
+            "url": "/api/members/:id/status-histories",
+            "method": "GET"
+        }
+    }
+}
+
+test_member_status_histories_read_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/:id/status-histories",
+            "method": "GET"
+        }
+    }
+}
+
+test_member_bulk_approved_update_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.update"]
+        },
+        "api": {
+            "url": "/api/members/bulk/approved",
+            "method": "PUT"
+        }
+    }
+}
+
+test_member_bulk_approved_update_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.read"]
+        },
+        "api": {
+            "url": "/api/members/bulk/approved",
+            "method": "PUT"
+        }
+    }
+}
+
+test_member_bulk_rejected_update_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.update"]
+        },
+        "api": {
+            "url": "/api/members/bulk/rejected",
+            "method": "PUT"
+        }
+    }
+}
+
+test_member_bulk_rejected_update_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.read"]
+        },
+        "api": {
+            "url": "/api/members/bulk/rejected",
+            "method": "PUT"
+        }
+    }
+}
+
+test_member_bulk_assign_roles_update_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.update"]
+        },
+        "api": {
+            "url": "/api/members/bulk/assign-roles",
+            "method": "PUT"
+        }
+    }
+}
+
+test_member_bulk_assign_roles_update_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.read"]
+        },
+        "api": {
+            "url": "/api/members/bulk/assign-roles",
+            "method": "PUT"
+        }
+    }
+}
+
+test_member_bulk_suspended_update_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.update"]
+        },
+        "api": {
+            "url": "/api/members/bulk/suspended",
+            "method": "PUT"
+        }
+    }
+}
+
+test_member_bulk_suspended_update_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.read"]
+        },
+        "api": {
+            "url": "/api/members/bulk/suspended",
+            "method": "PUT"
+        }
+    }
+}
+
+test_member_bulk_add_organization_update_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.update", "organization.update"]
+        },
+        "api": {
+            "url": "/api/members/bulk/add-organization",
+            "method": "PUT"
+        }
+    }
+}
+
+test_member_bulk_add_organization_update_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.update"]
+        },
+        "api": {
+            "url": "/api/members/bulk/add-organization",
+            "method": "PUT"
+        }
+    }
+}
+
+test_member_my_password_change_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/my/password",
+            "method": "PUT"
+        }
+    }
+}
+
+test_member_my_password_change_not_allowed {
+    not allowed with input as {
+        "api": {
+            "url": "/api/members/my/password",
+            "method": "PUT"
+        }
+    }
+}
+
+test_member_my_two_factor_start_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/my/two-factor",
+            "method": "POST"
+        }
+    }
+}
+
+test_member_my_two_factor_start_not_allowed {
+    not allowed with input as {
+        "api": {
+            "url": "/api/members/my/two-factor",
+            "method": "POST"
+        }
+    }
+}
+
+test_member_my_two_factor_activate_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/my/two-factor/activated",
+            "method": "PUT"
+        }
+    }
+}
+
+test_member_my_two_factor_activate_not_allowed {
+    not allowed with input as {
+        "api": {
+            "url": "/api/members/my/two-factor/activated",
+            "method": "PUT"
+        }
+    }
+}
+
+test_member_my_two_factor_deactivate_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/my/two-factor/deactivated",
+            "method": "PUT"
+        }
+    }
+}
+
+test_member_my_two_factor_deactivate_not_allowed {
+    not allowed with input as {
+        "api": {
+            "url": "/api/members/my/two-factor/deactivated",
+            "method": "PUT"
+        }
+    }
+}
+
+test_member_my_passkeys_options_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/my/passkeys/options",
+            "method": "POST"
+        }
+    }
+}
+
+test_member_my_passkeys_options_not_allowed {
+    not allowed with input as {
+        "api": {
+            "url": "/api/members/my/passkeys/options",
+            "method": "POST"
+        }
+    }
+}
+
+test_member_my_passkeys_register_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/my/passkeys",
+            "method": "POST"
+        }
+    }
+}
+
+test_member_my_passkeys_register_not_allowed {
+    not allowed with input as {
+        "api": {
+            "url": "/api/members/my/passkeys",
+            "method": "POST"
+        }
+    }
+}
+
+test_member_my_passkeys_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/my/passkeys",
+            "method": "GET"
+        }
+    }
+}
+
+test_member_my_passkeys_read_not_allowed {
+    not allowed with input as {
+        "api": {
+            "url": "/api/members/my/passkeys",
+            "method": "GET"
+        }
+    }
+}
+
+test_member_my_passkeys_delete_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/my/passkeys/:passkeyId",
+            "method": "DELETE"
+        }
+    }
+}
+
+test_member_my_passkeys_delete_not_allowed {
+    not allowed with input as {
+        "api": {
+            "url": "/api/members/my/passkeys/:passkeyId",
+            "method": "DELETE"
+        }
+    }
+}
+
+test_member_my_access_logs_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/my/access-logs",
+            "method": "GET"
+        }
+    }
+}
+
+test_member_my_access_logs_read_not_allowed {
+    not allowed with input as {
+        "api": {
+            "url": "/api/members/my/access-logs",
+            "method": "GET"
+        }
+    }
+}
+
+test_member_access_logs_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.read"]
+        },
+        "api": {
+            "url": "/api/members/access-logs",
+            "method": "GET"
+        }
+    }
+}
+
+test_member_access_logs_read_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/access-logs",
+            "method": "GET"
+        }
+    }
+}
+
+test_member_my_sessions_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/my/sessions",
+            "method": "GET"
+        }
+    }
+}
+
+test_member_my_sessions_read_not_allowed {
+    not allowed with input as {
+        "api": {
+            "url": "/api/members/my/sessions",
+            "method": "GET"
+        }
+    }
+}
+
+test_member_my_sessions_delete_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/my/sessions/:sessionId",
+            "method": "DELETE"
+        }
+    }
+}
+
+test_member_my_sessions_delete_not_allowed {
+    not allowed with input as {
+        "api": {
+            "url": "/api/members/my/sessions/:sessionId",
+            "method": "DELETE"
+        }
+    }
+}
+
+test_member_my_access_tokens_create_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/my/access-tokens",
+            "method": "POST"
+        }
+    }
+}
+
+test_member_my_access_tokens_create_not_allowed {
+    not allowed with input as {
+        "api": {
+            "url": "/api/members/my/access-tokens",
+            "method": "POST"
+        }
+    }
+}
+
+test_member_my_access_tokens_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/my/access-tokens",
+            "method": "GET"
+        }
+    }
+}
+
+test_member_my_access_tokens_read_not_allowed {
+    not allowed with input as {
+        "api": {
+            "url": "/api/members/my/access-tokens",
+            "method": "GET"
+        }
+    }
+}
+
+test_member_my_access_tokens_delete_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/my/access-tokens/:tokenId",
+            "method": "DELETE"
+        }
+    }
+}
+
+test_member_my_access_tokens_delete_not_allowed {
+    not allowed with input as {
+        "api": {
+            "url": "/api/members/my/access-tokens/:tokenId",
+            "method": "DELETE"
+        }
+    }
+}
+
+test_member_impersonation_start_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.impersonate"]
+        },
+        "api": {
+            "url": "/api/members/:id/impersonation",
+            "method": "POST"
+        }
+    }
+}
+
+test_member_impersonation_start_all_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.all"]
+        },
+        "api": {
+            "url": "/api/members/:id/impersonation",
+            "method": "POST"
+        }
+    }
+}
+
+test_member_impersonation_start_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.update"]
+        },
+        "api": {
+            "url": "/api/members/:id/impersonation",
+            "method": "POST"
+        }
+    }
+}
+
+test_member_my_impersonation_end_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/my/impersonation",
+            "method": "DELETE"
+        }
+    }
+}
+
+test_member_my_impersonation_end_not_allowed {
+    not allowed with input as {
+        "api": {
+            "url": "/api/members/my/impersonation",
+            "method": "DELETE"
+        }
+    }
+}
+
+test_member_impersonations_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.read"]
+        },
+        "api": {
+            "url": "/api/members/impersonations",
+            "method": "GET"
+        }
+    }
+}
+
+test_member_impersonations_read_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.impersonate"]
+        },
+        "api": {
+            "url": "/api/members/impersonations",
+            "method": "GET"
+        }
+    }
+}
+
+test_member_my_email_update_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/my/email",
+            "method": "PUT"
+        }
+    }
+}
+
+test_member_my_email_update_not_allowed {
+    not allowed with input as {
+        "api": {
+            "url": "/api/members/my/email",
+            "method": "PUT"
+        }
+    }
+}
+
+test_member_my_email_verification_resend_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/my/email-verification",
+            "method": "POST"
+        }
+    }
+}
+
+test_member_my_email_verification_resend_not_allowed {
+    not allowed with input as {
+        "api": {
+            "url": "/api/members/my/email-verification",
+            "method": "POST"
+        }
+    }
+}
+
+test_member_my_identity_link_create_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/my/identity-links",
+            "method": "POST"
+        }
+    }
+}
+
+test_member_my_identity_link_create_not_allowed {
+    not allowed with input as {
+        "api": {
+            "url": "/api/members/my/identity-links",
+            "method": "POST"
+        }
+    }
+}
+
+test_member_my_identity_link_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/my/identities",
+            "method": "POST"
+        }
+    }
+}
+
+test_member_my_identities_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/my/identities",
+            "method": "GET"
+        }
+    }
+}
+
+test_member_my_identities_read_not_allowed {
+    not allowed with input as {
+        "api": {
+            "url": "/api/members/my/identities",
+            "method": "GET"
+        }
+    }
+}
+
+test_member_my_identity_unlink_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/my/identities/:identityId",
+            "method": "DELETE"
+        }
+    }
+}
+
+test_member_email_verification_allowed {
+    allowed with input as {
+        "api": {
+            "url": "/api/members/email-verification",
+            "method": "POST"
+        }
+    }
+}
+
+test_member_invitations_create_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member-invitation.create"]
+        },
+        "api": {
+            "url": "/api/members/invitations",
+            "method": "POST"
+        }
+    }
+}
+
+test_member_invitations_create_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.update"]
+        },
+        "api": {
+            "url": "/api/members/invitations",
+            "method": "POST"
+        }
+    }
+}
+
+test_member_invitations_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member-invitation.read"]
+        },
+        "api": {
+            "url": "/api/members/invitations",
+            "method": "GET"
+        }
+    }
+}
+
+test_member_invitations_read_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.read"]
+        },
+        "api": {
+            "url": "/api/members/invitations",
+            "method": "GET"
+        }
+    }
+}
+
+test_member_invitations_delete_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member-invitation.all"]
+        },
+        "api": {
+            "url": "/api/members/invitations/:invitationId",
+            "method": "DELETE"
+        }
+    }
+}
+
+test_member_invitations_delete_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member-invitation.create"]
+        },
+        "api": {
+            "url": "/api/members/invitations/:invitationId",
+            "method": "DELETE"
+        }
+    }
+}
+
+test_member_passkeys_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.read"]
+        },
+        "api": {
+            "url": "/api/members/:id/passkeys",
+            "method": "GET"
+        }
+    }
+}
+
+test_member_passkeys_read_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/:id/passkeys",
+            "method": "GET"
+        }
+    }
+}
+
+test_member_passkey_delete_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.update"]
+        },
+        "api": {
+            "url": "/api/members/:id/passkeys/:passkeyId",
+            "method": "DELETE"
+        }
+    }
+}
+
+test_member_passkey_delete_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.read"]
+        },
+        "api": {
+            "url": "/api/members/:id/passkeys/:passkeyId",
+            "method": "DELETE"
+        }
+    }
+}
+
+test_member_identities_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.read"]
+        },
+        "api": {
+            "url": "/api/members/:id/identities",
+            "method": "GET"
+        }
+    }
+}
+
+test_member_identities_read_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.update"]
+        },
+        "api": {
+            "url": "/api/members/:id/identities",
+            "method": "GET"
+        }
+    }
+}
+
+test_member_merge_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.merge"]
+        },
+        "api": {
+            "url": "/api/members/:id/merge",
+            "method": "POST"
+        }
+    }
+}
+
+test_member_merge_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.update"]
+        },
+        "api": {
+            "url": "/api/members/:id/merge",
+            "method": "POST"
+        }
+    }
+}
+
+test_member_revoke_tokens_update_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.update"]
+        },
+        "api": {
+            "url": "/api/members/:id/revoke-tokens",
+            "method": "PUT"
+        }
+    }
+}
+
+test_member_revoke_tokens_update_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.read"]
+        },
+        "api": {
+            "url": "/api/members/:id/revoke-tokens",
+            "method": "PUT"
+        }
+    }
+}
+
+test_member_password_reset_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.update"]
+        },
+        "api": {
+            "url": "/api/members/:id/password-reset",
+            "method": "PUT"
+        }
+    }
+}
+
+test_member_password_reset_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.read"]
+        },
+        "api": {
+            "url": "/api/members/:id/password-reset",
+            "method": "PUT"
+        }
+    }
+}
+
+test_member_unlock_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.update"]
+        },
+        "api": {
+            "url": "/api/members/:id/unlocked",
+            "method": "PUT"
+        }
+    }
+}
+
+test_member_unlock_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.read"]
+        },
+        "api": {
+            "url": "/api/members/:id/unlocked",
+            "method": "PUT"
+        }
+    }
+}
+
+test_member_sessions_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.read"]
+        },
+        "api": {
+            "url": "/api/members/:id/sessions",
+            "method": "GET"
+        }
+    }
+}
+
+test_member_sessions_read_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/:id/sessions",
+            "method": "GET"
+        }
+    }
+}
+
+test_member_sessions_delete_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.update"]
+        },
+        "api": {
+            "url": "/api/members/:id/sessions/:sessionId",
+            "method": "DELETE"
+        }
+    }
+}
+
+test_member_sessions_delete_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.read"]
+        },
+        "api": {
+            "url": "/api/members/:id/sessions/:sessionId",
+            "method": "DELETE"
+        }
+    }
+}
+
+test_members_search_filters_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/members/search-filters",
+            "method": "GET"
+        }
+    }
+}
+
+test_members_search_filters_read_not_allowed {
+    not allowed with input as {
+        "api": {
+            "url": "/api/members/search-filters",
+            "method": "GET"
+        }
+    }
+}
+
+test_organization_create_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["organization.create"]
+        },
+        "api": {
+            "url": "/api/organizations",
+            "method": "POST"
+        }
+    }
+}
+
+test_organization_create_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/organizations",
+            "method": "POST"
+        }
+    }
+}
+
+test_organizations_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["organization.read"]
+        },
+        "api": {
+            "url": "/api/organizations",
+            "method": "GET"
+        }
+    }
+}
+
+test_organization_create_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["organization.create"]
+        },
+        "api": {
+            "url": "/api/organizations",
+            "method": "GET"
+        }
+    }
+}
+
+test_organization_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["organization.read"]
+        },
+        "api": {
+            "url": "/api/organizations/:organizationId",
+            "method": "GET"
+        }
+    }
+}
+
+test_organization_read_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["organization.create"]
+        },
+        "api": {
+            "url": "/api/organizations/:organizationId",
+            "method": "GET"
+        }
+    }
+}
+
+test_organization_delete_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["organization.delete"]
+        },
+        "api": {
+            "url": "/api/organizations/:organizationId",
+            "method": "DELETE"
+        }
+    }
+}
+
+test_organization_delete_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["organization.read"]
+        },
+        "api": {
+            "url": "/api/organizations/:organizationId",
+            "method": "DELETE"
+        }
+    }
+}
+
+test_organization_change_name_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["organization.update"]
+        },
+        "api": {
+            "url": "/api/organizations/:organizationId/name",
+            "method": "PUT"
+        }
+    }
+}
+
+test_organization_change_name_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["organization.read"]
+        },
+        "api": {
+            "url": "/api/organizations/:organizationId/name",
+            "method": "PUT"
+        }
+    }
+}
+
+test_organization_change_position_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["organization.update"]
+        },
+        "api": {
+            "url": "/api/organizations/:organizationId/change-position",
+            "method": "PUT"
+        }
+    }
+}
+
+test_organization_change_position_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["organization.read"]
+        },
+        "api": {
+            "url": "/api/organizations/:organizationId/change-position",
+            "method": "PUT"
+        }
+    }
+}
+
+test_organization_assign_roles_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["organization.update"]
+        },
+        "api": {
+            "url": "/api/organizations/:organizationId/assign-roles",
+            "method": "PUT"
+        }
+    }
+}
+
+test_organization_assign_roles_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["organization.read"]
+        },
+        "api": {
+            "url": "/api/organizations/:organizationId/assign-roles",
+            "method": "PUT"
+        }
+    }
+}
+
+test_organization_assign_members_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["organization.update"]
+        },
+        "api": {
+            "url": "/api/organizations/:organizationId/assign-members",
+            "method": "PUT"
+        }
+    }
+}
+
+test_organization_assign_members_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["organization.read"]
+        },
+        "api": {
+            "url": "/api/organizations/:organizationId/assign-members",
+            "method": "PUT"
+        }
+    }
+}
+
+test_site_settings_read_allowed {
+    allowed with input as {
+        "api": {
+            "url": "/api/site/settings",
+            "method": "GET"
+        }
+    }
+}
+
+test_site_settings_dooray_login_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.read"]
+        },
+        "api": {
+            "url": "/api/site/settings/dooray-login",
+            "method": "GET"
+        }
+    }
+}
+
+test_site_settings_dooray_login_read_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/site/settings/dooray-login",
+            "method": "GET"
+        }
+    }
+}
+
+test_site_settings_dooray_login_update_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.update"]
+        },
+        "api": {
+            "url": "/api/site/settings/dooray-login",
+            "method": "PUT"
+        }
+    }
+}
+
+test_site_settings_dooray_login_update_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.read"]
+        },
+        "api": {
+            "url": "/api/site/settings/dooray-login",
+            "method": "PUT"
+        }
+    }
+}
+
+test_site_settings_google_workspace_login_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.read"]
+        },
+        "api": {
+            "url": "/api/site/settings/google-workspace-login",
+            "method": "GET"
+        }
+    }
+}
+
+test_site_settings_google_workspace_login_read_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.update"]
+        },
+        "api": {
+            "url": "/api/site/settings/google-workspace-login",
+            "method": "GET"
+        }
+    }
+}
+
+test_site_settings_google_workspace_login_update_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.update"]
+        },
+        "api": {
+            "url": "/api/site/settings/google-workspace-login",
+            "method": "PUT"
+        }
+    }
+}
+
+test_site_settings_google_workspace_login_update_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.read"]
+        },
+        "api": {
+            "url": "/api/site/settings/google-workspace-login",
+            "method": "PUT"
+        }
+    }
+}
+
+test_site_settings_webauthn_login_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.read"]
+        },
+        "api": {
+            "url": "/api/site/settings/webauthn-login",
+            "method": "GET"
+        }
+    }
+}
+
+test_site_settings_webauthn_login_read_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/site/settings/webauthn-login",
+            "method": "GET"
+        }
+    }
+}
+
+test_site_settings_webauthn_login_update_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.update"]
+        },
+        "api": {
+            "url": "/api/site/settings/webauthn-login",
+            "method": "PUT"
+        }
+    }
+}
+
+test_site_settings_webauthn_login_update_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.read"]
+        },
+        "api": {
+            "url": "/api/site/settings/webauthn-login",
+            "method": "PUT"
+        }
+    }
+}
+
+test_site_settings_oidc_login_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.read"]
+        },
+        "api": {
+            "url": "/api/site/settings/oidc-login",
+            "method": "GET"
+        }
+    }
+}
+
+test_site_settings_oidc_login_read_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/site/settings/oidc-login",
+            "method": "GET"
+        }
+    }
+}
+
+test_site_settings_oidc_login_update_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.update"]
+        },
+        "api": {
+            "url": "/api/site/settings/oidc-login",
+            "method": "PUT"
+        }
+    }
+}
+
+test_site_settings_oidc_login_update_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.read"]
+        },
+        "api": {
+            "url": "/api/site/settings/oidc-login",
+            "method": "PUT"
+        }
+    }
+}
+
+test_site_settings_ldap_login_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.read"]
+        },
+        "api": {
+            "url": "/api/site/settings/ldap-login",
+            "method": "GET"
+        }
+    }
+}
+
+test_site_settings_ldap_login_read_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/site/settings/ldap-login",
+            "method": "GET"
+        }
+    }
+}
+
+test_site_settings_ldap_login_update_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.update"]
+        },
+        "api": {
+            "url": "/api/site/settings/ldap-login",
+            "method": "PUT"
+        }
+    }
+}
+
+test_site_settings_ldap_login_update_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.read"]
+        },
+        "api": {
+            "url": "/api/site/settings/ldap-login",
+            "method": "PUT"
+        }
+    }
+}
+
+test_site_settings_two_factor_auth_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.read"]
+        },
+        "api": {
+            "url": "/api/site/settings/two-factor-auth",
+            "method": "GET"
+        }
+    }
+}
+
+test_site_settings_two_factor_auth_read_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/site/settings/two-factor-auth",
+            "method": "GET"
+        }
+    }
+}
+
+test_site_settings_two_factor_auth_update_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.update"]
+        },
+        "api": {
+            "url": "/api/site/settings/two-factor-auth",
+            "method": "PUT"
+        }
+    }
+}
+
+test_site_settings_two_factor_auth_update_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.read"]
+        },
+        "api": {
+            "url": "/api/site/settings/two-factor-auth",
+            "method": "PUT"
+        }
+    }
+}
+
+test_site_settings_password_policy_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.read"]
+        },
+        "api": {
+            "url": "/api/site/settings/password-policy",
+            "method": "GET"
+        }
+    }
+}
+
+test_site_settings_password_policy_read_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/site/settings/password-policy",
+            "method": "GET"
+        }
+    }
+}
+
+test_site_settings_password_policy_update_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.update"]
+        },
+        "api": {
+            "url": "/api/site/settings/password-policy",
+            "method": "PUT"
+        }
+    }
+}
+
+test_site_settings_password_policy_update_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.read"]
+        },
+        "api": {
+            "url": "/api/site/settings/password-policy",
+            "method": "PUT"
+        }
+    }
+}
+
+test_site_settings_login_protection_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.read"]
+        },
+        "api": {
+            "url": "/api/site/settings/login-protection",
+            "method": "GET"
+        }
+    }
+}
+
+test_site_settings_login_protection_read_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/site/settings/login-protection",
+            "method": "GET"
+        }
+    }
+}
+
+test_site_settings_login_protection_update_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.update"]
+        },
+        "api": {
+            "url": "/api/site/settings/login-protection",
+            "method": "PUT"
+        }
+    }
+}
+
+test_site_settings_login_protection_update_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.read"]
+        },
+        "api": {
+            "url": "/api/site/settings/login-protection",
+            "method": "PUT"
+        }
+    }
+}
+
+test_site_settings_member_access_log_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.read"]
+        },
+        "api": {
+            "url": "/api/site/settings/member-access-log",
+            "method": "GET"
+        }
+    }
+}
+
+test_site_settings_member_access_log_read_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/site/settings/member-access-log",
+            "method": "GET"
+        }
+    }
+}
+
+test_site_settings_member_access_log_update_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.update"]
+        },
+        "api": {
+            "url": "/api/site/settings/member-access-log",
+            "method": "PUT"
+        }
+    }
+}
+
+test_site_settings_member_access_log_update_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.read"]
+        },
+        "api": {
+            "url": "/api/site/settings/member-access-log",
+            "method": "PUT"
+        }
+    }
+}
+
+test_site_settings_sign_up_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.read"]
+        },
+        "api": {
+            "url": "/api/site/settings/sign-up",
+            "method": "GET"
+        }
+    }
+}
+
+test_site_settings_sign_up_read_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/site/settings/sign-up",
+            "method": "GET"
+        }
+    }
+}
+
+test_site_settings_sign_up_update_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.update"]
         },
         "api": {
-            "url": "/api/site/settings/dooray-login",
+            "url": "/api/site/settings/sign-up",
             "method": "PUT"
         }
     }
 }
 
-test_site_settings_google_workspace_login_read_allowed {
+test_site_settings_sign_up_update_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.read"]
+        },
+        "api": {
+            "url": "/api/site/settings/sign-up",
+            "method": "PUT"
+        }
+    }
+}
+
+test_site_settings_member_provisioning_read_allowed {
     allowed with input as {
         "member": {
             "id": 1,
             "permissions": ["site-settings.read"]
         },
         "api": {
-            "url": "/api/site/settings/google-workspace-login",
+            "url": "/api/site/settings/member-provisioning",
             "method": "GET"
         }
     }
 }
 
-test_site_settings_google_workspace_login_read_not_allowed {
+test_site_settings_member_provisioning_read_not_allowed {
     not allowed with input as {
         "member": {
             "id": 1,
-            "permissions": ["site-settings.update"]
+            "permissions": []
         },
         "api": {
-            "url": "/api/site/settings/google-workspace-login",
+            "url": "/api/site/settings/member-provisioning",
             "method": "GET"
         }
     }
 }
 
-test_site_settings_google_workspace_login_update_allowed {
+test_site_settings_member_provisioning_update_allowed {
     allowed with input as {
         "member": {
             "id": 1,
             "permissions": ["site-settings.update"]
         },
         "api": {
-            "url": "/api/site/settings/google-workspace-login",
+            "url": "/api/site/settings/member-provisioning",
             "method": "PUT"
         }
     }
 }
 
-test_site_settings_google_workspace_login_update_not_allowed {
+test_site_settings_member_provisioning_update_not_allowed {
     not allowed with input as {
         "member": {
             "id": 1,
             "permissions": ["site-settings.read"]
         },
         "api": {
-            "url": "/api/site/settings/google-workspace-login",
+            "url": "/api/site/settings/member-provisioning",
             "method": "PUT"
         }
     }
 }
 
+test_site_settings_member_provisioning_dry_run_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.read"]
+        },
+        "api": {
+            "url": "/api/site/settings/member-provisioning/dry-run",
+            "method": "POST"
+        }
+    }
+}
+
+test_site_settings_member_provisioning_dry_run_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["member.read"]
+        },
+        "api": {
+            "url": "/api/site/settings/member-provisioning/dry-run",
+            "method": "POST"
+        }
+    }
+}
+
+test_site_settings_scim_tokens_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.read"]
+        },
+        "api": {
+            "url": "/api/site/settings/scim/tokens",
+            "method": "GET"
+        }
+    }
+}
+
+test_site_settings_scim_tokens_read_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.update"]
+        },
+        "api": {
+            "url": "/api/site/settings/scim/tokens",
+            "method": "GET"
+        }
+    }
+}
+
+test_site_settings_scim_tokens_create_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.update"]
+        },
+        "api": {
+            "url": "/api/site/settings/scim/tokens",
+            "method": "POST"
+        }
+    }
+}
+
+test_site_settings_scim_tokens_create_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.read"]
+        },
+        "api": {
+            "url": "/api/site/settings/scim/tokens",
+            "method": "POST"
+        }
+    }
+}
+
+test_site_settings_scim_tokens_delete_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.update"]
+        },
+        "api": {
+            "url": "/api/site/settings/scim/tokens/:tokenId",
+            "method": "DELETE"
+        }
+    }
+}
+
+test_site_settings_scim_tokens_delete_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.read"]
+        },
+        "api": {
+            "url": "/api/site/settings/scim/tokens/:tokenId",
+            "method": "DELETE"
+        }
+    }
+}
+
 test_site_settings_app_version_read_allowed {
     allowed with input as {
         "api": {
@@ -1011,4 +2979,186 @@ test_web_hook_note_create_not_allowed {
             "method": "POST"
         }
     }
+}
+
+test_oauth_clients_read_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["oauth-client.read"]
+        },
+        "api": {
+            "url": "/api/oauth/clients",
+            "method": "GET"
+        }
+    }
+}
+
+test_oauth_clients_read_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["web-hook.read"]
+        },
+        "api": {
+            "url": "/api/oauth/clients",
+            "method": "GET"
+        }
+    }
+}
+
+test_oauth_clients_create_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["oauth-client.create"]
+        },
+        "api": {
+            "url": "/api/oauth/clients",
+            "method": "POST"
+        }
+    }
+}
+
+test_oauth_clients_create_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["oauth-client.read"]
+        },
+        "api": {
+            "url": "/api/oauth/clients",
+            "method": "POST"
+        }
+    }
+}
+
+test_oauth_client_update_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["oauth-client.update"]
+        },
+        "api": {
+            "url": "/api/oauth/clients/:clientId",
+            "method": "PUT"
+        }
+    }
+}
+
+test_oauth_client_delete_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["oauth-client.update"]
+        },
+        "api": {
+            "url": "/api/oauth/clients/:clientId",
+            "method": "DELETE"
+        }
+    }
+}
+
+test_oauth_client_secret_renew_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["oauth-client.update"]
+        },
+        "api": {
+            "url": "/api/oauth/clients/:clientId/secret",
+            "method": "POST"
+        }
+    }
+}
+
+test_oauth_authorize_allowed {
+    allowed with input as {
+        "api": {
+            "url": "/api/oauth/authorize",
+            "method": "GET"
+        }
+    }
+}
+
+test_oauth_consent_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": []
+        },
+        "api": {
+            "url": "/api/oauth/consent",
+            "method": "POST"
+        }
+    }
+}
+
+test_oauth_consent_not_allowed {
+    not allowed with input as {
+        "api": {
+            "url": "/api/oauth/consent",
+            "method": "POST"
+        }
+    }
+}
+
+test_oauth_token_allowed {
+    allowed with input as {
+        "api": {
+            "url": "/api/oauth/token",
+            "method": "POST"
+        }
+    }
+}
+
+test_oauth_userinfo_allowed {
+    allowed with input as {
+        "api": {
+            "url": "/api/oauth/userinfo",
+            "method": "GET"
+        }
+    }
+}
+
+test_site_settings_scim_tokens_create_with_personal_access_token_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.update"],
+            "personalAccessToken": true
+        },
+        "api": {
+            "url": "/api/site/settings/scim/tokens",
+            "method": "POST"
+        }
+    }
+}
+
+test_site_settings_scim_tokens_read_with_personal_access_token_allowed {
+    allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["site-settings.read"],
+            "personalAccessToken": true
+        },
+        "api": {
+            "url": "/api/site/settings/scim/tokens",
+            "method": "GET"
+        }
+    }
+}
+
+test_oauth_client_secret_renew_with_personal_access_token_not_allowed {
+    not allowed with input as {
+        "member": {
+            "id": 1,
+            "permissions": ["oauth-client.all"],
+            "personalAccessToken": true
+        },
+        "api": {
+            "url": "/api/oauth/clients/:clientId/secret",
+            "method": "POST"
+        }
+    }
 }
\ No newline at end of file
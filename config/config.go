@@ -9,9 +9,21 @@ const (
 	EnvJwtSecret = "JWT_SECRET"
 )
 
+type JwtSigningKey struct {
+	KeyId     string
+	Algorithm string // RS256, ES256, EdDSA
+	// 서명에 사용하는 키는 PrivateKeyFile 을, 검증에만 사용하는(교체되어 은퇴한) 키는 PublicKeyFile 만 설정한다.
+	PrivateKeyFile string
+	PublicKeyFile  string
+}
+
 var Config = struct {
-	JwtSecret string
-	Dooray    struct {
+	JwtSecret  string
+	JwtSigning struct {
+		ActiveKeyId string
+		Keys        []JwtSigningKey
+	}
+	Dooray struct {
 		LdapDialUrl string
 	}
 	GoogleOAuth struct {
@@ -19,6 +31,31 @@ var Config = struct {
 		AuthUri  string
 		TokenUri string
 	}
+	Mail struct {
+		SmtpHost string
+		SmtpPort int
+		Username string
+		Password string
+		From     string
+		// 초대, 이메일 인증 메일의 링크에 사용하는 웹 화면 주소
+		WebUrl string
+	}
+	Session struct {
+		// bearer(기본 값) 또는 cookie. cookie 는 브라우저 세션 모드로 모든 로그인에서 리프레시 토큰을 응답 본문 대신 HttpOnly 쿠키로 전달한다.
+		Mode string
+		// Strict, Lax(기본 값), None. 웹 화면과 API 서버의 사이트가 다른 경우 None 으로 지정한다.
+		CookieSameSite string
+		// HTTPS 를 사용하지 않는 개발 환경에서만 true 로 지정한다.
+		AllowInsecureCookie bool
+		// 쿠키 세션 모드에서 요청을 허용할 웹 화면 주소 목록(예: https://better-admin.example.com). 목록에 없는 주소의 요청은 CORS 에서 거부한다.
+		AllowedOrigins []string
+	}
+	OidcProvider struct {
+		// 내부 앱에 발급하는 id_token 의 iss 이자 디스커버리 문서의 엔드포인트 주소
+		Issuer string
+		// 멤버가 로그인하고 앱에 대한 동의 여부를 선택하는 웹 화면 주소
+		ConsentPageUrl string
+	}
 }{}
 
 func InitConfig(file string) error {
@@ -14,6 +14,8 @@ const (
 	PermissionManageSystemSettings = "MANAGE_SYSTEM_SETTINGS"
 	PermissionNoteWebHooks         = "NOTE_WEB_HOOKS"
 	PermissionViewMonitoring       = "VIEW_MONITORING"
+	// 권한 정책(data.json)의 본인 정보 관리 범위. 모든 멤버가 가지며, 개인 액세스 토큰은 이 범위를 지정한 경우에만 사용할 수 있다.
+	PermissionScopeMemberSelf = "member.self"
 
 	// Member
 	TypeMemberSite       = "site"
@@ -22,12 +24,98 @@ const (
 	TypeMemberDoorayName = "두레이"
 	TypeMemberGoogle     = "google"
 	TypeMemberGoogleName = "구글"
+	TypeMemberOidc       = "oidc"
+	TypeMemberOidcName   = "OIDC"
+	TypeMemberLdap       = "ldap"
+	TypeMemberLdapName   = "LDAP"
+	TypeMemberScim       = "scim"
+	TypeMemberScimName   = "SCIM"
 	StatusMemberApplied  = "applied"
 	StatusMemberApproved = "approved"
+	// 관리자가 가입 신청을 거부한 멤버. 다시 활성화할 수 없다.
+	StatusMemberRejected = "rejected"
+	// SCIM 으로 프로비저닝 해제된 멤버. 삭제하지 않고 로그인만 막는다.
+	StatusMemberDeactivated = "deactivated"
+	// 관리자가 이용을 정지한 멤버
+	StatusMemberSuspended = "suspended"
+	// 보안상의 이유(계정 도용 의심 등)로 관리자가 잠근 멤버
+	StatusMemberLocked = "locked"
+	// 탈퇴한 멤버. 다시 활성화할 수 없다.
+	StatusMemberWithdrawn = "withdrawn"
+	// 계약 종료 등으로 이용 기간이 만료된 멤버
+	StatusMemberExpired         = "expired"
+	StatusMemberAppliedName     = "신청"
+	StatusMemberApprovedName    = "승인"
+	StatusMemberRejectedName    = "거부"
+	StatusMemberDeactivatedName = "비활성"
+	StatusMemberSuspendedName   = "정지"
+	StatusMemberLockedName      = "잠금"
+	StatusMemberWithdrawnName   = "탈퇴"
+	StatusMemberExpiredName     = "만료"
+	TwoFactorAuthIssuer         = "better-admin"
+
+	// 멤버 프로필 항목
+	ProfileFieldName        = "name"
+	ProfileFieldPhoneNumber = "phoneNumber"
+	ProfileFieldPicture     = "picture"
 
 	// Settings
 	SettingKeyDoorayLogin          = "dooray-login"
 	SettingKeyGoogleWorkspaceLogin = "google-workspace-login"
 	SettingKeyMemberAccessLog      = "member-access-log"
 	SettingKeyAppVersion           = "app-version"
+	SettingKeyTwoFactorAuth        = "two-factor-auth"
+	SettingKeyWebAuthnLogin        = "webauthn-login"
+	SettingKeyOidcLogin            = "oidc-login"
+	SettingKeyMemberProvisioning   = "member-provisioning"
+	SettingKeyLdapLogin            = "ldap-login"
+	SettingKeyPasswordPolicy       = "password-policy"
+	SettingKeyLoginProtection      = "login-protection"
+	SettingKeySignUp               = "sign-up"
+
+	// Sign up
+	SignUpModeOpen       = "open"
+	SignUpModeInviteOnly = "invite-only"
+	SignUpModeDisabled   = "disabled"
+
+	// Member bulk operation
+	// 한 멤버라도 실패하면 모든 멤버의 변경을 취소한다.
+	MemberBulkModeTransaction = "transaction"
+	// 실패한 멤버의 변경만 취소하고 성공한 멤버의 변경은 반영한다.
+	MemberBulkModeBestEffort = "best-effort"
+
+	// Member access log
+	MemberAccessTypeLogin        = "login"
+	MemberAccessTypeTokenRefresh = "token-refresh"
+	MemberAccessResultSuccess    = "success"
+	MemberAccessResultFailure    = "failure"
+	AuthTypePasskey              = "passkey"
+
+	// SCIM 2.0(RFC 7643, 7644)
+	ScimSchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	ScimSchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ScimSchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	ScimSchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ScimSchemaBulkRequest           = "urn:ietf:params:scim:api:messages:2.0:BulkRequest"
+	ScimSchemaBulkResponse          = "urn:ietf:params:scim:api:messages:2.0:BulkResponse"
+	ScimSchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	ScimSchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	ScimSchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	ScimContentType                 = "application/scim+json"
+
+	// OpenID Connect 제공자(내부 앱 로그인)
+	OAuthScopeOpenId                  = "openid"
+	OAuthScopeProfile                 = "profile"
+	OAuthScopeEmail                   = "email"
+	OAuthResponseTypeCode             = "code"
+	OAuthGrantTypeAuthorizationCode   = "authorization_code"
+	OAuthCodeChallengeMethodS256      = "S256"
+	OAuthErrorInvalidRequest          = "invalid_request"
+	OAuthErrorInvalidClient           = "invalid_client"
+	OAuthErrorInvalidGrant            = "invalid_grant"
+	OAuthErrorInvalidScope            = "invalid_scope"
+	OAuthErrorInvalidToken            = "invalid_token"
+	OAuthErrorAccessDenied            = "access_denied"
+	OAuthErrorUnsupportedResponseType = "unsupported_response_type"
+	OAuthErrorUnsupportedGrantType    = "unsupported_grant_type"
 )
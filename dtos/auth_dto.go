@@ -5,6 +5,22 @@ type MemberSignIn struct {
 	Password string `json:"password" binding:"required"`
 }
 
+// MemberPasswordChangeSignIn 비밀번호를 변경해야 하는 멤버가 로그인 과정에서 비밀번호를 변경한다.
+type MemberPasswordChangeSignIn struct {
+	PasswordChangeToken string `json:"passwordChangeToken" binding:"required"`
+	NewPassword         string `json:"newPassword" binding:"required"`
+}
+
+type MemberTwoFactorSignIn struct {
+	TwoFactorToken string `json:"twoFactorToken" binding:"required"`
+	// 인증 앱의 6자리 코드 또는 복구 코드
+	Code string `json:"code" binding:"required"`
+}
+
+type MemberTwoFactorEnrollmentRequest struct {
+	TwoFactorToken string `json:"twoFactorToken" binding:"required"`
+}
+
 type DoorayMember struct {
 	Id                   string `json:"id"`
 	UserCode             string `json:"userCode"`
@@ -19,3 +35,26 @@ type GoogleMember struct {
 	Picture string `json:"picture"`
 	Hd      string `json:"hd"`
 }
+
+type LdapMember struct {
+	UserId string
+	Dn     string
+	Name   string
+	Email  string
+	Groups []string
+}
+
+type OidcMember struct {
+	Issuer  string
+	Subject string
+	Name    string
+	Email   string
+	Picture string
+	// IdP 가 메일 주소를 확인했는지 여부(email_verified 클레임)
+	EmailVerified bool
+}
+
+// LoginCodeExchange 외부 IdP 로그인 후 리다이렉트로 전달받은 일회용 로그인 코드
+type LoginCodeExchange struct {
+	Code string `json:"code" binding:"required"`
+}
@@ -3,3 +3,21 @@ package dtos
 type ErrorMessage struct {
 	Message string `json:"message"`
 }
+
+// PasswordPolicyViolationMessage 비밀번호 정책을 만족하지 않는 항목(min-length, max-length, uppercase, lowercase, digit, special)
+type PasswordPolicyViolationMessage struct {
+	Message    string   `json:"message"`
+	Violations []string `json:"violations"`
+}
+
+// LoginRestrictionMessage 연속된 로그인 실패로 로그인이 제한된 경우 다시 시도할 수 있을 때까지 남은 시간(초)
+type LoginRestrictionMessage struct {
+	Message           string `json:"message"`
+	RetryAfterSeconds int    `json:"retryAfterSeconds"`
+}
+
+// InactiveMemberMessage 로그인할 수 없는 멤버의 상태(suspended, locked, withdrawn, expired, deactivated)
+type InactiveMemberMessage struct {
+	Message string `json:"message"`
+	Status  string `json:"status"`
+}
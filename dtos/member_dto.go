@@ -1,6 +1,7 @@
 package dtos
 
 import (
+	"better-admin-backend-service/constants"
 	"time"
 )
 
@@ -11,6 +12,12 @@ type MemberInformation struct {
 	TypeName            string               `json:"typeName"`
 	CandidateId         string               `json:"candidateId"`
 	Name                string               `json:"name"`
+	Email               string               `json:"email"`
+	EmailVerified       bool                 `json:"emailVerified"`
+	Status              string               `json:"status"`
+	StatusName          string               `json:"statusName"`
+	StatusReason        string               `json:"statusReason"`
+	StatusChangedAt     *time.Time           `json:"statusChangedAt"`
 	MemberRoles         []MemberRole         `json:"roles"`
 	MemberOrganizations []MemberOrganization `json:"organizations"`
 	CreatedAt           time.Time            `json:"createdAt"`
@@ -45,6 +52,17 @@ type CurrentMember struct {
 	Roles       []string `json:"roles"`
 	Permissions []string `json:"permissions"`
 	Picture     string   `json:"picture"`
+	// 2단계 인증 사용 여부
+	TwoFactorEnabled bool   `json:"twoFactorEnabled"`
+	Email            string `json:"email"`
+	EmailVerified    bool   `json:"emailVerified"`
+	// 대리 로그인 중인 경우 대리 로그인한 관리자
+	Impersonator *CurrentMemberImpersonator `json:"impersonator,omitempty"`
+}
+
+type CurrentMemberImpersonator struct {
+	Id   uint   `json:"id"`
+	Name string `json:"name"`
 }
 
 type MemberAssignedAllRoleAndPermission struct {
@@ -56,4 +74,209 @@ type MemberSignUp struct {
 	SignId   string `json:"signId" binding:"required"`
 	Name     string `json:"name" binding:"required"`
 	Password string `json:"password" binding:"required"`
+	Email    string `json:"email" binding:"omitempty,email,max=100"`
+	// 초대를 받아 가입하는 경우 초대 메일의 토큰
+	InvitationToken string `json:"invitationToken"`
+}
+
+type MemberPasswordChange struct {
+	CurrentPassword string `json:"currentPassword" binding:"required"`
+	NewPassword     string `json:"newPassword" binding:"required"`
+}
+
+type MemberPasswordReset struct {
+	// 멤버가 다음 로그인 할 때 변경해야 하는 임시 비밀번호
+	TemporaryPassword string `json:"temporaryPassword"`
+}
+
+type MemberTwoFactorEnrollment struct {
+	Secret     string `json:"secret"`
+	OtpAuthUri string `json:"otpAuthUri"`
+}
+
+type MemberTwoFactorCode struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type MemberTwoFactorRecoveryCodes struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+type MemberAccessLog struct {
+	Id            uint      `json:"id"`
+	MemberId      uint      `json:"memberId"`
+	LoginId       string    `json:"loginId"`
+	Type          string    `json:"type"`
+	AuthType      string    `json:"authType"`
+	Result        string    `json:"result"`
+	FailureReason string    `json:"failureReason,omitempty"`
+	IpAddress     string    `json:"ipAddress"`
+	UserAgent     string    `json:"userAgent"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+type MemberSession struct {
+	Id             uint      `json:"id"`
+	AuthType       string    `json:"authType"`
+	IpAddress      string    `json:"ipAddress"`
+	UserAgent      string    `json:"userAgent"`
+	Current        bool      `json:"current"`
+	CreatedAt      time.Time `json:"createdAt"`
+	LastAccessedAt time.Time `json:"lastAccessedAt"`
+	ExpiresAt      time.Time `json:"expiresAt"`
+}
+
+type PersonalAccessTokenCreation struct {
+	Name          string   `json:"name" binding:"required,max=100"`
+	Permissions   []string `json:"permissions" binding:"required,dive,required"`
+	ExpiresInDays int      `json:"expiresInDays" binding:"required,min=1,max=365"`
+}
+
+type PersonalAccessToken struct {
+	Id           uint       `json:"id"`
+	Name         string     `json:"name"`
+	DisplayToken string     `json:"displayToken"`
+	Permissions  []string   `json:"permissions"`
+	ExpiresAt    time.Time  `json:"expiresAt"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	LastUsedAt   *time.Time `json:"lastUsedAt"`
+	// 발급할 때 한 번만 반환한다.
+	Token string `json:"token,omitempty"`
+}
+
+type ImpersonationStart struct {
+	Reason string `json:"reason" binding:"max=200"`
+}
+
+type MemberImpersonation struct {
+	Id             uint       `json:"id"`
+	ImpersonatorId uint       `json:"impersonatorId"`
+	MemberId       uint       `json:"memberId"`
+	Reason         string     `json:"reason"`
+	IpAddress      string     `json:"ipAddress"`
+	UserAgent      string     `json:"userAgent"`
+	ExpiresAt      time.Time  `json:"expiresAt"`
+	EndedAt        *time.Time `json:"endedAt"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
+
+type MemberEmailChange struct {
+	Email string `json:"email" binding:"required,email,max=100"`
+}
+
+type MemberProfile struct {
+	Name        string `json:"name"`
+	PhoneNumber string `json:"phoneNumber"`
+	Picture     string `json:"picture"`
+	// 외부 인증 제공자가 원본이어서 멤버가 변경할 수 없는 항목
+	ManagedFields []string `json:"managedFields"`
+}
+
+type MemberProfileUpdate struct {
+	Name        string `json:"name" binding:"required,max=50"`
+	PhoneNumber string `json:"phoneNumber" binding:"omitempty,max=20"`
+	Picture     string `json:"picture" binding:"omitempty,url,max=1000"`
+}
+
+type MemberEmailVerification struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type MemberInvitationCreation struct {
+	Email           string `json:"email" binding:"required,email,max=100"`
+	RoleIds         []uint `json:"roleIds"`
+	OrganizationIds []uint `json:"organizationIds"`
+	ExpiresInDays   int    `json:"expiresInDays" binding:"required,min=1,max=30"`
+}
+
+type MemberInvitation struct {
+	Id               uint       `json:"id"`
+	Email            string     `json:"email"`
+	RoleIds          []uint     `json:"roleIds"`
+	OrganizationIds  []uint     `json:"organizationIds"`
+	ExpiresAt        time.Time  `json:"expiresAt"`
+	AcceptedAt       *time.Time `json:"acceptedAt"`
+	AcceptedMemberId uint       `json:"acceptedMemberId"`
+	CreatedBy        uint       `json:"createdBy"`
+	CreatedAt        time.Time  `json:"createdAt"`
+}
+
+type MemberIdentity struct {
+	// 멤버가 처음 가입한 로그인은 0
+	Id        uint   `json:"id"`
+	Type      string `json:"type"`
+	TypeName  string `json:"typeName"`
+	DisplayId string `json:"displayId"`
+	Primary   bool   `json:"primary"`
+	// 멤버가 처음 가입한 로그인은 nil
+	LinkedAt *time.Time `json:"linkedAt"`
+}
+
+type MemberIdentityLink struct {
+	LinkToken string    `json:"linkToken"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+type MemberIdentityLinking struct {
+	LinkToken string `json:"linkToken" binding:"required"`
+}
+
+type MemberMerge struct {
+	SourceMemberId uint `json:"sourceMemberId" binding:"required"`
+}
+
+type MemberStatusChange struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+type MemberStatusHistory struct {
+	Id         uint      `json:"id"`
+	FromStatus string    `json:"fromStatus"`
+	ToStatus   string    `json:"toStatus"`
+	Reason     string    `json:"reason"`
+	ActorId    uint      `json:"actorId"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// MemberBulkOperation 모드(transaction, best-effort)를 지정하지 않으면 transaction 으로 수행한다.
+type MemberBulkOperation struct {
+	MemberIds []uint `json:"memberIds" binding:"required,min=1,max=500,unique,dive,required"`
+	Mode      string `json:"mode" binding:"omitempty,oneof=transaction best-effort"`
+}
+
+func (o MemberBulkOperation) GetMode() string {
+	if len(o.Mode) == 0 {
+		return constants.MemberBulkModeTransaction
+	}
+	return o.Mode
+}
+
+type MemberBulkAssignRole struct {
+	MemberBulkOperation
+	RoleIds []uint `json:"roleIds" binding:"required"`
+}
+
+type MemberBulkAddOrganization struct {
+	MemberBulkOperation
+	OrganizationId uint `json:"organizationId" binding:"required"`
+}
+
+type MemberBulkStatusChange struct {
+	MemberBulkOperation
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+// MemberBulkResult 트랜잭션 모드에서 실패한 멤버가 있으면 성공한 멤버의 변경도 모두 취소(rolledBack)된다.
+type MemberBulkResult struct {
+	Mode         string                 `json:"mode"`
+	RolledBack   bool                   `json:"rolledBack"`
+	SuccessCount int                    `json:"successCount"`
+	FailureCount int                    `json:"failureCount"`
+	Results      []MemberBulkItemResult `json:"results"`
+}
+
+type MemberBulkItemResult struct {
+	MemberId uint   `json:"memberId"`
+	Success  bool   `json:"success"`
+	Error    string `json:"error,omitempty"`
 }
@@ -0,0 +1,118 @@
+package dtos
+
+import (
+	"net/url"
+	"time"
+)
+
+type OAuthClientInformation struct {
+	Name         string   `json:"name" binding:"required,max=100"`
+	RedirectUris []string `json:"redirectUris" binding:"required,min=1"`
+	// 시크릿을 안전하게 보관할 수 없는 앱(SPA, 모바일 앱)은 공개 클라이언트로 등록한다. 등록한 뒤에는 변경할 수 없다.
+	Public      bool `json:"public"`
+	SkipConsent bool `json:"skipConsent"`
+}
+
+type OAuthClient struct {
+	ClientId     string    `json:"clientId"`
+	Name         string    `json:"name"`
+	RedirectUris []string  `json:"redirectUris"`
+	Public       bool      `json:"public"`
+	SkipConsent  bool      `json:"skipConsent"`
+	CreatedAt    time.Time `json:"createdAt"`
+	// 등록하거나 재발급할 때 한 번만 반환한다.
+	ClientSecret string `json:"clientSecret,omitempty"`
+}
+
+// OAuthAuthorizationRequest https://openid.net/specs/openid-connect-core-1_0.html#AuthRequest
+// 인가 엔드포인트는 쿼리로, 동의 API 는 웹 화면이 쿼리를 그대로 옮겨 담은 JSON 으로 받는다.
+type OAuthAuthorizationRequest struct {
+	ResponseType        string `form:"response_type" json:"responseType"`
+	ClientId            string `form:"client_id" json:"clientId"`
+	RedirectUri         string `form:"redirect_uri" json:"redirectUri"`
+	Scope               string `form:"scope" json:"scope"`
+	State               string `form:"state" json:"state"`
+	Nonce               string `form:"nonce" json:"nonce"`
+	CodeChallenge       string `form:"code_challenge" json:"codeChallenge"`
+	CodeChallengeMethod string `form:"code_challenge_method" json:"codeChallengeMethod"`
+}
+
+// NewRedirectUri 앱의 리다이렉트 URI 에 인가 응답(code 또는 error)과 state 를 붙인다.
+// 등록된 리다이렉트 URI 와 일치하는지 확인한 뒤에만 사용한다.
+func (r OAuthAuthorizationRequest) NewRedirectUri(params url.Values) string {
+	redirectUri, err := url.Parse(r.RedirectUri)
+	if err != nil {
+		return r.RedirectUri
+	}
+
+	query := redirectUri.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	if len(r.State) > 0 {
+		query.Set("state", r.State)
+	}
+	redirectUri.RawQuery = query.Encode()
+
+	return redirectUri.String()
+}
+
+type OAuthConsent struct {
+	ClientId   string   `json:"clientId"`
+	ClientName string   `json:"clientName"`
+	Scopes     []string `json:"scopes"`
+	// 이미 동의했거나 동의를 생략하는 앱이면 false
+	ConsentRequired bool `json:"consentRequired"`
+}
+
+type OAuthConsentDecision struct {
+	OAuthAuthorizationRequest
+	Approved bool `json:"approved"`
+}
+
+// OAuthAuthorizationResponse 웹 화면은 redirectUri 로 이동해서 인가 코드(또는 오류)를 앱에 전달한다.
+type OAuthAuthorizationResponse struct {
+	RedirectUri string `json:"redirectUri"`
+}
+
+// OAuthTokenRequest https://datatracker.ietf.org/doc/html/rfc6749#section-4.1.3
+type OAuthTokenRequest struct {
+	GrantType    string `form:"grant_type"`
+	Code         string `form:"code"`
+	RedirectUri  string `form:"redirect_uri"`
+	CodeVerifier string `form:"code_verifier"`
+	// client_secret_post 방식 또는 공개 클라이언트
+	ClientId     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+}
+
+type OAuthToken struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+	IdToken     string `json:"id_token"`
+	Scope       string `json:"scope"`
+}
+
+// OAuthError https://datatracker.ietf.org/doc/html/rfc6749#section-5.2
+type OAuthError struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// OpenIdConfiguration https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderMetadata
+type OpenIdConfiguration struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserinfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JwksUri                           string   `json:"jwks_uri"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IdTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
@@ -15,12 +15,26 @@ const PageSize = 20
 type Pageable struct {
 	Page     int
 	PageSize int
+	// Offset 페이지가 아닌 항목 위치(0부터)로 조회할 때 지정한다.(SCIM 의 startIndex)
+	Offset int
 }
 
 func (p Pageable) GetOffset() int {
+	if p.Offset > 0 {
+		return p.Offset
+	}
 	return (p.Page - 1) * p.PageSize
 }
 
+// NewOffsetPageable 항목 위치(0부터)부터 limit 개를 조회한다.
+func NewOffsetPageable(offset, limit int) Pageable {
+	return Pageable{
+		Page:     1,
+		PageSize: limit,
+		Offset:   offset,
+	}
+}
+
 func NewPageableFromRequest(ctx *gin.Context) Pageable {
 	page, err := strconv.Atoi(ctx.Query("page"))
 	if err != nil {
@@ -0,0 +1,407 @@
+package dtos
+
+import (
+	"better-admin-backend-service/errors"
+	"encoding/json"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type ScimTokenCreation struct {
+	Name string `json:"name" binding:"required,max=100"`
+}
+
+type ScimToken struct {
+	Id           uint       `json:"id"`
+	Name         string     `json:"name"`
+	DisplayToken string     `json:"displayToken"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	LastUsedAt   *time.Time `json:"lastUsedAt"`
+	// 발급할 때 한 번만 반환한다.
+	Token string `json:"token,omitempty"`
+}
+
+type ScimMeta struct {
+	ResourceType string    `json:"resourceType"`
+	Created      time.Time `json:"created"`
+	LastModified time.Time `json:"lastModified"`
+	Location     string    `json:"location"`
+}
+
+type ScimName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+}
+
+type ScimMultiValuedAttribute struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+type ScimUser struct {
+	Schemas      []string                   `json:"schemas"`
+	Id           string                     `json:"id,omitempty"`
+	ExternalId   string                     `json:"externalId,omitempty"`
+	UserName     string                     `json:"userName" binding:"required,max=100"`
+	Name         *ScimName                  `json:"name,omitempty"`
+	DisplayName  string                     `json:"displayName,omitempty"`
+	Emails       []ScimMultiValuedAttribute `json:"emails,omitempty"`
+	PhoneNumbers []ScimMultiValuedAttribute `json:"phoneNumbers,omitempty"`
+	// 없으면 활성 상태로 본다.
+	Active *bool     `json:"active,omitempty"`
+	Meta   *ScimMeta `json:"meta,omitempty"`
+}
+
+// GetName 표시 이름, 전체 이름, 이름과 성, 사용자 이름 순으로 사용한다.
+func (u ScimUser) GetName() string {
+	if len(u.DisplayName) > 0 {
+		return u.DisplayName
+	}
+
+	if u.Name != nil {
+		if len(u.Name.Formatted) > 0 {
+			return u.Name.Formatted
+		}
+
+		if name := strings.TrimSpace(u.Name.GivenName + " " + u.Name.FamilyName); len(name) > 0 {
+			return name
+		}
+	}
+
+	return u.UserName
+}
+
+func (u ScimUser) GetEmail() string {
+	return getPrimaryValue(u.Emails)
+}
+
+func (u ScimUser) GetPhoneNumber() string {
+	return getPrimaryValue(u.PhoneNumbers)
+}
+
+func (u ScimUser) IsActive() bool {
+	return u.Active == nil || *u.Active
+}
+
+// getPrimaryValue 대표(primary) 값이 없으면 첫 번째 값을 사용한다.
+func getPrimaryValue(attributes []ScimMultiValuedAttribute) string {
+	for _, attribute := range attributes {
+		if attribute.Primary {
+			return attribute.Value
+		}
+	}
+
+	if len(attributes) > 0 {
+		return attributes[0].Value
+	}
+
+	return ""
+}
+
+type ScimGroupMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+}
+
+type ScimGroup struct {
+	Schemas     []string          `json:"schemas"`
+	Id          string            `json:"id,omitempty"`
+	DisplayName string            `json:"displayName" binding:"required,max=100"`
+	Members     []ScimGroupMember `json:"members"`
+	Meta        *ScimMeta         `json:"meta,omitempty"`
+}
+
+type ScimListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int64    `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    any      `json:"Resources"`
+}
+
+type ScimError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+type ScimPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []ScimPatchOperation `json:"Operations" binding:"required,min=1"`
+}
+
+type ScimPatchOperation struct {
+	Op    string `json:"op" binding:"required"`
+	Path  string `json:"path"`
+	Value any    `json:"value"`
+}
+
+type ScimBulkRequest struct {
+	Schemas []string `json:"schemas"`
+	// 오류가 이 개수만큼 발생하면 나머지 작업은 처리하지 않는다.(0 이면 모두 처리)
+	FailOnErrors int                 `json:"failOnErrors"`
+	Operations   []ScimBulkOperation `json:"Operations" binding:"required,min=1,max=1000"`
+}
+
+type ScimBulkOperation struct {
+	Method string          `json:"method" binding:"required"`
+	BulkId string          `json:"bulkId"`
+	Path   string          `json:"path" binding:"required"`
+	Data   json.RawMessage `json:"data"`
+}
+
+type ScimBulkResponse struct {
+	Schemas    []string                  `json:"schemas"`
+	Operations []ScimBulkOperationResult `json:"Operations"`
+}
+
+type ScimBulkOperationResult struct {
+	Method   string `json:"method"`
+	BulkId   string `json:"bulkId,omitempty"`
+	Location string `json:"location,omitempty"`
+	Status   string `json:"status"`
+	Response any    `json:"response,omitempty"`
+}
+
+// ScimFilter 인사 시스템, IdP 가 리소스를 찾을 때 사용하는 `속성 eq "값"` 형식의 필터만 지원한다.
+type ScimFilter struct {
+	Attribute string
+	Value     string
+}
+
+var scimFilterPattern = regexp.MustCompile(`^\s*([A-Za-z][\w.:]*)\s+(?i:eq)\s+(?:"((?:[^"\\]|\\.)*)"|(true|false|[\d.]+))\s*$`)
+
+func ParseScimFilter(filter string) (ScimFilter, error) {
+	matches := scimFilterPattern.FindStringSubmatch(filter)
+	if matches == nil {
+		return ScimFilter{}, errors.ErrInvalidScimFilter
+	}
+
+	value := matches[3]
+	if len(value) == 0 {
+		value = strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(matches[2])
+	}
+
+	return ScimFilter{Attribute: matches[1], Value: value}, nil
+}
+
+// Matches 속성 이름과 문자열 값은 대소문자를 구분하지 않는다.
+func (f ScimFilter) Matches(resource map[string]any) bool {
+	value, ok := resource[findScimAttributeKey(resource, f.Attribute)]
+	if ok == false {
+		return false
+	}
+
+	switch v := value.(type) {
+	case string:
+		return strings.EqualFold(v, f.Value)
+	case bool:
+		return strings.EqualFold(f.Value, map[bool]string{true: "true", false: "false"}[v])
+	}
+
+	return false
+}
+
+var scimPathPattern = regexp.MustCompile(`^([A-Za-z][\w:]*)(?:\[(.+)\])?(?:\.([A-Za-z]\w*))?$`)
+
+type scimPath struct {
+	attribute    string
+	filter       *ScimFilter
+	subAttribute string
+}
+
+func parseScimPath(path string) (scimPath, error) {
+	matches := scimPathPattern.FindStringSubmatch(path)
+	if matches == nil {
+		return scimPath{}, errors.ErrInvalidScimPath
+	}
+
+	parsedPath := scimPath{attribute: matches[1], subAttribute: matches[3]}
+	if len(matches[2]) > 0 {
+		filter, err := ParseScimFilter(matches[2])
+		if err != nil {
+			return scimPath{}, errors.ErrInvalidScimPath
+		}
+		parsedPath.filter = &filter
+	}
+
+	return parsedPath, nil
+}
+
+// ApplyTo JSON 으로 변환한 리소스(map)에 PATCH 작업을 순서대로 적용한다.
+// 속성 이름은 대소문자를 구분하지 않으며, 알 수 없는 확장 스키마(urn:...)의 속성은 무시한다.
+func (r ScimPatchRequest) ApplyTo(resource map[string]any) error {
+	for _, operation := range r.Operations {
+		op := strings.ToLower(operation.Op)
+		if op != "add" && op != "replace" && op != "remove" {
+			return errors.ErrInvalidScimValue
+		}
+
+		if len(operation.Path) == 0 {
+			if op == "remove" {
+				return errors.ErrInvalidScimPath
+			}
+
+			values, ok := operation.Value.(map[string]any)
+			if ok == false {
+				return errors.ErrInvalidScimValue
+			}
+
+			for attribute, value := range values {
+				if err := applyScimOperation(resource, op, scimPath{attribute: attribute}, value); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		path, err := parseScimPath(operation.Path)
+		if err != nil {
+			return err
+		}
+
+		if err := applyScimOperation(resource, op, path, operation.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func applyScimOperation(resource map[string]any, op string, path scimPath, value any) error {
+	if strings.HasPrefix(strings.ToLower(path.attribute), "urn:") {
+		return nil
+	}
+
+	// name.givenName 처럼 하위 속성을 지정한 경우
+	if path.filter == nil && len(path.subAttribute) > 0 {
+		key := findScimAttributeKey(resource, path.attribute)
+		complexValue, _ := resource[key].(map[string]any)
+		if complexValue == nil {
+			complexValue = map[string]any{}
+		}
+		resource[key] = complexValue
+		return applyScimOperation(complexValue, op, scimPath{attribute: path.subAttribute}, value)
+	}
+
+	key := findScimAttributeKey(resource, path.attribute)
+	if path.filter != nil {
+		return applyScimFilteredOperation(resource, key, op, path, value)
+	}
+
+	switch op {
+	case "add":
+		// 다중 값 속성은 기존 값에 추가한다.
+		if existingValues, ok := resource[key].([]any); ok {
+			if newValues, ok := value.([]any); ok {
+				resource[key] = appendScimValues(existingValues, newValues)
+				return nil
+			}
+		}
+		resource[key] = value
+	case "replace":
+		resource[key] = value
+	case "remove":
+		// 값을 지정하면 다중 값 속성에서 value 가 같은 항목만 제거한다.(members 에서 일부 멤버를 제거하는 경우 등)
+		existingValues, isMultiValued := resource[key].([]any)
+		removeValues, hasValue := value.([]any)
+		if isMultiValued && hasValue {
+			resource[key] = removeScimValues(existingValues, removeValues)
+			return nil
+		}
+		delete(resource, key)
+	}
+
+	return nil
+}
+
+// applyScimFilteredOperation emails[type eq "work"].value, members[value eq "1"] 처럼 필터로 지정한 항목에 작업을 적용한다.
+func applyScimFilteredOperation(resource map[string]any, key string, op string, path scimPath, value any) error {
+	existingValues, _ := resource[key].([]any)
+	values := make([]any, 0)
+	matched := false
+
+	for _, existingValue := range existingValues {
+		item, ok := existingValue.(map[string]any)
+		if ok == false || path.filter.Matches(item) == false {
+			values = append(values, existingValue)
+			continue
+		}
+
+		matched = true
+		if op == "remove" {
+			if len(path.subAttribute) > 0 {
+				delete(item, findScimAttributeKey(item, path.subAttribute))
+				values = append(values, item)
+			}
+			continue
+		}
+
+		if len(path.subAttribute) > 0 {
+			item[findScimAttributeKey(item, path.subAttribute)] = value
+			values = append(values, item)
+			continue
+		}
+
+		newItem, ok := value.(map[string]any)
+		if ok == false {
+			return errors.ErrInvalidScimValue
+		}
+		values = append(values, newItem)
+	}
+
+	// 필터에 맞는 항목이 없으면 필터의 값을 가진 항목을 새로 추가한다.(emails[type eq "work"].value 를 처음 지정하는 경우)
+	if matched == false && op != "remove" && len(path.subAttribute) > 0 {
+		values = append(values, map[string]any{path.filter.Attribute: path.filter.Value, path.subAttribute: value})
+	}
+
+	resource[key] = values
+	return nil
+}
+
+func appendScimValues(existingValues []any, newValues []any) []any {
+	for _, newValue := range newValues {
+		if containsScimValue(existingValues, newValue) == false {
+			existingValues = append(existingValues, newValue)
+		}
+	}
+	return existingValues
+}
+
+func removeScimValues(existingValues []any, removeValues []any) []any {
+	values := make([]any, 0)
+	for _, existingValue := range existingValues {
+		if containsScimValue(removeValues, existingValue) == false {
+			values = append(values, existingValue)
+		}
+	}
+	return values
+}
+
+// containsScimValue 다중 값 속성의 항목은 value 로 비교한다.
+func containsScimValue(values []any, target any) bool {
+	targetItem, _ := target.(map[string]any)
+	for _, value := range values {
+		item, _ := value.(map[string]any)
+		if item != nil && targetItem != nil && item["value"] == targetItem["value"] {
+			return true
+		}
+		if item == nil && targetItem == nil && value == target {
+			return true
+		}
+	}
+	return false
+}
+
+func findScimAttributeKey(resource map[string]any, attribute string) string {
+	for key := range resource {
+		if strings.EqualFold(key, attribute) {
+			return key
+		}
+	}
+	return attribute
+}
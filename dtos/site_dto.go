@@ -2,7 +2,14 @@ package dtos
 
 import (
 	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
 	"fmt"
+	"github.com/go-ldap/ldap/v3"
+	"golang.org/x/crypto/bcrypt"
+	"net/url"
+	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 type DoorayLoginSetting struct {
@@ -14,20 +21,332 @@ type DoorayLoginSetting struct {
 type SiteSettingsSummary struct {
 	DoorayLoginUsed          bool   `json:"doorayLoginUsed"`
 	GoogleWorkspaceLoginUsed bool   `json:"googleWorkspaceLoginUsed"`
-	GoogleWorkspaceOAuthUri  string `json:"googleWorkspaceOAuthUri"`
+	WebAuthnLoginUsed        bool   `json:"webAuthnLoginUsed"`
+	OidcLoginUsed            bool   `json:"oidcLoginUsed"`
+	OidcLoginProviderName    string `json:"oidcLoginProviderName"`
+	LdapLoginUsed            bool   `json:"ldapLoginUsed"`
+	SignUpMode               string `json:"signUpMode"`
 }
 
 type GoogleWorkspaceLoginSetting struct {
-	Used         *bool  `json:"used" binding:"required"`
-	Domain       string `json:"domain" binding:"required_if=Used true"`
+	Used *bool `json:"used" binding:"required"`
+	// 이전 설정과의 호환을 위해 남겨 둔 단일 도메인. 조직, 역할을 지정하려면 Domains 를 사용한다.
+	Domain string `json:"domain"`
+	// 로그인을 허용할 워크스페이스 도메인(예: 계열사 도메인) 목록
+	Domains      []GoogleWorkspaceDomain `json:"domains" binding:"dive"`
+	ClientId     string                  `json:"clientId" binding:"required_if=Used true"`
+	ClientSecret string                  `json:"clientSecret" binding:"required_if=Used true"`
+	RedirectUri  string                  `json:"redirectUri" binding:"required_if=Used true"`
+	// 로그인 후 돌아갈 수 있는 웹 화면 주소 목록(예: https://better-admin.example.com/login). 목록에 없는 주소로는 돌아가지 않는다.
+	AllowedRedirectUrls []string `json:"allowedRedirectUrls" binding:"dive,url"`
+}
+
+// GoogleWorkspaceDomain 처음 로그인한 멤버는 도메인에 지정한 조직에 추가되고 기본 역할이 할당된다.
+type GoogleWorkspaceDomain struct {
+	Domain         string `json:"domain" binding:"required"`
+	OrganizationId uint   `json:"organizationId"`
+	RoleIds        []uint `json:"roleIds"`
+}
+
+func (g GoogleWorkspaceLoginSetting) GetDomains() []GoogleWorkspaceDomain {
+	domains := append([]GoogleWorkspaceDomain{}, g.Domains...)
+	if len(g.Domain) == 0 {
+		return domains
+	}
+
+	for _, domain := range domains {
+		if strings.EqualFold(domain.Domain, g.Domain) {
+			return domains
+		}
+	}
+
+	return append([]GoogleWorkspaceDomain{{Domain: g.Domain}}, domains...)
+}
+
+func (g GoogleWorkspaceLoginSetting) GetDomainNames() []string {
+	names := make([]string, 0)
+	for _, domain := range g.GetDomains() {
+		names = append(names, domain.Domain)
+	}
+	return names
+}
+
+// FindDomain 구글 계정의 hd(호스팅 도메인)와 일치하는 도메인을 찾는다.
+func (g GoogleWorkspaceLoginSetting) FindDomain(hd string) (GoogleWorkspaceDomain, bool) {
+	for _, domain := range g.GetDomains() {
+		if len(hd) > 0 && strings.EqualFold(domain.Domain, hd) {
+			return domain, true
+		}
+	}
+	return GoogleWorkspaceDomain{}, false
+}
+
+func (g GoogleWorkspaceLoginSetting) GetOAuthUri(state string) string {
+	return fmt.Sprintf("%v?client_id=%v&redirect_uri=%v&response_type=code&scope=https://www.googleapis.com/auth/userinfo.profile https://www.googleapis.com/auth/userinfo.email&approval_prompt=force&access_type=offline&state=%v",
+		config.Config.GoogleOAuth.OAuthUri, g.ClientId, g.RedirectUri, url.QueryEscape(state))
+}
+
+// IsAllowedRedirectUrl 스킴과 호스트가 같고 경로가 허용한 주소의 경로로 시작하는 경우에만 허용한다.
+func (g GoogleWorkspaceLoginSetting) IsAllowedRedirectUrl(redirect string) bool {
+	return isAllowedRedirectUrl(g.AllowedRedirectUrls, redirect)
+}
+
+func isAllowedRedirectUrl(allowedRedirectUrls []string, redirect string) bool {
+	redirectUrl, err := url.Parse(redirect)
+	if err != nil || len(redirectUrl.Scheme) == 0 || len(redirectUrl.Host) == 0 || redirectUrl.User != nil {
+		return false
+	}
+
+	for _, allowedRedirectUrl := range allowedRedirectUrls {
+		allowedUrl, err := url.Parse(allowedRedirectUrl)
+		if err != nil {
+			continue
+		}
+
+		if !strings.EqualFold(allowedUrl.Scheme, redirectUrl.Scheme) || !strings.EqualFold(allowedUrl.Host, redirectUrl.Host) {
+			continue
+		}
+
+		// /login 을 허용한 경우 /login-other 같은 경로는 허용하지 않는다.
+		allowedPath := strings.TrimSuffix(allowedUrl.Path, "/")
+		if len(allowedPath) == 0 || redirectUrl.Path == allowedPath || strings.HasPrefix(redirectUrl.Path, allowedPath+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+type WebAuthnLoginSetting struct {
+	Used *bool `json:"used" binding:"required"`
+	// 예) better-admin.example.com (스킴과 포트를 제외한 도메인)
+	RpId          string `json:"rpId" binding:"required_if=Used true"`
+	RpDisplayName string `json:"rpDisplayName" binding:"required_if=Used true"`
+	// 예) https://better-admin.example.com
+	RpOrigins []string `json:"rpOrigins" binding:"required_if=Used true"`
+}
+
+// OidcLoginSetting OpenID Connect 를 지원하는 IdP(Keycloak, Azure AD, Okta 등)로 로그인하기 위한 설정
+type OidcLoginSetting struct {
+	Used *bool `json:"used" binding:"required"`
+	// 로그인 버튼 등에 표시할 IdP 이름
+	ProviderName string `json:"providerName" binding:"required_if=Used true"`
+	// 예) https://keycloak.example.com/realms/better (Issuer + /.well-known/openid-configuration 으로 디스커버리 문서를 조회한다)
+	Issuer       string `json:"issuer" binding:"required_if=Used true"`
 	ClientId     string `json:"clientId" binding:"required_if=Used true"`
-	ClientSecret string `json:"clientSecret" binding:"required_if=Used true"`
+	ClientSecret string `json:"clientSecret"`
 	RedirectUri  string `json:"redirectUri" binding:"required_if=Used true"`
+	// openid 는 항상 포함된다.
+	Scopes []string `json:"scopes"`
+	// id_token 의 클레임 중 멤버의 이름, 메일, 프로필 사진으로 사용할 클레임 이름(기본 값: name, email, picture)
+	NameClaim    string `json:"nameClaim"`
+	EmailClaim   string `json:"emailClaim"`
+	PictureClaim string `json:"pictureClaim"`
+	// IdP 에서 처음 로그인한 멤버를 바로 승인할지 여부(승인하지 않으면 관리자의 승인이 필요하다)
+	AutoApproval bool `json:"autoApproval"`
+	// 로그인 후 돌아갈 수 있는 웹 화면 주소 목록(예: https://better-admin.example.com/login). 목록에 없는 주소로는 돌아가지 않는다.
+	AllowedRedirectUrls []string `json:"allowedRedirectUrls" binding:"dive,url"`
+}
+
+// IsAllowedRedirectUrl 스킴과 호스트가 같고 경로가 허용한 주소의 경로로 시작하는 경우에만 허용한다.
+func (o OidcLoginSetting) IsAllowedRedirectUrl(redirect string) bool {
+	return isAllowedRedirectUrl(o.AllowedRedirectUrls, redirect)
 }
 
-func (g GoogleWorkspaceLoginSetting) GetOAuthUri() string {
-	return fmt.Sprintf("%v?client_id=%v&redirect_uri=%v&response_type=code&scope=https://www.googleapis.com/auth/userinfo.profile https://www.googleapis.com/auth/userinfo.email&approval_prompt=force&access_type=offline",
-		config.Config.GoogleOAuth.OAuthUri, g.ClientId, g.RedirectUri)
+func (o OidcLoginSetting) GetScopes() []string {
+	scopes := []string{"openid"}
+	for _, scope := range o.Scopes {
+		if scope != "openid" && len(scope) > 0 {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return scopes
+}
+
+func (o OidcLoginSetting) GetNameClaim() string {
+	if len(o.NameClaim) == 0 {
+		return "name"
+	}
+	return o.NameClaim
+}
+
+func (o OidcLoginSetting) GetEmailClaim() string {
+	if len(o.EmailClaim) == 0 {
+		return "email"
+	}
+	return o.EmailClaim
+}
+
+func (o OidcLoginSetting) GetPictureClaim() string {
+	if len(o.PictureClaim) == 0 {
+		return "picture"
+	}
+	return o.PictureClaim
+}
+
+// LdapLoginSetting 사내(on-premise) Active Directory, OpenLDAP 계정으로 로그인하기 위한 설정
+type LdapLoginSetting struct {
+	Used *bool `json:"used" binding:"required"`
+	// 예) ldap://ldap.example.com:389, ldaps://ldap.example.com:636
+	Url string `json:"url" binding:"required_if=Used true"`
+	// ldap:// 로 연결한 뒤 StartTLS 로 암호화한다.
+	StartTls bool `json:"startTls"`
+	// 사설 CA 로 발급된 서버 인증서를 검증하기 위한 CA 인증서(PEM). 비어 있으면 시스템 CA 를 사용한다.
+	CaCertificate string `json:"caCertificate"`
+	// 멤버를 검색할 서비스 계정. 비어 있으면 익명으로 검색한다.
+	BindDn       string `json:"bindDn"`
+	BindPassword string `json:"bindPassword"`
+	// 예) ou=people,dc=example,dc=com
+	SearchBase string `json:"searchBase" binding:"required_if=Used true"`
+	// {username} 은 로그인 아이디로 치환된다. 예) (&(objectClass=user)(sAMAccountName={username}))
+	UserFilter string `json:"userFilter" binding:"required_if=Used true"`
+	// 멤버의 이름, 메일, 그룹으로 사용할 속성 이름(기본 값: cn, mail, memberOf)
+	NameAttribute   string `json:"nameAttribute"`
+	EmailAttribute  string `json:"emailAttribute"`
+	GroupsAttribute string `json:"groupsAttribute"`
+	// 처음 로그인한 멤버를 바로 승인할지 여부(승인하지 않으면 관리자의 승인이 필요하다)
+	AutoApproval bool `json:"autoApproval"`
+}
+
+func (l LdapLoginSetting) GetUserFilter(username string) string {
+	return strings.ReplaceAll(l.UserFilter, "{username}", ldap.EscapeFilter(username))
+}
+
+func (l LdapLoginSetting) GetNameAttribute() string {
+	if len(l.NameAttribute) == 0 {
+		return "cn"
+	}
+	return l.NameAttribute
+}
+
+func (l LdapLoginSetting) GetEmailAttribute() string {
+	if len(l.EmailAttribute) == 0 {
+		return "mail"
+	}
+	return l.EmailAttribute
+}
+
+func (l LdapLoginSetting) GetGroupsAttribute() string {
+	if len(l.GroupsAttribute) == 0 {
+		return "memberOf"
+	}
+	return l.GroupsAttribute
+}
+
+type TwoFactorAuthSetting struct {
+	// 모든 권한(*.all)을 가진 사이트 멤버는 2단계 인증을 사용해야만 로그인 할 수 있다.
+	RequiredForAllPermissions *bool `json:"requiredForAllPermissions" binding:"required"`
+}
+
+// PasswordPolicySetting 사이트 멤버의 비밀번호 정책. 설정하지 않은 항목은 확인하지 않는다.
+type PasswordPolicySetting struct {
+	MinLength        int  `json:"minLength" binding:"min=0,max=72"`
+	RequireUppercase bool `json:"requireUppercase"`
+	RequireLowercase bool `json:"requireLowercase"`
+	RequireDigit     bool `json:"requireDigit"`
+	RequireSpecial   bool `json:"requireSpecial"`
+	// 현재 비밀번호를 포함하여 최근에 사용한 N 개의 비밀번호는 다시 사용할 수 없다.
+	HistoryCount int `json:"historyCount" binding:"min=0,max=24"`
+	// 비밀번호를 변경한 뒤 N 일이 지나면 로그인 할 때 비밀번호를 변경해야 한다.
+	MaxAgeDays int `json:"maxAgeDays" binding:"min=0"`
+	// bcrypt 비용(기본 값: bcrypt.DefaultCost). 더 낮은 비용으로 저장된 비밀번호는 로그인 할 때 다시 해시한다.
+	HashCost int `json:"hashCost" binding:"omitempty,min=4,max=14"`
+}
+
+func (p PasswordPolicySetting) GetHashCost() int {
+	if p.HashCost == 0 {
+		return bcrypt.DefaultCost
+	}
+	return p.HashCost
+}
+
+// Validate 정책을 만족하지 않는 항목을 반환한다.
+func (p PasswordPolicySetting) Validate(password string) []string {
+	var violations []string
+	if len(password) == 0 || utf8.RuneCountInString(password) < p.MinLength {
+		violations = append(violations, "min-length")
+	}
+
+	// bcrypt 는 72 바이트 까지만 사용한다.
+	if len(password) > 72 {
+		violations = append(violations, "max-length")
+	}
+
+	var hasUppercase, hasLowercase, hasDigit, hasSpecial bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUppercase = true
+		case unicode.IsLower(r):
+			hasLowercase = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			hasSpecial = true
+		}
+	}
+
+	if p.RequireUppercase && hasUppercase == false {
+		violations = append(violations, "uppercase")
+	}
+	if p.RequireLowercase && hasLowercase == false {
+		violations = append(violations, "lowercase")
+	}
+	if p.RequireDigit && hasDigit == false {
+		violations = append(violations, "digit")
+	}
+	if p.RequireSpecial && hasSpecial == false {
+		violations = append(violations, "special")
+	}
+
+	return violations
+}
+
+// LoginProtectionSetting 무차별 대입 공격을 막기 위한 로그인 제한 설정. 실패 횟수가 0 이면 잠그지 않는다.
+type LoginProtectionSetting struct {
+	// 같은 아이디로 연속해서 N 번 실패하면 잠근다.
+	MaxFailures int `json:"maxFailures" binding:"min=0"`
+	// 같은 IP 에서 연속해서 N 번 실패하면 잠근다(여러 아이디를 대입하는 경우).
+	IpMaxFailures int `json:"ipMaxFailures" binding:"min=0"`
+	// 잠금 시간(분). 마지막으로 실패한 뒤 이 시간이 지나면 실패 횟수를 초기화한다.
+	LockoutMinutes int `json:"lockoutMinutes" binding:"min=1"`
+}
+
+func NewLoginProtectionSetting() LoginProtectionSetting {
+	return LoginProtectionSetting{
+		MaxFailures:    5,
+		IpMaxFailures:  20,
+		LockoutMinutes: 15,
+	}
+}
+
+// MemberAccessLogSetting 접근 기록을 남길 대상
+type MemberAccessLogSetting struct {
+	LoginSuccessUsed *bool `json:"loginSuccessUsed" binding:"required"`
+	LoginFailureUsed *bool `json:"loginFailureUsed" binding:"required"`
+	TokenRefreshUsed *bool `json:"tokenRefreshUsed" binding:"required"`
+}
+
+func (s MemberAccessLogSetting) IsUsed(accessType string, success bool) bool {
+	if accessType == constants.MemberAccessTypeTokenRefresh {
+		return s.TokenRefreshUsed != nil && *s.TokenRefreshUsed
+	}
+
+	if success {
+		return s.LoginSuccessUsed != nil && *s.LoginSuccessUsed
+	}
+
+	return s.LoginFailureUsed != nil && *s.LoginFailureUsed
+}
+
+func NewMemberAccessLogSetting() MemberAccessLogSetting {
+	used := true
+	return MemberAccessLogSetting{
+		LoginSuccessUsed: &used,
+		LoginFailureUsed: &used,
+		TokenRefreshUsed: &used,
+	}
 }
 
 type AppVersionSetting struct {
@@ -43,3 +362,102 @@ func NewAppVersionSetting() AppVersionSetting {
 		Version: 1,
 	}
 }
+
+// SignUpSetting 사이트 회원 가입 방식. open(누구나 가입 신청), invite-only(초대받은 사람만 가입), disabled(가입 중지)
+type SignUpSetting struct {
+	Mode string `json:"mode" binding:"required,oneof=open invite-only disabled"`
+}
+
+// NewSignUpSetting 가입 방식을 설정하지 않은 경우 기존과 같이 누구나 가입 신청할 수 있다.
+func NewSignUpSetting() SignUpSetting {
+	return SignUpSetting{
+		Mode: constants.SignUpModeOpen,
+	}
+}
+
+// MemberProvisioningSetting 새 멤버에게 역할과 조직을 자동으로 할당하는 규칙.
+// 멤버가 외부 인증으로 처음 로그인해서 생성되거나 관리자가 승인할 때 모든 규칙을 평가한다.
+type MemberProvisioningSetting struct {
+	Rules []MemberProvisioningRule `json:"rules" binding:"dive"`
+}
+
+// NewMemberProvisioningSetting 규칙을 설정하지 않은 경우 아무것도 할당하지 않는다.
+func NewMemberProvisioningSetting() MemberProvisioningSetting {
+	return MemberProvisioningSetting{
+		Rules: []MemberProvisioningRule{},
+	}
+}
+
+// FindMatchedRules 멤버 유형, 이메일, LDAP 그룹이 조건을 만족하는 규칙을 반환한다.
+func (m MemberProvisioningSetting) FindMatchedRules(memberType string, emails []string, ldapGroups []string) []MemberProvisioningRule {
+	matchedRules := make([]MemberProvisioningRule, 0)
+	for _, rule := range m.Rules {
+		if rule.Matches(memberType, emails, ldapGroups) {
+			matchedRules = append(matchedRules, rule)
+		}
+	}
+	return matchedRules
+}
+
+// MemberProvisioningRule 지정한 조건을 모두 만족하면 역할과 조직을 할당한다(비어 있는 조건은 검사하지 않는다).
+type MemberProvisioningRule struct {
+	Name       string `json:"name" binding:"required,max=100"`
+	MemberType string `json:"memberType" binding:"omitempty,oneof=site dooray google oidc ldap"`
+	// 예) @team.example
+	EmailSuffix string `json:"emailSuffix" binding:"omitempty,max=100"`
+	// LDAP 멤버가 속한 그룹의 DN. 예) cn=developers,ou=groups,dc=example,dc=com
+	LdapGroup       string `json:"ldapGroup" binding:"omitempty,max=500"`
+	RoleIds         []uint `json:"roleIds"`
+	OrganizationIds []uint `json:"organizationIds"`
+}
+
+func (r MemberProvisioningRule) Matches(memberType string, emails []string, ldapGroups []string) bool {
+	if len(r.MemberType) > 0 && r.MemberType != memberType {
+		return false
+	}
+
+	return r.matchesEmail(emails) && r.matchesLdapGroup(ldapGroups)
+}
+
+func (r MemberProvisioningRule) matchesEmail(emails []string) bool {
+	if len(r.EmailSuffix) == 0 {
+		return true
+	}
+
+	for _, email := range emails {
+		if strings.HasSuffix(strings.ToLower(email), strings.ToLower(r.EmailSuffix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesLdapGroup DN 은 대소문자를 구분하지 않는다.
+func (r MemberProvisioningRule) matchesLdapGroup(ldapGroups []string) bool {
+	if len(r.LdapGroup) == 0 {
+		return true
+	}
+
+	for _, ldapGroup := range ldapGroups {
+		if strings.EqualFold(strings.TrimSpace(ldapGroup), strings.TrimSpace(r.LdapGroup)) {
+			return true
+		}
+	}
+	return false
+}
+
+type MemberProvisioningDryRun struct {
+	// 멤버를 지정하면 멤버의 유형, 이메일, LDAP 그룹으로 평가한다.
+	MemberId   uint     `json:"memberId"`
+	MemberType string   `json:"memberType" binding:"omitempty,oneof=site dooray google oidc ldap"`
+	Email      string   `json:"email" binding:"omitempty,max=100"`
+	LdapGroups []string `json:"ldapGroups"`
+	// 지정하지 않으면 저장된 규칙으로 평가한다(저장하기 전에 규칙을 확인할 때 사용).
+	Rules []MemberProvisioningRule `json:"rules" binding:"omitempty,dive"`
+}
+
+type MemberProvisioningDryRunResult struct {
+	MatchedRules    []MemberProvisioningRule `json:"matchedRules"`
+	RoleIds         []uint                   `json:"roleIds"`
+	OrganizationIds []uint                   `json:"organizationIds"`
+}
@@ -0,0 +1,33 @@
+package dtos
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// WebAuthnCeremony 브라우저의 navigator.credentials.create()/get() 에 전달할 옵션과
+// 세리머니를 완료할 때 함께 전달해야 하는 세션 아이디
+type WebAuthnCeremony struct {
+	SessionId string `json:"sessionId"`
+	Options   any    `json:"options"`
+}
+
+type WebAuthnCredentialRegistration struct {
+	SessionId string `json:"sessionId" binding:"required"`
+	Name      string `json:"name" binding:"required"`
+	// navigator.credentials.create() 의 결과(PublicKeyCredential)
+	Credential json.RawMessage `json:"credential" binding:"required"`
+}
+
+type WebAuthnAssertion struct {
+	SessionId string `json:"sessionId" binding:"required"`
+	// navigator.credentials.get() 의 결과(PublicKeyCredential)
+	Credential json.RawMessage `json:"credential" binding:"required"`
+}
+
+type MemberPasskey struct {
+	Id         uint       `json:"id"`
+	Name       string     `json:"name"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt"`
+}
@@ -1,15 +1,62 @@
 package errors
 
-import "github.com/pkg/errors"
+import (
+	"github.com/pkg/errors"
+	"strings"
+	"time"
+)
 
 var (
-	ErrNotFound                  = errors.New("not found")
-	ErrAuthentication            = errors.New("error authentication")
-	ErrDuplicated                = errors.New("duplicated")
-	ErrNonChangeable             = errors.New("non changeable")
-	ErrAlreadyApproved           = errors.New("already approved")
-	ErrUnApproved                = errors.New("unapproved")
-	ErrNotSupportedAccessLogType = errors.New("not supported access log type")
+	ErrNotFound                      = errors.New("not found")
+	ErrRoleNotFound                  = errors.New("role not found")
+	ErrAuthentication                = errors.New("error authentication")
+	ErrDuplicated                    = errors.New("duplicated")
+	ErrNonChangeable                 = errors.New("non changeable")
+	ErrAlreadyApproved               = errors.New("already approved")
+	ErrUnApproved                    = errors.New("unapproved")
+	ErrInvalidStatusTransition       = errors.New("invalid status transition")
+	ErrNotSupportedAccessLogType     = errors.New("not supported access log type")
+	ErrInvalidRefreshToken           = errors.New("invalid refresh token")
+	ErrRefreshTokenReused            = errors.New("refresh token reused")
+	ErrNotSupportedTwoFactor         = errors.New("not supported two-factor authentication")
+	ErrTwoFactorAlreadyEnabled       = errors.New("two-factor authentication already enabled")
+	ErrTwoFactorNotEnabled           = errors.New("two-factor authentication not enabled")
+	ErrInvalidTwoFactorCode          = errors.New("invalid two-factor code")
+	ErrNotSupportedWebAuthn          = errors.New("not supported webauthn login")
+	ErrInvalidWebAuthnSession        = errors.New("invalid webauthn session")
+	ErrInvalidWebAuthnCredential     = errors.New("invalid webauthn credential")
+	ErrNotSupportedOidc              = errors.New("not supported oidc login")
+	ErrInvalidOidcState              = errors.New("invalid oidc state")
+	ErrInvalidOidcIdToken            = errors.New("invalid oidc id token")
+	ErrNotSupportedLdap              = errors.New("not supported ldap login")
+	ErrNotSupportedPassword          = errors.New("not supported password for member type")
+	ErrPasswordReused                = errors.New("password recently used")
+	ErrNotAllowedPermission          = errors.New("not allowed permission")
+	ErrImpersonation                 = errors.New("not allowed while impersonating")
+	ErrReauthenticationRequired      = errors.New("reauthentication required")
+	ErrNotImpersonating              = errors.New("not impersonating")
+	ErrSelfImpersonation             = errors.New("cannot impersonate yourself")
+	ErrImpersonationNotAllowed       = errors.New("cannot impersonate member with more permissions")
+	ErrSignUpNotAllowed              = errors.New("sign up not allowed")
+	ErrInvalidInvitation             = errors.New("invalid invitation")
+	ErrInvalidEmailVerification      = errors.New("invalid email verification token")
+	ErrEmailAlreadyVerified          = errors.New("email already verified")
+	ErrEmailNotRegistered            = errors.New("email not registered")
+	ErrMailNotConfigured             = errors.New("mail sender not configured")
+	ErrGoogleWorkspaceDomainRequired = errors.New("google workspace domain required")
+	ErrInvalidIdentityLink           = errors.New("invalid identity link")
+	ErrSiteMemberCannotBeLinked      = errors.New("site member cannot be linked")
+	ErrSelfMerge                     = errors.New("cannot merge member into itself")
+	ErrInvalidScimFilter             = errors.New("invalid scim filter")
+	ErrInvalidScimPath               = errors.New("invalid scim path")
+	ErrInvalidScimValue              = errors.New("invalid scim value")
+	ErrInvalidOAuthClient            = errors.New("invalid oauth client")
+	ErrInvalidOAuthRedirectUri       = errors.New("invalid oauth redirect uri")
+	ErrNotSupportedGoogleWorkspace   = errors.New("not supported google workspace login")
+	ErrNotAllowedRedirectUrl         = errors.New("not allowed redirect url")
+	ErrInvalidLoginState             = errors.New("invalid login state")
+	ErrInvalidLoginCode              = errors.New("invalid login code")
+	ErrInvalidCsrfToken              = errors.New("invalid csrf token")
 )
 
 type ErrInvalidGoogleWorkspaceAccount struct {
@@ -17,3 +64,65 @@ type ErrInvalidGoogleWorkspaceAccount struct {
 }
 
 func (e *ErrInvalidGoogleWorkspaceAccount) Error() string { return e.Domain }
+
+// ErrTwoFactorRequired 비밀번호 인증은 통과했지만 2단계 인증이 남아 있음을 나타낸다.
+type ErrTwoFactorRequired struct {
+	TwoFactorToken     string
+	EnrollmentRequired bool
+}
+
+func (e *ErrTwoFactorRequired) Error() string { return "two-factor authentication required" }
+
+// ErrPasswordPolicyViolation 비밀번호가 사이트의 비밀번호 정책을 만족하지 않음을 나타낸다.
+type ErrPasswordPolicyViolation struct {
+	Violations []string
+}
+
+func (e *ErrPasswordPolicyViolation) Error() string {
+	return "password policy violation: " + strings.Join(e.Violations, ", ")
+}
+
+// ErrPasswordChangeRequired 비밀번호 인증은 통과했지만 비밀번호를 변경해야만 로그인 할 수 있음을 나타낸다.
+type ErrPasswordChangeRequired struct {
+	PasswordChangeToken string
+}
+
+func (e *ErrPasswordChangeRequired) Error() string { return "password change required" }
+
+// ErrLoginLocked 연속된 로그인 실패로 로그인이 잠겨 있음을 나타낸다.
+type ErrLoginLocked struct {
+	RetryAfter time.Duration
+}
+
+func (e *ErrLoginLocked) Error() string { return "login locked" }
+
+// ErrInactiveMember 정지, 잠금, 탈퇴, 만료 등으로 로그인할 수 없는 멤버임을 나타낸다.
+type ErrInactiveMember struct {
+	Status string
+}
+
+func (e *ErrInactiveMember) Error() string { return "inactive member" }
+
+// ErrLoginThrottled 연속된 로그인 실패로 잠시 후에 다시 로그인해야 함을 나타낸다.
+type ErrLoginThrottled struct {
+	RetryAfter time.Duration
+}
+
+func (e *ErrLoginThrottled) Error() string { return "too many login attempts" }
+
+// ErrProfileManagedByProvider 외부 인증 제공자가 원본인 프로필 항목은 멤버가 변경할 수 없음을 나타낸다.
+type ErrProfileManagedByProvider struct {
+	Fields []string
+}
+
+func (e *ErrProfileManagedByProvider) Error() string {
+	return "profile managed by identity provider: " + strings.Join(e.Fields, ", ")
+}
+
+// ErrOAuth 내부 앱에 OAuth 2.0 오류 응답(RFC 6749 4.1.2.1, 5.2)으로 전달하는 오류
+type ErrOAuth struct {
+	Code        string
+	Description string
+}
+
+func (e *ErrOAuth) Error() string { return e.Code }
@@ -10,6 +10,8 @@ import (
 
 const ContextDBKey = "DB"
 const ContextUserClaimKey = "userClaim"
+const ContextClientIpKey = "clientIp"
+const ContextClientUserAgentKey = "clientUserAgent"
 
 var (
 	contextHelperOnce     sync.Once
@@ -56,3 +58,27 @@ func (contextHelper) GetUserClaim(ctx context.Context) (*security.UserClaim, err
 	}
 	return nil, errors.New("UserClaim is not exist")
 }
+
+func (contextHelper) SetClientIp(ctx context.Context, clientIp string) context.Context {
+	return context.WithValue(ctx, ContextClientIpKey, clientIp)
+}
+
+// GetClientIp 요청한 클라이언트의 IP. HTTP 요청이 아닌 경우 빈 문자열을 반환한다.
+func (contextHelper) GetClientIp(ctx context.Context) string {
+	if clientIp, ok := ctx.Value(ContextClientIpKey).(string); ok {
+		return clientIp
+	}
+	return ""
+}
+
+func (contextHelper) SetClientUserAgent(ctx context.Context, userAgent string) context.Context {
+	return context.WithValue(ctx, ContextClientUserAgentKey, userAgent)
+}
+
+// GetClientUserAgent 요청한 클라이언트의 User-Agent. HTTP 요청이 아닌 경우 빈 문자열을 반환한다.
+func (contextHelper) GetClientUserAgent(ctx context.Context) string {
+	if userAgent, ok := ctx.Value(ContextClientUserAgentKey).(string); ok {
+		return userAgent
+	}
+	return ""
+}
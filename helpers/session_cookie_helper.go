@@ -0,0 +1,107 @@
+package helpers
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/security"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const SessionModeCookie = "cookie"
+const RefreshTokenCookieName = "refreshToken"
+
+// CsrfTokenCookieName 웹 화면이 읽어서 CsrfTokenHeaderName 헤더로 함께 전송하는(double-submit) CSRF 토큰 쿠키
+const CsrfTokenCookieName = "csrfToken"
+const CsrfTokenHeaderName = "X-CSRF-Token"
+
+var (
+	sessionCookieHelperOnce     sync.Once
+	sessionCookieHelperInstance *sessionCookieHelper
+)
+
+func SessionCookieHelper() *sessionCookieHelper {
+	sessionCookieHelperOnce.Do(func() {
+		sessionCookieHelperInstance = &sessionCookieHelper{}
+	})
+
+	return sessionCookieHelperInstance
+}
+
+type sessionCookieHelper struct {
+}
+
+func (sessionCookieHelper) IsCookieMode() bool {
+	return config.Config.Session.Mode == SessionModeCookie
+}
+
+// SetSessionCookies 리프레시 토큰 쿠키와 함께 같은 기간 동안 사용할 CSRF 토큰 쿠키를 설정한다.
+func (h sessionCookieHelper) SetSessionCookies(w http.ResponseWriter, jwtToken security.JwtToken) error {
+	expires := jwtToken.GetRefreshTokenExpiresForCookie()
+
+	http.SetCookie(w, h.newCookie(RefreshTokenCookieName, jwtToken.RefreshToken, true, expires))
+	return h.SetCsrfTokenCookie(w, expires)
+}
+
+// SetCsrfTokenCookie 웹 화면의 스크립트가 읽을 수 있도록 HttpOnly 를 지정하지 않는다.
+func (h sessionCookieHelper) SetCsrfTokenCookie(w http.ResponseWriter, expires time.Time) error {
+	csrfToken, err := security.NewRandomId()
+	if err != nil {
+		return err
+	}
+
+	http.SetCookie(w, h.newCookie(CsrfTokenCookieName, csrfToken, false, expires))
+	return nil
+}
+
+func (h sessionCookieHelper) ClearSessionCookies(w http.ResponseWriter) {
+	for _, name := range []string{RefreshTokenCookieName, CsrfTokenCookieName} {
+		cookie := h.newCookie(name, "", name == RefreshTokenCookieName, time.Time{})
+		cookie.MaxAge = -1
+		http.SetCookie(w, cookie)
+	}
+}
+
+// IsAllowedOrigin 쿠키 세션 모드에서 요청을 허용할 웹 화면 주소(Origin)인지 확인한다.
+func (sessionCookieHelper) IsAllowedOrigin(origin string) bool {
+	for _, allowedOrigin := range config.Config.Session.AllowedOrigins {
+		if strings.EqualFold(strings.TrimSuffix(allowedOrigin, "/"), origin) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (sessionCookieHelper) GetRefreshToken(r *http.Request) string {
+	cookie, err := r.Cookie(RefreshTokenCookieName)
+	if err != nil {
+		return ""
+	}
+
+	return cookie.Value
+}
+
+func (sessionCookieHelper) newCookie(name, value string, httpOnly bool, expires time.Time) *http.Cookie {
+	return &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		Expires:  expires,
+		HttpOnly: httpOnly,
+		Secure:   config.Config.Session.AllowInsecureCookie == false,
+		SameSite: getCookieSameSite(),
+	}
+}
+
+func getCookieSameSite() http.SameSite {
+	switch strings.ToLower(config.Config.Session.CookieSameSite) {
+	case "strict":
+		return http.SameSiteStrictMode
+	case "none":
+		return http.SameSiteNoneMode
+	default:
+		return http.SameSiteLaxMode
+	}
+}
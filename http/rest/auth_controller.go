@@ -6,27 +6,35 @@ import (
 	"better-admin-backend-service/helpers"
 	"better-admin-backend-service/security"
 	"better-admin-backend-service/services"
-	"context"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"math"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 )
 
+// 구글 워크스페이스 로그인을 시작한 브라우저에 state 의 nonce 를 보관하는 쿠키
+const googleWorkspaceLoginNonceCookie = "googleWorkspaceLoginNonce"
+
+const googleWorkspaceLoginNonceMaxAge = time.Minute * 10
+
 type AuthController struct {
-	routerGroup   *gin.RouterGroup
-	authService   *services.AuthService
-	memberService *services.MemberService
+	routerGroup     *gin.RouterGroup
+	authService     *services.AuthService
+	webAuthnService *services.WebAuthnService
 }
 
 func NewAuthController(
 	routerGroup *gin.RouterGroup,
 	authService *services.AuthService,
-	memberService *services.MemberService) *AuthController {
+	webAuthnService *services.WebAuthnService) *AuthController {
 
 	return &AuthController{
-		routerGroup:   routerGroup,
-		authService:   authService,
-		memberService: memberService,
+		routerGroup:     routerGroup,
+		authService:     authService,
+		webAuthnService: webAuthnService,
 	}
 }
 
@@ -34,9 +42,20 @@ func (c AuthController) MapRoutes() {
 	route := c.routerGroup.Group("/auth")
 
 	route.POST("", c.authWithSignIdPassword)
+	route.POST("/password", c.authWithPasswordChange)
+	route.POST("/two-factor", c.authWithTwoFactor)
+	route.POST("/two-factor/enrollment", c.startTwoFactorEnrollment)
+	route.POST("/passkey/options", c.beginPasskeyLogin)
+	route.POST("/passkey", c.authWithPasskey)
 	route.POST("/dooray", c.authWithDoorayIdPassword)
+	route.POST("/ldap", c.authWithLdapIdPassword)
+	route.GET("/google-workspace/authorization", c.beginGoogleWorkspaceLogin)
 	route.GET("/google-workspace", c.authWithGoogleWorkspaceAccount)
+	route.POST("/login-code", c.exchangeLoginCode)
+	route.GET("/oidc/authorization", c.beginOidcLogin)
+	route.GET("/oidc", c.authWithOidc)
 	route.POST("/token/refresh", c.refreshAccessToken)
+	route.POST("/logout", c.logout)
 }
 
 func (c AuthController) authWithSignIdPassword(ctx *gin.Context) {
@@ -49,6 +68,10 @@ func (c AuthController) authWithSignIdPassword(ctx *gin.Context) {
 
 	jwtToken, err := c.authService.AuthWithSignIdPassword(ctx.Request.Context(), memberSignIn)
 	if err != nil {
+		if c.respondLoginRestriction(ctx, err) {
+			return
+		}
+
 		if err == errors.ErrNotFound || err == errors.ErrAuthentication {
 			ctx.JSON(http.StatusBadRequest, err.Error())
 			return
@@ -59,15 +82,227 @@ func (c AuthController) authWithSignIdPassword(ctx *gin.Context) {
 			return
 		}
 
+		if c.respondInactiveMember(ctx, err) {
+			return
+		}
+
+		if e, ok := err.(*errors.ErrTwoFactorRequired); ok {
+			// 2단계 인증이 완료되기 전까지는 토큰을 발급하지 않는다.
+			result := map[string]any{}
+			result["twoFactorRequired"] = true
+			result["twoFactorToken"] = e.TwoFactorToken
+			result["twoFactorEnrollmentRequired"] = e.EnrollmentRequired
+
+			ctx.JSON(http.StatusOK, result)
+			return
+		}
+
+		if e, ok := err.(*errors.ErrPasswordChangeRequired); ok {
+			// 비밀번호를 변경하기 전까지는 토큰을 발급하지 않는다.
+			result := map[string]any{}
+			result["passwordChangeRequired"] = true
+			result["passwordChangeToken"] = e.PasswordChangeToken
+
+			ctx.JSON(http.StatusOK, result)
+			return
+		}
+
 		helpers.ErrorHelper().InternalServerError(ctx, err)
 		return
 	}
 
-	result := map[string]any{}
-	result["accessToken"] = jwtToken.AccessToken
-	result["expiresAt"] = jwtToken.ExpiresAt
-	result["refreshToken"] = jwtToken.RefreshToken
-	result["refreshTokenExpiresIn"] = jwtToken.RefreshTokenExpiresIn
+	result, err := c.newJwtTokenResult(ctx, jwtToken, helpers.SessionCookieHelper().IsCookieMode())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (c AuthController) authWithPasswordChange(ctx *gin.Context) {
+	var passwordChangeSignIn dtos.MemberPasswordChangeSignIn
+
+	if err := ctx.BindJSON(&passwordChangeSignIn); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	jwtToken, err := c.authService.AuthWithPasswordChange(ctx.Request.Context(), passwordChangeSignIn)
+	if err != nil {
+		if err == security.InvalidPasswordChangeToken {
+			ctx.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Message: err.Error()})
+			return
+		}
+
+		if e, ok := err.(*errors.ErrPasswordPolicyViolation); ok {
+			ctx.JSON(http.StatusBadRequest, dtos.PasswordPolicyViolationMessage{Message: e.Error(), Violations: e.Violations})
+			return
+		}
+
+		if err == errors.ErrPasswordReused {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if err == errors.ErrUnApproved {
+			ctx.JSON(http.StatusNotAcceptable, err.Error())
+			return
+		}
+
+		if c.respondInactiveMember(ctx, err) {
+			return
+		}
+
+		if e, ok := err.(*errors.ErrTwoFactorRequired); ok {
+			// 변경한 비밀번호는 저장되며, 2단계 인증이 완료되기 전까지는 토큰을 발급하지 않는다.
+			result := map[string]any{}
+			result["twoFactorRequired"] = true
+			result["twoFactorToken"] = e.TwoFactorToken
+			result["twoFactorEnrollmentRequired"] = e.EnrollmentRequired
+
+			ctx.JSON(http.StatusOK, result)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	result, err := c.newJwtTokenResult(ctx, jwtToken, helpers.SessionCookieHelper().IsCookieMode())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (c AuthController) authWithTwoFactor(ctx *gin.Context) {
+	var twoFactorSignIn dtos.MemberTwoFactorSignIn
+
+	if err := ctx.BindJSON(&twoFactorSignIn); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	jwtToken, recoveryCodes, err := c.authService.AuthWithTwoFactor(ctx.Request.Context(), twoFactorSignIn)
+	if err != nil {
+		if c.respondLoginRestriction(ctx, err) {
+			return
+		}
+
+		if err == security.InvalidTwoFactorToken {
+			ctx.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Message: err.Error()})
+			return
+		}
+
+		if err == errors.ErrInvalidTwoFactorCode || err == errors.ErrTwoFactorNotEnabled {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if err == errors.ErrUnApproved {
+			ctx.JSON(http.StatusNotAcceptable, err.Error())
+			return
+		}
+
+		if c.respondInactiveMember(ctx, err) {
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	result, err := c.newJwtTokenResult(ctx, jwtToken, helpers.SessionCookieHelper().IsCookieMode())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+	if len(recoveryCodes) > 0 {
+		// 로그인 과정에서 2단계 인증을 등록한 경우 복구 코드는 이 응답에서 한 번만 제공된다.
+		result["recoveryCodes"] = recoveryCodes
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (c AuthController) startTwoFactorEnrollment(ctx *gin.Context) {
+	var request dtos.MemberTwoFactorEnrollmentRequest
+
+	if err := ctx.BindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	enrollment, err := c.authService.StartTwoFactorEnrollment(ctx.Request.Context(), request.TwoFactorToken)
+	if err != nil {
+		if err == security.InvalidTwoFactorToken {
+			ctx.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Message: err.Error()})
+			return
+		}
+
+		if err == errors.ErrNotSupportedTwoFactor || err == errors.ErrTwoFactorAlreadyEnabled {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, enrollment)
+}
+
+func (c AuthController) beginPasskeyLogin(ctx *gin.Context) {
+	ceremony, err := c.webAuthnService.BeginLogin(ctx.Request.Context())
+	if err != nil {
+		if err == errors.ErrNotSupportedWebAuthn {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ceremony)
+}
+
+func (c AuthController) authWithPasskey(ctx *gin.Context) {
+	var assertion dtos.WebAuthnAssertion
+
+	if err := ctx.BindJSON(&assertion); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	jwtToken, err := c.authService.AuthWithPasskey(ctx.Request.Context(), assertion)
+	if err != nil {
+		if err == errors.ErrNotSupportedWebAuthn || err == errors.ErrInvalidWebAuthnSession || err == errors.ErrInvalidWebAuthnCredential {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if err == errors.ErrUnApproved {
+			ctx.JSON(http.StatusNotAcceptable, err.Error())
+			return
+		}
+
+		if c.respondInactiveMember(ctx, err) {
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	result, err := c.newJwtTokenResult(ctx, jwtToken, helpers.SessionCookieHelper().IsCookieMode())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
 
 	ctx.JSON(http.StatusOK, result)
 }
@@ -82,6 +317,19 @@ func (c AuthController) authWithDoorayIdPassword(ctx *gin.Context) {
 
 	jwtToken, err := c.authService.AuthWithDoorayIdAndPassword(ctx.Request.Context(), memberSignIn)
 	if err != nil {
+		if c.respondLoginRestriction(ctx, err) {
+			return
+		}
+
+		if err == errors.ErrUnApproved {
+			ctx.JSON(http.StatusNotAcceptable, err.Error())
+			return
+		}
+
+		if c.respondInactiveMember(ctx, err) {
+			return
+		}
+
 		if err == errors.ErrAuthentication {
 			ctx.JSON(http.StatusBadRequest, err.Error())
 			return
@@ -91,92 +339,355 @@ func (c AuthController) authWithDoorayIdPassword(ctx *gin.Context) {
 		return
 	}
 
+	result, err := c.newJwtTokenResult(ctx, jwtToken, helpers.SessionCookieHelper().IsCookieMode())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (c AuthController) authWithLdapIdPassword(ctx *gin.Context) {
+	var memberSignIn dtos.MemberSignIn
+
+	if err := ctx.BindJSON(&memberSignIn); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	jwtToken, err := c.authService.AuthWithLdap(ctx.Request.Context(), memberSignIn)
+	if err != nil {
+		if c.respondLoginRestriction(ctx, err) {
+			return
+		}
+
+		if err == errors.ErrAuthentication || err == errors.ErrNotSupportedLdap {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if err == errors.ErrUnApproved {
+			ctx.JSON(http.StatusNotAcceptable, err.Error())
+			return
+		}
+
+		if c.respondInactiveMember(ctx, err) {
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	result, err := c.newJwtTokenResult(ctx, jwtToken, helpers.SessionCookieHelper().IsCookieMode())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// beginGoogleWorkspaceLogin 로그인 후 돌아갈 웹 화면 주소(redirect)는 구글 워크스페이스 로그인 설정의 허용 목록에 있어야 한다.
+func (c AuthController) beginGoogleWorkspaceLogin(ctx *gin.Context) {
+	authorizationUri, nonce, err := c.authService.BeginGoogleWorkspaceLogin(ctx.Request.Context(), ctx.Query("redirect"))
+	if err != nil {
+		if err == errors.ErrNotSupportedGoogleWorkspace || err == errors.ErrNotAllowedRedirectUrl {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	// 구글에서 돌아오는 최상위 이동에도 전달되도록 SameSite=Lax 로 지정한다.
+	http.SetCookie(ctx.Writer, &http.Cookie{
+		Name:     googleWorkspaceLoginNonceCookie,
+		Value:    nonce,
+		Path:     "/api/auth/google-workspace",
+		MaxAge:   int(googleWorkspaceLoginNonceMaxAge.Seconds()),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	ctx.Redirect(http.StatusFound, authorizationUri)
+}
+
+// authWithGoogleWorkspaceAccount 액세스 토큰은 URL 에 노출하지 않고 일회용 로그인 코드(loginCode)로 전달한다.
+func (c AuthController) authWithGoogleWorkspaceAccount(ctx *gin.Context) {
+	nonce := ""
+	if nonceCookie, err := ctx.Request.Cookie(googleWorkspaceLoginNonceCookie); err == nil {
+		nonce = nonceCookie.Value
+	}
+
+	jwtToken, redirect, err := c.authService.AuthWithGoogleWorkspaceAccount(ctx.Request.Context(), ctx.Query("state"), nonce, ctx.Query("code"))
+	if err != nil {
+		if err == errors.ErrInvalidLoginState {
+			// 서명을 확인할 수 없는 state 의 주소로는 돌아가지 않는다.
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if e, ok := err.(*errors.ErrInvalidGoogleWorkspaceAccount); ok {
+			ctx.Redirect(http.StatusFound, appendQuery(redirect, "error", fmt.Sprintf("%v 로 끝나는 메일 주소만 사용 가능 합니다", e.Domain)))
+			return
+		}
+
+		if err == errors.ErrUnApproved {
+			ctx.Redirect(http.StatusFound, appendQuery(redirect, "error", "unapproved"))
+			return
+		}
+
+		if _, ok := err.(*errors.ErrInactiveMember); ok {
+			ctx.Redirect(http.StatusFound, appendQuery(redirect, "error", "inactive-member"))
+			return
+		}
+
+		if len(redirect) == 0 {
+			helpers.ErrorHelper().InternalServerError(ctx, err)
+			return
+		}
+
+		ctx.Redirect(http.StatusFound, appendQuery(redirect, "error", "server-internal-error"))
+		return
+	}
+
+	loginCode, err := c.authService.IssueLoginCode(ctx.Request.Context(), jwtToken)
+	if err != nil {
+		ctx.Redirect(http.StatusFound, appendQuery(redirect, "error", "server-internal-error"))
+		return
+	}
+
+	if err := helpers.SessionCookieHelper().SetSessionCookies(ctx.Writer, jwtToken); err != nil {
+		ctx.Redirect(http.StatusFound, appendQuery(redirect, "error", "server-internal-error"))
+		return
+	}
+
+	// nonce 는 한 번만 사용한다.
+	http.SetCookie(ctx.Writer, &http.Cookie{
+		Name:     googleWorkspaceLoginNonceCookie,
+		Value:    "",
+		Path:     "/api/auth/google-workspace",
+		MaxAge:   -1,
+		HttpOnly: true,
+	})
+	ctx.Redirect(http.StatusFound, appendQuery(redirect, "loginCode", loginCode))
+}
+
+// exchangeLoginCode 웹 화면은 리다이렉트로 전달받은 로그인 코드를 한 번만 액세스 토큰으로 교환할 수 있다.
+func (c AuthController) exchangeLoginCode(ctx *gin.Context) {
+	var request dtos.LoginCodeExchange
+	if err := ctx.BindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	jwtToken, err := c.authService.ExchangeLoginCode(ctx.Request.Context(), request.Code)
+	if err != nil {
+		if err == errors.ErrInvalidLoginCode {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
 	result := map[string]any{}
 	result["accessToken"] = jwtToken.AccessToken
 	result["expiresAt"] = jwtToken.ExpiresAt
-	result["refreshToken"] = jwtToken.RefreshToken
-	result["refreshTokenExpiresIn"] = jwtToken.RefreshTokenExpiresIn
 
 	ctx.JSON(http.StatusOK, result)
 }
 
-func (c AuthController) authWithGoogleWorkspaceAccount(ctx *gin.Context) {
-	code := ctx.Query("code")
-	redirect := ctx.Query("state")
+// beginOidcLogin 로그인 후 돌아갈 웹 화면 주소(redirect)는 OIDC 로그인 설정의 허용 목록에 있어야 한다.
+func (c AuthController) beginOidcLogin(ctx *gin.Context) {
+	authorizationUri, err := c.authService.BeginOidcLogin(ctx.Request.Context(), ctx.Query("redirect"))
+	if err != nil {
+		if err == errors.ErrNotSupportedOidc || err == errors.ErrNotAllowedRedirectUrl {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, authorizationUri)
+}
 
-	jwtToken, err := c.authService.AuthWithGoogleWorkspaceAccount(ctx.Request.Context(), code)
+// authWithOidc 액세스 토큰은 URL 에 노출하지 않고 일회용 로그인 코드(loginCode)로 전달한다.
+func (c AuthController) authWithOidc(ctx *gin.Context) {
+	jwtToken, redirect, err := c.authService.AuthWithOidc(ctx.Request.Context(), ctx.Query("state"), ctx.Query("code"))
 	if err != nil {
-		if e, ok := err.(*errors.ErrInvalidGoogleWorkspaceAccount); ok {
-			ctx.Redirect(http.StatusFound, redirect+fmt.Sprintf("&error=%v 로 끝나는 메일 주소만 사용 가능 합니다", e.Domain))
+		if err == errors.ErrInvalidOidcState || err == errors.ErrNotSupportedOidc {
+			// 로그인을 시작한 세션을 알 수 없으므로 되돌아갈 곳도 알 수 없다.
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if len(redirect) == 0 {
+			helpers.ErrorHelper().InternalServerError(ctx, err)
+			return
+		}
+
+		if err == errors.ErrUnApproved {
+			ctx.Redirect(http.StatusFound, appendQuery(redirect, "error", "unapproved"))
+			return
+		}
+
+		if _, ok := err.(*errors.ErrInactiveMember); ok {
+			ctx.Redirect(http.StatusFound, appendQuery(redirect, "error", "inactive-member"))
+			return
+		}
+
+		if err == errors.ErrAuthentication || err == errors.ErrInvalidOidcIdToken {
+			ctx.Redirect(http.StatusFound, appendQuery(redirect, "error", "authentication-failed"))
 			return
 		}
 
-		ctx.Redirect(http.StatusFound, redirect+"&error=server-internal-error")
+		ctx.Redirect(http.StatusFound, appendQuery(redirect, "error", "server-internal-error"))
+		return
+	}
+
+	loginCode, err := c.authService.IssueLoginCode(ctx.Request.Context(), jwtToken)
+	if err != nil {
+		ctx.Redirect(http.StatusFound, appendQuery(redirect, "error", "server-internal-error"))
+		return
+	}
+
+	if err := helpers.SessionCookieHelper().SetSessionCookies(ctx.Writer, jwtToken); err != nil {
+		ctx.Redirect(http.StatusFound, appendQuery(redirect, "error", "server-internal-error"))
 		return
 	}
 
-	refreshToken, err := ctx.Request.Cookie("refreshToken")
-	if err != nil || len(refreshToken.Value) == 0 {
-		cookie := new(http.Cookie)
-		cookie.Name = "refreshToken"
-		cookie.Value = jwtToken.RefreshToken
-		cookie.HttpOnly = true
-		cookie.Path = "/"
-		cookie.Expires = jwtToken.GetRefreshTokenExpiresForCookie()
+	ctx.Redirect(http.StatusFound, appendQuery(redirect, "loginCode", loginCode))
+}
 
-		http.SetCookie(ctx.Writer, cookie)
+// respondLoginRestriction 연속된 로그인 실패로 잠긴 경우 423, 잠시 후 다시 시도해야 하는 경우 429 로 응답한다.
+func (c AuthController) respondLoginRestriction(ctx *gin.Context, err error) bool {
+	status, retryAfter := 0, time.Duration(0)
+	if e, ok := err.(*errors.ErrLoginLocked); ok {
+		status, retryAfter = http.StatusLocked, e.RetryAfter
+	} else if e, ok := err.(*errors.ErrLoginThrottled); ok {
+		status, retryAfter = http.StatusTooManyRequests, e.RetryAfter
 	} else {
-		refreshToken.Value = jwtToken.RefreshToken
-		refreshToken.HttpOnly = true
-		refreshToken.Path = "/"
-		refreshToken.Expires = jwtToken.GetRefreshTokenExpiresForCookie()
+		return false
+	}
+
+	retryAfterSeconds := int(math.Ceil(retryAfter.Seconds()))
+	ctx.Header("Retry-After", strconv.Itoa(retryAfterSeconds))
+	ctx.JSON(status, dtos.LoginRestrictionMessage{Message: err.Error(), RetryAfterSeconds: retryAfterSeconds})
+	return true
+}
+
+// respondInactiveMember 정지, 잠금, 탈퇴, 만료 등으로 로그인할 수 없는 멤버인 경우 403 과 멤버의 상태로 응답한다.
+func (c AuthController) respondInactiveMember(ctx *gin.Context, err error) bool {
+	e, ok := err.(*errors.ErrInactiveMember)
+	if ok == false {
+		return false
+	}
+
+	ctx.JSON(http.StatusForbidden, dtos.InactiveMemberMessage{Message: e.Error(), Status: e.Status})
+	return true
+}
+
+// newJwtTokenResult 세션 쿠키를 사용하는 경우 리프레시 토큰은 응답 본문 대신 HttpOnly 쿠키로만 전달한다.
+func (c AuthController) newJwtTokenResult(ctx *gin.Context, jwtToken security.JwtToken, sessionCookie bool) (map[string]any, error) {
+	result := map[string]any{}
+	result["accessToken"] = jwtToken.AccessToken
+	result["expiresAt"] = jwtToken.ExpiresAt
 
-		http.SetCookie(ctx.Writer, refreshToken)
+	if sessionCookie {
+		if err := helpers.SessionCookieHelper().SetSessionCookies(ctx.Writer, jwtToken); err != nil {
+			return nil, err
+		}
+		return result, nil
 	}
 
-	ctx.Redirect(http.StatusFound, redirect+"&accessToken="+jwtToken.AccessToken)
+	result["refreshToken"] = jwtToken.RefreshToken
+	result["refreshTokenExpiresIn"] = jwtToken.RefreshTokenExpiresIn
+	return result, nil
 }
 
+// refreshAccessToken 요청 본문에 리프레시 토큰이 없으면 쿠키의 리프레시 토큰을 사용하고, 새로 발급한 리프레시 토큰도 쿠키로 전달한다.
 func (c AuthController) refreshAccessToken(ctx *gin.Context) {
 	var request map[string]string
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.BindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+	}
 
-	if err := ctx.BindJSON(&request); err != nil {
-		ctx.JSON(http.StatusBadRequest, err.Error())
-		return
+	refreshToken := request["refreshToken"]
+	sessionCookie := len(refreshToken) == 0
+	if sessionCookie {
+		refreshToken = helpers.SessionCookieHelper().GetRefreshToken(ctx.Request)
 	}
 
-	jwtAuthentication := security.JwtAuthentication{}
-	accessToken, expiresAt, err := jwtAuthentication.RefreshAccessToken(request["refreshToken"])
+	if len(refreshToken) == 0 {
+		ctx.JSON(http.StatusBadRequest, errors.ErrInvalidRefreshToken.Error())
+		return
+	}
 
+	jwtToken, err := c.authService.RefreshAccessToken(ctx.Request.Context(), refreshToken)
 	if err != nil {
+		if err == errors.ErrInvalidRefreshToken || err == errors.ErrRefreshTokenReused {
+			ctx.JSON(http.StatusUnauthorized, dtos.ErrorMessage{Message: err.Error()})
+			return
+		}
+
 		helpers.ErrorHelper().InternalServerError(ctx, err)
 		return
 	}
 
-	err = c.logMemberAccessAtByToken(ctx.Request.Context(), request["refreshToken"])
+	result, err := c.newJwtTokenResult(ctx, jwtToken, sessionCookie)
 	if err != nil {
 		helpers.ErrorHelper().InternalServerError(ctx, err)
 		return
 	}
 
-	result := map[string]any{}
-	result["accessToken"] = accessToken
-	result["expiresAt"] = expiresAt
-
 	ctx.JSON(http.StatusOK, result)
 }
 
-func (c AuthController) logMemberAccessAtByToken(ctx context.Context, token string) error {
-	jwtAuthentication := security.JwtAuthentication{}
-	userClaim, err := jwtAuthentication.ConvertTokenUserClaim(token)
-	if err != nil {
-		return err
+func (c AuthController) logout(ctx *gin.Context) {
+	var request map[string]string
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.BindJSON(&request); err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	refreshToken := request["refreshToken"]
+	if refreshTokenCookie := helpers.SessionCookieHelper().GetRefreshToken(ctx.Request); len(refreshTokenCookie) > 0 {
+		if len(refreshToken) == 0 {
+			refreshToken = refreshTokenCookie
+		}
+
+		helpers.SessionCookieHelper().ClearSessionCookies(ctx.Writer)
 	}
 
-	err = c.memberService.UpdateMemberLastAccessAt(ctx, userClaim.Id)
+	if err := c.authService.Logout(ctx.Request.Context(), refreshToken); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// appendQuery 리다이렉트 주소의 기존 쿼리를 유지하고 파라미터를 추가한다.
+func appendQuery(redirect, key, value string) string {
+	redirectUrl, err := url.Parse(redirect)
 	if err != nil {
-		return err
+		return redirect
 	}
 
-	return nil
+	query := redirectUrl.Query()
+	query.Set(key, value)
+	redirectUrl.RawQuery = query.Encode()
+	return redirectUrl.String()
 }
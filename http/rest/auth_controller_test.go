@@ -1,15 +1,24 @@
 package rest
 
 import (
+	authDomain "better-admin-backend-service/auth/domain"
 	"better-admin-backend-service/config"
+	"better-admin-backend-service/dtos"
+	memberDomain "better-admin-backend-service/member/domain"
 	"better-admin-backend-service/security"
 	"better-admin-backend-service/testdata/testdb"
+	"better-admin-backend-service/testdata/testldap"
+	"better-admin-backend-service/testdata/testoidc"
+	"better-admin-backend-service/testdata/testwebauthn"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
@@ -46,13 +55,36 @@ func Test_authWithSignIdPassword(t *testing.T) {
 	assert.Equal(t, accessTokenClaim.Roles, []string{"SYSTEM MANAGER", "MEMBER MANAGER"})
 	assert.Equal(t, accessTokenClaim.Permissions, []string{"MANAGE_SYSTEM_SETTINGS", "MANAGE_MEMBERS"})
 
-	refreshTokenClaim, err := security.JwtAuthentication{}.ConvertTokenUserClaim(actual["refreshToken"].(string))
+	refreshTokenClaim, err := security.JwtAuthentication{}.ConvertRefreshTokenUserClaim(actual["refreshToken"].(string))
 	if err != nil {
 		t.Error(err)
 	}
 	assert.Equal(t, refreshTokenClaim.Id, uint(1))
 	assert.Equal(t, refreshTokenClaim.Roles, []string{"SYSTEM MANAGER", "MEMBER MANAGER"})
 	assert.Equal(t, refreshTokenClaim.Permissions, []string{"MANAGE_SYSTEM_SETTINGS", "MANAGE_MEMBERS"})
+
+	// 액세스 토큰과 리프레시 토큰은 서로 바꿔 사용할 수 없다.
+	_, err = security.JwtAuthentication{}.ConvertTokenUserClaim(actual["refreshToken"].(string))
+	assert.Equal(t, security.InvalidAccessToken, err)
+	_, err = security.JwtAuthentication{}.ConvertRefreshTokenUserClaim(actual["accessToken"].(string))
+	assert.Equal(t, security.InvalidAccessToken, err)
+}
+
+func Test_리프레시_토큰으로_API_를_호출하는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	refreshToken := signIn(t, "siteadm", "123456")["refreshToken"].(string)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", refreshToken))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
 }
 
 func Test_authWithSignIdPassword_Bad_Request(t *testing.T) {
@@ -179,22 +211,21 @@ func Test_authWithGoogleWorkspaceAccount(t *testing.T) {
 	defer server.Close()
 	serverPort := server.Listener.Addr().(*net.TCPAddr).Port
 
-	url := fmt.Sprintf("http://localhost:%v", serverPort)
-	config.Config.GoogleOAuth.AuthUri = url
-	config.Config.GoogleOAuth.TokenUri = url
-
-	// given
-	code := "test-google-code"
-	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/auth/google-workspace?code=%v", code), nil)
-	rec := httptest.NewRecorder()
+	googleWorkspaceServerUrl := fmt.Sprintf("http://localhost:%v", serverPort)
+	config.Config.GoogleOAuth.AuthUri = googleWorkspaceServerUrl
+	config.Config.GoogleOAuth.TokenUri = googleWorkspaceServerUrl
 
 	// when
-	ginApp.ServeHTTP(rec, req)
+	rec := authWithGoogleWorkspace(t)
 
 	// then
 	fmt.Println(rec.Body.String())
 	assert.Equal(t, http.StatusFound, rec.Code)
-	assert.True(t, strings.Contains(rec.Header().Get("Location"), "accessToken="))
+	location, _ := url.Parse(rec.Header().Get("Location"))
+	assert.Equal(t, "localhost:3000", location.Host)
+	assert.Equal(t, "/login", location.Path)
+	assert.NotEmpty(t, location.Query().Get("loginCode"))
+	assert.Empty(t, location.Query().Get("accessToken"))
 
 	// assert Cookie value
 	headerSetCookie := rec.Header().Get("Set-Cookie")
@@ -207,7 +238,7 @@ func Test_authWithGoogleWorkspaceAccount(t *testing.T) {
 	assert.True(t, strings.Contains(headerSetCookie, "HttpOnly"))
 
 	refreshToken := headerSetCookie[strings.Index(headerSetCookie, "refreshToken=")+len("refreshToken=") : strings.Index(headerSetCookie, ";")]
-	tokenUserClaim, _ := security.JwtAuthentication{}.ConvertTokenUserClaim(refreshToken)
+	tokenUserClaim, _ := security.JwtAuthentication{}.ConvertRefreshTokenUserClaim(refreshToken)
 	assert.Equal(t, uint(5), tokenUserClaim.Id)
 }
 
@@ -253,29 +284,403 @@ func Test_authWithGoogleWorkspaceAccount_구글_워크스페이스_멤버가_아
 	config.Config.GoogleOAuth.AuthUri = googleWorkspaceServerUrl
 	config.Config.GoogleOAuth.TokenUri = googleWorkspaceServerUrl
 
+	// when
+	rec := authWithGoogleWorkspace(t)
+
+	// then
+	fmt.Println("Location", rec.Header().Get("Location"))
+	fmt.Println("Set-Cookie", rec.Header().Get("Set-Cookie"))
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.False(t, strings.Contains(rec.Header().Get("Set-Cookie"), "refreshToken="))
+	location, _ := url.Parse(rec.Header().Get("Location"))
+	assert.Empty(t, location.Query().Get("loginCode"))
+	assert.Equal(t, "bettercode.kr 로 끝나는 메일 주소만 사용 가능 합니다", location.Query().Get("error"))
+}
+
+func Test_authWithGoogleWorkspaceAccount_도메인에_조직과_역할이_지정된_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
 	// given
-	code := "test-google-code"
-	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/auth/google-workspace?code=%v", code), nil)
-	rec := httptest.NewRecorder()
+	gormDB.Exec("UPDATE site_settings SET value = ? WHERE key = ?", `{
+		"used": true,
+		"domain": "bettercode.kr",
+		"domains": [{"domain": "sub.bettercode.kr", "organizationId": 4, "roleIds": [2]}],
+		"clientId": "test-client-id",
+		"clientSecret": "test-secret",
+		"redirectUri": "http://localhost:2016",
+		"allowedRedirectUrls": ["http://localhost:3000/login"]
+	}`, "google-workspace-login")
+
+	server := newGoogleWorkspaceServer("sub.bettercode.kr")
+	defer server.Close()
+	config.Config.GoogleOAuth.AuthUri = server.URL
+	config.Config.GoogleOAuth.TokenUri = server.URL
 
 	// when
+	rec := authWithGoogleWorkspace(t)
+
+	// then
+	fmt.Println("Location", rec.Header().Get("Location"))
+	assert.Equal(t, http.StatusFound, rec.Code)
+
+	memberEntity := memberDomain.MemberEntity{}
+	gormDB.Preload("Roles").Where("google_id = ?", "123456").First(&memberEntity)
+	assert.Equal(t, []string{"MEMBER MANAGER"}, memberEntity.GetRoleNames())
+
+	var count int64
+	gormDB.Table("organization_members").Where("organization_entity_id = ? AND member_entity_id = ?", 4, memberEntity.ID).Count(&count)
+	assert.Equal(t, int64(1), count)
+
+	// 조직에 추가된 뒤 발급한 액세스 토큰을 사용할 수 있다.
+	accessToken := exchangeLoginCode(t, rec.Header().Get("Location"))
+	req := httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	rec = httptest.NewRecorder()
 	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func Test_authWithGoogleWorkspaceAccount_허용하지_않은_도메인인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	gormDB.Exec("UPDATE site_settings SET value = ? WHERE key = ?", `{
+		"used": true,
+		"domains": [{"domain": "bettercode.kr"}, {"domain": "sub.bettercode.kr"}],
+		"clientId": "test-client-id",
+		"clientSecret": "test-secret",
+		"redirectUri": "http://localhost:2016",
+		"allowedRedirectUrls": ["http://localhost:3000/login"]
+	}`, "google-workspace-login")
+
+	server := newGoogleWorkspaceServer("other.kr")
+	defer server.Close()
+	config.Config.GoogleOAuth.AuthUri = server.URL
+	config.Config.GoogleOAuth.TokenUri = server.URL
+
+	// when
+	rec := authWithGoogleWorkspace(t)
 
 	// then
 	fmt.Println("Location", rec.Header().Get("Location"))
-	fmt.Println("Set-Cookie", rec.Header().Get("Set-Cookie"))
 	assert.Equal(t, http.StatusFound, rec.Code)
-	assert.False(t, strings.Contains(rec.Header().Get("Location"), "accessToken="))
-	assert.False(t, strings.Contains(rec.Header().Get("Set-Cookie"), "refreshToken="))
-	// 받환 메시지( error=bettercode.kr 로 끝나는 메일 주소만 사용 가능 합니다.) 중 한글 부분이 인코딩되어 있기 때문에 인코딩 값을 비교
-	assert.True(t, strings.Contains(rec.Header().Get("Location"),
-		"error=bettercode.kr %eb%a1%9c %eb%81%9d%eb%82%98%eb%8a%94 %eb%a9%94%ec%9d%bc %ec%a3%bc%ec%86%8c%eb%a7%8c %ec%82%ac%ec%9a%a9 %ea%b0%80%eb%8a%a5 %ed%95%a9%eb%8b%88%eb%8b%a4"))
+	location, _ := url.Parse(rec.Header().Get("Location"))
+	assert.Empty(t, location.Query().Get("loginCode"))
+	assert.True(t, strings.HasPrefix(location.Query().Get("error"), "bettercode.kr, sub.bettercode.kr "))
+}
+
+func Test_authWithGoogleWorkspaceAccount_자동_할당_규칙이_있는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	setMemberProvisioningRules(t, `[
+		{"name": "베터코드", "memberType": "google", "emailSuffix": "@bettercode.kr", "roleIds": [3], "organizationIds": [4]},
+		{"name": "다른 회사", "emailSuffix": "@other.kr", "roleIds": [2]}
+	]`)
+
+	server := newGoogleWorkspaceServer("bettercode.kr")
+	defer server.Close()
+	config.Config.GoogleOAuth.AuthUri = server.URL
+	config.Config.GoogleOAuth.TokenUri = server.URL
+
+	// when
+	rec := authWithGoogleWorkspace(t)
+
+	// then
+	assert.Equal(t, http.StatusFound, rec.Code)
+	location := rec.Header().Get("Location")
+
+	memberEntity := memberDomain.MemberEntity{}
+	gormDB.Preload("Roles").Where("google_id = ?", "123456").First(&memberEntity)
+	assert.Equal(t, []string{"테스트 관리자"}, memberEntity.GetRoleNames())
+
+	var count int64
+	gormDB.Table("organization_members").Where("organization_entity_id = ? AND member_entity_id = ?", 4, memberEntity.ID).Count(&count)
+	assert.Equal(t, int64(1), count)
+
+	accessToken := exchangeLoginCode(t, location)
+	req := httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+// newGoogleWorkspaceServer hd(호스팅 도메인)가 지정된 구글 계정을 응답하는 서버
+func newGoogleWorkspaceServer(hd string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			w.Write([]byte(fmt.Sprintf(`{
+				"id": "123456",
+				"email": "ymyoo@%v",
+				"verified_email": true,
+				"name": "유영모",
+				"hd": "%v",
+				"picture": "https://example.com/picture.png"
+			}`, hd, hd)))
+		} else if r.Method == http.MethodPost {
+			w.Write([]byte(`{"access_token": "test-token", "expires_in": 3599, "token_type": "Bearer"}`))
+		} else {
+			w.WriteHeader(404)
+		}
+	}))
+}
+
+// beginGoogleWorkspaceLogin 로그인을 시작하고 구글에 전달한 state 와 브라우저에 보관한 nonce 쿠키를 반환한다.
+func beginGoogleWorkspaceLogin(t *testing.T, redirect string) (string, *http.Cookie) {
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, httptest.NewRequest(http.MethodGet,
+		"/api/auth/google-workspace/authorization?redirect="+url.QueryEscape(redirect), nil))
+	if rec.Code != http.StatusFound {
+		t.Fatalf("begin google workspace login failed: %v", rec.Body.String())
+	}
+
+	location, _ := url.Parse(rec.Header().Get("Location"))
+	for _, cookie := range rec.Result().Cookies() {
+		if cookie.Name == "googleWorkspaceLoginNonce" {
+			return location.Query().Get("state"), cookie
+		}
+	}
+
+	t.Fatalf("nonce cookie not found")
+	return "", nil
+}
+
+// authWithGoogleWorkspace 로그인을 시작한 브라우저로 구글에서 돌아온 요청을 보낸다.
+func authWithGoogleWorkspace(t *testing.T) *httptest.ResponseRecorder {
+	state, nonceCookie := beginGoogleWorkspaceLogin(t, "http://localhost:3000/login")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/google-workspace?code=test-google-code&state="+url.QueryEscape(state), nil)
+	req.AddCookie(nonceCookie)
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	return rec
+}
+
+// exchangeLoginCode 리다이렉트 주소로 전달받은 로그인 코드를 액세스 토큰으로 교환한다.
+func exchangeLoginCode(t *testing.T, location string) string {
+	locationUrl, _ := url.Parse(location)
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/login-code",
+		strings.NewReader(fmt.Sprintf(`{"code": "%v"}`, locationUrl.Query().Get("loginCode")))))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("exchange login code failed: %v", rec.Body.String())
+	}
+
+	var result map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &result)
+	return result["accessToken"].(string)
+}
+
+func Test_beginGoogleWorkspaceLogin(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/google-workspace/authorization?redirect="+
+		url.QueryEscape("http://localhost:3000/login/callback?from=home"), nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusFound, rec.Code)
+	location, _ := url.Parse(rec.Header().Get("Location"))
+	assert.Equal(t, "test-client-id", location.Query().Get("client_id"))
+	assert.NotEmpty(t, location.Query().Get("state"))
+
+	cookies := rec.Result().Cookies()
+	assert.Equal(t, 1, len(cookies))
+	assert.Equal(t, "googleWorkspaceLoginNonce", cookies[0].Name)
+	assert.True(t, cookies[0].HttpOnly)
+
+	// state 는 API 호출에 사용할 수 없다.
+	req = httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", location.Query().Get("state")))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func Test_beginGoogleWorkspaceLogin_허용하지_않은_리다이렉트_주소인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	for _, redirect := range []string{
+		"https://evil.example.com/login",
+		"http://localhost:3000/login-other",
+		"http://localhost:3001/login",
+		"https://localhost:3000/login",
+		"http://user@localhost:3000/login",
+		"/login",
+		"",
+	} {
+		// given
+		req := httptest.NewRequest(http.MethodGet, "/api/auth/google-workspace/authorization?redirect="+url.QueryEscape(redirect), nil)
+		rec := httptest.NewRecorder()
+
+		// when
+		ginApp.ServeHTTP(rec, req)
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rec.Code, redirect)
+		assert.Empty(t, rec.Result().Cookies(), redirect)
+	}
+}
+
+func Test_authWithGoogleWorkspaceAccount_state_가_올바르지_않은_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	server := newGoogleWorkspaceServer("bettercode.kr")
+	defer server.Close()
+	config.Config.GoogleOAuth.AuthUri = server.URL
+	config.Config.GoogleOAuth.TokenUri = server.URL
+
+	state, nonceCookie := beginGoogleWorkspaceLogin(t, "http://localhost:3000/login")
+	otherState, _ := beginGoogleWorkspaceLogin(t, "http://localhost:3000/login")
+
+	tests := map[string]struct {
+		query  string
+		cookie *http.Cookie
+	}{
+		"state 가 없는 경우":           {query: "code=test-google-code", cookie: nonceCookie},
+		"리다이렉트 주소를 state 로 보낸 경우": {query: "code=test-google-code&state=" + url.QueryEscape("https://evil.example.com/?"), cookie: nonceCookie},
+		"nonce 쿠키가 없는 경우":         {query: "code=test-google-code&state=" + url.QueryEscape(state)},
+		"다른 브라우저에서 시작한 경우":        {query: "code=test-google-code&state=" + url.QueryEscape(otherState), cookie: nonceCookie},
+	}
+
+	for name, test := range tests {
+		req := httptest.NewRequest(http.MethodGet, "/api/auth/google-workspace?"+test.query, nil)
+		if test.cookie != nil {
+			req.AddCookie(test.cookie)
+		}
+		rec := httptest.NewRecorder()
+
+		// when
+		ginApp.ServeHTTP(rec, req)
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rec.Code, name)
+		assert.Empty(t, rec.Header().Get("Location"), name)
+	}
+
+	var count int64
+	gormDB.Model(&memberDomain.MemberEntity{}).Where("google_id = ?", "123456").Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+func Test_exchangeLoginCode_로그인_코드는_한_번만_교환할_수_있다(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	server := newGoogleWorkspaceServer("bettercode.kr")
+	defer server.Close()
+	config.Config.GoogleOAuth.AuthUri = server.URL
+	config.Config.GoogleOAuth.TokenUri = server.URL
+
+	location, _ := url.Parse(authWithGoogleWorkspace(t).Header().Get("Location"))
+	requestBody := fmt.Sprintf(`{"code": "%v"}`, location.Query().Get("loginCode"))
+
+	// when
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/login-code", strings.NewReader(requestBody)))
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.NotEmpty(t, actual["accessToken"])
+	assert.NotEmpty(t, actual["expiresAt"])
+	assert.Nil(t, actual["refreshToken"])
+
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/login-code", strings.NewReader(requestBody)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func Test_exchangeLoginCode_만료된_로그인_코드인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	server := newGoogleWorkspaceServer("bettercode.kr")
+	defer server.Close()
+	config.Config.GoogleOAuth.AuthUri = server.URL
+	config.Config.GoogleOAuth.TokenUri = server.URL
+
+	location, _ := url.Parse(authWithGoogleWorkspace(t).Header().Get("Location"))
+	gormDB.Model(&authDomain.LoginCodeEntity{}).Where("1 = 1").Update("expires_at", time.Now().Add(-time.Second))
+
+	// when
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/login-code",
+		strings.NewReader(fmt.Sprintf(`{"code": "%v"}`, location.Query().Get("loginCode")))))
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var count int64
+	gormDB.Model(&authDomain.LoginCodeEntity{}).Count(&count)
+	assert.Equal(t, int64(0), count)
 }
 
 func Test_refreshAccessToken(t *testing.T) {
 	// setup Fixture
 	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
 
+	// given
+	refreshToken := signIn(t, "siteadm", "123456")["refreshToken"].(string)
+
+	requestBody := fmt.Sprintf(`{
+		"refreshToken": "%s"
+	}`, refreshToken)
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/token/refresh", strings.NewReader(requestBody))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.NotEmpty(t, actual["accessToken"])
+	assert.NotEmpty(t, actual["refreshToken"])
+	assert.NotEqual(t, refreshToken, actual["refreshToken"])
+}
+
+func Test_refreshAccessToken_토큰_갱신을_접근_기록으로_남긴다(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	refreshToken := signIn(t, "siteadm", "123456")["refreshToken"].(string)
+
+	// when
+	for i := 0; i < 2; i++ {
+		rec := httptest.NewRecorder()
+		ginApp.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/token/refresh",
+			strings.NewReader(fmt.Sprintf(`{"refreshToken": "%s"}`, refreshToken))))
+	}
+
+	// then
+	var accessLogs []memberDomain.MemberAccessLogEntity
+	gormDB.Where("type = ?", "token-refresh").Order("id").Find(&accessLogs)
+	assert.Equal(t, 2, len(accessLogs))
+	assert.Equal(t, uint(1), accessLogs[0].MemberId)
+	assert.Equal(t, "site", accessLogs[0].AuthType)
+	assert.Equal(t, "success", accessLogs[0].Result)
+	assert.Equal(t, uint(1), accessLogs[1].MemberId)
+	assert.Equal(t, "failure", accessLogs[1].Result)
+	assert.Equal(t, "refresh token reused", accessLogs[1].FailureReason)
+}
+
+func Test_refreshAccessToken_서버에_저장되지_않은_토큰인_경우(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
 	// given
 	refreshToken, err := generateTestJWT(map[string]any{
 		"Id":          1,
@@ -298,22 +703,1720 @@ func Test_refreshAccessToken(t *testing.T) {
 
 	// then
 	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func Test_refreshAccessToken_회전된_토큰을_재사용하면_토큰_패밀리_전체가_폐기된다(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	firstRefreshToken := signIn(t, "siteadm", "123456")["refreshToken"].(string)
+
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/token/refresh",
+		strings.NewReader(fmt.Sprintf(`{"refreshToken": "%s"}`, firstRefreshToken))))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var rotated map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &rotated)
+	secondRefreshToken := rotated["refreshToken"].(string)
+
+	// when
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/token/refresh",
+		strings.NewReader(fmt.Sprintf(`{"refreshToken": "%s"}`, firstRefreshToken))))
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/token/refresh",
+		strings.NewReader(fmt.Sprintf(`{"refreshToken": "%s"}`, secondRefreshToken))))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func Test_refreshAccessToken_변경된_역할과_권한으로_토큰을_발급한다(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	signInResult := signIn(t, "ymyoo", "123456")
+	accessToken := signInResult["accessToken"].(string)
+	userClaim, _ := security.JwtAuthentication{}.ConvertTokenUserClaim(accessToken)
+	assert.Equal(t, []string{"SYSTEM MANAGER"}, userClaim.Roles)
+
+	// 멤버가 속한 조직의 역할을 변경한다.
+	adminToken, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"organization.update"},
+	}, time.Minute*15)
+	req := httptest.NewRequest(http.MethodPut, "/api/organizations/4/assign-roles", strings.NewReader(`{"roleIds": [2]}`))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", adminToken))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// 이전 권한으로 발급된 액세스 토큰은 거부된다.
+	req = httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, `{"message":"access token permission changed"}`, rec.Body.String())
+
+	req = httptest.NewRequest(http.MethodPost, "/api/auth/token/refresh",
+		strings.NewReader(fmt.Sprintf(`{"refreshToken": "%s"}`, signInResult["refreshToken"])))
+	rec = httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
 	assert.Equal(t, http.StatusOK, rec.Code)
 
-	var actual interface{}
+	var actual map[string]any
 	json.Unmarshal(rec.Body.Bytes(), &actual)
-	assert.NotEmpty(t, actual.(map[string]interface{})["accessToken"])
+	userClaim, _ = security.JwtAuthentication{}.ConvertTokenUserClaim(actual["accessToken"].(string))
+	assert.Equal(t, []string{"MEMBER MANAGER"}, userClaim.Roles)
+	assert.Equal(t, []string{"MANAGE_MEMBERS"}, userClaim.Permissions)
+	assert.Equal(t, uint(1), userClaim.PermissionVersion)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", actual["accessToken"]))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
 }
 
-func Test_refreshAccessToken_토큰이_없는_경우(t *testing.T) {
+func Test_refreshAccessToken_멤버의_역할이_변경된_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
 	// given
-	req := httptest.NewRequest(http.MethodPost, "/api/auth/token/refresh", nil)
+	signInResult := signIn(t, "siteadm", "123456")
+
+	adminToken, _ := generateTestJWT(map[string]any{
+		"Id":          2,
+		"Permissions": []string{"member.update"},
+	}, time.Minute*15)
+	req := httptest.NewRequest(http.MethodPut, "/api/members/1/assign-roles", strings.NewReader(`{"roleIds": [3]}`))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", adminToken))
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/auth/token/refresh",
+		strings.NewReader(fmt.Sprintf(`{"refreshToken": "%s"}`, signInResult["refreshToken"])))
+	rec = httptest.NewRecorder()
 
 	// when
 	ginApp.ServeHTTP(rec, req)
 
 	// then
-	fmt.Println(rec.Body.String())
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	userClaim, _ := security.JwtAuthentication{}.ConvertTokenUserClaim(actual["accessToken"].(string))
+	// 멤버에게 직접 할당된 역할과 조직(1)에서 받은 역할
+	assert.ElementsMatch(t, []string{"테스트 관리자", "SYSTEM MANAGER", "MEMBER MANAGER"}, userClaim.Roles)
+}
+
+func Test_refreshAccessToken_승인되지_않은_멤버인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	refreshToken := signIn(t, "ymyoo", "123456")["refreshToken"].(string)
+	gormDB.Exec("UPDATE members SET status = ? WHERE id = ?", "applied", 3)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/token/refresh",
+		strings.NewReader(fmt.Sprintf(`{"refreshToken": "%s"}`, refreshToken)))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, `{"message":"invalid refresh token"}`, rec.Body.String())
+}
+
+func Test_refreshAccessToken_삭제된_멤버인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	refreshToken := signIn(t, "ymyoo", "123456")["refreshToken"].(string)
+	gormDB.Exec("UPDATE members SET deleted_at = datetime('now') WHERE id = ?", 3)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/token/refresh",
+		strings.NewReader(fmt.Sprintf(`{"refreshToken": "%s"}`, refreshToken)))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func Test_refreshAccessToken_토큰이_없는_경우(t *testing.T) {
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/token/refresh", nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func Test_logout(t *testing.T) {
+	// setup Fixture
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	token := signIn(t, "siteadm", "123456")
+	accessToken := token["accessToken"].(string)
+	refreshToken := token["refreshToken"].(string)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/logout",
+		strings.NewReader(fmt.Sprintf(`{"refreshToken": "%s"}`, refreshToken)))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/token/refresh",
+		strings.NewReader(fmt.Sprintf(`{"refreshToken": "%s"}`, refreshToken))))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func Test_logout_토큰이_없는_경우(t *testing.T) {
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/logout", nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+// setUpCookieSessionMode 테스트가 끝나면 bearer 모드로 되돌린다.
+func setUpCookieSessionMode(t *testing.T) {
+	config.Config.Session.Mode = "cookie"
+	t.Cleanup(func() {
+		config.Config.Session.Mode = "bearer"
+	})
+}
+
+func findCookie(rec *httptest.ResponseRecorder, name string) *http.Cookie {
+	for _, cookie := range rec.Result().Cookies() {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func Test_authWithSignIdPassword_쿠키_세션_모드인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpCookieSessionMode(t)
+
+	// when
+	rec := trySignIn("siteadm", "123456")
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.NotEmpty(t, actual["accessToken"])
+	assert.NotEmpty(t, actual["expiresAt"])
+	assert.Nil(t, actual["refreshToken"])
+
+	refreshTokenCookie := findCookie(rec, "refreshToken")
+	assert.NotEmpty(t, refreshTokenCookie.Value)
+	assert.True(t, refreshTokenCookie.HttpOnly)
+	assert.True(t, refreshTokenCookie.Secure)
+	assert.Equal(t, http.SameSiteLaxMode, refreshTokenCookie.SameSite)
+
+	// 웹 화면이 읽어서 헤더로 전송할 수 있어야 한다.
+	csrfTokenCookie := findCookie(rec, "csrfToken")
+	assert.NotEmpty(t, csrfTokenCookie.Value)
+	assert.False(t, csrfTokenCookie.HttpOnly)
+}
+
+func Test_refreshAccessToken_쿠키_세션_모드인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpCookieSessionMode(t)
+
+	signInRec := trySignIn("siteadm", "123456")
+	refreshTokenCookie := findCookie(signInRec, "refreshToken")
+	csrfTokenCookie := findCookie(signInRec, "csrfToken")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/token/refresh", nil)
+	req.AddCookie(refreshTokenCookie)
+	req.AddCookie(csrfTokenCookie)
+	req.Header.Set("X-CSRF-Token", csrfTokenCookie.Value)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.NotEmpty(t, actual["accessToken"])
+	assert.Nil(t, actual["refreshToken"])
+
+	rotatedRefreshTokenCookie := findCookie(rec, "refreshToken")
+	assert.NotEmpty(t, rotatedRefreshTokenCookie.Value)
+	assert.NotEqual(t, refreshTokenCookie.Value, rotatedRefreshTokenCookie.Value)
+	assert.True(t, rotatedRefreshTokenCookie.HttpOnly)
+}
+
+func Test_refreshAccessToken_쿠키_세션_모드에서_CSRF_토큰이_없는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpCookieSessionMode(t)
+
+	signInRec := trySignIn("siteadm", "123456")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/token/refresh", nil)
+	req.AddCookie(findCookie(signInRec, "refreshToken"))
+	req.AddCookie(findCookie(signInRec, "csrfToken"))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Nil(t, findCookie(rec, "refreshToken"))
+}
+
+func Test_refreshAccessToken_쿠키_세션_모드에서_본문으로_리프레시_토큰을_전달하는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// API 클라이언트는 bearer 모드로 로그인한 뒤 쿠키 세션 모드로 바뀌어도 본문으로 토큰을 갱신할 수 있다.
+	refreshToken := signIn(t, "siteadm", "123456")["refreshToken"].(string)
+	setUpCookieSessionMode(t)
+
+	// when
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/token/refresh",
+		strings.NewReader(fmt.Sprintf(`{"refreshToken": "%s"}`, refreshToken))))
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.NotEmpty(t, actual["refreshToken"])
+	assert.Nil(t, findCookie(rec, "refreshToken"))
+}
+
+func Test_cors_쿠키_세션_모드에서는_허용한_웹_화면_주소만_허용한다(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpCookieSessionMode(t)
+
+	tests := []struct {
+		origin       string
+		expectedCode int
+	}{
+		{origin: "http://localhost:3000", expectedCode: http.StatusNoContent},
+		{origin: "https://attacker.example.com", expectedCode: http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodOptions, "/api/auth/token/refresh", nil)
+		req.Header.Set("Origin", test.origin)
+		req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+		rec := httptest.NewRecorder()
+
+		// when
+		ginApp.ServeHTTP(rec, req)
+
+		// then
+		assert.Equal(t, test.expectedCode, rec.Code, test.origin)
+		if test.expectedCode == http.StatusNoContent {
+			assert.Equal(t, test.origin, rec.Header().Get("Access-Control-Allow-Origin"))
+			assert.Equal(t, "true", rec.Header().Get("Access-Control-Allow-Credentials"))
+		}
+	}
+}
+
+func Test_logout_쿠키_세션_모드인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpCookieSessionMode(t)
+
+	signInRec := trySignIn("siteadm", "123456")
+	var token map[string]any
+	json.Unmarshal(signInRec.Body.Bytes(), &token)
+	refreshTokenCookie := findCookie(signInRec, "refreshToken")
+	csrfTokenCookie := findCookie(signInRec, "csrfToken")
+
+	// 리프레시 토큰 쿠키로 로그아웃하므로 액세스 토큰이 있어도 CSRF 토큰이 필요하다.
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/logout", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token["accessToken"]))
+	req.AddCookie(refreshTokenCookie)
+	req.AddCookie(csrfTokenCookie)
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/auth/logout", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token["accessToken"]))
+	req.Header.Set("X-CSRF-Token", csrfTokenCookie.Value)
+	req.AddCookie(refreshTokenCookie)
+	req.AddCookie(csrfTokenCookie)
+	rec = httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, -1, findCookie(rec, "refreshToken").MaxAge)
+	assert.Equal(t, -1, findCookie(rec, "csrfToken").MaxAge)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/auth/token/refresh", nil)
+	req.AddCookie(refreshTokenCookie)
+	req.AddCookie(csrfTokenCookie)
+	req.Header.Set("X-CSRF-Token", csrfTokenCookie.Value)
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func Test_authWithSignIdPassword_2단계_인증을_사용하는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	activateTwoFactor(t, signIn(t, "ymyoo", "123456")["accessToken"].(string))
+
+	// when
+	actual := signIn(t, "ymyoo", "123456")
+
+	// then
+	assert.Nil(t, actual["accessToken"])
+	assert.Equal(t, true, actual["twoFactorRequired"])
+	assert.Equal(t, false, actual["twoFactorEnrollmentRequired"])
+	assert.NotEmpty(t, actual["twoFactorToken"])
+}
+
+func Test_authWithSignIdPassword_낮은_비용으로_저장된_비밀번호는_다시_해시한다(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	signIn(t, "ymyoo", "123456")
+
+	// then
+	var memberEntity memberDomain.MemberEntity
+	gormDB.First(&memberEntity, 3)
+	cost, err := bcrypt.Cost([]byte(memberEntity.Password))
+	assert.NoError(t, err)
+	assert.Equal(t, bcrypt.DefaultCost, cost)
+	assert.NoError(t, memberEntity.ValidatePassword("123456"))
+}
+
+func Test_authWithSignIdPassword_비밀번호_사용_기간이_지난_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpPasswordPolicy(t, `{"maxAgeDays": 90}`)
+	gormDB.Model(&memberDomain.MemberEntity{}).Where("id = ?", 3).
+		Update("password_changed_at", time.Now().AddDate(0, 0, -91))
+
+	// when
+	actual := signIn(t, "ymyoo", "123456")
+
+	// then
+	assert.Nil(t, actual["accessToken"])
+	assert.Equal(t, true, actual["passwordChangeRequired"])
+	assert.NotEmpty(t, actual["passwordChangeToken"])
+}
+
+func Test_authWithPasswordChange(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	setUpPasswordPolicy(t, `{"maxAgeDays": 90, "historyCount": 1}`)
+	gormDB.Model(&memberDomain.MemberEntity{}).Where("id = ?", 3).
+		Update("password_changed_at", time.Now().AddDate(0, 0, -91))
+	passwordChangeToken := signIn(t, "ymyoo", "123456")["passwordChangeToken"].(string)
+
+	// 현재 비밀번호는 다시 사용할 수 없다.
+	rec := changePasswordWithToken(passwordChangeToken, "123456")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// when
+	rec = changePasswordWithToken(passwordChangeToken, "654321")
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	accessTokenClaim, err := security.JwtAuthentication{}.ConvertTokenUserClaim(actual["accessToken"].(string))
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, uint(3), accessTokenClaim.Id)
+	assert.NotEmpty(t, signIn(t, "ymyoo", "654321")["accessToken"])
+
+	// 비밀번호를 변경한 뒤에는 비밀번호 변경 대기 토큰을 다시 사용할 수 없다.
+	rec = changePasswordWithToken(passwordChangeToken, "987654")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func Test_authWithPasswordChange_2단계_인증을_사용하는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	activateTwoFactor(t, signIn(t, "ymyoo", "123456")["accessToken"].(string))
+	setUpPasswordPolicy(t, `{"maxAgeDays": 90}`)
+	gormDB.Model(&memberDomain.MemberEntity{}).Where("id = ?", 3).
+		Update("password_changed_at", time.Now().AddDate(0, 0, -91))
+	passwordChangeToken := signIn(t, "ymyoo", "123456")["passwordChangeToken"].(string)
+
+	// when
+	rec := changePasswordWithToken(passwordChangeToken, "654321")
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Nil(t, actual["accessToken"])
+	assert.Equal(t, true, actual["twoFactorRequired"])
+	assert.NotEmpty(t, actual["twoFactorToken"])
+}
+
+func Test_authWithPasswordChange_비밀번호_정책을_만족하지_않는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	setUpPasswordPolicy(t, `{"minLength": 8, "requireDigit": true, "maxAgeDays": 90}`)
+	gormDB.Model(&memberDomain.MemberEntity{}).Where("id = ?", 3).
+		Update("password_changed_at", time.Now().AddDate(0, 0, -91))
+	passwordChangeToken := signIn(t, "ymyoo", "123456")["passwordChangeToken"].(string)
+
+	// when
+	rec := changePasswordWithToken(passwordChangeToken, "password")
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var actual dtos.PasswordPolicyViolationMessage
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, []string{"digit"}, actual.Violations)
+}
+
+func Test_authWithPasswordChange_2단계_인증_대기_토큰을_사용하는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	activateTwoFactor(t, signIn(t, "ymyoo", "123456")["accessToken"].(string))
+	twoFactorToken := signIn(t, "ymyoo", "123456")["twoFactorToken"].(string)
+
+	// when
+	rec := changePasswordWithToken(twoFactorToken, "654321")
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func Test_authWithSignIdPassword_연속으로_실패하면_잠긴다(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	setUpLoginProtection(t, `{"maxFailures": 3, "ipMaxFailures": 0, "lockoutMinutes": 15}`)
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusBadRequest, trySignIn("ymyoo", "qwert").Code)
+		skipLoginDelay()
+	}
+
+	// when
+	rec := trySignIn("ymyoo", "123456")
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusLocked, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+	var actual dtos.LoginRestrictionMessage
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.True(t, actual.RetryAfterSeconds > 14*60)
+
+	// 다른 아이디는 잠기지 않는다.
+	assert.NotEmpty(t, signIn(t, "siteadm", "123456")["accessToken"])
+}
+
+func Test_authWithSignIdPassword_실패할_때마다_대기_시간이_늘어난다(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	assert.Equal(t, http.StatusBadRequest, trySignIn("ymyoo", "qwert").Code)
+	assert.Equal(t, http.StatusBadRequest, trySignIn("ymyoo", "qwert").Code)
+
+	// when
+	rec := trySignIn("ymyoo", "123456")
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+
+	skipLoginDelay()
+	assert.NotEmpty(t, signIn(t, "ymyoo", "123456")["accessToken"])
+}
+
+func Test_authWithSignIdPassword_같은_IP_에서_연속으로_실패하면_잠긴다(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	setUpLoginProtection(t, `{"maxFailures": 5, "ipMaxFailures": 3, "lockoutMinutes": 15}`)
+	for _, signId := range []string{"admin", "root", "ymyoo"} {
+		assert.Equal(t, http.StatusBadRequest, trySignIn(signId, "qwert").Code)
+	}
+
+	// when
+	rec := trySignIn("siteadm", "123456")
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusLocked, rec.Code)
+}
+
+func Test_authWithSignIdPassword_로그인에_성공하면_실패_기록을_초기화한다(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	assert.Equal(t, http.StatusBadRequest, trySignIn("ymyoo", "qwert").Code)
+
+	// when
+	signIn(t, "ymyoo", "123456")
+
+	// then
+	var count int64
+	gormDB.Model(&authDomain.LoginFailureEntity{}).Where("login_type = ? AND login_id = ?", "site", "ymyoo").Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+func Test_authWithSignIdPassword_로그인_성공과_실패를_접근_기록으로_남긴다(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/auth", strings.NewReader(`{"id": "ymyoo", "password": "qwert"}`))
+	req.Header.Set("User-Agent", "test-agent")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	skipLoginDelay()
+
+	// when
+	signIn(t, "ymyoo", "123456")
+
+	// then
+	var accessLogs []memberDomain.MemberAccessLogEntity
+	gormDB.Order("id").Find(&accessLogs)
+	assert.Equal(t, 2, len(accessLogs))
+
+	assert.Equal(t, uint(3), accessLogs[0].MemberId)
+	assert.Equal(t, "ymyoo", accessLogs[0].LoginId)
+	assert.Equal(t, "login", accessLogs[0].Type)
+	assert.Equal(t, "site", accessLogs[0].AuthType)
+	assert.Equal(t, "failure", accessLogs[0].Result)
+	assert.Equal(t, "error authentication", accessLogs[0].FailureReason)
+	assert.Equal(t, "192.0.2.1", accessLogs[0].IpAddress)
+	assert.Equal(t, "test-agent", accessLogs[0].UserAgent)
+
+	assert.Equal(t, uint(3), accessLogs[1].MemberId)
+	assert.Equal(t, "success", accessLogs[1].Result)
+	assert.Empty(t, accessLogs[1].FailureReason)
+}
+
+func Test_authWithSignIdPassword_존재하지_않는_아이디로_실패한_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	trySignIn("unknown", "123456")
+
+	// then
+	var accessLog memberDomain.MemberAccessLogEntity
+	gormDB.First(&accessLog)
+	assert.Equal(t, uint(0), accessLog.MemberId)
+	assert.Equal(t, "unknown", accessLog.LoginId)
+	assert.Equal(t, "failure", accessLog.Result)
+}
+
+func Test_authWithSignIdPassword_접근_기록_설정에서_제외한_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	setUpMemberAccessLog(t, `{"loginSuccessUsed": true, "loginFailureUsed": false, "tokenRefreshUsed": false}`)
+	assert.Equal(t, http.StatusBadRequest, trySignIn("ymyoo", "qwert").Code)
+	skipLoginDelay()
+
+	// when
+	refreshToken := signIn(t, "ymyoo", "123456")["refreshToken"].(string)
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/token/refresh",
+		strings.NewReader(fmt.Sprintf(`{"refreshToken": "%s"}`, refreshToken))))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// then
+	var accessLogs []memberDomain.MemberAccessLogEntity
+	gormDB.Find(&accessLogs)
+	assert.Equal(t, 1, len(accessLogs))
+	assert.Equal(t, "login", accessLogs[0].Type)
+	assert.Equal(t, "success", accessLogs[0].Result)
+}
+
+func Test_authWithTwoFactor_코드를_연속으로_틀리면_잠긴다(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	setUpLoginProtection(t, `{"maxFailures": 3, "ipMaxFailures": 0, "lockoutMinutes": 15}`)
+	activateTwoFactor(t, signIn(t, "ymyoo", "123456")["accessToken"].(string))
+	twoFactorToken := signIn(t, "ymyoo", "123456")["twoFactorToken"].(string)
+
+	requestBody := fmt.Sprintf(`{"twoFactorToken": "%s", "code": "000000"}`, twoFactorToken)
+	for i := 0; i < 3; i++ {
+		rec := httptest.NewRecorder()
+		ginApp.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/two-factor", strings.NewReader(requestBody)))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		skipLoginDelay()
+	}
+
+	// when
+	rec := trySignIn("ymyoo", "123456")
+
+	// then
+	assert.Equal(t, http.StatusLocked, rec.Code)
+}
+
+func Test_authWithTwoFactor(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	recoveryCodes := activateTwoFactor(t, signIn(t, "ymyoo", "123456")["accessToken"].(string))
+	twoFactorToken := signIn(t, "ymyoo", "123456")["twoFactorToken"].(string)
+
+	requestBody := fmt.Sprintf(`{"twoFactorToken": "%s", "code": "%s"}`, twoFactorToken, recoveryCodes[0])
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/two-factor", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	accessTokenClaim, err := security.JwtAuthentication{}.ConvertTokenUserClaim(actual["accessToken"].(string))
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, uint(3), accessTokenClaim.Id)
+	assert.Nil(t, actual["recoveryCodes"])
+
+	// 사용된 복구 코드는 다시 사용할 수 없다.
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/two-factor", strings.NewReader(requestBody)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func Test_authWithTwoFactor_코드가_유효하지_않은_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	activateTwoFactor(t, signIn(t, "ymyoo", "123456")["accessToken"].(string))
+	twoFactorToken := signIn(t, "ymyoo", "123456")["twoFactorToken"].(string)
+
+	requestBody := fmt.Sprintf(`{"twoFactorToken": "%s", "code": "000000"}`, twoFactorToken)
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/two-factor", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func Test_authWithTwoFactor_2단계_인증_대기_토큰은_액세스_토큰으로_사용할_수_없다(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	activateTwoFactor(t, signIn(t, "ymyoo", "123456")["accessToken"].(string))
+	twoFactorToken := signIn(t, "ymyoo", "123456")["twoFactorToken"].(string)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", twoFactorToken))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func Test_authWithTwoFactor_모든_권한을_가진_멤버는_2단계_인증을_등록해야_한다(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	gormDB.Exec("INSERT INTO permissions(id, type, name, description, created_at, updated_at) VALUES(?, ?, ?, ?, ?, ?)",
+		4, "pre-define", "member.all", "멤버 관리에 관한 모든 권한", time.Now(), time.Now())
+	gormDB.Exec("INSERT INTO role_permissions(role_entity_id, permission_entity_id) VALUES(?, ?)", 2, 4)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/two-factor-auth", strings.NewReader(`{"requiredForAllPermissions": true}`))
+	token, err := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"site-settings.update"},
+	}, time.Minute*15)
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// 모든 권한(*.all)이 없는 멤버는 2단계 인증 없이 로그인 할 수 있다.
+	assert.NotNil(t, signIn(t, "ymyoo", "123456")["accessToken"])
+
+	signInResult := signIn(t, "siteadm", "123456")
+	assert.Nil(t, signInResult["accessToken"])
+	assert.Equal(t, true, signInResult["twoFactorEnrollmentRequired"])
+	twoFactorToken := signInResult["twoFactorToken"].(string)
+
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/two-factor/enrollment",
+		strings.NewReader(fmt.Sprintf(`{"twoFactorToken": "%s"}`, twoFactorToken))))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var enrollment dtos.MemberTwoFactorEnrollment
+	json.Unmarshal(rec.Body.Bytes(), &enrollment)
+
+	code, err := security.GenerateTotpCode(enrollment.Secret, time.Now())
+	if err != nil {
+		t.Error(err)
+	}
+
+	// when
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/two-factor",
+		strings.NewReader(fmt.Sprintf(`{"twoFactorToken": "%s", "code": "%s"}`, twoFactorToken, code))))
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.NotEmpty(t, actual["accessToken"])
+	assert.Equal(t, 10, len(actual["recoveryCodes"].([]any)))
+}
+
+func Test_authWithPasskey(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	authenticator := registerPasskey(t, signIn(t, "ymyoo", "123456")["accessToken"].(string))
+	ceremony := beginPasskeyLogin(t)
+	credential, err := authenticator.GetAssertion(ceremony["options"].(map[string]any))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requestBody, _ := json.Marshal(map[string]any{
+		"sessionId":  ceremony["sessionId"],
+		"credential": credential,
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/passkey", bytes.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	accessTokenClaim, err := security.JwtAuthentication{}.ConvertTokenUserClaim(actual["accessToken"].(string))
+	if err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, uint(3), accessTokenClaim.Id)
+}
+
+func Test_authWithPasskey_세션을_재사용하는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	authenticator := registerPasskey(t, signIn(t, "ymyoo", "123456")["accessToken"].(string))
+	ceremony := beginPasskeyLogin(t)
+	credential, err := authenticator.GetAssertion(ceremony["options"].(map[string]any))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requestBody, _ := json.Marshal(map[string]any{
+		"sessionId":  ceremony["sessionId"],
+		"credential": credential,
+	})
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/passkey", bytes.NewReader(requestBody)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// when
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/passkey", bytes.NewReader(requestBody)))
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func Test_authWithPasskey_등록되지_않은_인증기인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	registerPasskey(t, signIn(t, "ymyoo", "123456")["accessToken"].(string))
+	unregisteredAuthenticator := testwebauthn.NewSoftwareAuthenticator("localhost", "http://localhost:2016")
+	unregisteredAuthenticator.CreateCredential(beginPasskeyRegistration(t, signIn(t, "ymyoo", "123456")["accessToken"].(string))["options"].(map[string]any))
+
+	ceremony := beginPasskeyLogin(t)
+	credential, err := unregisteredAuthenticator.GetAssertion(ceremony["options"].(map[string]any))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requestBody, _ := json.Marshal(map[string]any{
+		"sessionId":  ceremony["sessionId"],
+		"credential": credential,
+	})
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/passkey", bytes.NewReader(requestBody)))
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func Test_beginPasskeyLogin_패스키_로그인을_사용하지_않는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	gormDB.Exec("DELETE FROM site_settings WHERE key = ?", "webauthn-login")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/passkey/options", nil))
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func Test_authWithLdapIdPassword(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	ldapServer := newTestLdapServer()
+	defer ldapServer.Close()
+	setUpLdapLoginSetting(t, map[string]any{"url": ldapServer.Url(), "autoApproval": true})
+
+	// when
+	rec := ldapSignIn("YMYOO", "ldap-password")
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.NotEmpty(t, actual["accessToken"])
+	assert.NotEmpty(t, actual["refreshToken"])
+
+	var memberEntity memberDomain.MemberEntity
+	gormDB.Where(&memberDomain.MemberEntity{LdapUserId: "ymyoo"}).First(&memberEntity)
+	assert.Equal(t, "ldap", memberEntity.Type)
+	assert.Equal(t, "유영모", memberEntity.Name)
+	assert.Equal(t, "ymyoo@bettercode.kr", memberEntity.LdapMail)
+	assert.Equal(t, "uid=ymyoo,ou=people,dc=bettercode,dc=kr", memberEntity.LdapDn)
+	assert.Equal(t, []string{"cn=developers,ou=groups,dc=bettercode,dc=kr", "cn=admins,ou=groups,dc=bettercode,dc=kr"}, memberEntity.GetLdapGroups())
+	assert.Equal(t, "approved", memberEntity.Status)
+}
+
+func Test_authWithLdapIdPassword_LDAP_그룹이_자동_할당_규칙과_일치하는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	ldapServer := newTestLdapServer()
+	defer ldapServer.Close()
+	setUpLdapLoginSetting(t, map[string]any{"url": ldapServer.Url(), "autoApproval": true})
+	setMemberProvisioningRules(t, `[
+		{"name": "개발자 그룹", "ldapGroup": "CN=Developers,OU=Groups,DC=bettercode,DC=kr", "roleIds": [3], "organizationIds": [4]},
+		{"name": "운영자 그룹", "ldapGroup": "cn=operators,ou=groups,dc=bettercode,dc=kr", "roleIds": [2]}
+	]`)
+
+	// when
+	rec := ldapSignIn("ymyoo", "ldap-password")
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// 멤버가 속하지 않은 그룹의 규칙은 적용하지 않는다.
+	var memberEntity memberDomain.MemberEntity
+	gormDB.Preload("Roles").Where(&memberDomain.MemberEntity{LdapUserId: "ymyoo"}).First(&memberEntity)
+	assert.Equal(t, []string{"테스트 관리자"}, memberEntity.GetRoleNames())
+
+	var count int64
+	gormDB.Table("organization_members").Where("organization_entity_id = ? AND member_entity_id = ?", 4, memberEntity.ID).Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func Test_authWithLdapIdPassword_이미_가입한_멤버는_디렉터리의_정보로_갱신한다(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	ldapServer := newTestLdapServer()
+	defer ldapServer.Close()
+	setUpLdapLoginSetting(t, map[string]any{"url": ldapServer.Url(), "autoApproval": true})
+	ldapSignIn("ymyoo", "ldap-password")
+	gormDB.Model(&memberDomain.MemberEntity{}).Where("ldap_user_id = ?", "ymyoo").
+		Updates(map[string]any{"name": "이전 이름", "ldap_mail": "old@bettercode.kr"})
+
+	// when
+	rec := ldapSignIn("ymyoo", "ldap-password")
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var memberEntities []memberDomain.MemberEntity
+	gormDB.Where(&memberDomain.MemberEntity{LdapUserId: "ymyoo"}).Find(&memberEntities)
+	assert.Equal(t, 1, len(memberEntities))
+	assert.Equal(t, "유영모", memberEntities[0].Name)
+	assert.Equal(t, "ymyoo@bettercode.kr", memberEntities[0].LdapMail)
+}
+
+func Test_authWithLdapIdPassword_비밀번호가_틀린_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	ldapServer := newTestLdapServer()
+	defer ldapServer.Close()
+	setUpLdapLoginSetting(t, map[string]any{"url": ldapServer.Url(), "autoApproval": true})
+
+	// when
+	rec := ldapSignIn("ymyoo", "wrong-password")
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func Test_authWithLdapIdPassword_필터에_해당하는_멤버가_없는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	ldapServer := newTestLdapServer()
+	defer ldapServer.Close()
+	setUpLdapLoginSetting(t, map[string]any{"url": ldapServer.Url(), "autoApproval": true})
+
+	// when
+	// 필터 주입(*)은 이스케이프 되어 어떤 멤버와도 일치하지 않는다.
+	rec := ldapSignIn("*", "ldap-password")
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func Test_authWithLdapIdPassword_자동_승인을_사용하지_않는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	ldapServer := newTestLdapServer()
+	defer ldapServer.Close()
+	setUpLdapLoginSetting(t, map[string]any{"url": ldapServer.Url(), "autoApproval": false})
+
+	// when
+	rec := ldapSignIn("ymyoo", "ldap-password")
+
+	// then
+	assert.Equal(t, http.StatusNotAcceptable, rec.Code)
+
+	var memberEntity memberDomain.MemberEntity
+	gormDB.Where(&memberDomain.MemberEntity{LdapUserId: "ymyoo"}).First(&memberEntity)
+	assert.Equal(t, "applied", memberEntity.Status)
+}
+
+func Test_authWithLdapIdPassword_StartTLS(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	ldapServer := newTestLdapServer()
+	defer ldapServer.Close()
+	caCertificate := ldapServer.EnableStartTls()
+	setUpLdapLoginSetting(t, map[string]any{"url": ldapServer.Url(), "startTls": true, "caCertificate": caCertificate, "autoApproval": true})
+
+	// when
+	rec := ldapSignIn("ymyoo", "ldap-password")
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func Test_authWithLdapIdPassword_StartTLS_서버_인증서를_신뢰할_수_없는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	ldapServer := newTestLdapServer()
+	defer ldapServer.Close()
+	ldapServer.EnableStartTls()
+	setUpLdapLoginSetting(t, map[string]any{"url": ldapServer.Url(), "startTls": true, "autoApproval": true})
+
+	// when
+	rec := ldapSignIn("ymyoo", "ldap-password")
+
+	// then
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func Test_authWithLdapIdPassword_LDAP_로그인을_사용하지_않는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := ldapSignIn("ymyoo", "ldap-password")
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func Test_authWithOidc(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	oidcServer := testoidc.NewMockOidcServer("better-admin")
+	defer oidcServer.Close()
+	setUpOidcLoginSetting(t, oidcServer.Issuer(), true)
+
+	authorizationUri := beginOidcLogin(t, "http://localhost:2016/login?oidc=true")
+	assert.True(t, strings.HasPrefix(authorizationUri, oidcServer.Issuer()+"/authorize?"))
+	assert.True(t, strings.Contains(authorizationUri, "code_challenge="))
+	assert.True(t, strings.Contains(authorizationUri, "nonce="))
+
+	code, state := oidcServer.Authorize(authorizationUri, map[string]any{
+		"sub":   "oidc-member-1",
+		"name":  "홍길동",
+		"email": "hong@bettercode.kr",
+	})
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/auth/oidc?code=%v&state=%v", code, state), nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusFound, rec.Code)
+	// 액세스 토큰은 URL 에 노출하지 않는다.
+	assert.True(t, strings.HasPrefix(rec.Header().Get("Location"), "http://localhost:2016/login?loginCode="))
+	assert.False(t, strings.Contains(rec.Header().Get("Location"), "accessToken"))
+	assert.True(t, strings.HasPrefix(rec.Header().Get("Set-Cookie"), "refreshToken="))
+
+	userClaim, err := security.JwtAuthentication{}.ConvertTokenUserClaim(exchangeLoginCode(t, rec.Header().Get("Location")))
+	assert.NoError(t, err)
+
+	var memberEntity memberDomain.MemberEntity
+	gormDB.Where(&memberDomain.MemberEntity{OidcIssuer: oidcServer.Issuer(), OidcSubject: "oidc-member-1"}).First(&memberEntity)
+	assert.Equal(t, "oidc", memberEntity.Type)
+	assert.Equal(t, "홍길동", memberEntity.Name)
+	assert.Equal(t, "hong@bettercode.kr", memberEntity.OidcMail)
+	assert.Equal(t, "approved", memberEntity.Status)
+	assert.Equal(t, memberEntity.ID, userClaim.Id)
+}
+
+func Test_authWithOidc_확인되지_않은_메일이_자동_할당_규칙과_일치하는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	oidcServer := testoidc.NewMockOidcServer("better-admin")
+	defer oidcServer.Close()
+	setUpOidcLoginSetting(t, oidcServer.Issuer(), true)
+	setMemberProvisioningRules(t, `[
+		{"name": "베터코드 메일", "emailSuffix": "@bettercode.kr", "roleIds": [3], "organizationIds": [1]}
+	]`)
+
+	code, state := oidcServer.Authorize(beginOidcLogin(t, "http://localhost:2016/login"), map[string]any{
+		"sub":            "oidc-member-1",
+		"name":           "홍길동",
+		"email":          "hong@bettercode.kr",
+		"email_verified": false,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/auth/oidc?code=%v&state=%v", code, state), nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusFound, rec.Code)
+
+	// IdP 가 확인하지 않은 메일 주소로는 역할, 조직을 할당하지 않는다.
+	var memberEntity memberDomain.MemberEntity
+	gormDB.Preload("Roles").Where(&memberDomain.MemberEntity{OidcSubject: "oidc-member-1"}).First(&memberEntity)
+	assert.Equal(t, "hong@bettercode.kr", memberEntity.OidcMail)
+	assert.Empty(t, memberEntity.GetRoleNames())
+
+	var count int64
+	gormDB.Table("organization_members").Where("member_entity_id = ?", memberEntity.ID).Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+func Test_authWithOidc_확인된_메일이_자동_할당_규칙과_일치하는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	oidcServer := testoidc.NewMockOidcServer("better-admin")
+	defer oidcServer.Close()
+	setUpOidcLoginSetting(t, oidcServer.Issuer(), true)
+	setMemberProvisioningRules(t, `[
+		{"name": "베터코드 메일", "emailSuffix": "@bettercode.kr", "roleIds": [3]}
+	]`)
+
+	code, state := oidcServer.Authorize(beginOidcLogin(t, "http://localhost:2016/login"), map[string]any{
+		"sub":            "oidc-member-1",
+		"name":           "홍길동",
+		"email":          "hong@bettercode.kr",
+		"email_verified": true,
+	})
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/auth/oidc?code=%v&state=%v", code, state), nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusFound, rec.Code)
+
+	var memberEntity memberDomain.MemberEntity
+	gormDB.Preload("Roles").Where(&memberDomain.MemberEntity{OidcSubject: "oidc-member-1"}).First(&memberEntity)
+	assert.Equal(t, []string{"테스트 관리자"}, memberEntity.GetRoleNames())
+}
+
+func Test_authWithOidc_클레임_매핑을_설정한_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	oidcServer := testoidc.NewMockOidcServer("better-admin")
+	defer oidcServer.Close()
+	putOidcLoginSetting(t, fmt.Sprintf(`{
+		"used": true,
+		"providerName": "Azure AD",
+		"issuer": "%v",
+		"clientId": "better-admin",
+		"redirectUri": "http://localhost:2016/api/auth/oidc",
+		"nameClaim": "preferred_username",
+		"emailClaim": "upn",
+		"autoApproval": true,
+		"allowedRedirectUrls": ["http://localhost:2016/login"]
+	}`, oidcServer.Issuer()))
+
+	code, state := oidcServer.Authorize(beginOidcLogin(t, "http://localhost:2016/login?oidc=true"), map[string]any{
+		"sub":                "oidc-member-1",
+		"preferred_username": "ymyoo",
+		"upn":                "ymyoo@bettercode.kr",
+	})
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/auth/oidc?code=%v&state=%v", code, state), nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.True(t, strings.Contains(rec.Header().Get("Location"), "loginCode="))
+
+	var memberEntity memberDomain.MemberEntity
+	gormDB.Where(&memberDomain.MemberEntity{OidcSubject: "oidc-member-1"}).First(&memberEntity)
+	assert.Equal(t, "ymyoo", memberEntity.Name)
+	assert.Equal(t, "ymyoo@bettercode.kr", memberEntity.OidcMail)
+}
+
+func Test_authWithOidc_자동_승인을_사용하지_않는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	oidcServer := testoidc.NewMockOidcServer("better-admin")
+	defer oidcServer.Close()
+	setUpOidcLoginSetting(t, oidcServer.Issuer(), false)
+
+	code, state := oidcServer.Authorize(beginOidcLogin(t, "http://localhost:2016/login?oidc=true"), map[string]any{
+		"sub": "oidc-member-1",
+	})
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/auth/oidc?code=%v&state=%v", code, state), nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "http://localhost:2016/login?error=unapproved&oidc=true", rec.Header().Get("Location"))
+	assert.Empty(t, rec.Header().Get("Set-Cookie"))
+
+	// 관리자가 승인할 수 있도록 멤버는 신청 상태로 생성된다.
+	var memberEntity memberDomain.MemberEntity
+	gormDB.Where(&memberDomain.MemberEntity{OidcSubject: "oidc-member-1"}).First(&memberEntity)
+	assert.Equal(t, "applied", memberEntity.Status)
+}
+
+func Test_authWithOidc_nonce가_일치하지_않는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	oidcServer := testoidc.NewMockOidcServer("better-admin")
+	defer oidcServer.Close()
+	setUpOidcLoginSetting(t, oidcServer.Issuer(), true)
+
+	code, state := oidcServer.Authorize(beginOidcLogin(t, "http://localhost:2016/login?oidc=true"), map[string]any{
+		"sub":   "oidc-member-1",
+		"nonce": "replayed-nonce",
+	})
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/auth/oidc?code=%v&state=%v", code, state), nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "http://localhost:2016/login?error=authentication-failed&oidc=true", rec.Header().Get("Location"))
+}
+
+func Test_authWithOidc_다른_클라이언트에게_발급된_id_token인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	oidcServer := testoidc.NewMockOidcServer("better-admin")
+	defer oidcServer.Close()
+	setUpOidcLoginSetting(t, oidcServer.Issuer(), true)
+
+	code, state := oidcServer.Authorize(beginOidcLogin(t, "http://localhost:2016/login?oidc=true"), map[string]any{
+		"sub": "oidc-member-1",
+		"aud": "other-client",
+	})
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/auth/oidc?code=%v&state=%v", code, state), nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "http://localhost:2016/login?error=authentication-failed&oidc=true", rec.Header().Get("Location"))
+}
+
+func Test_authWithOidc_state를_재사용하는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	oidcServer := testoidc.NewMockOidcServer("better-admin")
+	defer oidcServer.Close()
+	setUpOidcLoginSetting(t, oidcServer.Issuer(), true)
+
+	code, state := oidcServer.Authorize(beginOidcLogin(t, "http://localhost:2016/login?oidc=true"), map[string]any{
+		"sub": "oidc-member-1",
+	})
+	ginApp.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/auth/oidc?code=%v&state=%v", code, state), nil))
+
+	req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/api/auth/oidc?code=%v&state=%v", code, state), nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func Test_beginOidcLogin_OIDC_로그인을_사용하지_않는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/authorization?redirect=http://localhost:2016/login", nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func Test_beginOidcLogin_허용하지_않은_redirect인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	oidcServer := testoidc.NewMockOidcServer("better-admin")
+	defer oidcServer.Close()
+	setUpOidcLoginSetting(t, oidcServer.Issuer(), true)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/authorization?redirect="+url.QueryEscape("https://attacker.example.com/login"), nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, `"not allowed redirect url"`, rec.Body.String())
+
+	var count int64
+	gormDB.Model(&authDomain.OidcAuthSessionEntity{}).Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+func beginPasskeyLogin(t *testing.T) map[string]any {
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/passkey/options", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("passkey login options failed: %v", rec.Body.String())
+	}
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	return actual
+}
+
+func signIn(t *testing.T, signId, password string) map[string]any {
+	rec := trySignIn(signId, password)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("sign in failed: %v", rec.Body.String())
+	}
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	return actual
+}
+
+func trySignIn(signId, password string) *httptest.ResponseRecorder {
+	requestBody := fmt.Sprintf(`{
+		"id": "%s",
+		"password": "%s"
+	}`, signId, password)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func setUpLoginProtection(t *testing.T, requestBody string) {
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"site-settings.update"},
+	}, time.Minute*15)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/login-protection", strings.NewReader(requestBody))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("set login protection failed: %v", rec.Body.String())
+	}
+}
+
+func setUpMemberAccessLog(t *testing.T, requestBody string) {
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"site-settings.update"},
+	}, time.Minute*15)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/member-access-log", strings.NewReader(requestBody))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("set member access log failed: %v", rec.Body.String())
+	}
+}
+
+// skipLoginDelay 실패할 때마다 늘어나는 대기 시간이 지난 것처럼 마지막 실패 시각을 1분 전으로 변경한다.
+func skipLoginDelay() {
+	gormDB.Model(&authDomain.LoginFailureEntity{}).Where("1 = 1").Update("last_failed_at", time.Now().Add(-time.Minute))
+}
+
+func setUpPasswordPolicy(t *testing.T, requestBody string) {
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"site-settings.update"},
+	}, time.Minute*15)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/password-policy", strings.NewReader(requestBody))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("set password policy failed: %v", rec.Body.String())
+	}
+}
+
+func changePasswordWithToken(passwordChangeToken, newPassword string) *httptest.ResponseRecorder {
+	requestBody := fmt.Sprintf(`{"passwordChangeToken": "%s", "newPassword": "%s"}`, passwordChangeToken, newPassword)
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/password", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func setUpOidcLoginSetting(t *testing.T, issuer string, autoApproval bool) {
+	putOidcLoginSetting(t, fmt.Sprintf(`{
+		"used": true,
+		"providerName": "Keycloak",
+		"issuer": "%v",
+		"clientId": "better-admin",
+		"clientSecret": "better-admin-secret",
+		"redirectUri": "http://localhost:2016/api/auth/oidc",
+		"scopes": ["openid", "profile", "email"],
+		"autoApproval": %v,
+		"allowedRedirectUrls": ["http://localhost:2016/login"]
+	}`, issuer, autoApproval))
+}
+
+func putOidcLoginSetting(t *testing.T, requestBody string) {
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"site-settings.update"},
+	}, time.Minute*15)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/oidc-login", strings.NewReader(requestBody))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("set oidc login setting failed: %v", rec.Body.String())
+	}
+}
+
+func beginOidcLogin(t *testing.T, redirect string) string {
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/auth/oidc/authorization?redirect="+url.QueryEscape(redirect), nil))
+
+	if rec.Code != http.StatusFound {
+		t.Fatalf("oidc login failed: %v", rec.Body.String())
+	}
+
+	return rec.Header().Get("Location")
+}
+
+func newTestLdapServer() *testldap.LdapServer {
+	return testldap.NewLdapServer(
+		testldap.Entry{
+			Dn:       "cn=admin,dc=bettercode,dc=kr",
+			Password: "admin-password",
+		},
+		testldap.Entry{
+			Dn:       "uid=ymyoo,ou=people,dc=bettercode,dc=kr",
+			Password: "ldap-password",
+			Attributes: map[string][]string{
+				"objectClass": {"person"},
+				"uid":         {"ymyoo"},
+				"cn":          {"유영모"},
+				"mail":        {"ymyoo@bettercode.kr"},
+				"memberOf":    {"cn=developers,ou=groups,dc=bettercode,dc=kr", "cn=admins,ou=groups,dc=bettercode,dc=kr"},
+			},
+		},
+	)
+}
+
+func setUpLdapLoginSetting(t *testing.T, setting map[string]any) {
+	requestBody := map[string]any{
+		"used":         true,
+		"bindDn":       "cn=admin,dc=bettercode,dc=kr",
+		"bindPassword": "admin-password",
+		"searchBase":   "ou=people,dc=bettercode,dc=kr",
+		"userFilter":   "(&(objectClass=person)(uid={username}))",
+	}
+	for key, value := range setting {
+		requestBody[key] = value
+	}
+	body, _ := json.Marshal(requestBody)
+
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"site-settings.update"},
+	}, time.Minute*15)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/ldap-login", bytes.NewReader(body))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("set ldap login setting failed: %v", rec.Body.String())
+	}
+}
+
+func ldapSignIn(signId, password string) *httptest.ResponseRecorder {
+	requestBody := fmt.Sprintf(`{
+		"id": "%s",
+		"password": "%s"
+	}`, signId, password)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/ldap", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func Test_authWithGoogleWorkspaceAccount_다른_멤버에_연결된_로그인인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	server := newGoogleWorkspaceServer("bettercode.kr")
+	defer server.Close()
+	config.Config.GoogleOAuth.AuthUri = server.URL
+	config.Config.GoogleOAuth.TokenUri = server.URL
+
+	authWithGoogleWorkspace(t)
+	googleMemberEntity := memberDomain.MemberEntity{}
+	gormDB.Where("google_id = ?", "123456").First(&googleMemberEntity)
+
+	if rec := linkIdentity(t, googleMemberEntity.ID, createIdentityLink(t, 3)); rec.Code != http.StatusNoContent {
+		t.Fatalf("link identity failed: %v", rec.Body.String())
+	}
+
+	// when
+	rec := authWithGoogleWorkspace(t)
+
+	// then
+	assert.Equal(t, http.StatusFound, rec.Code)
+	accessToken := exchangeLoginCode(t, rec.Header().Get("Location"))
+	tokenUserClaim, _ := security.JwtAuthentication{}.ConvertTokenUserClaim(accessToken)
+	assert.Equal(t, uint(3), tokenUserClaim.Id)
+
+	// 연결된 로그인의 프로필로 멤버의 프로필을 바꾸지 않는다.
+	memberEntity := memberDomain.MemberEntity{}
+	gormDB.First(&memberEntity, 3)
+	assert.Empty(t, memberEntity.Picture)
+
+	var count int64
+	gormDB.Model(&memberDomain.MemberEntity{}).Where("google_id = ?", "123456").Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+// createScimUser SCIM API 로 멤버를 프로비저닝하고 멤버 아이디를 반환한다.
+func createScimUser(t *testing.T, userName string) string {
+	req := httptest.NewRequest(http.MethodPost, "/scim/v2/Users", strings.NewReader(fmt.Sprintf(`{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+		"userName": "%v",
+		"displayName": "SCIM 사용자",
+		"emails": [{"value": "%v", "primary": true}]
+	}`, userName, userName)))
+	req.Header.Set("Authorization", "Bearer bscim_test-token")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create scim user failed: %v", rec.Body.String())
+	}
+
+	var scimUser map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &scimUser)
+	return scimUser["id"].(string)
+}
+
+func Test_authWithGoogleWorkspaceAccount_SCIM_으로_프로비저닝된_멤버인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	server := newGoogleWorkspaceServer("bettercode.kr")
+	defer server.Close()
+	config.Config.GoogleOAuth.AuthUri = server.URL
+	config.Config.GoogleOAuth.TokenUri = server.URL
+
+	scimUserId := createScimUser(t, "YMYOO@bettercode.kr")
+
+	// when
+	rec := authWithGoogleWorkspace(t)
+
+	// then
+	assert.Equal(t, http.StatusFound, rec.Code)
+	accessToken := exchangeLoginCode(t, rec.Header().Get("Location"))
+	tokenUserClaim, _ := security.JwtAuthentication{}.ConvertTokenUserClaim(accessToken)
+	assert.Equal(t, scimUserId, fmt.Sprint(tokenUserClaim.Id))
+
+	var count int64
+	gormDB.Model(&memberDomain.MemberEntity{}).Where("google_id = ?", "123456").Count(&count)
+	assert.Equal(t, int64(0), count)
+
+	identityEntity := memberDomain.MemberIdentityEntity{}
+	gormDB.Where("type = ? AND subject = ?", "google", "123456").First(&identityEntity)
+	assert.Equal(t, scimUserId, fmt.Sprint(identityEntity.MemberId))
+}
+
+func Test_authWithGoogleWorkspaceAccount_SCIM_으로_비활성화된_멤버인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	server := newGoogleWorkspaceServer("bettercode.kr")
+	defer server.Close()
+	config.Config.GoogleOAuth.AuthUri = server.URL
+	config.Config.GoogleOAuth.TokenUri = server.URL
+
+	scimUserId := createScimUser(t, "ymyoo@bettercode.kr")
+	authWithGoogleWorkspace(t)
+
+	deleteReq := httptest.NewRequest(http.MethodDelete, "/scim/v2/Users/"+scimUserId, nil)
+	deleteReq.Header.Set("Authorization", "Bearer bscim_test-token")
+	ginApp.ServeHTTP(httptest.NewRecorder(), deleteReq)
+
+	// when
+	rec := authWithGoogleWorkspace(t)
+
+	// then
+	assert.Equal(t, http.StatusFound, rec.Code)
+	location, _ := url.Parse(rec.Header().Get("Location"))
+	assert.Equal(t, "inactive-member", location.Query().Get("error"))
+	assert.Empty(t, location.Query().Get("loginCode"))
 }
@@ -0,0 +1,17 @@
+package rest
+
+import (
+	"better-admin-backend-service/security"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// requireOidcProvider 비대칭 서명 키가 설정되지 않은 경우 OpenID Connect 제공자 API 를 제공하지 않는다.
+func requireOidcProvider(ctx *gin.Context) {
+	if (security.JwtAuthentication{}).HasAsymmetricSigningKey() == false {
+		ctx.AbortWithStatus(http.StatusNotFound)
+		return
+	}
+
+	ctx.Next()
+}
@@ -20,7 +20,7 @@ func init() {
 		panic(err)
 	}
 
-	testAppServer := testserver.NewTestAppServer(Router{})
+	testAppServer := testserver.NewTestAppServer(NewRouter())
 	gormDB = testAppServer.GetDB()
 	ginApp = testAppServer.GetGin()
 }
@@ -1,35 +1,75 @@
 package rest
 
 import (
+	authDomain "better-admin-backend-service/auth/domain"
 	"better-admin-backend-service/constants"
 	"better-admin-backend-service/dtos"
 	"better-admin-backend-service/errors"
 	"better-admin-backend-service/helpers"
+	memberDomain "better-admin-backend-service/member/domain"
+	"better-admin-backend-service/security"
 	"better-admin-backend-service/services"
 	etag "github.com/bettercode-oss/gin-middleware-etag"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type MemberController struct {
-	routerGroup         *gin.RouterGroup
-	rbacService         *services.RoleBasedAccessControlService
-	memberService       *services.MemberService
-	organizationService *services.OrganizationService
+	routerGroup                *gin.RouterGroup
+	rbacService                *services.RoleBasedAccessControlService
+	memberService              *services.MemberService
+	organizationService        *services.OrganizationService
+	tokenRevocationService     *services.TokenRevocationService
+	webAuthnService            *services.WebAuthnService
+	loginProtectionService     *services.LoginProtectionService
+	memberAccessLogService     *services.MemberAccessLogService
+	sessionService             *services.SessionService
+	personalAccessTokenService *services.PersonalAccessTokenService
+	impersonationService       *services.ImpersonationService
+	signUpService              *services.SignUpService
+	emailVerificationService   *services.EmailVerificationService
+	memberProvisioningService  *services.MemberProvisioningService
+	memberIdentityService      *services.MemberIdentityService
+	memberBulkService          *services.MemberBulkService
 }
 
 func NewMemberController(routerGroup *gin.RouterGroup,
 	rbacService *services.RoleBasedAccessControlService,
 	memberService *services.MemberService,
-	organizationService *services.OrganizationService) *MemberController {
+	organizationService *services.OrganizationService,
+	tokenRevocationService *services.TokenRevocationService,
+	webAuthnService *services.WebAuthnService,
+	loginProtectionService *services.LoginProtectionService,
+	memberAccessLogService *services.MemberAccessLogService,
+	sessionService *services.SessionService,
+	personalAccessTokenService *services.PersonalAccessTokenService,
+	impersonationService *services.ImpersonationService,
+	signUpService *services.SignUpService,
+	emailVerificationService *services.EmailVerificationService,
+	memberProvisioningService *services.MemberProvisioningService,
+	memberIdentityService *services.MemberIdentityService,
+	memberBulkService *services.MemberBulkService) *MemberController {
 
 	return &MemberController{
-		routerGroup:         routerGroup,
-		rbacService:         rbacService,
-		memberService:       memberService,
-		organizationService: organizationService,
+		routerGroup:                routerGroup,
+		rbacService:                rbacService,
+		memberService:              memberService,
+		organizationService:        organizationService,
+		tokenRevocationService:     tokenRevocationService,
+		webAuthnService:            webAuthnService,
+		loginProtectionService:     loginProtectionService,
+		memberAccessLogService:     memberAccessLogService,
+		sessionService:             sessionService,
+		personalAccessTokenService: personalAccessTokenService,
+		impersonationService:       impersonationService,
+		signUpService:              signUpService,
+		emailVerificationService:   emailVerificationService,
+		memberProvisioningService:  memberProvisioningService,
+		memberIdentityService:      memberIdentityService,
+		memberBulkService:          memberBulkService,
 	}
 }
 
@@ -37,12 +77,62 @@ func (c MemberController) MapRoutes() {
 	route := c.routerGroup.Group("/members")
 
 	route.POST("", c.signUpMember)
+	route.POST("/email-verification", c.verifyEmail)
 	route.GET("", etag.HttpEtagCache(0), c.getMembers)
 	route.GET("/my", c.getCurrentMember)
+	route.GET("/my/profile", c.getMyProfile)
+	route.PUT("/my/profile", c.updateMyProfile)
+	route.PUT("/my/password", c.changePassword)
+	route.POST("/my/two-factor", c.startTwoFactorEnrollment)
+	route.PUT("/my/two-factor/activated", c.activateTwoFactor)
+	route.PUT("/my/two-factor/deactivated", c.deactivateTwoFactor)
+	route.POST("/my/passkeys/options", c.beginPasskeyRegistration)
+	route.POST("/my/passkeys", c.registerPasskey)
+	route.GET("/my/passkeys", c.getMyPasskeys)
+	route.DELETE("/my/passkeys/:passkeyId", c.deleteMyPasskey)
+	route.GET("/my/access-logs", c.getMyAccessLogs)
+	route.GET("/my/sessions", c.getMySessions)
+	route.DELETE("/my/sessions/:sessionId", c.deleteMySession)
+	route.POST("/my/access-tokens", c.createMyPersonalAccessToken)
+	route.GET("/my/access-tokens", c.getMyPersonalAccessTokens)
+	route.DELETE("/my/access-tokens/:tokenId", c.deleteMyPersonalAccessToken)
+	route.DELETE("/my/impersonation", c.endMyImpersonation)
+	route.PUT("/my/email", c.changeMyEmail)
+	route.POST("/my/email-verification", c.resendMyEmailVerification)
+	route.POST("/my/identity-links", c.createMyIdentityLink)
+	route.POST("/my/identities", c.linkMyIdentity)
+	route.GET("/my/identities", c.getMyIdentities)
+	route.DELETE("/my/identities/:identityId", c.unlinkMyIdentity)
+	route.GET("/access-logs", c.getMemberAccessLogs)
+	route.GET("/impersonations", c.getImpersonations)
+	route.POST("/invitations", c.createInvitation)
+	route.GET("/invitations", c.getInvitations)
+	route.DELETE("/invitations/:invitationId", c.cancelInvitation)
+	route.PUT("/bulk/approved", c.approveMembers)
+	route.PUT("/bulk/rejected", c.rejectMembers)
+	route.PUT("/bulk/assign-roles", c.assignRolesToMembers)
+	route.PUT("/bulk/add-organization", c.addMembersToOrganization)
+	route.PUT("/bulk/suspended", c.suspendMembers)
 	route.GET("/:id", etag.HttpEtagCache(0), c.getMember)
 	route.PUT("/:id/assign-roles", c.assignRole)
 	route.PUT("/:id/approved", c.approveMember)
 	route.PUT("/:id/rejected", c.rejectMember)
+	route.PUT("/:id/suspended", c.changeMemberStatus(constants.StatusMemberSuspended))
+	route.PUT("/:id/locked", c.changeMemberStatus(constants.StatusMemberLocked))
+	route.PUT("/:id/withdrawn", c.changeMemberStatus(constants.StatusMemberWithdrawn))
+	route.PUT("/:id/expired", c.changeMemberStatus(constants.StatusMemberExpired))
+	route.PUT("/:id/reactivated", c.changeMemberStatus(constants.StatusMemberApproved))
+	route.GET("/:id/status-histories", c.getMemberStatusHistories)
+	route.PUT("/:id/revoke-tokens", c.revokeTokens)
+	route.PUT("/:id/password-reset", c.resetPassword)
+	route.PUT("/:id/unlocked", c.unlockMember)
+	route.POST("/:id/impersonation", c.startImpersonation)
+	route.GET("/:id/passkeys", c.getPasskeys)
+	route.DELETE("/:id/passkeys/:passkeyId", c.deletePasskey)
+	route.GET("/:id/sessions", c.getSessions)
+	route.DELETE("/:id/sessions/:sessionId", c.deleteSession)
+	route.GET("/:id/identities", c.getIdentities)
+	route.POST("/:id/merge", c.mergeMember)
 	route.GET("/search-filters", etag.HttpEtagCache(0), c.getSearchFilters)
 }
 
@@ -53,12 +143,23 @@ func (c MemberController) signUpMember(ctx *gin.Context) {
 		return
 	}
 
-	err := c.memberService.SignUpMember(ctx.Request.Context(), memberSignUp)
+	err := c.signUpService.SignUpMember(ctx.Request.Context(), memberSignUp)
 	if err != nil {
-		if err == errors.ErrDuplicated {
+		if err == errors.ErrDuplicated || err == errors.ErrInvalidInvitation {
 			ctx.JSON(http.StatusBadRequest, err.Error())
 			return
 		}
+
+		if err == errors.ErrSignUpNotAllowed {
+			ctx.JSON(http.StatusForbidden, dtos.ErrorMessage{Message: err.Error()})
+			return
+		}
+
+		if e, ok := err.(*errors.ErrPasswordPolicyViolation); ok {
+			ctx.JSON(http.StatusBadRequest, dtos.PasswordPolicyViolationMessage{Message: e.Error(), Violations: e.Violations})
+			return
+		}
+
 		helpers.ErrorHelper().InternalServerError(ctx, err)
 		return
 	}
@@ -90,24 +191,165 @@ func (c MemberController) getCurrentMember(ctx *gin.Context) {
 	}
 
 	memberInformation := dtos.CurrentMember{
-		Id:          memberEntity.ID,
-		Type:        memberEntity.Type,
-		TypeName:    memberEntity.GetTypeName(),
-		Name:        memberEntity.Name,
-		Roles:       memberAssignedAllRoleAndPermission.Roles,
-		Permissions: memberAssignedAllRoleAndPermission.Permissions,
-		Picture:     memberEntity.Picture,
+		Id:               memberEntity.ID,
+		Type:             memberEntity.Type,
+		TypeName:         memberEntity.GetTypeName(),
+		Name:             memberEntity.Name,
+		Roles:            memberAssignedAllRoleAndPermission.Roles,
+		Permissions:      memberAssignedAllRoleAndPermission.Permissions,
+		Picture:          memberEntity.Picture,
+		TwoFactorEnabled: memberEntity.TwoFactorEnabled,
+		Email:            memberEntity.Email,
+		EmailVerified:    memberEntity.IsEmailVerified(),
+	}
+
+	if userClaim.IsImpersonation() {
+		memberInformation.Impersonator = &dtos.CurrentMemberImpersonator{Id: userClaim.Impersonator.Id}
+
+		impersonatorEntity, err := c.memberService.GetMemberById(ctx.Request.Context(), userClaim.Impersonator.Id)
+		if err != nil && err != errors.ErrNotFound {
+			helpers.ErrorHelper().InternalServerError(ctx, err)
+			return
+		}
+		memberInformation.Impersonator.Name = impersonatorEntity.Name
 	}
 
 	ctx.JSON(http.StatusOK, memberInformation)
 }
 
+func (c MemberController) changePassword(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var passwordChange dtos.MemberPasswordChange
+	if err := ctx.BindJSON(&passwordChange); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = c.memberService.ChangePassword(ctx.Request.Context(), userClaim.Id, passwordChange)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		if err == errors.ErrAuthentication || err == errors.ErrNotSupportedPassword || err == errors.ErrPasswordReused {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		if e, ok := err.(*errors.ErrPasswordPolicyViolation); ok {
+			ctx.JSON(http.StatusBadRequest, dtos.PasswordPolicyViolationMessage{Message: e.Error(), Violations: e.Violations})
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c MemberController) startTwoFactorEnrollment(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !c.requireRecentLogin(ctx, userClaim) {
+		return
+	}
+
+	enrollment, err := c.memberService.StartTwoFactorEnrollment(ctx.Request.Context(), userClaim.Id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		if err == errors.ErrNotSupportedTwoFactor || err == errors.ErrTwoFactorAlreadyEnabled {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, enrollment)
+}
+
+func (c MemberController) activateTwoFactor(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !c.requireRecentLogin(ctx, userClaim) {
+		return
+	}
+
+	var twoFactorCode dtos.MemberTwoFactorCode
+	if err := ctx.BindJSON(&twoFactorCode); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	recoveryCodes, err := c.memberService.ActivateTwoFactor(ctx.Request.Context(), userClaim.Id, twoFactorCode.Code)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		if err == errors.ErrInvalidTwoFactorCode || err == errors.ErrTwoFactorNotEnabled || err == errors.ErrTwoFactorAlreadyEnabled {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.MemberTwoFactorRecoveryCodes{RecoveryCodes: recoveryCodes})
+}
+
+func (c MemberController) deactivateTwoFactor(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var twoFactorCode dtos.MemberTwoFactorCode
+	if err := ctx.BindJSON(&twoFactorCode); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = c.memberService.DeactivateTwoFactor(ctx.Request.Context(), userClaim.Id, twoFactorCode.Code)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		if err == errors.ErrInvalidTwoFactorCode || err == errors.ErrTwoFactorNotEnabled {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
 func (c MemberController) getMembers(ctx *gin.Context) {
 	pageable := dtos.NewPageableFromRequest(ctx)
 	filters := map[string]interface{}{}
 
+	// 여러 상태는 콤마로 구분한다.(예: status=suspended,locked)
 	if len(ctx.Query("status")) > 0 {
-		filters["status"] = ctx.Query("status")
+		filters["statuses"] = strings.Split(ctx.Query("status"), ",")
 	}
 
 	if len(ctx.Query("name")) > 0 {
@@ -151,15 +393,21 @@ func (c MemberController) getMembers(ctx *gin.Context) {
 			})
 		}
 		memberInformation := dtos.MemberInformation{
-			Id:           entity.ID,
-			SignId:       entity.SignId,
-			CandidateId:  entity.GetCandidateId(),
-			Type:         entity.Type,
-			TypeName:     entity.GetTypeName(),
-			Name:         entity.Name,
-			MemberRoles:  roles,
-			CreatedAt:    entity.CreatedAt,
-			LastAccessAt: entity.LastAccessAt,
+			Id:              entity.ID,
+			SignId:          entity.SignId,
+			CandidateId:     entity.GetCandidateId(),
+			Type:            entity.Type,
+			TypeName:        entity.GetTypeName(),
+			Name:            entity.Name,
+			Email:           entity.Email,
+			EmailVerified:   entity.IsEmailVerified(),
+			Status:          entity.Status,
+			StatusName:      entity.GetStatusName(),
+			StatusReason:    entity.StatusReason,
+			StatusChangedAt: entity.StatusChangedAt,
+			MemberRoles:     roles,
+			CreatedAt:       entity.CreatedAt,
+			LastAccessAt:    entity.LastAccessAt,
 		}
 
 		var memberOrganizations = make([]dtos.MemberOrganization, 0)
@@ -219,11 +467,17 @@ func (c MemberController) getMember(ctx *gin.Context) {
 		})
 	}
 	memberInformation := dtos.MemberInformation{
-		Id:          memberEntity.ID,
-		Type:        memberEntity.Type,
-		TypeName:    memberEntity.GetTypeName(),
-		Name:        memberEntity.Name,
-		MemberRoles: roles,
+		Id:              memberEntity.ID,
+		Type:            memberEntity.Type,
+		TypeName:        memberEntity.GetTypeName(),
+		Name:            memberEntity.Name,
+		Email:           memberEntity.Email,
+		EmailVerified:   memberEntity.IsEmailVerified(),
+		Status:          memberEntity.Status,
+		StatusName:      memberEntity.GetStatusName(),
+		StatusReason:    memberEntity.StatusReason,
+		StatusChangedAt: memberEntity.StatusChangedAt,
+		MemberRoles:     roles,
 	}
 
 	ctx.JSON(http.StatusOK, memberInformation)
@@ -262,7 +516,7 @@ func (c MemberController) approveMember(ctx *gin.Context) {
 		return
 	}
 
-	err = c.memberService.ApproveMember(ctx.Request.Context(), uint(memberId))
+	err = c.memberProvisioningService.ApproveMember(ctx.Request.Context(), uint(memberId))
 	if err != nil {
 		if err == errors.ErrNotFound {
 			ctx.Status(http.StatusNotFound)
@@ -297,10 +551,61 @@ func (c MemberController) getSearchFilters(ctx *gin.Context) {
 				Text:  constants.TypeMemberGoogleName,
 				Value: constants.TypeMemberGoogle,
 			},
+			{
+				Text:  constants.TypeMemberOidcName,
+				Value: constants.TypeMemberOidc,
+			},
+			{
+				Text:  constants.TypeMemberLdapName,
+				Value: constants.TypeMemberLdap,
+			},
+			{
+				Text:  constants.TypeMemberScimName,
+				Value: constants.TypeMemberScim,
+			},
 		},
 	}
 	filters = append(filters, memberTypeSearchFilter)
 
+	memberStatusSearchFilter := dtos.SearchFilter{
+		Name: "status",
+		Filters: []dtos.Filter{
+			{
+				Text:  constants.StatusMemberAppliedName,
+				Value: constants.StatusMemberApplied,
+			},
+			{
+				Text:  constants.StatusMemberApprovedName,
+				Value: constants.StatusMemberApproved,
+			},
+			{
+				Text:  constants.StatusMemberRejectedName,
+				Value: constants.StatusMemberRejected,
+			},
+			{
+				Text:  constants.StatusMemberSuspendedName,
+				Value: constants.StatusMemberSuspended,
+			},
+			{
+				Text:  constants.StatusMemberLockedName,
+				Value: constants.StatusMemberLocked,
+			},
+			{
+				Text:  constants.StatusMemberExpiredName,
+				Value: constants.StatusMemberExpired,
+			},
+			{
+				Text:  constants.StatusMemberWithdrawnName,
+				Value: constants.StatusMemberWithdrawn,
+			},
+			{
+				Text:  constants.StatusMemberDeactivatedName,
+				Value: constants.StatusMemberDeactivated,
+			},
+		},
+	}
+	filters = append(filters, memberStatusSearchFilter)
+
 	allRoles, _, err := c.rbacService.GetRoles(ctx.Request.Context(), nil, dtos.Pageable{Page: 0})
 	if err != nil {
 		helpers.ErrorHelper().InternalServerError(ctx, err)
@@ -336,6 +641,1126 @@ func (c MemberController) rejectMember(ctx *gin.Context) {
 			ctx.Status(http.StatusNotFound)
 			return
 		}
+		if err == errors.ErrInvalidStatusTransition {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// changeMemberStatus 멤버를 정지, 잠금, 탈퇴, 만료 처리하거나 재활성화(승인 상태로 변경)한다. 변경 사유는 필수이다.
+func (c MemberController) changeMemberStatus(status string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		memberId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var statusChange dtos.MemberStatusChange
+		if err := ctx.BindJSON(&statusChange); err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		err = c.memberService.ChangeMemberStatus(ctx.Request.Context(), uint(memberId), status, statusChange.Reason)
+		if err != nil {
+			if err == errors.ErrNotFound {
+				ctx.Status(http.StatusNotFound)
+				return
+			}
+			if err == errors.ErrInvalidStatusTransition {
+				ctx.JSON(http.StatusBadRequest, err.Error())
+				return
+			}
+			helpers.ErrorHelper().InternalServerError(ctx, err)
+			return
+		}
+
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+func (c MemberController) getMemberStatusHistories(ctx *gin.Context) {
+	memberId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entities, err := c.memberService.GetMemberStatusHistories(ctx.Request.Context(), uint(memberId))
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	statusHistories := make([]dtos.MemberStatusHistory, 0)
+	for _, entity := range entities {
+		statusHistories = append(statusHistories, dtos.MemberStatusHistory{
+			Id:         entity.ID,
+			FromStatus: entity.FromStatus,
+			ToStatus:   entity.ToStatus,
+			Reason:     entity.Reason,
+			ActorId:    entity.ActorId,
+			CreatedAt:  entity.CreatedAt,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, statusHistories)
+}
+
+// approveMembers 여러 멤버를 한 번에 처리하는 요청은 멤버별 성공/실패를 응답한다.(dtos.MemberBulkResult)
+func (c MemberController) approveMembers(ctx *gin.Context) {
+	var bulk dtos.MemberBulkOperation
+	if err := ctx.BindJSON(&bulk); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.memberBulkService.ApproveMembers(ctx.Request.Context(), bulk)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (c MemberController) rejectMembers(ctx *gin.Context) {
+	var bulk dtos.MemberBulkOperation
+	if err := ctx.BindJSON(&bulk); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.memberBulkService.RejectMembers(ctx.Request.Context(), bulk)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (c MemberController) assignRolesToMembers(ctx *gin.Context) {
+	var bulk dtos.MemberBulkAssignRole
+	if err := ctx.BindJSON(&bulk); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.memberBulkService.AssignRoles(ctx.Request.Context(), bulk)
+	if err != nil {
+		if err == errors.ErrRoleNotFound {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (c MemberController) addMembersToOrganization(ctx *gin.Context) {
+	var bulk dtos.MemberBulkAddOrganization
+	if err := ctx.BindJSON(&bulk); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.memberBulkService.AddToOrganization(ctx.Request.Context(), bulk)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (c MemberController) suspendMembers(ctx *gin.Context) {
+	var bulk dtos.MemberBulkStatusChange
+	if err := ctx.BindJSON(&bulk); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.memberBulkService.SuspendMembers(ctx.Request.Context(), bulk)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (c MemberController) revokeTokens(ctx *gin.Context) {
+	memberId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = c.tokenRevocationService.RevokeAllTokensOfMember(ctx.Request.Context(), uint(memberId))
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// resetPassword 임시 비밀번호로 초기화하고 기존 비밀번호로 발급된 토큰은 모두 폐기한다.
+func (c MemberController) resetPassword(ctx *gin.Context) {
+	memberId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	temporaryPassword, err := c.memberService.ResetPassword(ctx.Request.Context(), uint(memberId))
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		if err == errors.ErrNotSupportedPassword {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	if err := c.tokenRevocationService.RevokeSessionsOfMember(ctx.Request.Context(), uint(memberId)); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.MemberPasswordReset{TemporaryPassword: temporaryPassword})
+}
+
+// unlockMember 연속된 로그인 실패로 잠긴 멤버의 잠금을 해제한다.
+func (c MemberController) unlockMember(ctx *gin.Context) {
+	memberId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = c.loginProtectionService.UnlockMember(ctx.Request.Context(), uint(memberId))
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// requireRecentLogin 최근에 로그인하지 않은 경우 403 으로 응답하고 false 를 반환한다.
+func (c MemberController) requireRecentLogin(ctx *gin.Context, userClaim *security.UserClaim) bool {
+	if err := c.sessionService.RequireRecentLogin(ctx.Request.Context(), userClaim); err != nil {
+		if err == errors.ErrReauthenticationRequired {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, dtos.ErrorMessage{Message: err.Error()})
+			return false
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return false
+	}
+
+	return true
+}
+
+func (c MemberController) beginPasskeyRegistration(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !c.requireRecentLogin(ctx, userClaim) {
+		return
+	}
+
+	ceremony, err := c.webAuthnService.BeginRegistration(ctx.Request.Context(), userClaim.Id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		if err == errors.ErrNotSupportedWebAuthn {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ceremony)
+}
+
+func (c MemberController) registerPasskey(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !c.requireRecentLogin(ctx, userClaim) {
+		return
+	}
+
+	var registration dtos.WebAuthnCredentialRegistration
+	if err := ctx.BindJSON(&registration); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	credentialEntity, err := c.webAuthnService.FinishRegistration(ctx.Request.Context(), userClaim.Id, registration)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		if err == errors.ErrNotSupportedWebAuthn || err == errors.ErrInvalidWebAuthnSession || err == errors.ErrInvalidWebAuthnCredential {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dtos.MemberPasskey{
+		Id:         credentialEntity.ID,
+		Name:       credentialEntity.Name,
+		CreatedAt:  credentialEntity.CreatedAt,
+		LastUsedAt: credentialEntity.LastUsedAt,
+	})
+}
+
+func (c MemberController) getMyPasskeys(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.responsePasskeys(ctx, userClaim.Id)
+}
+
+func (c MemberController) deleteMyPasskey(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.deletePasskeyOfMember(ctx, userClaim.Id)
+}
+
+func (c MemberController) getPasskeys(ctx *gin.Context) {
+	memberId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.responsePasskeys(ctx, uint(memberId))
+}
+
+func (c MemberController) deletePasskey(ctx *gin.Context) {
+	memberId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.deletePasskeyOfMember(ctx, uint(memberId))
+}
+
+func (c MemberController) responsePasskeys(ctx *gin.Context, memberId uint) {
+	credentialEntities, err := c.webAuthnService.GetCredentials(ctx.Request.Context(), memberId)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	passkeys := make([]dtos.MemberPasskey, 0)
+	for _, credentialEntity := range credentialEntities {
+		passkeys = append(passkeys, dtos.MemberPasskey{
+			Id:         credentialEntity.ID,
+			Name:       credentialEntity.Name,
+			CreatedAt:  credentialEntity.CreatedAt,
+			LastUsedAt: credentialEntity.LastUsedAt,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, passkeys)
+}
+
+func (c MemberController) deletePasskeyOfMember(ctx *gin.Context, memberId uint) {
+	passkeyId, err := strconv.ParseInt(ctx.Param("passkeyId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = c.webAuthnService.DeleteCredential(ctx.Request.Context(), memberId, uint(passkeyId))
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c MemberController) getMemberAccessLogs(ctx *gin.Context) {
+	filters, err := c.getMemberAccessLogFilters(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if len(ctx.Query("memberId")) > 0 {
+		memberId, err := strconv.ParseUint(ctx.Query("memberId"), 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		filters["memberId"] = uint(memberId)
+	}
+
+	if len(ctx.Query("loginId")) > 0 {
+		filters["loginId"] = ctx.Query("loginId")
+	}
+
+	if len(ctx.Query("ipAddress")) > 0 {
+		filters["ipAddress"] = ctx.Query("ipAddress")
+	}
+
+	c.responseMemberAccessLogs(ctx, filters)
+}
+
+func (c MemberController) getMyAccessLogs(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	filters, err := c.getMemberAccessLogFilters(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+	filters["memberId"] = userClaim.Id
+
+	c.responseMemberAccessLogs(ctx, filters)
+}
+
+// getMemberAccessLogFilters 접근 유형(types), 인증 유형(authTypes), 결과(result), 기간(from, to. RFC3339) 필터
+func (MemberController) getMemberAccessLogFilters(ctx *gin.Context) (map[string]interface{}, error) {
+	filters := map[string]interface{}{}
+
+	if len(ctx.Query("types")) > 0 {
+		types := strings.Split(ctx.Query("types"), ",")
+		for _, accessType := range types {
+			if accessType != constants.MemberAccessTypeLogin && accessType != constants.MemberAccessTypeTokenRefresh {
+				return nil, errors.ErrNotSupportedAccessLogType
+			}
+		}
+		filters["types"] = types
+	}
+
+	if len(ctx.Query("authTypes")) > 0 {
+		filters["authTypes"] = strings.Split(ctx.Query("authTypes"), ",")
+	}
+
+	if len(ctx.Query("result")) > 0 {
+		filters["result"] = ctx.Query("result")
+	}
+
+	for _, key := range []string{"from", "to"} {
+		if len(ctx.Query(key)) == 0 {
+			continue
+		}
+
+		value, err := time.Parse(time.RFC3339, ctx.Query(key))
+		if err != nil {
+			return nil, err
+		}
+		filters[key] = value
+	}
+
+	return filters, nil
+}
+
+func (c MemberController) responseMemberAccessLogs(ctx *gin.Context, filters map[string]interface{}) {
+	pageable := dtos.NewPageableFromRequest(ctx)
+	accessLogEntities, totalCount, err := c.memberAccessLogService.GetMemberAccessLogs(ctx.Request.Context(), filters, pageable)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	accessLogs := make([]dtos.MemberAccessLog, 0)
+	for _, entity := range accessLogEntities {
+		accessLogs = append(accessLogs, dtos.MemberAccessLog{
+			Id:            entity.ID,
+			MemberId:      entity.MemberId,
+			LoginId:       entity.LoginId,
+			Type:          entity.Type,
+			AuthType:      entity.AuthType,
+			Result:        entity.Result,
+			FailureReason: entity.FailureReason,
+			IpAddress:     entity.IpAddress,
+			UserAgent:     entity.UserAgent,
+			CreatedAt:     entity.CreatedAt,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, dtos.PageResult{
+		Result:     accessLogs,
+		TotalCount: totalCount,
+	})
+}
+
+func (c MemberController) getMySessions(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.responseSessions(ctx, userClaim.Id, userClaim.SessionId)
+}
+
+func (c MemberController) deleteMySession(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.deleteSessionOfMember(ctx, userClaim.Id)
+}
+
+func (c MemberController) getSessions(ctx *gin.Context) {
+	memberId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.responseSessions(ctx, uint(memberId), "")
+}
+
+func (c MemberController) deleteSession(ctx *gin.Context) {
+	memberId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.deleteSessionOfMember(ctx, uint(memberId))
+}
+
+// responseSessions currentSessionId 는 요청한 토큰의 세션으로 표시한다.
+func (c MemberController) responseSessions(ctx *gin.Context, memberId uint, currentSessionId string) {
+	sessionEntities, err := c.sessionService.GetActiveSessions(ctx.Request.Context(), memberId)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	sessions := make([]dtos.MemberSession, 0)
+	for _, sessionEntity := range sessionEntities {
+		sessions = append(sessions, dtos.MemberSession{
+			Id:             sessionEntity.ID,
+			AuthType:       sessionEntity.AuthType,
+			IpAddress:      sessionEntity.IpAddress,
+			UserAgent:      sessionEntity.UserAgent,
+			Current:        len(currentSessionId) > 0 && sessionEntity.SessionId == currentSessionId,
+			CreatedAt:      sessionEntity.CreatedAt,
+			LastAccessedAt: sessionEntity.LastAccessedAt,
+			ExpiresAt:      sessionEntity.ExpiresAt,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, sessions)
+}
+
+func (c MemberController) deleteSessionOfMember(ctx *gin.Context, memberId uint) {
+	sessionId, err := strconv.ParseInt(ctx.Param("sessionId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = c.sessionService.RevokeSession(ctx.Request.Context(), memberId, uint(sessionId))
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c MemberController) createMyPersonalAccessToken(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var creation dtos.PersonalAccessTokenCreation
+	if err := ctx.BindJSON(&creation); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, token, err := c.personalAccessTokenService.CreatePersonalAccessToken(ctx.Request.Context(), userClaim.Id, creation)
+	if err != nil {
+		if err == errors.ErrNotAllowedPermission {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	personalAccessToken := newPersonalAccessToken(entity)
+	personalAccessToken.Token = token
+	ctx.JSON(http.StatusCreated, personalAccessToken)
+}
+
+func (c MemberController) getMyPersonalAccessTokens(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entities, err := c.personalAccessTokenService.GetPersonalAccessTokens(ctx.Request.Context(), userClaim.Id)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	personalAccessTokens := make([]dtos.PersonalAccessToken, 0)
+	for _, entity := range entities {
+		personalAccessTokens = append(personalAccessTokens, newPersonalAccessToken(entity))
+	}
+
+	ctx.JSON(http.StatusOK, personalAccessTokens)
+}
+
+func (c MemberController) deleteMyPersonalAccessToken(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	tokenId, err := strconv.ParseInt(ctx.Param("tokenId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = c.personalAccessTokenService.DeletePersonalAccessToken(ctx.Request.Context(), userClaim.Id, uint(tokenId))
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func newPersonalAccessToken(entity authDomain.PersonalAccessTokenEntity) dtos.PersonalAccessToken {
+	return dtos.PersonalAccessToken{
+		Id:           entity.ID,
+		Name:         entity.Name,
+		DisplayToken: entity.DisplayToken,
+		Permissions:  entity.GetPermissions(),
+		ExpiresAt:    entity.ExpiresAt,
+		CreatedAt:    entity.CreatedAt,
+		LastUsedAt:   entity.LastUsedAt,
+	}
+}
+
+func (c MemberController) startImpersonation(ctx *gin.Context) {
+	memberId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var start dtos.ImpersonationStart
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.BindJSON(&start); err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+	}
+
+	accessToken, expiresAt, err := c.impersonationService.StartImpersonation(ctx.Request.Context(), uint(memberId), start)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		if err == errors.ErrUnApproved || err == errors.ErrSelfImpersonation {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		if err == errors.ErrImpersonationNotAllowed {
+			ctx.AbortWithStatusJSON(http.StatusForbidden, dtos.ErrorMessage{Message: err.Error()})
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	result := map[string]any{}
+	result["accessToken"] = accessToken
+	result["expiresAt"] = expiresAt
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (c MemberController) endMyImpersonation(ctx *gin.Context) {
+	err := c.impersonationService.EndImpersonation(ctx.Request.Context())
+	if err != nil {
+		if err == errors.ErrNotImpersonating {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		if err == errors.ErrNotFound {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c MemberController) getImpersonations(ctx *gin.Context) {
+	filters := map[string]interface{}{}
+	for _, key := range []string{"memberId", "impersonatorId"} {
+		if len(ctx.Query(key)) == 0 {
+			continue
+		}
+
+		id, err := strconv.ParseUint(ctx.Query(key), 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		filters[key] = uint(id)
+	}
+
+	pageable := dtos.NewPageableFromRequest(ctx)
+	entities, totalCount, err := c.impersonationService.GetImpersonations(ctx.Request.Context(), filters, pageable)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	impersonations := make([]dtos.MemberImpersonation, 0)
+	for _, entity := range entities {
+		impersonations = append(impersonations, dtos.MemberImpersonation{
+			Id:             entity.ID,
+			ImpersonatorId: entity.ImpersonatorId,
+			MemberId:       entity.MemberId,
+			Reason:         entity.Reason,
+			IpAddress:      entity.IpAddress,
+			UserAgent:      entity.UserAgent,
+			ExpiresAt:      entity.ExpiresAt,
+			EndedAt:        entity.EndedAt,
+			CreatedAt:      entity.CreatedAt,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, dtos.PageResult{
+		Result:     impersonations,
+		TotalCount: totalCount,
+	})
+}
+
+func (c MemberController) getMyProfile(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	memberEntity, err := c.memberService.GetMemberById(ctx.Request.Context(), userClaim.Id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newMemberProfile(memberEntity))
+}
+
+func (c MemberController) updateMyProfile(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var profileUpdate dtos.MemberProfileUpdate
+	if err := ctx.BindJSON(&profileUpdate); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	memberEntity, err := c.memberService.UpdateProfile(ctx.Request.Context(), userClaim.Id, profileUpdate)
+	if err != nil {
+		if e, ok := err.(*errors.ErrProfileManagedByProvider); ok {
+			ctx.JSON(http.StatusBadRequest, e.Error())
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newMemberProfile(memberEntity))
+}
+
+func newMemberProfile(memberEntity memberDomain.MemberEntity) dtos.MemberProfile {
+	return dtos.MemberProfile{
+		Name:          memberEntity.Name,
+		PhoneNumber:   memberEntity.PhoneNumber,
+		Picture:       memberEntity.Picture,
+		ManagedFields: memberEntity.GetProviderManagedProfileFields(),
+	}
+}
+
+func (c MemberController) changeMyEmail(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !c.requireRecentLogin(ctx, userClaim) {
+		return
+	}
+
+	var emailChange dtos.MemberEmailChange
+	if err := ctx.BindJSON(&emailChange); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.emailVerificationService.ChangeEmail(ctx.Request.Context(), userClaim.Id, emailChange.Email); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c MemberController) resendMyEmailVerification(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.emailVerificationService.ResendEmailVerification(ctx.Request.Context(), userClaim.Id); err != nil {
+		if err == errors.ErrEmailNotRegistered || err == errors.ErrEmailAlreadyVerified {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c MemberController) verifyEmail(ctx *gin.Context) {
+	var emailVerification dtos.MemberEmailVerification
+	if err := ctx.BindJSON(&emailVerification); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.emailVerificationService.VerifyEmail(ctx.Request.Context(), emailVerification.Token); err != nil {
+		if err == errors.ErrInvalidEmailVerification {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c MemberController) createInvitation(ctx *gin.Context) {
+	var creation dtos.MemberInvitationCreation
+	if err := ctx.BindJSON(&creation); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, err := c.signUpService.CreateInvitation(ctx.Request.Context(), creation)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, newMemberInvitation(entity))
+}
+
+func (c MemberController) getInvitations(ctx *gin.Context) {
+	filters := map[string]interface{}{}
+	if len(ctx.Query("email")) > 0 {
+		filters["email"] = ctx.Query("email")
+	}
+
+	if len(ctx.Query("accepted")) > 0 {
+		accepted, err := strconv.ParseBool(ctx.Query("accepted"))
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		filters["accepted"] = accepted
+	}
+
+	pageable := dtos.NewPageableFromRequest(ctx)
+	entities, totalCount, err := c.signUpService.GetInvitations(ctx.Request.Context(), filters, pageable)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	invitations := make([]dtos.MemberInvitation, 0)
+	for _, entity := range entities {
+		invitations = append(invitations, newMemberInvitation(entity))
+	}
+
+	ctx.JSON(http.StatusOK, dtos.PageResult{
+		Result:     invitations,
+		TotalCount: totalCount,
+	})
+}
+
+func (c MemberController) cancelInvitation(ctx *gin.Context) {
+	invitationId, err := strconv.ParseInt(ctx.Param("invitationId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.signUpService.CancelInvitation(ctx.Request.Context(), uint(invitationId)); err != nil {
+		if err == errors.ErrNotFound {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		if err == errors.ErrInvalidInvitation {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func newMemberInvitation(entity memberDomain.MemberInvitationEntity) dtos.MemberInvitation {
+	return dtos.MemberInvitation{
+		Id:               entity.ID,
+		Email:            entity.Email,
+		RoleIds:          entity.GetRoleIds(),
+		OrganizationIds:  entity.GetOrganizationIds(),
+		ExpiresAt:        entity.ExpiresAt,
+		AcceptedAt:       entity.AcceptedAt,
+		AcceptedMemberId: entity.AcceptedMemberId,
+		CreatedBy:        entity.CreatedBy,
+		CreatedAt:        entity.CreatedAt,
+	}
+}
+
+func (c MemberController) createMyIdentityLink(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !c.requireRecentLogin(ctx, userClaim) {
+		return
+	}
+
+	identityLink, err := c.memberIdentityService.CreateIdentityLink(ctx.Request.Context(), userClaim.Id)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, identityLink)
+}
+
+// linkMyIdentity 연결할 로그인으로 로그인한 멤버가 다른 로그인에서 발급한 토큰을 제출한다.
+// 현재 로그인한 멤버는 토큰을 발급한 멤버에 연결된 뒤 삭제되므로 토큰을 발급한 로그인으로 다시 로그인해야 한다.
+func (c MemberController) linkMyIdentity(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if !c.requireRecentLogin(ctx, userClaim) {
+		return
+	}
+
+	var linking dtos.MemberIdentityLinking
+	if err := ctx.BindJSON(&linking); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = c.memberIdentityService.LinkIdentity(ctx.Request.Context(), userClaim.Id, linking.LinkToken)
+	if err != nil {
+		if err == errors.ErrInvalidIdentityLink || err == errors.ErrSiteMemberCannotBeLinked {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c MemberController) getMyIdentities(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.responseIdentities(ctx, userClaim.Id)
+}
+
+func (c MemberController) unlinkMyIdentity(ctx *gin.Context) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx.Request.Context())
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	identityId, err := strconv.ParseInt(ctx.Param("identityId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = c.memberIdentityService.UnlinkIdentity(ctx.Request.Context(), userClaim.Id, uint(identityId))
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c MemberController) getIdentities(ctx *gin.Context) {
+	memberId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	c.responseIdentities(ctx, uint(memberId))
+}
+
+func (c MemberController) responseIdentities(ctx *gin.Context, memberId uint) {
+	identities, err := c.memberIdentityService.GetIdentities(ctx.Request.Context(), memberId)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, identities)
+}
+
+func (c MemberController) mergeMember(ctx *gin.Context) {
+	memberId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	var merge dtos.MemberMerge
+	if err := ctx.BindJSON(&merge); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	err = c.memberIdentityService.MergeMembers(ctx.Request.Context(), uint(memberId), merge.SourceMemberId)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		if err == errors.ErrSelfMerge || err == errors.ErrSiteMemberCannotBeLinked {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
 		helpers.ErrorHelper().InternalServerError(ctx, err)
 		return
 	}
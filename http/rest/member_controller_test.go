@@ -1,12 +1,21 @@
 package rest
 
 import (
+	"better-admin-backend-service/adapters"
+	authDomain "better-admin-backend-service/auth/domain"
+	"better-admin-backend-service/dtos"
+	memberDomain "better-admin-backend-service/member/domain"
+	"better-admin-backend-service/security"
 	"better-admin-backend-service/testdata/testdb"
+	"better-admin-backend-service/testdata/testwebauthn"
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -56,6 +65,32 @@ func TestMemberController_signUpMember_아이디_중복(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 }
 
+func TestMemberController_signUpMember_비밀번호_정책을_만족하지_않는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	setUpPasswordPolicy(t, `{"minLength": 8, "requireUppercase": true}`)
+	requestBody := `{
+		"signId": "ymyoo1",
+		"name": "유영모",
+		"password": "1111"
+	}`
+
+	req := httptest.NewRequest(http.MethodPost, "/api/members", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	var actual dtos.PasswordPolicyViolationMessage
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, []string{"min-length", "uppercase"}, actual.Violations)
+}
+
 func TestMemberController_signUpMember_필수값_확인(t *testing.T) {
 	// given
 	requestBody := `{
@@ -139,13 +174,16 @@ func TestMemberController_getCurrentMember(t *testing.T) {
 	json.Unmarshal(rec.Body.Bytes(), &actual)
 
 	expected := map[string]any{
-		"id":          float64(1),
-		"type":        "site",
-		"typeName":    "사이트",
-		"name":        "사이트 관리자",
-		"roles":       []any{"SYSTEM MANAGER", "MEMBER MANAGER"},
-		"permissions": []any{"MANAGE_SYSTEM_SETTINGS", "MANAGE_MEMBERS"},
-		"picture":     "",
+		"id":               float64(1),
+		"type":             "site",
+		"typeName":         "사이트",
+		"name":             "사이트 관리자",
+		"roles":            []any{"SYSTEM MANAGER", "MEMBER MANAGER"},
+		"permissions":      []any{"MANAGE_SYSTEM_SETTINGS", "MANAGE_MEMBERS"},
+		"picture":          "",
+		"twoFactorEnabled": false,
+		"email":            "",
+		"emailVerified":    false,
 	}
 	assert.Equal(t, expected, actual)
 }
@@ -179,14 +217,20 @@ func TestMemberController_getMembers_by_멤버_역할(t *testing.T) {
 	expected := map[string]any{
 		"result": []any{
 			map[string]any{
-				"id":           float64(1),
-				"signId":       "siteadm",
-				"type":         "site",
-				"typeName":     "사이트",
-				"candidateId":  "siteadm",
-				"name":         "사이트 관리자",
-				"createdAt":    "1982-01-04T00:00:00Z",
-				"lastAccessAt": "1982-01-05T00:00:00Z",
+				"id":              float64(1),
+				"signId":          "siteadm",
+				"type":            "site",
+				"typeName":        "사이트",
+				"candidateId":     "siteadm",
+				"email":           "",
+				"emailVerified":   false,
+				"status":          "approved",
+				"statusName":      "승인",
+				"statusReason":    "",
+				"statusChangedAt": nil,
+				"name":            "사이트 관리자",
+				"createdAt":       "1982-01-04T00:00:00Z",
+				"lastAccessAt":    "1982-01-05T00:00:00Z",
 				"roles": []any{
 					map[string]any{
 						"id":   float64(1),
@@ -211,14 +255,20 @@ func TestMemberController_getMembers_by_멤버_역할(t *testing.T) {
 				},
 			},
 			map[string]any{
-				"id":           float64(2),
-				"signId":       "",
-				"type":         "dooray",
-				"typeName":     "두레이",
-				"candidateId":  "2222",
-				"name":         "유영모",
-				"createdAt":    "1982-01-04T00:00:00Z",
-				"lastAccessAt": "1982-01-05T00:00:00Z",
+				"id":              float64(2),
+				"signId":          "",
+				"type":            "dooray",
+				"typeName":        "두레이",
+				"candidateId":     "2222",
+				"email":           "",
+				"emailVerified":   false,
+				"status":          "approved",
+				"statusName":      "승인",
+				"statusReason":    "",
+				"statusChangedAt": nil,
+				"name":            "유영모",
+				"createdAt":       "1982-01-04T00:00:00Z",
+				"lastAccessAt":    "1982-01-05T00:00:00Z",
 				"roles": []any{
 					map[string]any{
 						"id":   float64(1),
@@ -281,12 +331,18 @@ func TestMemberController_getMembers_승인된_멤버(t *testing.T) {
 	expected := map[string]any{
 		"result": []any{
 			map[string]any{
-				"id":          float64(1),
-				"signId":      "siteadm",
-				"candidateId": "siteadm",
-				"type":        "site",
-				"typeName":    "사이트",
-				"name":        "사이트 관리자",
+				"id":              float64(1),
+				"signId":          "siteadm",
+				"candidateId":     "siteadm",
+				"email":           "",
+				"emailVerified":   false,
+				"status":          "approved",
+				"statusName":      "승인",
+				"statusReason":    "",
+				"statusChangedAt": nil,
+				"type":            "site",
+				"typeName":        "사이트",
+				"name":            "사이트 관리자",
 				"roles": []any{
 					map[string]any{
 						"id":   float64(1),
@@ -313,12 +369,18 @@ func TestMemberController_getMembers_승인된_멤버(t *testing.T) {
 				"lastAccessAt": "1982-01-05T00:00:00Z",
 			},
 			map[string]any{
-				"id":          float64(2),
-				"signId":      "",
-				"candidateId": "2222",
-				"type":        "dooray",
-				"typeName":    "두레이",
-				"name":        "유영모",
+				"id":              float64(2),
+				"signId":          "",
+				"candidateId":     "2222",
+				"email":           "",
+				"emailVerified":   false,
+				"status":          "approved",
+				"statusName":      "승인",
+				"statusReason":    "",
+				"statusChangedAt": nil,
+				"type":            "dooray",
+				"typeName":        "두레이",
+				"name":            "유영모",
 				"roles": []any{
 					map[string]any{
 						"id":   float64(1),
@@ -384,16 +446,22 @@ func TestMemberController_getMembers_신청한_멤버(t *testing.T) {
 	expected := map[string]any{
 		"result": []any{
 			map[string]any{
-				"id":            float64(4),
-				"signId":        "ymyoo3",
-				"candidateId":   "ymyoo3",
-				"type":          "site",
-				"typeName":      "사이트",
-				"name":          "유영모3",
-				"roles":         []any{},
-				"organizations": []any{},
-				"createdAt":     "1982-01-04T00:00:00Z",
-				"lastAccessAt":  "1982-01-05T00:00:00Z",
+				"id":              float64(4),
+				"signId":          "ymyoo3",
+				"candidateId":     "ymyoo3",
+				"email":           "",
+				"emailVerified":   false,
+				"status":          "applied",
+				"statusName":      "신청",
+				"statusReason":    "",
+				"statusChangedAt": nil,
+				"type":            "site",
+				"typeName":        "사이트",
+				"name":            "유영모3",
+				"roles":           []any{},
+				"organizations":   []any{},
+				"createdAt":       "1982-01-04T00:00:00Z",
+				"lastAccessAt":    "1982-01-05T00:00:00Z",
 			},
 		},
 		"totalCount": float64(1),
@@ -431,14 +499,20 @@ func TestMemberController_getMembers_by_멤버_이름(t *testing.T) {
 	expected := map[string]any{
 		"result": []any{
 			map[string]any{
-				"id":           float64(2),
-				"signId":       "",
-				"type":         "dooray",
-				"typeName":     "두레이",
-				"candidateId":  "2222",
-				"name":         "유영모",
-				"createdAt":    "1982-01-04T00:00:00Z",
-				"lastAccessAt": "1982-01-05T00:00:00Z",
+				"id":              float64(2),
+				"signId":          "",
+				"type":            "dooray",
+				"typeName":        "두레이",
+				"candidateId":     "2222",
+				"email":           "",
+				"emailVerified":   false,
+				"status":          "approved",
+				"statusName":      "승인",
+				"statusReason":    "",
+				"statusChangedAt": nil,
+				"name":            "유영모",
+				"createdAt":       "1982-01-04T00:00:00Z",
+				"lastAccessAt":    "1982-01-05T00:00:00Z",
 				"roles": []any{
 					map[string]any{
 						"id":   float64(1),
@@ -467,15 +541,21 @@ func TestMemberController_getMembers_by_멤버_이름(t *testing.T) {
 				},
 			},
 			map[string]any{
-				"id":           float64(3),
-				"signId":       "ymyoo",
-				"type":         "site",
-				"typeName":     "사이트",
-				"candidateId":  "ymyoo",
-				"name":         "유영모2",
-				"createdAt":    "1982-01-04T00:00:00Z",
-				"lastAccessAt": "1982-01-05T00:00:00Z",
-				"roles":        []any{},
+				"id":              float64(3),
+				"signId":          "ymyoo",
+				"type":            "site",
+				"typeName":        "사이트",
+				"candidateId":     "ymyoo",
+				"email":           "",
+				"emailVerified":   false,
+				"status":          "approved",
+				"statusName":      "승인",
+				"statusReason":    "",
+				"statusChangedAt": nil,
+				"name":            "유영모2",
+				"createdAt":       "1982-01-04T00:00:00Z",
+				"lastAccessAt":    "1982-01-05T00:00:00Z",
+				"roles":           []any{},
 				"organizations": []any{
 					map[string]any{
 						"id":   float64(4),
@@ -525,14 +605,20 @@ func TestMemberController_getMembers_by_멤버_유형(t *testing.T) {
 	expected := map[string]any{
 		"result": []any{
 			map[string]any{
-				"id":           float64(1),
-				"signId":       "siteadm",
-				"type":         "site",
-				"typeName":     "사이트",
-				"candidateId":  "siteadm",
-				"name":         "사이트 관리자",
-				"createdAt":    "1982-01-04T00:00:00Z",
-				"lastAccessAt": "1982-01-05T00:00:00Z",
+				"id":              float64(1),
+				"signId":          "siteadm",
+				"type":            "site",
+				"typeName":        "사이트",
+				"candidateId":     "siteadm",
+				"email":           "",
+				"emailVerified":   false,
+				"status":          "approved",
+				"statusName":      "승인",
+				"statusReason":    "",
+				"statusChangedAt": nil,
+				"name":            "사이트 관리자",
+				"createdAt":       "1982-01-04T00:00:00Z",
+				"lastAccessAt":    "1982-01-05T00:00:00Z",
 				"roles": []any{
 					map[string]any{
 						"id":   float64(1),
@@ -557,14 +643,20 @@ func TestMemberController_getMembers_by_멤버_유형(t *testing.T) {
 				},
 			},
 			map[string]any{
-				"id":           float64(2),
-				"signId":       "",
-				"type":         "dooray",
-				"typeName":     "두레이",
-				"candidateId":  "2222",
-				"name":         "유영모",
-				"createdAt":    "1982-01-04T00:00:00Z",
-				"lastAccessAt": "1982-01-05T00:00:00Z",
+				"id":              float64(2),
+				"signId":          "",
+				"type":            "dooray",
+				"typeName":        "두레이",
+				"candidateId":     "2222",
+				"email":           "",
+				"emailVerified":   false,
+				"status":          "approved",
+				"statusName":      "승인",
+				"statusReason":    "",
+				"statusChangedAt": nil,
+				"name":            "유영모",
+				"createdAt":       "1982-01-04T00:00:00Z",
+				"lastAccessAt":    "1982-01-05T00:00:00Z",
 				"roles": []any{
 					map[string]any{
 						"id":   float64(1),
@@ -593,15 +685,21 @@ func TestMemberController_getMembers_by_멤버_유형(t *testing.T) {
 				},
 			},
 			map[string]any{
-				"id":           float64(3),
-				"signId":       "ymyoo",
-				"type":         "site",
-				"typeName":     "사이트",
-				"candidateId":  "ymyoo",
-				"name":         "유영모2",
-				"createdAt":    "1982-01-04T00:00:00Z",
-				"lastAccessAt": "1982-01-05T00:00:00Z",
-				"roles":        []any{},
+				"id":              float64(3),
+				"signId":          "ymyoo",
+				"type":            "site",
+				"typeName":        "사이트",
+				"candidateId":     "ymyoo",
+				"email":           "",
+				"emailVerified":   false,
+				"status":          "approved",
+				"statusName":      "승인",
+				"statusReason":    "",
+				"statusChangedAt": nil,
+				"name":            "유영모2",
+				"createdAt":       "1982-01-04T00:00:00Z",
+				"lastAccessAt":    "1982-01-05T00:00:00Z",
+				"roles":           []any{},
 				"organizations": []any{
 					map[string]any{
 						"id":   float64(4),
@@ -931,6 +1029,40 @@ func TestMemberController_approveMember(t *testing.T) {
 	assert.Equal(t, http.StatusNoContent, rec.Code)
 }
 
+func TestMemberController_approveMember_자동_할당_규칙이_있는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	setMemberProvisioningRules(t, `[
+		{"name": "사이트 멤버", "memberType": "site", "roleIds": [3], "organizationIds": [1]},
+		{"name": "구글 멤버", "memberType": "google", "roleIds": [2]}
+	]`)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/members/4/approved", nil)
+	token, _ := generateTestJWT(map[string]any{
+		"Id": 1,
+		"Permissions": []string{
+			"member.update",
+		},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	memberEntity := memberDomain.MemberEntity{}
+	gormDB.Preload("Roles").First(&memberEntity, 4)
+	assert.Equal(t, []string{"테스트 관리자"}, memberEntity.GetRoleNames())
+
+	var count int64
+	gormDB.Table("organization_members").Where("organization_entity_id = ? AND member_entity_id = ?", 1, 4).Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
 func TestMemberController_approveMember_이미_승인된_경우(t *testing.T) {
 	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
 
@@ -1037,6 +1169,17 @@ func TestMemberController_rejectMember(t *testing.T) {
 	// then
 	fmt.Println(rec.Body.String())
 	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// 삭제하지 않고 거부 상태로 변경하고 변경 기록을 남긴다.
+	memberEntity := memberDomain.MemberEntity{}
+	gormDB.First(&memberEntity, 4)
+	assert.Equal(t, "rejected", memberEntity.Status)
+	assert.Equal(t, uint(1), memberEntity.StatusChangedBy)
+
+	var count int64
+	gormDB.Model(&memberDomain.MemberStatusHistoryEntity{}).
+		Where("member_id = ? AND from_status = ? AND to_status = ?", 4, "applied", "rejected").Count(&count)
+	assert.Equal(t, int64(1), count)
 }
 
 func TestMemberController_getSearchFilters(t *testing.T) {
@@ -1079,6 +1222,55 @@ func TestMemberController_getSearchFilters(t *testing.T) {
 					"text":  "구글",
 					"value": "google",
 				},
+				map[string]any{
+					"text":  "OIDC",
+					"value": "oidc",
+				},
+				map[string]any{
+					"text":  "LDAP",
+					"value": "ldap",
+				},
+				map[string]any{
+					"text":  "SCIM",
+					"value": "scim",
+				},
+			},
+		},
+		map[string]any{
+			"name": "status",
+			"filters": []any{
+				map[string]any{
+					"text":  "신청",
+					"value": "applied",
+				},
+				map[string]any{
+					"text":  "승인",
+					"value": "approved",
+				},
+				map[string]any{
+					"text":  "거부",
+					"value": "rejected",
+				},
+				map[string]any{
+					"text":  "정지",
+					"value": "suspended",
+				},
+				map[string]any{
+					"text":  "잠금",
+					"value": "locked",
+				},
+				map[string]any{
+					"text":  "만료",
+					"value": "expired",
+				},
+				map[string]any{
+					"text":  "탈퇴",
+					"value": "withdrawn",
+				},
+				map[string]any{
+					"text":  "비활성",
+					"value": "deactivated",
+				},
 			},
 		},
 		map[string]any{
@@ -1102,3 +1294,2581 @@ func TestMemberController_getSearchFilters(t *testing.T) {
 
 	assert.Equal(t, expected, actual)
 }
+
+func TestMemberController_revokeTokens(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	memberAccessToken := signIn(t, "ymyoo", "123456")["accessToken"].(string)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/members/3/revoke-tokens", nil)
+	token, err := generateTestJWT(map[string]any{
+		"Id": 1,
+		"Permissions": []string{
+			"member.update",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", memberAccessToken))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestMemberController_rejectMember_승인된_멤버인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPut, "/api/members/3/rejected", nil)
+	token, err := generateTestJWT(map[string]any{
+		"Id": 1,
+		"Permissions": []string{
+			"member.update",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, `"invalid status transition"`, rec.Body.String())
+
+	var count int64
+	gormDB.Model(&memberDomain.MemberEntity{}).Where("id = ? AND status = ?", 3, "approved").Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestMemberController_suspendMember(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	memberAccessToken := signIn(t, "ymyoo", "123456")["accessToken"].(string)
+
+	// when
+	rec := changeMemberStatus(t, 3, "suspended", `{"reason": "계정 도용 의심"}`)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	memberEntity := memberDomain.MemberEntity{}
+	gormDB.First(&memberEntity, 3)
+	assert.Equal(t, "suspended", memberEntity.Status)
+	assert.Equal(t, "계정 도용 의심", memberEntity.StatusReason)
+	assert.Equal(t, uint(1), memberEntity.StatusChangedBy)
+	assert.NotNil(t, memberEntity.StatusChangedAt)
+
+	// 정지된 멤버의 토큰은 더 이상 사용할 수 없다.
+	req := httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", memberAccessToken))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = trySignIn("ymyoo", "123456")
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "suspended", actual["status"])
+}
+
+func TestMemberController_suspendMember_권한_확인(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPut, "/api/members/3/suspended", strings.NewReader(`{"reason": "계정 도용 의심"}`))
+	token, err := generateTestJWT(map[string]any{
+		"Id": 1,
+		"Permissions": []string{
+			"member.read",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestMemberController_suspendMember_사유가_없는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := changeMemberStatus(t, 3, "suspended", `{}`)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestMemberController_suspendMember_member_id_가_유효하지_않은_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := changeMemberStatus(t, 1000, "suspended", `{"reason": "계정 도용 의심"}`)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestMemberController_reactivateMember(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	assert.Equal(t, http.StatusNoContent, changeMemberStatus(t, 3, "locked", `{"reason": "계정 도용 의심"}`).Code)
+
+	// when
+	rec := changeMemberStatus(t, 3, "reactivated", `{"reason": "본인 확인 완료"}`)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	signIn(t, "ymyoo", "123456")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/members/3/status-histories", nil)
+	token, _ := generateTestJWT(map[string]any{
+		"Id": 1,
+		"Permissions": []string{
+			"member.read",
+		},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual []map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, 2, len(actual))
+	assert.Equal(t, "locked", actual[0]["fromStatus"])
+	assert.Equal(t, "approved", actual[0]["toStatus"])
+	assert.Equal(t, "본인 확인 완료", actual[0]["reason"])
+	assert.Equal(t, float64(1), actual[0]["actorId"])
+	assert.Equal(t, "approved", actual[1]["fromStatus"])
+	assert.Equal(t, "locked", actual[1]["toStatus"])
+}
+
+func TestMemberController_reactivateMember_탈퇴한_멤버인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	assert.Equal(t, http.StatusNoContent, changeMemberStatus(t, 3, "withdrawn", `{"reason": "탈퇴 요청"}`).Code)
+
+	// when
+	rec := changeMemberStatus(t, 3, "reactivated", `{"reason": "재가입"}`)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestMemberController_reactivateMember_신청한_멤버인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := changeMemberStatus(t, 4, "reactivated", `{"reason": "승인"}`)
+
+	// then
+	// 신청한 멤버는 승인(approved)해야 한다.
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestMemberController_getMembers_여러_상태(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	assert.Equal(t, http.StatusNoContent, changeMemberStatus(t, 3, "suspended", `{"reason": "계정 도용 의심"}`).Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/members?page=1&pageSize=10&status=suspended,applied", nil)
+	token, _ := generateTestJWT(map[string]any{
+		"Id": 1,
+		"Permissions": []string{
+			"member.read",
+		},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, float64(2), actual["totalCount"])
+	members := actual["result"].([]any)
+	assert.Equal(t, float64(3), members[0].(map[string]any)["id"])
+	assert.Equal(t, "suspended", members[0].(map[string]any)["status"])
+	assert.Equal(t, "계정 도용 의심", members[0].(map[string]any)["statusReason"])
+	assert.Equal(t, float64(4), members[1].(map[string]any)["id"])
+}
+
+func TestMemberController_approveMembers(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := runMemberBulkOperation(t, "approved", `{"memberIds": [4]}`)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	expected := map[string]any{
+		"mode":         "transaction",
+		"rolledBack":   false,
+		"successCount": float64(1),
+		"failureCount": float64(0),
+		"results": []any{
+			map[string]any{"memberId": float64(4), "success": true},
+		},
+	}
+	assert.Equal(t, expected, actual)
+
+	memberEntity := memberDomain.MemberEntity{}
+	gormDB.First(&memberEntity, 4)
+	assert.Equal(t, "approved", memberEntity.Status)
+}
+
+func TestMemberController_approveMembers_트랜잭션_모드에서_실패한_멤버가_있는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := runMemberBulkOperation(t, "approved", `{"memberIds": [4, 3], "mode": "transaction"}`)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	expected := map[string]any{
+		"mode":         "transaction",
+		"rolledBack":   true,
+		"successCount": float64(1),
+		"failureCount": float64(1),
+		"results": []any{
+			map[string]any{"memberId": float64(4), "success": true},
+			map[string]any{"memberId": float64(3), "success": false, "error": "already approved"},
+		},
+	}
+	assert.Equal(t, expected, actual)
+
+	// 성공한 멤버의 변경도 취소된다.
+	memberEntity := memberDomain.MemberEntity{}
+	gormDB.First(&memberEntity, 4)
+	assert.Equal(t, "applied", memberEntity.Status)
+}
+
+func TestMemberController_approveMembers_best_effort_모드(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := runMemberBulkOperation(t, "approved", `{"memberIds": [3, 4, 1000], "mode": "best-effort"}`)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	expected := map[string]any{
+		"mode":         "best-effort",
+		"rolledBack":   false,
+		"successCount": float64(1),
+		"failureCount": float64(2),
+		"results": []any{
+			map[string]any{"memberId": float64(3), "success": false, "error": "already approved"},
+			map[string]any{"memberId": float64(4), "success": true},
+			map[string]any{"memberId": float64(1000), "success": false, "error": "not found"},
+		},
+	}
+	assert.Equal(t, expected, actual)
+
+	memberEntity := memberDomain.MemberEntity{}
+	gormDB.First(&memberEntity, 4)
+	assert.Equal(t, "approved", memberEntity.Status)
+}
+
+func TestMemberController_approveMembers_요청이_유효하지_않은_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	assert.Equal(t, http.StatusBadRequest, runMemberBulkOperation(t, "approved", `{"memberIds": []}`).Code)
+	assert.Equal(t, http.StatusBadRequest, runMemberBulkOperation(t, "approved", `{"memberIds": [4, 4]}`).Code)
+	assert.Equal(t, http.StatusBadRequest, runMemberBulkOperation(t, "approved", `{"memberIds": [4], "mode": "all-or-nothing"}`).Code)
+}
+
+func TestMemberController_rejectMembers(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := runMemberBulkOperation(t, "rejected", `{"memberIds": [3, 4, 1000], "mode": "best-effort"}`)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, float64(1), actual["successCount"])
+	assert.Equal(t, float64(2), actual["failureCount"])
+
+	// 승인된 멤버(3)는 거부할 수 없다.
+	for memberId, status := range map[uint]string{3: "approved", 4: "rejected"} {
+		memberEntity := memberDomain.MemberEntity{}
+		gormDB.First(&memberEntity, memberId)
+		assert.Equal(t, status, memberEntity.Status)
+	}
+}
+
+func TestMemberController_assignRolesToMembers(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := runMemberBulkOperation(t, "assign-roles", `{"memberIds": [3, 4], "roleIds": [2]}`)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	for _, memberId := range []uint{3, 4} {
+		memberEntity := memberDomain.MemberEntity{}
+		gormDB.Preload("Roles").First(&memberEntity, memberId)
+		assert.Equal(t, 1, len(memberEntity.Roles))
+		assert.Equal(t, uint(2), memberEntity.Roles[0].ID)
+	}
+}
+
+func TestMemberController_assignRolesToMembers_역할이_없는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := runMemberBulkOperation(t, "assign-roles", `{"memberIds": [3, 4], "roleIds": [2, 1000], "mode": "best-effort"}`)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// 어떤 멤버의 역할도 변경하지 않는다.
+	for _, memberId := range []uint{3, 4} {
+		memberEntity := memberDomain.MemberEntity{}
+		gormDB.Preload("Roles").First(&memberEntity, memberId)
+		assert.Equal(t, 0, len(memberEntity.Roles))
+	}
+}
+
+func TestMemberController_addMembersToOrganization(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := runMemberBulkOperation(t, "add-organization", `{"memberIds": [3, 4], "organizationId": 4}`)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var memberIds []uint
+	gormDB.Table("organization_members").Where("organization_entity_id = ?", 4).Order("member_entity_id").
+		Pluck("member_entity_id", &memberIds)
+	assert.Equal(t, []uint{3, 4}, memberIds)
+}
+
+func TestMemberController_addMembersToOrganization_조직이_없는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := runMemberBulkOperation(t, "add-organization", `{"memberIds": [3], "organizationId": 1000}`)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestMemberController_addMembersToOrganization_권한_확인(t *testing.T) {
+	// given
+	req := httptest.NewRequest(http.MethodPut, "/api/members/bulk/add-organization",
+		strings.NewReader(`{"memberIds": [3], "organizationId": 4}`))
+	token, err := generateTestJWT(map[string]any{
+		"Id": 1,
+		"Permissions": []string{
+			"member.update",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	// 조직에 멤버를 추가하려면 조직 변경 권한도 필요하다.
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestMemberController_suspendMembers(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// when
+	rec := runMemberBulkOperation(t, "suspended", `{"memberIds": [2, 3], "reason": "퇴사 예정"}`)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	for _, memberId := range []uint{2, 3} {
+		memberEntity := memberDomain.MemberEntity{}
+		gormDB.First(&memberEntity, memberId)
+		assert.Equal(t, "suspended", memberEntity.Status)
+		assert.Equal(t, "퇴사 예정", memberEntity.StatusReason)
+	}
+
+	var count int64
+	gormDB.Model(&memberDomain.MemberStatusHistoryEntity{}).Where("to_status = ?", "suspended").Count(&count)
+	assert.Equal(t, int64(2), count)
+}
+
+func runMemberBulkOperation(t *testing.T, operation, requestBody string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPut, "/api/members/bulk/"+operation, strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]any{
+		"Id": 1,
+		"Permissions": []string{
+			"member.update",
+			"organization.update",
+		},
+	}, time.Minute*15)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func changeMemberStatus(t *testing.T, memberId uint, status, requestBody string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/members/%v/%v", memberId, status), strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]any{
+		"Id": 1,
+		"Permissions": []string{
+			"member.update",
+		},
+	}, time.Minute*15)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMemberController_activateTwoFactor(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	memberAccessToken := signIn(t, "ymyoo", "123456")["accessToken"].(string)
+	enrollment := startTwoFactorEnrollment(t, memberAccessToken)
+	assert.True(t, strings.HasPrefix(enrollment["otpAuthUri"].(string), "otpauth://totp/better-admin:ymyoo?"))
+
+	code, err := security.GenerateTotpCode(enrollment["secret"].(string), time.Now())
+	if err != nil {
+		t.Error(err)
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "/api/members/my/two-factor/activated", strings.NewReader(fmt.Sprintf(`{"code": "%s"}`, code)))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", memberAccessToken))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, 10, len(actual["recoveryCodes"].([]any)))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", memberAccessToken))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, true, actual["twoFactorEnabled"])
+}
+
+func TestMemberController_activateTwoFactor_코드가_유효하지_않은_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	memberAccessToken := signIn(t, "ymyoo", "123456")["accessToken"].(string)
+	startTwoFactorEnrollment(t, memberAccessToken)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/members/my/two-factor/activated", strings.NewReader(`{"code": "abcdef"}`))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", memberAccessToken))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestMemberController_startTwoFactorEnrollment_사이트_멤버가_아닌_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/members/my/two-factor", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", generateRecentLoginTestJWT(t, 2)))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestMemberController_startTwoFactorEnrollment_최근에_로그인하지_않은_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	memberAccessToken := signIn(t, "ymyoo", "123456")["accessToken"].(string)
+	gormDB.Exec("UPDATE member_sessions SET created_at = ?", time.Now().Add(-10*time.Minute))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/members/my/two-factor", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", memberAccessToken))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Equal(t, `{"message":"reauthentication required"}`, rec.Body.String())
+}
+
+func TestMemberController_deactivateTwoFactor(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	memberAccessToken := signIn(t, "ymyoo", "123456")["accessToken"].(string)
+	recoveryCodes := activateTwoFactor(t, memberAccessToken)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/members/my/two-factor/deactivated", strings.NewReader(fmt.Sprintf(`{"code": "%s"}`, recoveryCodes[0])))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", memberAccessToken))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// 2단계 인증을 해제하면 비밀번호만으로 로그인 할 수 있다.
+	assert.NotNil(t, signIn(t, "ymyoo", "123456")["accessToken"])
+}
+
+func startTwoFactorEnrollment(t *testing.T, accessToken string) map[string]any {
+	req := httptest.NewRequest(http.MethodPost, "/api/members/my/two-factor", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("two-factor enrollment failed: %v", rec.Body.String())
+	}
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	return actual
+}
+
+func activateTwoFactor(t *testing.T, accessToken string) []string {
+	enrollment := startTwoFactorEnrollment(t, accessToken)
+	code, err := security.GenerateTotpCode(enrollment["secret"].(string), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodPut, "/api/members/my/two-factor/activated", strings.NewReader(fmt.Sprintf(`{"code": "%s"}`, code)))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("two-factor activation failed: %v", rec.Body.String())
+	}
+
+	var actual dtos.MemberTwoFactorRecoveryCodes
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	return actual.RecoveryCodes
+}
+
+func TestMemberController_registerPasskey(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	memberAccessToken := signIn(t, "ymyoo", "123456")["accessToken"].(string)
+	ceremony := beginPasskeyRegistration(t, memberAccessToken)
+	authenticator := testwebauthn.NewSoftwareAuthenticator("localhost", "http://localhost:2016")
+	credential, err := authenticator.CreateCredential(ceremony["options"].(map[string]any))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requestBody, _ := json.Marshal(map[string]any{
+		"sessionId":  ceremony["sessionId"],
+		"name":       "내 노트북",
+		"credential": credential,
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/members/my/passkeys", bytes.NewReader(requestBody))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", memberAccessToken))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/members/my/passkeys", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", memberAccessToken))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual []map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, 1, len(actual))
+	assert.Equal(t, "내 노트북", actual[0]["name"])
+	assert.Nil(t, actual[0]["lastUsedAt"])
+
+	// 세션은 한 번만 사용할 수 있다.
+	req = httptest.NewRequest(http.MethodPost, "/api/members/my/passkeys", bytes.NewReader(requestBody))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", memberAccessToken))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestMemberController_registerPasskey_다른_멤버의_세션인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	ceremony := beginPasskeyRegistration(t, signIn(t, "siteadm", "123456")["accessToken"].(string))
+	authenticator := testwebauthn.NewSoftwareAuthenticator("localhost", "http://localhost:2016")
+	credential, err := authenticator.CreateCredential(ceremony["options"].(map[string]any))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requestBody, _ := json.Marshal(map[string]any{
+		"sessionId":  ceremony["sessionId"],
+		"name":       "내 노트북",
+		"credential": credential,
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/members/my/passkeys", bytes.NewReader(requestBody))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", signIn(t, "ymyoo", "123456")["accessToken"].(string)))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestMemberController_deletePasskey(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	registerPasskey(t, signIn(t, "ymyoo", "123456")["accessToken"].(string))
+	token, err := generateTestJWT(map[string]any{
+		"Id": 1,
+		"Permissions": []string{
+			"member.read",
+			"member.update",
+		},
+	}, time.Minute*15)
+	if err != nil {
+		t.Failed()
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/members/3/passkeys", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	var passkeys []map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &passkeys)
+	assert.Equal(t, 1, len(passkeys))
+
+	req = httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/members/3/passkeys/%v", passkeys[0]["id"]), nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/members/3/passkeys", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	json.Unmarshal(rec.Body.Bytes(), &passkeys)
+	assert.Equal(t, 0, len(passkeys))
+}
+
+func TestMemberController_deleteMyPasskey_다른_멤버의_패스키인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	registerPasskey(t, signIn(t, "ymyoo", "123456")["accessToken"].(string))
+	siteAdmAccessToken := signIn(t, "siteadm", "123456")["accessToken"].(string)
+
+	var passkeyId uint
+	gormDB.Raw("SELECT id FROM member_webauthn_credentials WHERE member_id = 3").Scan(&passkeyId)
+
+	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/members/my/passkeys/%v", passkeyId), nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", siteAdmAccessToken))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestMemberController_beginPasskeyRegistration_최근에_로그인하지_않은_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	memberAccessToken := signIn(t, "ymyoo", "123456")["accessToken"].(string)
+	gormDB.Exec("UPDATE member_sessions SET created_at = ?", time.Now().Add(-10*time.Minute))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/members/my/passkeys/options", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", memberAccessToken))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	// 액세스 토큰만으로는 인증 수단을 추가할 수 없고 다시 로그인해야 한다.
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), "reauthentication required")
+}
+
+func TestMemberController_beginPasskeyRegistration_개인_액세스_토큰으로_요청하는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	personalAccessToken := createPersonalAccessToken(t, signIn(t, "siteadm", "123456")["accessToken"].(string),
+		`{"name": "CI", "permissions": [], "expiresInDays": 30}`)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/members/my/passkeys/options", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", personalAccessToken))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func beginPasskeyRegistration(t *testing.T, accessToken string) map[string]any {
+	req := httptest.NewRequest(http.MethodPost, "/api/members/my/passkeys/options", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("passkey registration options failed: %v", rec.Body.String())
+	}
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	return actual
+}
+
+func registerPasskey(t *testing.T, accessToken string) *testwebauthn.SoftwareAuthenticator {
+	ceremony := beginPasskeyRegistration(t, accessToken)
+	authenticator := testwebauthn.NewSoftwareAuthenticator("localhost", "http://localhost:2016")
+	credential, err := authenticator.CreateCredential(ceremony["options"].(map[string]any))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requestBody, _ := json.Marshal(map[string]any{
+		"sessionId":  ceremony["sessionId"],
+		"name":       "passkey",
+		"credential": credential,
+	})
+	req := httptest.NewRequest(http.MethodPost, "/api/members/my/passkeys", bytes.NewReader(requestBody))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("passkey registration failed: %v", rec.Body.String())
+	}
+
+	return authenticator
+}
+
+func TestMemberController_changePassword(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	accessToken := signIn(t, "ymyoo", "123456")["accessToken"].(string)
+
+	requestBody := `{"currentPassword": "123456", "newPassword": "a&b<c>d"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/members/my/password", strings.NewReader(requestBody))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	// 비밀번호는 원문 그대로 저장된다.
+	assert.NotEmpty(t, signIn(t, "ymyoo", "a&b<c>d")["accessToken"])
+}
+
+func TestMemberController_changePassword_현재_비밀번호가_유효하지_않은_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	accessToken := signIn(t, "ymyoo", "123456")["accessToken"].(string)
+
+	requestBody := `{"currentPassword": "111111", "newPassword": "654321"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/members/my/password", strings.NewReader(requestBody))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestMemberController_changePassword_사이트_멤버가_아닌_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          2,
+		"Permissions": []string{},
+	}, time.Minute*15)
+
+	requestBody := `{"currentPassword": "123456", "newPassword": "654321"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/members/my/password", strings.NewReader(requestBody))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestMemberController_resetPassword(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	setUpPasswordPolicy(t, `{"minLength": 20, "requireUppercase": true, "requireLowercase": true, "requireDigit": true, "requireSpecial": true}`)
+	memberAccessToken := signIn(t, "ymyoo", "123456")["accessToken"].(string)
+
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"member.update"},
+	}, time.Minute*15)
+	req := httptest.NewRequest(http.MethodPut, "/api/members/3/password-reset", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual dtos.MemberPasswordReset
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, 20, len(actual.TemporaryPassword))
+
+	// 기존 비밀번호로 발급된 토큰은 더 이상 사용할 수 없다.
+	req = httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", memberAccessToken))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// 토큰 에포크는 한 번만 증가하고 모든 세션이 폐기된다.
+	memberEntity := memberDomain.MemberEntity{}
+	gormDB.First(&memberEntity, 3)
+	assert.Equal(t, uint(1), memberEntity.TokenEpoch)
+	var count int64
+	gormDB.Model(&authDomain.SessionEntity{}).Where("member_id = ? AND revoked_at IS NULL", 3).Count(&count)
+	assert.Equal(t, int64(0), count)
+
+	// 임시 비밀번호로 로그인하면 비밀번호를 변경해야 한다.
+	signInResult := signIn(t, "ymyoo", actual.TemporaryPassword)
+	assert.Nil(t, signInResult["accessToken"])
+	assert.Equal(t, true, signInResult["passwordChangeRequired"])
+
+	rec = changePasswordWithToken(signInResult["passwordChangeToken"].(string), "New-Password-1234567")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotEmpty(t, signIn(t, "ymyoo", "New-Password-1234567")["accessToken"])
+}
+
+func TestMemberController_resetPassword_사이트_멤버가_아닌_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"member.update"},
+	}, time.Minute*15)
+	req := httptest.NewRequest(http.MethodPut, "/api/members/2/password-reset", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestMemberController_unlockMember(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	setUpLoginProtection(t, `{"maxFailures": 1, "ipMaxFailures": 0, "lockoutMinutes": 15}`)
+	assert.Equal(t, http.StatusBadRequest, trySignIn("ymyoo", "qwert").Code)
+	assert.Equal(t, http.StatusLocked, trySignIn("ymyoo", "123456").Code)
+
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"member.update"},
+	}, time.Minute*15)
+	req := httptest.NewRequest(http.MethodPut, "/api/members/3/unlocked", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.NotEmpty(t, signIn(t, "ymyoo", "123456")["accessToken"])
+}
+
+func TestMemberController_unlockMember_member_id_가_유효하지_않은_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"member.update"},
+	}, time.Minute*15)
+	req := httptest.NewRequest(http.MethodPut, "/api/members/99/unlocked", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestMemberController_getMemberAccessLogs(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	signIn(t, "siteadm", "123456")
+	signIn(t, "ymyoo", "123456")
+	assert.Equal(t, http.StatusBadRequest, trySignIn("ymyoo", "qwert").Code)
+
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"member.read"},
+	}, time.Minute*15)
+	req := httptest.NewRequest(http.MethodGet, "/api/members/access-logs?memberId=3&types=login&page=1&pageSize=10", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual struct {
+		Result     []dtos.MemberAccessLog `json:"result"`
+		TotalCount int64                  `json:"totalCount"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, int64(2), actual.TotalCount)
+	// 최근 기록부터 조회한다.
+	assert.Equal(t, "failure", actual.Result[0].Result)
+	assert.Equal(t, "success", actual.Result[1].Result)
+	assert.Equal(t, "ymyoo", actual.Result[1].LoginId)
+	assert.Equal(t, "site", actual.Result[1].AuthType)
+}
+
+func TestMemberController_getMemberAccessLogs_결과와_기간으로_조회하는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	signIn(t, "ymyoo", "123456")
+	assert.Equal(t, http.StatusBadRequest, trySignIn("ymyoo", "qwert").Code)
+
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"member.read"},
+	}, time.Minute*15)
+	query := url.Values{}
+	query.Set("result", "failure")
+	query.Set("from", time.Now().Add(-time.Hour).Format(time.RFC3339))
+	query.Set("to", time.Now().Add(time.Hour).Format(time.RFC3339))
+	req := httptest.NewRequest(http.MethodGet, "/api/members/access-logs?"+query.Encode(), nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual struct {
+		Result     []dtos.MemberAccessLog `json:"result"`
+		TotalCount int64                  `json:"totalCount"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, int64(1), actual.TotalCount)
+	assert.Equal(t, "error authentication", actual.Result[0].FailureReason)
+}
+
+func TestMemberController_getMemberAccessLogs_접근_유형이_유효하지_않은_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"member.read"},
+	}, time.Minute*15)
+	req := httptest.NewRequest(http.MethodGet, "/api/members/access-logs?types=logout", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, `"not supported access log type"`, rec.Body.String())
+}
+
+func TestMemberController_getMemberAccessLogs_기간이_유효하지_않은_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"member.read"},
+	}, time.Minute*15)
+	req := httptest.NewRequest(http.MethodGet, "/api/members/access-logs?from=2022-01-01", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestMemberController_getMyAccessLogs(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	signIn(t, "siteadm", "123456")
+	accessToken := signIn(t, "ymyoo", "123456")["accessToken"].(string)
+
+	// 다른 멤버의 기록은 조회할 수 없다.
+	req := httptest.NewRequest(http.MethodGet, "/api/members/my/access-logs?memberId=1", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual struct {
+		Result     []dtos.MemberAccessLog `json:"result"`
+		TotalCount int64                  `json:"totalCount"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, int64(1), actual.TotalCount)
+	assert.Equal(t, uint(3), actual.Result[0].MemberId)
+}
+
+func TestMemberController_getMySessions(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	signIn(t, "ymyoo", "123456")
+	accessToken := signIn(t, "ymyoo", "123456")["accessToken"].(string)
+	signIn(t, "siteadm", "123456")
+
+	req := httptest.NewRequest(http.MethodGet, "/api/members/my/sessions", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual []dtos.MemberSession
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, 2, len(actual))
+	currentCount := 0
+	for _, session := range actual {
+		assert.Equal(t, "site", session.AuthType)
+		assert.Equal(t, "192.0.2.1", session.IpAddress)
+		if session.Current {
+			currentCount++
+		}
+	}
+	assert.Equal(t, 1, currentCount)
+}
+
+func TestMemberController_deleteMySession(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	otherSession := signIn(t, "ymyoo", "123456")
+	accessToken := signIn(t, "ymyoo", "123456")["accessToken"].(string)
+
+	var sessions []dtos.MemberSession
+	req := httptest.NewRequest(http.MethodGet, "/api/members/my/sessions", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	json.Unmarshal(rec.Body.Bytes(), &sessions)
+
+	var otherSessionId uint
+	for _, session := range sessions {
+		if session.Current == false {
+			otherSessionId = session.Id
+		}
+	}
+
+	req = httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/members/my/sessions/%v", otherSessionId), nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	rec = httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/token/refresh",
+		strings.NewReader(fmt.Sprintf(`{"refreshToken": "%s"}`, otherSession["refreshToken"]))))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", otherSession["accessToken"]))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestMemberController_deleteMySession_다른_멤버의_세션인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	adminAccessToken := signIn(t, "siteadm", "123456")["accessToken"].(string)
+	var sessions []dtos.MemberSession
+	req := httptest.NewRequest(http.MethodGet, "/api/members/my/sessions", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", adminAccessToken))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	json.Unmarshal(rec.Body.Bytes(), &sessions)
+
+	accessToken := signIn(t, "ymyoo", "123456")["accessToken"].(string)
+	req = httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/members/my/sessions/%v", sessions[0].Id), nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	rec = httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestMemberController_deleteSession(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	refreshToken := signIn(t, "ymyoo", "123456")["refreshToken"].(string)
+
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"member.read", "member.update"},
+	}, time.Minute*15)
+
+	var sessions []dtos.MemberSession
+	req := httptest.NewRequest(http.MethodGet, "/api/members/3/sessions", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	json.Unmarshal(rec.Body.Bytes(), &sessions)
+	assert.Equal(t, 1, len(sessions))
+
+	req = httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/members/3/sessions/%v", sessions[0].Id), nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/api/auth/token/refresh",
+		strings.NewReader(fmt.Sprintf(`{"refreshToken": "%s"}`, refreshToken))))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/members/3/sessions", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.JSONEq(t, `[]`, rec.Body.String())
+}
+
+func TestMemberController_createMyPersonalAccessToken(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	addPermissionToRole(t, 1, "member.read")
+	accessToken := signIn(t, "siteadm", "123456")["accessToken"].(string)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/members/my/access-tokens",
+		strings.NewReader(`{"name": "CI", "permissions": ["MANAGE_MEMBERS"], "expiresInDays": 30}`))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var actual dtos.PersonalAccessToken
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.True(t, strings.HasPrefix(actual.Token, "bat_"))
+	assert.Equal(t, actual.Token[:12], actual.DisplayToken)
+	assert.Equal(t, []string{"MANAGE_MEMBERS"}, actual.Permissions)
+	assert.WithinDuration(t, time.Now().AddDate(0, 0, 30), actual.ExpiresAt, time.Minute)
+
+	// 토큰 원문은 저장하지 않는다.
+	var count int64
+	gormDB.Table("personal_access_tokens").Where("token_hash = ?", security.HashToken(actual.Token)).Count(&count)
+	assert.Equal(t, int64(1), count)
+
+	// 멤버는 member.read 권한이 있지만 토큰에는 없다.
+	req = httptest.NewRequest(http.MethodGet, "/api/members/3", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", actual.Token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/members/3", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestMemberController_createMyPersonalAccessToken_권한을_가진_토큰으로_요청하는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	addPermissionToRole(t, 1, "member.read")
+	personalAccessToken := createPersonalAccessToken(t, signIn(t, "siteadm", "123456")["accessToken"].(string),
+		`{"name": "CI", "permissions": ["member.read"], "expiresInDays": 30}`)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/members/3", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", personalAccessToken))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// 멤버의 권한이 회수되면 토큰으로도 사용할 수 없다.
+	gormDB.Exec("DELETE FROM role_permissions WHERE role_entity_id = 1")
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestMemberController_createMyPersonalAccessToken_가지지_않은_권한인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	accessToken := signIn(t, "siteadm", "123456")["accessToken"].(string)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/members/my/access-tokens",
+		strings.NewReader(`{"name": "CI", "permissions": ["member.all"], "expiresInDays": 30}`))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, `"not allowed permission"`, rec.Body.String())
+}
+
+func TestMemberController_createMyPersonalAccessToken_개인_액세스_토큰으로_요청하는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	personalAccessToken := createPersonalAccessToken(t, signIn(t, "siteadm", "123456")["accessToken"].(string),
+		`{"name": "CI", "permissions": [], "expiresInDays": 30}`)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/members/my/access-tokens",
+		strings.NewReader(`{"name": "CI2", "permissions": [], "expiresInDays": 30}`))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", personalAccessToken))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestMemberController_getMyPersonalAccessTokens(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	accessToken := signIn(t, "siteadm", "123456")["accessToken"].(string)
+	personalAccessToken := createPersonalAccessToken(t, accessToken, `{"name": "CI", "permissions": [], "expiresInDays": 30}`)
+
+	// 토큰을 사용하면 마지막 사용 시각을 기록한다.
+	req := httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", personalAccessToken))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/members/my/access-tokens", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	rec = httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual []dtos.PersonalAccessToken
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, 1, len(actual))
+	assert.Equal(t, "CI", actual[0].Name)
+	assert.Empty(t, actual[0].Token)
+	assert.NotNil(t, actual[0].LastUsedAt)
+}
+
+func TestMemberController_deleteMyPersonalAccessToken(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	accessToken := signIn(t, "siteadm", "123456")["accessToken"].(string)
+	personalAccessToken := createPersonalAccessToken(t, accessToken, `{"name": "CI", "permissions": [], "expiresInDays": 30}`)
+
+	var tokenId uint
+	gormDB.Table("personal_access_tokens").Select("id").Where("token_hash = ?", security.HashToken(personalAccessToken)).Scan(&tokenId)
+
+	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/members/my/access-tokens/%v", tokenId), nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", personalAccessToken))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestMemberController_personalAccessToken_만료되었거나_폐기된_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	expiredToken := createPersonalAccessToken(t, signIn(t, "siteadm", "123456")["accessToken"].(string),
+		`{"name": "expired", "permissions": [], "expiresInDays": 1}`)
+	gormDB.Exec("UPDATE personal_access_tokens SET expires_at = ?", time.Now().Add(-time.Minute))
+	revokedToken := createPersonalAccessToken(t, signIn(t, "siteadm", "123456")["accessToken"].(string),
+		`{"name": "revoked", "permissions": [], "expiresInDays": 1}`)
+
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"member.update"},
+	}, time.Minute*15)
+	req := httptest.NewRequest(http.MethodPut, "/api/members/1/revoke-tokens", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	for _, personalAccessToken := range []string{expiredToken, revokedToken} {
+		req = httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", personalAccessToken))
+		rec = httptest.NewRecorder()
+
+		// when
+		ginApp.ServeHTTP(rec, req)
+
+		// then
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	}
+}
+
+func TestMemberController_startImpersonation(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"member.impersonate"},
+	}, time.Minute*15)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/members/3/impersonation", strings.NewReader(`{"reason": "메뉴가 보이지 않는다는 문의"}`))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.NotEmpty(t, actual["expiresAt"])
+	userClaim, err := security.JwtAuthentication{}.ConvertTokenUserClaim(actual["accessToken"].(string))
+	assert.NoError(t, err)
+	assert.Equal(t, uint(3), userClaim.Id)
+	assert.Equal(t, uint(1), userClaim.Impersonator.Id)
+	assert.Equal(t, []string{"SYSTEM MANAGER"}, userClaim.Roles)
+
+	var impersonation authDomain.ImpersonationEntity
+	gormDB.Where("jti = ?", userClaim.Jti).First(&impersonation)
+	assert.Equal(t, uint(1), impersonation.ImpersonatorId)
+	assert.Equal(t, uint(3), impersonation.MemberId)
+	assert.Equal(t, "메뉴가 보이지 않는다는 문의", impersonation.Reason)
+	assert.Equal(t, "192.0.2.1", impersonation.IpAddress)
+	assert.Nil(t, impersonation.EndedAt)
+
+	// 대리 로그인 중임을 표시한다.
+	req = httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", actual["accessToken"]))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var currentMember dtos.CurrentMember
+	json.Unmarshal(rec.Body.Bytes(), &currentMember)
+	assert.Equal(t, uint(3), currentMember.Id)
+	assert.Equal(t, &dtos.CurrentMemberImpersonator{Id: 1, Name: "사이트 관리자"}, currentMember.Impersonator)
+}
+
+func TestMemberController_startImpersonation_대리_로그인할_수_없는_멤버인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"member.impersonate"},
+	}, time.Minute*15)
+
+	tests := []struct {
+		memberId     int
+		expectedCode int
+		expectedBody string
+	}{
+		{memberId: 1, expectedCode: http.StatusBadRequest, expectedBody: `"cannot impersonate yourself"`},
+		{memberId: 4, expectedCode: http.StatusBadRequest, expectedBody: `"unapproved"`},
+		{memberId: 1000, expectedCode: http.StatusNotFound, expectedBody: ""},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/members/%v/impersonation", test.memberId), nil)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		rec := httptest.NewRecorder()
+
+		// when
+		ginApp.ServeHTTP(rec, req)
+
+		// then
+		assert.Equal(t, test.expectedCode, rec.Code)
+		assert.Equal(t, test.expectedBody, rec.Body.String())
+	}
+}
+
+func TestMemberController_impersonation_민감한_변경은_할_수_없다(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	// 멤버 관리 권한을 가진 멤버(2)로 대리 로그인하더라도 민감한 변경은 할 수 없다.
+	addPermissionToRole(t, 1, "member.all")
+	impersonationToken := startImpersonation(t, 2)
+
+	tests := []struct {
+		method string
+		url    string
+		body   string
+	}{
+		{method: http.MethodPut, url: "/api/members/my/password", body: `{"currentPassword": "123456", "newPassword": "new-password-1234"}`},
+		{method: http.MethodPost, url: "/api/members/my/two-factor", body: ""},
+		{method: http.MethodPost, url: "/api/members/my/access-tokens", body: `{"name": "CI", "permissions": [], "expiresInDays": 30}`},
+		{method: http.MethodPut, url: "/api/members/3/assign-roles", body: `{"roleIds": [1]}`},
+		{method: http.MethodPut, url: "/api/members/3/revoke-tokens", body: ""},
+		{method: http.MethodPost, url: "/api/members/3/impersonation", body: ""},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, test.url, strings.NewReader(test.body))
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", impersonationToken))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		// when
+		ginApp.ServeHTTP(rec, req)
+
+		// then
+		assert.Equal(t, http.StatusForbidden, rec.Code, test.url)
+		assert.Equal(t, `{"message":"not allowed while impersonating"}`, rec.Body.String(), test.url)
+	}
+}
+
+func TestMemberController_impersonation_조회만_할_수_있다(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	impersonationToken := startImpersonation(t, 3)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/members/my/profile", strings.NewReader(`{"name": "유영모", "nickName": "ymyoo"}`))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", impersonationToken))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Equal(t, `{"message":"not allowed while impersonating"}`, rec.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/api/members/my/profile", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", impersonationToken))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestMemberController_impersonation_관리자의_토큰이_폐기된_경우(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "토큰 에포크가 변경된 경우", query: "UPDATE members SET token_epoch = token_epoch + 1 WHERE id = 1"},
+		{name: "승인 상태가 아닌 경우", query: "UPDATE members SET status = 'suspended' WHERE id = 1"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+			// given
+			impersonationToken := startImpersonation(t, 3)
+			gormDB.Exec(test.query)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+			req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", impersonationToken))
+			rec := httptest.NewRecorder()
+
+			// when
+			ginApp.ServeHTTP(rec, req)
+
+			// then
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		})
+	}
+}
+
+func TestMemberController_startImpersonation_더_많은_권한을_가진_멤버인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	// 멤버(2)만 멤버 관리 권한을 가진다.
+	addPermissionToRole(t, 2, "member.all")
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          3,
+		"Permissions": []string{"member.impersonate"},
+	}, time.Minute*15)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/members/2/impersonation", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Equal(t, `{"message":"cannot impersonate member with more permissions"}`, rec.Body.String())
+
+	var count int64
+	gormDB.Model(&authDomain.ImpersonationEntity{}).Where("member_id = ?", 2).Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestMemberController_endMyImpersonation(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	impersonationToken := startImpersonation(t, 3)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/members/my/impersonation", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", impersonationToken))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	var impersonation authDomain.ImpersonationEntity
+	gormDB.Where("member_id = ?", 3).First(&impersonation)
+	assert.NotNil(t, impersonation.EndedAt)
+
+	// 종료된 대리 로그인 토큰은 사용할 수 없다.
+	req = httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", impersonationToken))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestMemberController_endMyImpersonation_대리_로그인_중이_아닌_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	accessToken := signIn(t, "ymyoo", "123456")["accessToken"].(string)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/members/my/impersonation", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, `"not impersonating"`, rec.Body.String())
+}
+
+func TestMemberController_getImpersonations(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	startImpersonation(t, 2)
+	startImpersonation(t, 3)
+
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"member.read"},
+	}, time.Minute*15)
+	req := httptest.NewRequest(http.MethodGet, "/api/members/impersonations?memberId=3", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual struct {
+		Result     []dtos.MemberImpersonation `json:"result"`
+		TotalCount int64                      `json:"totalCount"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, int64(1), actual.TotalCount)
+	assert.Equal(t, uint(1), actual.Result[0].ImpersonatorId)
+	assert.Equal(t, uint(3), actual.Result[0].MemberId)
+}
+
+// startImpersonation 사이트 관리자(1)로 대리 로그인 토큰을 발급한다.
+func startImpersonation(t *testing.T, memberId uint) string {
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"member.impersonate"},
+	}, time.Minute*15)
+
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/members/%v/impersonation", memberId), nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("start impersonation failed: %v", rec.Body.String())
+	}
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	return actual["accessToken"].(string)
+}
+
+func createPersonalAccessToken(t *testing.T, accessToken, requestBody string) string {
+	req := httptest.NewRequest(http.MethodPost, "/api/members/my/access-tokens", strings.NewReader(requestBody))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create personal access token failed: %v", rec.Body.String())
+	}
+
+	var actual dtos.PersonalAccessToken
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	return actual.Token
+}
+
+// addPermissionToRole 권한 정책(data.json)에서 사용하는 권한을 역할에 추가한다.
+func addPermissionToRole(t *testing.T, roleId uint, permission string) {
+	if err := gormDB.Exec("INSERT INTO permissions(type, name, created_at, updated_at, created_by, updated_by) VALUES('user-define', ?, datetime('now'), datetime('now'), 1, 1)",
+		permission).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := gormDB.Exec("INSERT INTO role_permissions(role_entity_id, permission_entity_id) SELECT ?, id FROM permissions WHERE name = ?",
+		roleId, permission).Error; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMemberController_signUpMember_초대_전용인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	setSignUpMode(t, "invite-only")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/members",
+		strings.NewReader(`{"signId": "ymyoo1", "name": "유영모", "password": "1111"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestMemberController_signUpMember_가입을_허용하지_않는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	sender := setUpTestMailSender(t)
+	setSignUpMode(t, "invite-only")
+	invitationToken := createInvitation(t, sender, `{"email": "ymyoo@example.com", "expiresInDays": 7}`)
+	setSignUpMode(t, "disabled")
+
+	req := httptest.NewRequest(http.MethodPost, "/api/members",
+		strings.NewReader(fmt.Sprintf(`{"signId": "ymyoo1", "name": "유영모", "password": "1111", "invitationToken": "%v"}`, invitationToken)))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestMemberController_signUpMember_초대를_받은_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	sender := setUpTestMailSender(t)
+	setSignUpMode(t, "invite-only")
+	invitationToken := createInvitation(t, sender,
+		`{"email": "ymyoo@example.com", "roleIds": [2], "organizationIds": [4], "expiresInDays": 7}`)
+
+	requestBody := fmt.Sprintf(`{"signId": "ymyoo1", "name": "유영모", "password": "1111", "invitationToken": "%v"}`, invitationToken)
+	req := httptest.NewRequest(http.MethodPost, "/api/members", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	accessToken := signIn(t, "ymyoo1", "1111")["accessToken"].(string)
+	req = httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "ymyoo@example.com", actual["email"])
+	assert.Equal(t, true, actual["emailVerified"])
+	assert.Contains(t, actual["roles"], "MEMBER MANAGER")
+	assert.Contains(t, actual["roles"], "SYSTEM MANAGER")
+
+	// 이미 사용한 초대는 다시 사용할 수 없다.
+	req = httptest.NewRequest(http.MethodPost, "/api/members",
+		strings.NewReader(fmt.Sprintf(`{"signId": "ymyoo2", "name": "유영모", "password": "1111", "invitationToken": "%v"}`, invitationToken)))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestMemberController_signUpMember_초대를_받은_멤버에게_자동_할당_규칙을_적용한다(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	sender := setUpTestMailSender(t)
+	setSignUpMode(t, "invite-only")
+	setMemberProvisioningRules(t, `[
+		{"name": "예제 메일", "emailSuffix": "@example.com", "roleIds": [3], "organizationIds": [1]}
+	]`)
+	invitationToken := createInvitation(t, sender, `{"email": "ymyoo@example.com", "roleIds": [2], "expiresInDays": 7}`)
+
+	requestBody := fmt.Sprintf(`{"signId": "ymyoo1", "name": "유영모", "password": "1111", "invitationToken": "%v"}`, invitationToken)
+	req := httptest.NewRequest(http.MethodPost, "/api/members", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	// 초대할 때 지정한 역할은 유지하고 규칙의 역할, 조직을 추가한다.
+	var memberEntity memberDomain.MemberEntity
+	gormDB.Preload("Roles").Where(&memberDomain.MemberEntity{SignId: "ymyoo1"}).First(&memberEntity)
+	assert.ElementsMatch(t, []string{"MEMBER MANAGER", "테스트 관리자"}, memberEntity.GetRoleNames())
+
+	var count int64
+	gormDB.Table("organization_members").Where("organization_entity_id = ? AND member_entity_id = ?", 1, memberEntity.ID).Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestMemberController_verifyEmail(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	sender := setUpTestMailSender(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/members",
+		strings.NewReader(`{"signId": "ymyoo1", "name": "유영모", "password": "1111", "email": "ymyoo@example.com"}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, 1, len(sender.mails))
+	assert.Equal(t, []string{"ymyoo@example.com"}, sender.mails[0].To)
+
+	token := findTokenInMail(t, sender.mails[0], "token")
+	req = httptest.NewRequest(http.MethodPost, "/api/members/email-verification",
+		strings.NewReader(fmt.Sprintf(`{"token": "%v"}`, token)))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	var verified int64
+	gormDB.Raw("SELECT count(1) FROM members WHERE sign_id = ? AND email_verified_at IS NOT NULL", "ymyoo1").Scan(&verified)
+	assert.Equal(t, int64(1), verified)
+
+	// 이미 사용한 토큰은 다시 사용할 수 없다.
+	req = httptest.NewRequest(http.MethodPost, "/api/members/email-verification",
+		strings.NewReader(fmt.Sprintf(`{"token": "%v"}`, token)))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestMemberController_changeMyEmail(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	sender := setUpTestMailSender(t)
+	accessToken := signIn(t, "ymyoo", "123456")["accessToken"].(string)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/members/my/email", strings.NewReader(`{"email": "ymyoo@example.com"}`))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, 1, len(sender.mails))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "ymyoo@example.com", actual["email"])
+	assert.Equal(t, false, actual["emailVerified"])
+}
+
+func TestMemberController_changeMyEmail_최근에_로그인하지_않은_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	// 탈취한 액세스 토큰만으로 계정의 메일 주소를 바꿀 수 없도록 다시 로그인해야 한다.
+	sender := setUpTestMailSender(t)
+	accessToken := signIn(t, "ymyoo", "123456")["accessToken"].(string)
+	gormDB.Exec("UPDATE member_sessions SET created_at = ?", time.Now().Add(-10*time.Minute))
+
+	req := httptest.NewRequest(http.MethodPut, "/api/members/my/email", strings.NewReader(`{"email": "ymyoo@example.com"}`))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Equal(t, `{"message":"reauthentication required"}`, rec.Body.String())
+	assert.Equal(t, 0, len(sender.mails))
+}
+
+func TestMemberController_cancelInvitation(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	sender := setUpTestMailSender(t)
+	createInvitation(t, sender, `{"email": "ymyoo@example.com", "expiresInDays": 7}`)
+
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"member-invitation.all"},
+	}, time.Minute*15)
+	req := httptest.NewRequest(http.MethodGet, "/api/members/invitations?accepted=false", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var invitations struct {
+		Result     []dtos.MemberInvitation `json:"result"`
+		TotalCount int64                   `json:"totalCount"`
+	}
+	json.Unmarshal(rec.Body.Bytes(), &invitations)
+	assert.Equal(t, int64(1), invitations.TotalCount)
+
+	req = httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/members/invitations/%v", invitations.Result[0].Id), nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/members/invitations", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	json.Unmarshal(rec.Body.Bytes(), &invitations)
+	assert.Equal(t, int64(0), invitations.TotalCount)
+}
+
+func TestMemberController_updateMyProfile(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	accessToken := signIn(t, "ymyoo", "123456")["accessToken"].(string)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/members/my/profile",
+		strings.NewReader(`{"name": "유영모", "phoneNumber": "010-1234-5678", "picture": "https://example.com/picture.png"}`))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"name": "유영모",
+		"phoneNumber": "010-1234-5678",
+		"picture": "https://example.com/picture.png",
+		"managedFields": []
+	}`, rec.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "유영모", actual["name"])
+	assert.Equal(t, "https://example.com/picture.png", actual["picture"])
+}
+
+func TestMemberController_updateMyProfile_외부_인증_제공자가_원본인_항목을_변경하는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          2,
+		"Permissions": []string{},
+	}, time.Minute*15)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/members/my/profile", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"name": "유영모", "phoneNumber": "", "picture": "", "managedFields": ["name"]}`, rec.Body.String())
+
+	req = httptest.NewRequest(http.MethodPut, "/api/members/my/profile",
+		strings.NewReader(`{"name": "유영모(두레이)", "phoneNumber": "010-1234-5678"}`))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// 두레이가 원본인 이름을 변경하지 않으면 나머지 항목은 변경할 수 있다.
+	req = httptest.NewRequest(http.MethodPut, "/api/members/my/profile",
+		strings.NewReader(`{"name": "유영모", "phoneNumber": "010-1234-5678"}`))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestMemberController_updateMyProfile_개인_액세스_토큰으로_요청하는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	accessToken := signIn(t, "ymyoo", "123456")["accessToken"].(string)
+	tokenWithoutScope := createPersonalAccessToken(t, accessToken, `{"name": "CI", "permissions": ["MANAGE_SYSTEM_SETTINGS"], "expiresInDays": 30}`)
+	tokenWithScope := createPersonalAccessToken(t, accessToken, `{"name": "프로필", "permissions": ["member.self"], "expiresInDays": 30}`)
+	requestBody := `{"name": "유영모", "phoneNumber": "010-1234-5678"}`
+
+	req := httptest.NewRequest(http.MethodPut, "/api/members/my/profile", strings.NewReader(requestBody))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tokenWithoutScope))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPut, "/api/members/my/profile", strings.NewReader(requestBody))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", tokenWithScope))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+type testMailSender struct {
+	mails []dtos.Mail
+}
+
+func (s *testMailSender) Send(mail dtos.Mail) error {
+	s.mails = append(s.mails, mail)
+	return nil
+}
+
+// setUpTestMailSender 메일을 보내지 않고 기록하는 MailSender 로 바꾼다.
+func setUpTestMailSender(t *testing.T) *testMailSender {
+	sender := &testMailSender{}
+	previous := adapters.MailAdapter()
+	adapters.SetMailSender(sender)
+	t.Cleanup(func() {
+		adapters.SetMailSender(previous)
+	})
+	return sender
+}
+
+func findTokenInMail(t *testing.T, mail dtos.Mail, name string) string {
+	matches := regexp.MustCompile(name + `=([^\s&]+)`).FindStringSubmatch(mail.Body)
+	if len(matches) != 2 {
+		t.Fatalf("token not found in mail: %v", mail.Body)
+	}
+	return matches[1]
+}
+
+func setSignUpMode(t *testing.T, mode string) {
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"site-settings.update"},
+	}, time.Minute*15)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/sign-up", strings.NewReader(fmt.Sprintf(`{"mode": "%v"}`, mode)))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("set sign up mode failed: %v", rec.Body.String())
+	}
+}
+
+// createInvitation 초대 메일을 보내고 메일에 포함된 초대 토큰을 반환한다.
+func createInvitation(t *testing.T, sender *testMailSender, requestBody string) string {
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"member-invitation.create"},
+	}, time.Minute*15)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/members/invitations", strings.NewReader(requestBody))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create invitation failed: %v", rec.Body.String())
+	}
+
+	return findTokenInMail(t, sender.mails[len(sender.mails)-1], "invitationToken")
+}
+
+func setMemberProvisioningRules(t *testing.T, rules string) {
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"site-settings.update"},
+	}, time.Minute*15)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/member-provisioning",
+		strings.NewReader(fmt.Sprintf(`{"rules": %v}`, rules)))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("set member provisioning failed: %v", rec.Body.String())
+	}
+}
+
+func TestMemberController_linkMyIdentity(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	linkToken := createIdentityLink(t, 3)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/members/my/identities",
+		strings.NewReader(fmt.Sprintf(`{"linkToken": "%v"}`, linkToken)))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", generateRecentLoginTestJWT(t, 2)))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	var count int64
+	gormDB.Model(&memberDomain.MemberEntity{}).Where("id = ?", 2).Count(&count)
+	assert.Equal(t, int64(0), count)
+
+	// 역할은 옮기지 않는다.
+	memberEntity := memberDomain.MemberEntity{}
+	gormDB.Preload("Roles").First(&memberEntity, 3)
+	assert.Equal(t, 0, len(memberEntity.Roles))
+
+	req = httptest.NewRequest(http.MethodGet, "/api/members/my/identities", nil)
+	token, _ := generateTestJWT(map[string]any{
+		"Id": 3,
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var identities []map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &identities)
+	assert.Equal(t, 2, len(identities))
+	assert.Equal(t, "site", identities[0]["type"])
+	assert.Equal(t, true, identities[0]["primary"])
+	assert.Nil(t, identities[0]["linkedAt"])
+	assert.Equal(t, "dooray", identities[1]["type"])
+	assert.Equal(t, "두레이", identities[1]["typeName"])
+	assert.Equal(t, false, identities[1]["primary"])
+	assert.NotNil(t, identities[1]["linkedAt"])
+}
+
+func TestMemberController_linkMyIdentity_토큰은_한번만_사용할_수_있다(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	linkToken := createIdentityLink(t, 3)
+	linkIdentity(t, 2, linkToken)
+
+	// when
+	rec := linkIdentity(t, 1, linkToken)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, `"invalid identity link"`, rec.Body.String())
+}
+
+func TestMemberController_linkMyIdentity_사이트_멤버는_연결할_수_없다(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	linkToken := createIdentityLink(t, 2)
+
+	// when
+	rec := linkIdentity(t, 3, linkToken)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, `"site member cannot be linked"`, rec.Body.String())
+
+	var count int64
+	gormDB.Model(&memberDomain.MemberEntity{}).Where("id = ?", 3).Count(&count)
+	assert.Equal(t, int64(1), count)
+}
+
+func TestMemberController_linkMyIdentity_자기_자신의_토큰인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	linkToken := createIdentityLink(t, 2)
+
+	// when
+	rec := linkIdentity(t, 2, linkToken)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, `"invalid identity link"`, rec.Body.String())
+}
+
+func TestMemberController_unlinkMyIdentity(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	linkIdentity(t, 2, createIdentityLink(t, 3))
+
+	identityEntity := memberDomain.MemberIdentityEntity{}
+	gormDB.Where("member_id = ?", 3).First(&identityEntity)
+
+	// 다른 멤버의 로그인은 연결을 해제할 수 없다.
+	req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/members/my/identities/%v", identityEntity.ID), nil)
+	token, _ := generateTestJWT(map[string]any{
+		"Id": 1,
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	req = httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/members/my/identities/%v", identityEntity.ID), nil)
+	token, _ = generateTestJWT(map[string]any{
+		"Id": 3,
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	var count int64
+	gormDB.Model(&memberDomain.MemberIdentityEntity{}).Where("member_id = ?", 3).Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestMemberController_mergeMember(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/members/3/merge", strings.NewReader(`{"sourceMemberId": 2}`))
+	token, _ := generateTestJWT(map[string]any{
+		"Id": 1,
+		"Permissions": []string{
+			"member.merge",
+		},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	memberEntity := memberDomain.MemberEntity{}
+	gormDB.Preload("Roles").First(&memberEntity, 3)
+	assert.ElementsMatch(t, []string{"SYSTEM MANAGER", "MEMBER MANAGER"}, memberEntity.GetRoleNames())
+
+	var count int64
+	gormDB.Table("organization_members").Where("member_entity_id = ?", 3).Count(&count)
+	assert.Equal(t, int64(2), count)
+
+	gormDB.Model(&memberDomain.MemberEntity{}).Where("id = ?", 2).Count(&count)
+	assert.Equal(t, int64(0), count)
+
+	identityEntity := memberDomain.MemberIdentityEntity{}
+	gormDB.Where("member_id = ?", 3).First(&identityEntity)
+	assert.Equal(t, "dooray", identityEntity.Type)
+}
+
+func TestMemberController_mergeMember_사이트_멤버를_병합하는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/members/2/merge", strings.NewReader(`{"sourceMemberId": 3}`))
+	token, _ := generateTestJWT(map[string]any{
+		"Id": 1,
+		"Permissions": []string{
+			"member.merge",
+		},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t, `"site member cannot be linked"`, rec.Body.String())
+}
+
+// createIdentityLink 멤버가 다른 로그인을 연결하기 위한 토큰을 발급한다.
+func TestMemberController_createMyIdentityLink_최근에_로그인하지_않은_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	// 탈취한 액세스 토큰만으로 다른 로그인을 연결해서 계정을 가로챌 수 없도록 다시 로그인해야 한다.
+	memberAccessToken := signIn(t, "ymyoo", "123456")["accessToken"].(string)
+	gormDB.Exec("UPDATE member_sessions SET created_at = ?", time.Now().Add(-10*time.Minute))
+
+	req := httptest.NewRequest(http.MethodPost, "/api/members/my/identity-links", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", memberAccessToken))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Equal(t, `{"message":"reauthentication required"}`, rec.Body.String())
+
+	var count int64
+	gormDB.Model(&memberDomain.MemberIdentityLinkTokenEntity{}).Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+// generateRecentLoginTestJWT 최근에 로그인한 세션의 토큰이 필요한 API 를 호출할 때 세션을 만들어 토큰에 포함한다.
+func generateRecentLoginTestJWT(t *testing.T, memberId uint) string {
+	sessionId := fmt.Sprintf("test-%d-%d", memberId, time.Now().UnixNano())
+	if err := gormDB.Create(&authDomain.SessionEntity{
+		MemberId:       memberId,
+		SessionId:      sessionId,
+		AuthType:       "site",
+		LastAccessedAt: time.Now(),
+		ExpiresAt:      time.Now().Add(time.Hour),
+	}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := generateTestJWT(map[string]any{
+		"Id":  memberId,
+		"sid": sessionId,
+	}, time.Minute*15)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func createIdentityLink(t *testing.T, memberId uint) string {
+	token := generateRecentLoginTestJWT(t, memberId)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/members/my/identity-links", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create identity link failed: %v", rec.Body.String())
+	}
+
+	var identityLink map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &identityLink)
+	return identityLink["linkToken"].(string)
+}
+
+func linkIdentity(t *testing.T, memberId uint, linkToken string) *httptest.ResponseRecorder {
+	token := generateRecentLoginTestJWT(t, memberId)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/members/my/identities",
+		strings.NewReader(fmt.Sprintf(`{"linkToken": "%v"}`, linkToken)))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	return rec
+}
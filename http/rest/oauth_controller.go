@@ -0,0 +1,308 @@
+package rest
+
+import (
+	authDomain "better-admin-backend-service/auth/domain"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/services"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// OAuthController 내부 앱이 멤버 계정으로 로그인할 수 있도록 OpenID Connect 제공자 역할을 한다.
+type OAuthController struct {
+	routerGroup          *gin.RouterGroup
+	oauthClientService   *services.OAuthClientService
+	oauthProviderService *services.OAuthProviderService
+}
+
+func NewOAuthController(
+	routerGroup *gin.RouterGroup,
+	oauthClientService *services.OAuthClientService,
+	oauthProviderService *services.OAuthProviderService) *OAuthController {
+
+	return &OAuthController{
+		routerGroup:          routerGroup,
+		oauthClientService:   oauthClientService,
+		oauthProviderService: oauthProviderService,
+	}
+}
+
+func (c OAuthController) MapRoutes() {
+	route := c.routerGroup.Group("/oauth")
+
+	route.GET("/clients", c.getOAuthClients)
+	route.POST("/clients", c.createOAuthClient)
+	route.GET("/clients/:clientId", c.getOAuthClient)
+	route.PUT("/clients/:clientId", c.updateOAuthClient)
+	route.DELETE("/clients/:clientId", c.deleteOAuthClient)
+	route.POST("/clients/:clientId/secret", c.renewOAuthClientSecret)
+
+	route.GET("/authorize", requireOidcProvider, c.authorize)
+	route.GET("/consent", requireOidcProvider, c.getConsent)
+	route.POST("/consent", requireOidcProvider, c.consent)
+	route.POST("/token", requireOidcProvider, c.issueToken)
+	route.GET("/userinfo", requireOidcProvider, c.getUserInfo)
+	route.POST("/userinfo", requireOidcProvider, c.getUserInfo)
+}
+
+func (c OAuthController) getOAuthClients(ctx *gin.Context) {
+	entities, err := c.oauthClientService.GetOAuthClients(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	oauthClients := make([]dtos.OAuthClient, 0)
+	for _, entity := range entities {
+		oauthClients = append(oauthClients, newOAuthClient(entity))
+	}
+
+	ctx.JSON(http.StatusOK, oauthClients)
+}
+
+func (c OAuthController) createOAuthClient(ctx *gin.Context) {
+	var information dtos.OAuthClientInformation
+	if err := ctx.BindJSON(&information); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, clientSecret, err := c.oauthClientService.CreateOAuthClient(ctx.Request.Context(), information)
+	if err != nil {
+		if err == errors.ErrInvalidOAuthRedirectUri {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	oauthClient := newOAuthClient(entity)
+	oauthClient.ClientSecret = clientSecret
+	ctx.JSON(http.StatusCreated, oauthClient)
+}
+
+func (c OAuthController) getOAuthClient(ctx *gin.Context) {
+	entity, err := c.oauthClientService.GetOAuthClient(ctx.Request.Context(), ctx.Param("clientId"))
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newOAuthClient(entity))
+}
+
+func (c OAuthController) updateOAuthClient(ctx *gin.Context) {
+	var information dtos.OAuthClientInformation
+	if err := ctx.BindJSON(&information); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.oauthClientService.UpdateOAuthClient(ctx.Request.Context(), ctx.Param("clientId"), information); err != nil {
+		if err == errors.ErrNotFound {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		if err == errors.ErrInvalidOAuthRedirectUri {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c OAuthController) deleteOAuthClient(ctx *gin.Context) {
+	if err := c.oauthClientService.DeleteOAuthClient(ctx.Request.Context(), ctx.Param("clientId")); err != nil {
+		if err == errors.ErrNotFound {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c OAuthController) renewOAuthClientSecret(ctx *gin.Context) {
+	entity, clientSecret, err := c.oauthClientService.RenewOAuthClientSecret(ctx.Request.Context(), ctx.Param("clientId"))
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		if err == errors.ErrNonChangeable {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	oauthClient := newOAuthClient(entity)
+	oauthClient.ClientSecret = clientSecret
+	ctx.JSON(http.StatusOK, oauthClient)
+}
+
+// authorize https://openid.net/specs/openid-connect-core-1_0.html#AuthorizationEndpoint
+// 로그인과 동의는 웹 화면에서 진행하므로 요청을 확인한 뒤 웹 화면으로 보낸다.
+func (c OAuthController) authorize(ctx *gin.Context) {
+	var request dtos.OAuthAuthorizationRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	redirectUri, err := c.oauthProviderService.BeginAuthorization(ctx.Request.Context(), request, ctx.Request.URL.RawQuery)
+	if err != nil {
+		// 클라이언트나 리다이렉트 URI 가 올바르지 않으면 요청한 앱으로 돌려보내지 않는다.(RFC 6749 4.1.2.1)
+		if err == errors.ErrInvalidOAuthClient || err == errors.ErrInvalidOAuthRedirectUri {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Redirect(http.StatusFound, redirectUri)
+}
+
+func (c OAuthController) getConsent(ctx *gin.Context) {
+	var request dtos.OAuthAuthorizationRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	consent, err := c.oauthProviderService.GetConsent(ctx.Request.Context(), request)
+	if err != nil {
+		if err == errors.ErrInvalidOAuthClient || err == errors.ErrInvalidOAuthRedirectUri {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if e, ok := err.(*errors.ErrOAuth); ok {
+			ctx.JSON(http.StatusBadRequest, dtos.OAuthError{Error: e.Code, ErrorDescription: e.Description})
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, consent)
+}
+
+// consent 웹 화면은 응답으로 받은 redirectUri 로 이동해서 인가 코드(또는 오류)를 앱에 전달한다.
+func (c OAuthController) consent(ctx *gin.Context) {
+	var decision dtos.OAuthConsentDecision
+	if err := ctx.BindJSON(&decision); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	redirectUri, err := c.oauthProviderService.Authorize(ctx.Request.Context(), decision)
+	if err != nil {
+		if err == errors.ErrInvalidOAuthClient || err == errors.ErrInvalidOAuthRedirectUri {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dtos.OAuthAuthorizationResponse{RedirectUri: redirectUri})
+}
+
+// issueToken https://datatracker.ietf.org/doc/html/rfc6749#section-5
+func (c OAuthController) issueToken(ctx *gin.Context) {
+	// 토큰과 오류 응답은 캐시하지 않는다.(RFC 6749 5.1)
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header("Pragma", "no-cache")
+
+	var tokenRequest dtos.OAuthTokenRequest
+	if err := ctx.ShouldBindWith(&tokenRequest, binding.Form); err != nil {
+		ctx.JSON(http.StatusBadRequest, dtos.OAuthError{Error: constants.OAuthErrorInvalidRequest, ErrorDescription: err.Error()})
+		return
+	}
+
+	basicClientId, basicClientSecret, ok := ctx.Request.BasicAuth()
+	if ok {
+		// client_secret_basic 은 클라이언트 아이디와 시크릿을 URL 인코딩해서 전달한다.(RFC 6749 2.3.1)
+		basicClientId, _ = url.QueryUnescape(basicClientId)
+		basicClientSecret, _ = url.QueryUnescape(basicClientSecret)
+	}
+
+	token, err := c.oauthProviderService.ExchangeAuthorizationCode(ctx.Request.Context(), tokenRequest, basicClientId, basicClientSecret)
+	if err != nil {
+		if e, ok := err.(*errors.ErrOAuth); ok {
+			if e.Code == constants.OAuthErrorInvalidClient {
+				ctx.Header("WWW-Authenticate", `Basic realm="oauth"`)
+				ctx.JSON(http.StatusUnauthorized, dtos.OAuthError{Error: e.Code, ErrorDescription: e.Description})
+				return
+			}
+
+			ctx.JSON(http.StatusBadRequest, dtos.OAuthError{Error: e.Code, ErrorDescription: e.Description})
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, token)
+}
+
+// getUserInfo 내부 앱이 발급받은 액세스 토큰으로만 호출할 수 있다.(RFC 6750)
+func (c OAuthController) getUserInfo(ctx *gin.Context) {
+	accessToken := strings.TrimSpace(strings.TrimPrefix(ctx.Request.Header.Get("Authorization"), "Bearer"))
+
+	userInfo, err := c.oauthProviderService.GetUserInfo(ctx.Request.Context(), accessToken)
+	if err != nil {
+		if e, ok := err.(*errors.ErrOAuth); ok {
+			ctx.Header("WWW-Authenticate", fmt.Sprintf(`Bearer error="%v"`, e.Code))
+			ctx.JSON(http.StatusUnauthorized, dtos.OAuthError{Error: e.Code, ErrorDescription: e.Description})
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, userInfo)
+}
+
+func newOAuthClient(entity authDomain.OAuthClientEntity) dtos.OAuthClient {
+	return dtos.OAuthClient{
+		ClientId:     entity.ClientId,
+		Name:         entity.Name,
+		RedirectUris: entity.GetRedirectUris(),
+		Public:       entity.IsPublic(),
+		SkipConsent:  entity.SkipConsent,
+		CreatedAt:    entity.CreatedAt,
+	}
+}
@@ -0,0 +1,777 @@
+package rest
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/security"
+	"better-admin-backend-service/testdata/testdb"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const (
+	testOAuthCodeVerifier    = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	testWikiClientId         = "internal-wiki"
+	testWikiClientSecret     = "wiki-secret"
+	testWikiRedirectUri      = "https://wiki.example.com/callback"
+	testDashboardClientId    = "internal-dashboard"
+	testDashboardRedirectUri = "https://dashboard.example.com/callback"
+)
+
+var testOidcProviderPrivateKey, _ = rsa.GenerateKey(rand.Reader, 2048)
+
+func TestOAuthController_createOAuthClient(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{
+		"name": "사내 메신저",
+		"redirectUris": ["https://messenger.example.com/callback?tenant=a&lang=ko"]
+	}`
+	req := httptest.NewRequest(http.MethodPost, "/api/oauth/clients", strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"oauth-client.create"},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var actual dtos.OAuthClient
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, 32, len(actual.ClientId))
+	assert.Equal(t, 64, len(actual.ClientSecret))
+	assert.Equal(t, "사내 메신저", actual.Name)
+	assert.Equal(t, []string{"https://messenger.example.com/callback?tenant=a&lang=ko"}, actual.RedirectUris)
+	assert.False(t, actual.Public)
+	assert.False(t, actual.SkipConsent)
+}
+
+func TestOAuthController_createOAuthClient_공개_클라이언트(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{
+		"name": "사내 모바일 앱",
+		"redirectUris": ["com.example.app://callback"],
+		"public": true
+	}`
+	req := httptest.NewRequest(http.MethodPost, "/api/oauth/clients", strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"oauth-client.create"},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var actual dtos.OAuthClient
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.True(t, actual.Public)
+	assert.Empty(t, actual.ClientSecret)
+}
+
+func TestOAuthController_createOAuthClient_리다이렉트_URI_가_올바르지_않은_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	for _, redirectUri := range []string{"/callback", "https://wiki.example.com/callback#token"} {
+		// given
+		requestBody := fmt.Sprintf(`{"name": "사내 메신저", "redirectUris": ["%s"]}`, redirectUri)
+		req := httptest.NewRequest(http.MethodPost, "/api/oauth/clients", strings.NewReader(requestBody))
+		token, _ := generateTestJWT(map[string]any{
+			"Id":          1,
+			"Permissions": []string{"oauth-client.create"},
+		}, time.Minute*15)
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+
+		// when
+		ginApp.ServeHTTP(rec, req)
+
+		// then
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestOAuthController_getOAuthClients(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/oauth/clients", nil)
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"oauth-client.read"},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual []dtos.OAuthClient
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, 2, len(actual))
+	assert.Equal(t, testWikiClientId, actual[0].ClientId)
+	assert.False(t, actual[0].Public)
+	assert.Empty(t, actual[0].ClientSecret)
+	assert.Equal(t, testDashboardClientId, actual[1].ClientId)
+	assert.True(t, actual[1].Public)
+	assert.True(t, actual[1].SkipConsent)
+	assert.Equal(t, []string{testDashboardRedirectUri, "http://localhost:8080/callback"}, actual[1].RedirectUris)
+}
+
+func TestOAuthController_updateOAuthClient(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{
+		"name": "사내 위키(신규)",
+		"redirectUris": ["https://wiki2.example.com/callback"],
+		"public": true,
+		"skipConsent": true
+	}`
+	req := httptest.NewRequest(http.MethodPut, "/api/oauth/clients/"+testWikiClientId, strings.NewReader(requestBody))
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"oauth-client.update", "oauth-client.read"},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/oauth/clients/"+testWikiClientId, nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	var actual dtos.OAuthClient
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "사내 위키(신규)", actual.Name)
+	assert.Equal(t, []string{"https://wiki2.example.com/callback"}, actual.RedirectUris)
+	assert.True(t, actual.SkipConsent)
+	// 등록한 뒤에는 클라이언트 유형을 변경할 수 없다.
+	assert.False(t, actual.Public)
+}
+
+func TestOAuthController_deleteOAuthClient(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpOidcProviderSigningKey(t)
+
+	// given
+	code := authorizeOAuthClient(t, 1, testWikiClientId, testWikiRedirectUri, "openid")
+	tokenResponse := exchangeOAuthCode(testWikiClientId, testWikiClientSecret, code, testWikiRedirectUri, testOAuthCodeVerifier)
+	var oauthToken dtos.OAuthToken
+	json.Unmarshal(tokenResponse.Body.Bytes(), &oauthToken)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/oauth/clients/"+testWikiClientId, nil)
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"oauth-client.delete", "oauth-client.read"},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/oauth/clients/"+testWikiClientId, nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	// 삭제한 클라이언트에 발급한 액세스 토큰은 사용할 수 없다.
+	assert.Equal(t, http.StatusUnauthorized, getOAuthUserInfo(oauthToken.AccessToken).Code)
+}
+
+func TestOAuthController_renewOAuthClientSecret(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpOidcProviderSigningKey(t)
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/oauth/clients/"+testWikiClientId+"/secret", nil)
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"oauth-client.update"},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual dtos.OAuthClient
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, 64, len(actual.ClientSecret))
+
+	// 이전 시크릿은 사용할 수 없다.
+	code := authorizeOAuthClient(t, 1, testWikiClientId, testWikiRedirectUri, "openid")
+	rec = exchangeOAuthCode(testWikiClientId, testWikiClientSecret, code, testWikiRedirectUri, testOAuthCodeVerifier)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = exchangeOAuthCode(testWikiClientId, actual.ClientSecret, code, testWikiRedirectUri, testOAuthCodeVerifier)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestOAuthController_renewOAuthClientSecret_공개_클라이언트인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/oauth/clients/"+testDashboardClientId+"/secret", nil)
+	token, _ := generateTestJWT(map[string]any{
+		"Id":          1,
+		"Permissions": []string{"oauth-client.update"},
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestOAuthController_authorize(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpOidcProviderSigningKey(t)
+
+	// given
+	query := newOAuthAuthorizationQuery(testWikiClientId, testWikiRedirectUri, "openid profile")
+	req := httptest.NewRequest(http.MethodGet, "/api/oauth/authorize?"+query.Encode(), nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, config.Config.OidcProvider.ConsentPageUrl+"?"+query.Encode(), rec.Header().Get("Location"))
+}
+
+func TestOAuthController_authorize_등록되지_않은_리다이렉트_URI_인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpOidcProviderSigningKey(t)
+
+	// given
+	query := newOAuthAuthorizationQuery(testWikiClientId, "https://attacker.example.com/callback", "openid")
+	req := httptest.NewRequest(http.MethodGet, "/api/oauth/authorize?"+query.Encode(), nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	// 등록되지 않은 곳으로는 오류도 보내지 않는다.
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Empty(t, rec.Header().Get("Location"))
+}
+
+func TestOAuthController_authorize_PKCE_가_없는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpOidcProviderSigningKey(t)
+
+	// given
+	query := newOAuthAuthorizationQuery(testWikiClientId, testWikiRedirectUri, "openid")
+	query.Del("code_challenge")
+	req := httptest.NewRequest(http.MethodGet, "/api/oauth/authorize?"+query.Encode(), nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusFound, rec.Code)
+
+	location, _ := url.Parse(rec.Header().Get("Location"))
+	assert.Equal(t, "wiki.example.com", location.Host)
+	assert.Equal(t, "invalid_request", location.Query().Get("error"))
+	assert.Equal(t, "xyz", location.Query().Get("state"))
+}
+
+func TestOAuthController_authorize_openid_scope_가_없는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpOidcProviderSigningKey(t)
+
+	// given
+	query := newOAuthAuthorizationQuery(testWikiClientId, testWikiRedirectUri, "profile")
+	req := httptest.NewRequest(http.MethodGet, "/api/oauth/authorize?"+query.Encode(), nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusFound, rec.Code)
+
+	location, _ := url.Parse(rec.Header().Get("Location"))
+	assert.Equal(t, "invalid_scope", location.Query().Get("error"))
+}
+
+func TestOAuthController_getConsent(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpOidcProviderSigningKey(t)
+
+	// given
+	query := newOAuthAuthorizationQuery(testWikiClientId, testWikiRedirectUri, "openid profile email")
+	req := httptest.NewRequest(http.MethodGet, "/api/oauth/consent?"+query.Encode(), nil)
+	token, _ := generateTestJWT(map[string]any{
+		"Id": 1,
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual dtos.OAuthConsent
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "사내 위키", actual.ClientName)
+	assert.Equal(t, []string{"openid", "profile", "email"}, actual.Scopes)
+	assert.True(t, actual.ConsentRequired)
+
+	// 동의한 scope 안에서는 다시 묻지 않는다.
+	authorizeOAuthClient(t, 1, testWikiClientId, testWikiRedirectUri, "openid profile email")
+
+	query = newOAuthAuthorizationQuery(testWikiClientId, testWikiRedirectUri, "openid email")
+	req = httptest.NewRequest(http.MethodGet, "/api/oauth/consent?"+query.Encode(), nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.False(t, actual.ConsentRequired)
+}
+
+func TestOAuthController_getConsent_동의를_생략하는_앱인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpOidcProviderSigningKey(t)
+
+	// given
+	query := newOAuthAuthorizationQuery(testDashboardClientId, testDashboardRedirectUri, "openid")
+	req := httptest.NewRequest(http.MethodGet, "/api/oauth/consent?"+query.Encode(), nil)
+	token, _ := generateTestJWT(map[string]any{
+		"Id": 1,
+	}, time.Minute*15)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual dtos.OAuthConsent
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.False(t, actual.ConsentRequired)
+}
+
+func TestOAuthController_consent_거절한_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpOidcProviderSigningKey(t)
+
+	// given
+	rec := postOAuthConsent(1, testWikiClientId, testWikiRedirectUri, "openid", false)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual dtos.OAuthAuthorizationResponse
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	location, _ := url.Parse(actual.RedirectUri)
+	assert.Equal(t, "access_denied", location.Query().Get("error"))
+	assert.Equal(t, "xyz", location.Query().Get("state"))
+	assert.Empty(t, location.Query().Get("code"))
+}
+
+func TestOAuthController_consent_로그인하지_않은_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpOidcProviderSigningKey(t)
+
+	// given
+	requestBody := fmt.Sprintf(`{"responseType": "code", "clientId": "%s", "redirectUri": "%s", "scope": "openid",
+		"codeChallenge": "%s", "codeChallengeMethod": "S256", "approved": true}`,
+		testWikiClientId, testWikiRedirectUri, security.GetPkceS256CodeChallenge(testOAuthCodeVerifier))
+	req := httptest.NewRequest(http.MethodPost, "/api/oauth/consent", strings.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestOAuthController_issueToken(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpOidcProviderSigningKey(t)
+
+	// given
+	code := authorizeOAuthClient(t, 1, testWikiClientId, testWikiRedirectUri, "openid profile")
+
+	// when
+	rec := exchangeOAuthCode(testWikiClientId, testWikiClientSecret, code, testWikiRedirectUri, testOAuthCodeVerifier)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+
+	var actual dtos.OAuthToken
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.True(t, strings.HasPrefix(actual.AccessToken, "boauth_"))
+	assert.Equal(t, "Bearer", actual.TokenType)
+	assert.Equal(t, int64(3600), actual.ExpiresIn)
+	assert.Equal(t, "openid profile", actual.Scope)
+
+	// id_token 은 JWKS 로 공개한 비대칭 키로 서명한다.
+	parsedIdToken, err := jwt.Parse(actual.IdToken, func(token *jwt.Token) (interface{}, error) {
+		return &testOidcProviderPrivateKey.PublicKey, nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "RS256", parsedIdToken.Method.Alg())
+
+	idTokenClaims := parsedIdToken.Claims.(jwt.MapClaims)
+	assert.Equal(t, config.Config.OidcProvider.Issuer, idTokenClaims["iss"])
+	assert.Equal(t, "1", idTokenClaims["sub"])
+	assert.Equal(t, testWikiClientId, idTokenClaims["aud"])
+	assert.Equal(t, "n-0S6_WzA2Mj", idTokenClaims["nonce"])
+	assert.Equal(t, "사이트 관리자", idTokenClaims["name"])
+	// 조직에 할당된 역할도 포함한다.
+	assert.Equal(t, []any{"SYSTEM MANAGER", "MEMBER MANAGER"}, idTokenClaims["roles"])
+	assert.NotEmpty(t, idTokenClaims["permissions"])
+	// email scope 를 요청하지 않았다.
+	assert.Nil(t, idTokenClaims["email"])
+
+	// id_token 으로는 멤버의 API 를 호출할 수 없다.
+	req := httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", actual.IdToken))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// 액세스 토큰으로도 멤버의 API 를 호출할 수 없다.
+	req = httptest.NewRequest(http.MethodGet, "/api/members/my", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", actual.AccessToken))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestOAuthController_issueToken_공개_클라이언트(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpOidcProviderSigningKey(t)
+
+	// given
+	code := authorizeOAuthClient(t, 1, testDashboardClientId, testDashboardRedirectUri, "openid")
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {testDashboardRedirectUri},
+		"code_verifier": {testOAuthCodeVerifier},
+		"client_id":     {testDashboardClientId},
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/oauth/token", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestOAuthController_issueToken_code_verifier_가_다른_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpOidcProviderSigningKey(t)
+
+	// given
+	code := authorizeOAuthClient(t, 1, testWikiClientId, testWikiRedirectUri, "openid")
+
+	// when
+	rec := exchangeOAuthCode(testWikiClientId, testWikiClientSecret, code, testWikiRedirectUri, "wrong-code-verifier")
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var actual dtos.OAuthError
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "invalid_grant", actual.Error)
+}
+
+func TestOAuthController_issueToken_클라이언트_시크릿이_다른_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpOidcProviderSigningKey(t)
+
+	// given
+	code := authorizeOAuthClient(t, 1, testWikiClientId, testWikiRedirectUri, "openid")
+
+	// when
+	rec := exchangeOAuthCode(testWikiClientId, "wrong-secret", code, testWikiRedirectUri, testOAuthCodeVerifier)
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	var actual dtos.OAuthError
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "invalid_client", actual.Error)
+}
+
+func TestOAuthController_issueToken_인가_코드를_재사용한_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpOidcProviderSigningKey(t)
+
+	// given
+	code := authorizeOAuthClient(t, 1, testWikiClientId, testWikiRedirectUri, "openid")
+	rec := exchangeOAuthCode(testWikiClientId, testWikiClientSecret, code, testWikiRedirectUri, testOAuthCodeVerifier)
+	var oauthToken dtos.OAuthToken
+	json.Unmarshal(rec.Body.Bytes(), &oauthToken)
+
+	// when
+	rec = exchangeOAuthCode(testWikiClientId, testWikiClientSecret, code, testWikiRedirectUri, testOAuthCodeVerifier)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var actual dtos.OAuthError
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "invalid_grant", actual.Error)
+
+	// 재사용된 코드로 발급한 토큰은 폐기된다.
+	assert.Equal(t, http.StatusUnauthorized, getOAuthUserInfo(oauthToken.AccessToken).Code)
+}
+
+func TestOAuthController_getUserInfo(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpOidcProviderSigningKey(t)
+
+	// given
+	if err := gormDB.Exec("UPDATE members SET email = ?, email_verified_at = datetime('now') WHERE id = 1",
+		"siteadm@example.com").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	code := authorizeOAuthClient(t, 1, testWikiClientId, testWikiRedirectUri, "openid email")
+	rec := exchangeOAuthCode(testWikiClientId, testWikiClientSecret, code, testWikiRedirectUri, testOAuthCodeVerifier)
+	var oauthToken dtos.OAuthToken
+	json.Unmarshal(rec.Body.Bytes(), &oauthToken)
+
+	// when
+	rec = getOAuthUserInfo(oauthToken.AccessToken)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "1", actual["sub"])
+	assert.Equal(t, "siteadm@example.com", actual["email"])
+	assert.Equal(t, true, actual["email_verified"])
+	assert.Equal(t, []any{"SYSTEM MANAGER", "MEMBER MANAGER"}, actual["roles"])
+	// profile scope 를 요청하지 않았다.
+	assert.Nil(t, actual["name"])
+}
+
+func TestOAuthController_getUserInfo_멤버의_토큰인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+	setUpOidcProviderSigningKey(t)
+
+	// given
+	token, _ := generateTestJWT(map[string]any{
+		"Id": 1,
+	}, time.Minute*15)
+
+	// when
+	rec := getOAuthUserInfo(token)
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, `Bearer error="invalid_token"`, rec.Header().Get("WWW-Authenticate"))
+}
+
+func TestOAuthController_getOpenIdConfiguration(t *testing.T) {
+	setUpOidcProviderSigningKey(t)
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/.well-known/openid-configuration", nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual dtos.OpenIdConfiguration
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, config.Config.OidcProvider.Issuer, actual.Issuer)
+	assert.Equal(t, config.Config.OidcProvider.Issuer+"/api/oauth/token", actual.TokenEndpoint)
+	assert.Equal(t, config.Config.OidcProvider.Issuer+"/.well-known/jwks.json", actual.JwksUri)
+	assert.Equal(t, []string{"S256"}, actual.CodeChallengeMethodsSupported)
+	assert.Equal(t, []string{"RS256"}, actual.IdTokenSigningAlgValuesSupported)
+}
+
+func TestOAuthController_비대칭_서명_키가_없는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	// JwtSecret 으로 서명한 id_token 은 내부 앱이 검증할 수 없으므로 OpenID Connect 제공자를 사용할 수 없다.
+	requests := []*http.Request{
+		httptest.NewRequest(http.MethodGet, "/.well-known/openid-configuration", nil),
+		httptest.NewRequest(http.MethodGet, "/api/oauth/authorize?"+
+			newOAuthAuthorizationQuery(testWikiClientId, testWikiRedirectUri, "openid").Encode(), nil),
+		httptest.NewRequest(http.MethodPost, "/api/oauth/token", nil),
+	}
+
+	for _, req := range requests {
+		rec := httptest.NewRecorder()
+
+		// when
+		ginApp.ServeHTTP(rec, req)
+
+		// then
+		assert.Equal(t, http.StatusNotFound, rec.Code, req.URL.Path)
+	}
+}
+
+// setUpOidcProviderSigningKey OpenID Connect 제공자가 id_token 을 서명할 비대칭 키를 설정한다.
+func setUpOidcProviderSigningKey(t *testing.T) {
+	der, err := x509.MarshalPKCS8PrivateKey(testOidcProviderPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privateKeyFile := filepath.Join(t.TempDir(), "private.pem")
+	if err := os.WriteFile(privateKeyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() {
+		config.Config.JwtSigning.ActiveKeyId = ""
+		config.Config.JwtSigning.Keys = nil
+		security.InitSigningKeys()
+	})
+
+	config.Config.JwtSigning.ActiveKeyId = "oidc-test"
+	config.Config.JwtSigning.Keys = []config.JwtSigningKey{{KeyId: "oidc-test", Algorithm: "RS256", PrivateKeyFile: privateKeyFile}}
+	if err := security.InitSigningKeys(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newOAuthAuthorizationQuery(clientId, redirectUri, scope string) url.Values {
+	return url.Values{
+		"response_type":         {"code"},
+		"client_id":             {clientId},
+		"redirect_uri":          {redirectUri},
+		"scope":                 {scope},
+		"state":                 {"xyz"},
+		"nonce":                 {"n-0S6_WzA2Mj"},
+		"code_challenge":        {security.GetPkceS256CodeChallenge(testOAuthCodeVerifier)},
+		"code_challenge_method": {"S256"},
+	}
+}
+
+func postOAuthConsent(memberId uint, clientId, redirectUri, scope string, approved bool) *httptest.ResponseRecorder {
+	requestBody := fmt.Sprintf(`{"responseType": "code", "clientId": "%s", "redirectUri": "%s", "scope": "%s",
+		"state": "xyz", "nonce": "n-0S6_WzA2Mj", "codeChallenge": "%s", "codeChallengeMethod": "S256", "approved": %v}`,
+		clientId, redirectUri, scope, security.GetPkceS256CodeChallenge(testOAuthCodeVerifier), approved)
+
+	token, _ := generateTestJWT(map[string]any{
+		"Id": memberId,
+	}, time.Minute*15)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/oauth/consent", strings.NewReader(requestBody))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func authorizeOAuthClient(t *testing.T, memberId uint, clientId, redirectUri, scope string) string {
+	rec := postOAuthConsent(memberId, clientId, redirectUri, scope, true)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("oauth consent failed: %v", rec.Body.String())
+	}
+
+	var authorizationResponse dtos.OAuthAuthorizationResponse
+	json.Unmarshal(rec.Body.Bytes(), &authorizationResponse)
+
+	location, _ := url.Parse(authorizationResponse.RedirectUri)
+	assert.Equal(t, "xyz", location.Query().Get("state"))
+	return location.Query().Get("code")
+}
+
+func exchangeOAuthCode(clientId, clientSecret, code, redirectUri, codeVerifier string) *httptest.ResponseRecorder {
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {redirectUri},
+		"code_verifier": {codeVerifier},
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/api/oauth/token", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(clientId), url.QueryEscape(clientSecret))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func getOAuthUserInfo(accessToken string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/oauth/userinfo", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", accessToken))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+
+	return rec
+}
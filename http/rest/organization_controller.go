@@ -48,7 +48,7 @@ func (c OrganizationController) createOrganization(ctx *gin.Context) {
 		return
 	}
 
-	err := c.organizationService.CreateOrganization(ctx.Request.Context(), organizationInformation)
+	_, err := c.organizationService.CreateOrganization(ctx.Request.Context(), organizationInformation)
 	if err != nil {
 		helpers.ErrorHelper().InternalServerError(ctx, err)
 		return
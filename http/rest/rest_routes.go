@@ -1,6 +1,8 @@
 package rest
 
 import (
+	"better-admin-backend-service/app/middlewares"
+	authRepository "better-admin-backend-service/auth/repository"
 	memberRepository "better-admin-backend-service/member/repository"
 	organizationRepository "better-admin-backend-service/organization/repository"
 	rbacRepository "better-admin-backend-service/rbac/repository"
@@ -10,47 +12,150 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
+// Router 서비스는 한 번만 생성해서 API 와 인증 미들웨어가 함께 사용한다.
 type Router struct {
+	rbacService                *services.RoleBasedAccessControlService
+	siteService                *services.SiteService
+	memberService              *services.MemberService
+	organizationService        *services.OrganizationService
+	webHookService             *services.WebHookService
+	tokenRevocationService     *services.TokenRevocationService
+	webAuthnService            *services.WebAuthnService
+	loginProtectionService     *services.LoginProtectionService
+	memberAccessLogService     *services.MemberAccessLogService
+	memberProvisioningService  *services.MemberProvisioningService
+	authService                *services.AuthService
+	sessionService             *services.SessionService
+	personalAccessTokenService *services.PersonalAccessTokenService
+	emailVerificationService   *services.EmailVerificationService
+	signUpService              *services.SignUpService
+	impersonationService       *services.ImpersonationService
+	memberIdentityService      *services.MemberIdentityService
+	memberBulkService          *services.MemberBulkService
+	scimTokenService           *services.ScimTokenService
+	oauthClientService         *services.OAuthClientService
+	oauthProviderService       *services.OAuthProviderService
 }
 
-func (Router) MapRoutes(routerGroup *gin.RouterGroup) {
+func NewRouter() *Router {
 	rbacService := services.NewRoleBasedAccessControlService(&rbacRepository.PermissionRepository{}, &rbacRepository.RoleRepository{})
-	memberService := services.NewMemberService(rbacService, &memberRepository.MemberRepository{})
-	organizationService := services.NewOrganizationService(rbacService, &organizationRepository.OrganizationRepository{}, memberService)
 	siteService := services.NewSiteService(&siteRepository.SiteSettingRepository{})
+	memberService := services.NewMemberService(rbacService, siteService, &memberRepository.MemberRepository{},
+		&memberRepository.MemberIdentityRepository{}, &memberRepository.MemberStatusHistoryRepository{})
+	organizationService := services.NewOrganizationService(rbacService, &organizationRepository.OrganizationRepository{}, memberService)
 	webHookService := services.NewWebHookService(&webHookRepository.WebHookRepository{})
-	authService := services.NewAuthService(memberService, organizationService, siteService)
+	tokenRevocationService := services.NewTokenRevocationService(&memberRepository.MemberRepository{},
+		&authRepository.RefreshTokenRepository{}, &authRepository.RevokedAccessTokenRepository{},
+		&authRepository.SessionRepository{})
+	webAuthnService := services.NewWebAuthnService(memberService, siteService,
+		&memberRepository.WebAuthnCredentialRepository{}, &authRepository.WebAuthnSessionRepository{})
+	loginProtectionService := services.NewLoginProtectionService(memberService, siteService, &authRepository.LoginFailureRepository{})
+	memberAccessLogService := services.NewMemberAccessLogService(siteService, &memberRepository.MemberAccessLogRepository{})
+	memberProvisioningService := services.NewMemberProvisioningService(memberService, organizationService, siteService)
+	authService := services.NewAuthService(memberService, organizationService, siteService, tokenRevocationService,
+		webAuthnService, &authRepository.RefreshTokenRepository{}, &authRepository.OidcAuthSessionRepository{},
+		loginProtectionService, memberAccessLogService, &authRepository.SessionRepository{}, memberProvisioningService,
+		&authRepository.LoginCodeRepository{})
+	sessionService := services.NewSessionService(tokenRevocationService, &authRepository.SessionRepository{})
+	personalAccessTokenService := services.NewPersonalAccessTokenService(memberService, organizationService,
+		&authRepository.PersonalAccessTokenRepository{})
+	emailVerificationService := services.NewEmailVerificationService(memberService,
+		&memberRepository.EmailVerificationTokenRepository{})
+	signUpService := services.NewSignUpService(memberService, organizationService, siteService, emailVerificationService,
+		memberProvisioningService, &memberRepository.MemberInvitationRepository{})
+	impersonationService := services.NewImpersonationService(memberService, organizationService, tokenRevocationService,
+		&authRepository.ImpersonationRepository{})
+	memberIdentityService := services.NewMemberIdentityService(memberService, organizationService, tokenRevocationService,
+		&memberRepository.MemberIdentityRepository{}, &memberRepository.MemberIdentityLinkTokenRepository{})
+	memberBulkService := services.NewMemberBulkService(memberService, memberProvisioningService, organizationService)
+	scimTokenService := services.NewScimTokenService(&authRepository.ScimTokenRepository{})
+	oauthClientService := services.NewOAuthClientService(&authRepository.OAuthClientRepository{},
+		&authRepository.OAuthConsentRepository{}, &authRepository.OAuthAccessTokenRepository{})
+	oauthProviderService := services.NewOAuthProviderService(memberService, organizationService,
+		&authRepository.OAuthClientRepository{}, &authRepository.OAuthAuthorizationCodeRepository{},
+		&authRepository.OAuthConsentRepository{}, &authRepository.OAuthAccessTokenRepository{})
 
+	return &Router{
+		rbacService:                rbacService,
+		siteService:                siteService,
+		memberService:              memberService,
+		organizationService:        organizationService,
+		webHookService:             webHookService,
+		tokenRevocationService:     tokenRevocationService,
+		webAuthnService:            webAuthnService,
+		loginProtectionService:     loginProtectionService,
+		memberAccessLogService:     memberAccessLogService,
+		memberProvisioningService:  memberProvisioningService,
+		authService:                authService,
+		sessionService:             sessionService,
+		personalAccessTokenService: personalAccessTokenService,
+		emailVerificationService:   emailVerificationService,
+		signUpService:              signUpService,
+		impersonationService:       impersonationService,
+		memberIdentityService:      memberIdentityService,
+		memberBulkService:          memberBulkService,
+		scimTokenService:           scimTokenService,
+		oauthClientService:         oauthClientService,
+		oauthProviderService:       oauthProviderService,
+	}
+}
+
+// JwtToken API 와 같은 토큰 폐기, 개인 액세스 토큰 서비스로 액세스 토큰을 확인한다.
+func (r Router) JwtToken() gin.HandlerFunc {
+	return middlewares.JwtToken(r.tokenRevocationService, r.personalAccessTokenService)
+}
+
+func (r Router) MapRoutes(routerGroup *gin.RouterGroup) {
 	NewAccessControlController(
 		routerGroup,
-		rbacService,
+		r.rbacService,
 	).MapRoutes()
 
 	NewMemberController(
 		routerGroup,
-		rbacService,
-		memberService,
-		organizationService,
+		r.rbacService,
+		r.memberService,
+		r.organizationService,
+		r.tokenRevocationService,
+		r.webAuthnService,
+		r.loginProtectionService,
+		r.memberAccessLogService,
+		r.sessionService,
+		r.personalAccessTokenService,
+		r.impersonationService,
+		r.signUpService,
+		r.emailVerificationService,
+		r.memberProvisioningService,
+		r.memberIdentityService,
+		r.memberBulkService,
 	).MapRoutes()
 
 	NewOrganizationController(
 		routerGroup,
-		organizationService,
+		r.organizationService,
 	).MapRoutes()
 
 	NewSiteController(
 		routerGroup,
-		siteService,
+		r.siteService,
+		r.memberProvisioningService,
+		r.scimTokenService,
 	).MapRoutes()
 
 	NewWebHookController(
 		routerGroup,
-		webHookService,
+		r.webHookService,
 	).MapRoutes()
 
 	NewAuthController(
 		routerGroup,
-		authService,
-		memberService,
+		r.authService,
+		r.webAuthnService,
+	).MapRoutes()
+
+	NewOAuthController(
+		routerGroup,
+		r.oauthClientService,
+		r.oauthProviderService,
 	).MapRoutes()
 }
@@ -1,6 +1,7 @@
 package rest
 
 import (
+	authDomain "better-admin-backend-service/auth/domain"
 	"better-admin-backend-service/constants"
 	"better-admin-backend-service/dtos"
 	"better-admin-backend-service/errors"
@@ -11,20 +12,27 @@ import (
 	"github.com/mitchellh/mapstructure"
 	pkgerrors "github.com/pkg/errors"
 	"net/http"
+	"strconv"
 )
 
 type SiteController struct {
-	routerGroup *gin.RouterGroup
-	siteService *services.SiteService
+	routerGroup               *gin.RouterGroup
+	siteService               *services.SiteService
+	memberProvisioningService *services.MemberProvisioningService
+	scimTokenService          *services.ScimTokenService
 }
 
 func NewSiteController(
 	routerGroup *gin.RouterGroup,
-	siteService *services.SiteService) *SiteController {
+	siteService *services.SiteService,
+	memberProvisioningService *services.MemberProvisioningService,
+	scimTokenService *services.ScimTokenService) *SiteController {
 
 	return &SiteController{
-		routerGroup: routerGroup,
-		siteService: siteService,
+		routerGroup:               routerGroup,
+		siteService:               siteService,
+		memberProvisioningService: memberProvisioningService,
+		scimTokenService:          scimTokenService,
 	}
 }
 
@@ -36,6 +44,28 @@ func (c SiteController) MapRoutes() {
 	route.PUT("/settings/dooray-login", c.setDoorayLoginSetting)
 	route.GET("/settings/google-workspace-login", etag.HttpEtagCache(0), c.getGoogleWorkspaceLoginSetting)
 	route.PUT("/settings/google-workspace-login", c.setGoogleWorkspaceLoginSetting)
+	route.GET("/settings/webauthn-login", etag.HttpEtagCache(0), c.getWebAuthnLoginSetting)
+	route.PUT("/settings/webauthn-login", c.setWebAuthnLoginSetting)
+	route.GET("/settings/oidc-login", etag.HttpEtagCache(0), c.getOidcLoginSetting)
+	route.PUT("/settings/oidc-login", c.setOidcLoginSetting)
+	route.GET("/settings/ldap-login", etag.HttpEtagCache(0), c.getLdapLoginSetting)
+	route.PUT("/settings/ldap-login", c.setLdapLoginSetting)
+	route.GET("/settings/two-factor-auth", etag.HttpEtagCache(0), c.getTwoFactorAuthSetting)
+	route.PUT("/settings/two-factor-auth", c.setTwoFactorAuthSetting)
+	route.GET("/settings/password-policy", etag.HttpEtagCache(0), c.getPasswordPolicySetting)
+	route.PUT("/settings/password-policy", c.setPasswordPolicySetting)
+	route.GET("/settings/login-protection", etag.HttpEtagCache(0), c.getLoginProtectionSetting)
+	route.PUT("/settings/login-protection", c.setLoginProtectionSetting)
+	route.GET("/settings/member-access-log", etag.HttpEtagCache(0), c.getMemberAccessLogSetting)
+	route.PUT("/settings/member-access-log", c.setMemberAccessLogSetting)
+	route.GET("/settings/sign-up", etag.HttpEtagCache(0), c.getSignUpSetting)
+	route.PUT("/settings/sign-up", c.setSignUpSetting)
+	route.GET("/settings/member-provisioning", etag.HttpEtagCache(0), c.getMemberProvisioningSetting)
+	route.PUT("/settings/member-provisioning", c.setMemberProvisioningSetting)
+	route.POST("/settings/member-provisioning/dry-run", c.dryRunMemberProvisioning)
+	route.GET("/settings/scim/tokens", c.getScimTokens)
+	route.POST("/settings/scim/tokens", c.createScimToken)
+	route.DELETE("/settings/scim/tokens/:tokenId", c.deleteScimToken)
 	route.GET("/settings/app-version", etag.HttpEtagCache(0), c.getAppVersion)
 	route.PUT("/settings/app-version", c.increaseAppVersion)
 }
@@ -47,7 +77,7 @@ func (c SiteController) getSettingsSummary(ctx *gin.Context) {
 		return
 	}
 
-	summary := dtos.SiteSettingsSummary{}
+	summary := dtos.SiteSettingsSummary{SignUpMode: constants.SignUpModeOpen}
 
 	for _, setting := range settings {
 		if setting.Key == constants.SettingKeyDoorayLogin {
@@ -73,9 +103,59 @@ func (c SiteController) getSettingsSummary(ctx *gin.Context) {
 
 			if *googleWorkspaceSetting.Used {
 				summary.GoogleWorkspaceLoginUsed = true
-				summary.GoogleWorkspaceOAuthUri = googleWorkspaceSetting.GetOAuthUri()
 			}
 		}
+
+		if setting.Key == constants.SettingKeyWebAuthnLogin {
+			var webAuthnLoginSetting dtos.WebAuthnLoginSetting
+			err := mapstructure.Decode(setting.ValueObject, &webAuthnLoginSetting)
+			if err != nil {
+				ctx.JSON(http.StatusInternalServerError, pkgerrors.Wrap(err, "map to struct decode error"))
+				return
+			}
+
+			if *webAuthnLoginSetting.Used {
+				summary.WebAuthnLoginUsed = true
+			}
+		}
+
+		if setting.Key == constants.SettingKeyOidcLogin {
+			var oidcLoginSetting dtos.OidcLoginSetting
+			err := mapstructure.Decode(setting.ValueObject, &oidcLoginSetting)
+			if err != nil {
+				ctx.JSON(http.StatusInternalServerError, pkgerrors.Wrap(err, "map to struct decode error"))
+				return
+			}
+
+			if *oidcLoginSetting.Used {
+				summary.OidcLoginUsed = true
+				summary.OidcLoginProviderName = oidcLoginSetting.ProviderName
+			}
+		}
+
+		if setting.Key == constants.SettingKeyLdapLogin {
+			var ldapLoginSetting dtos.LdapLoginSetting
+			err := mapstructure.Decode(setting.ValueObject, &ldapLoginSetting)
+			if err != nil {
+				ctx.JSON(http.StatusInternalServerError, pkgerrors.Wrap(err, "map to struct decode error"))
+				return
+			}
+
+			if *ldapLoginSetting.Used {
+				summary.LdapLoginUsed = true
+			}
+		}
+
+		if setting.Key == constants.SettingKeySignUp {
+			var signUpSetting dtos.SignUpSetting
+			err := mapstructure.Decode(setting.ValueObject, &signUpSetting)
+			if err != nil {
+				ctx.JSON(http.StatusInternalServerError, pkgerrors.Wrap(err, "map to struct decode error"))
+				return
+			}
+
+			summary.SignUpMode = signUpSetting.Mode
+		}
 	}
 
 	ctx.JSON(http.StatusOK, summary)
@@ -135,6 +215,11 @@ func (c SiteController) setGoogleWorkspaceLoginSetting(ctx *gin.Context) {
 		return
 	}
 
+	if *setting.Used && len(setting.GetDomains()) == 0 {
+		ctx.JSON(http.StatusBadRequest, errors.ErrGoogleWorkspaceDomainRequired.Error())
+		return
+	}
+
 	if err := c.siteService.SetSettingWithKey(ctx.Request.Context(), constants.SettingKeyGoogleWorkspaceLogin, setting); err != nil {
 		ctx.JSON(http.StatusInternalServerError, err.Error())
 		return
@@ -143,6 +228,208 @@ func (c SiteController) setGoogleWorkspaceLoginSetting(ctx *gin.Context) {
 	ctx.Status(http.StatusNoContent)
 }
 
+func (c SiteController) getWebAuthnLoginSetting(ctx *gin.Context) {
+	setting, err := c.siteService.GetSettingWithKey(ctx.Request.Context(), constants.SettingKeyWebAuthnLogin)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.JSON(http.StatusOK, dtos.WebAuthnLoginSetting{})
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c SiteController) setWebAuthnLoginSetting(ctx *gin.Context) {
+	var setting dtos.WebAuthnLoginSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.siteService.SetSettingWithKey(ctx.Request.Context(), constants.SettingKeyWebAuthnLogin, setting); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c SiteController) getOidcLoginSetting(ctx *gin.Context) {
+	setting, err := c.siteService.GetSettingWithKey(ctx.Request.Context(), constants.SettingKeyOidcLogin)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.JSON(http.StatusOK, dtos.OidcLoginSetting{})
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c SiteController) setOidcLoginSetting(ctx *gin.Context) {
+	var setting dtos.OidcLoginSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.siteService.SetSettingWithKey(ctx.Request.Context(), constants.SettingKeyOidcLogin, setting); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c SiteController) getLdapLoginSetting(ctx *gin.Context) {
+	setting, err := c.siteService.GetSettingWithKey(ctx.Request.Context(), constants.SettingKeyLdapLogin)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.JSON(http.StatusOK, dtos.LdapLoginSetting{})
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c SiteController) setLdapLoginSetting(ctx *gin.Context) {
+	var setting dtos.LdapLoginSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.siteService.SetSettingWithKey(ctx.Request.Context(), constants.SettingKeyLdapLogin, setting); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c SiteController) getTwoFactorAuthSetting(ctx *gin.Context) {
+	setting, err := c.siteService.GetSettingWithKey(ctx.Request.Context(), constants.SettingKeyTwoFactorAuth)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.JSON(http.StatusOK, dtos.TwoFactorAuthSetting{})
+			return
+		}
+
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c SiteController) setTwoFactorAuthSetting(ctx *gin.Context) {
+	var setting dtos.TwoFactorAuthSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.siteService.SetSettingWithKey(ctx.Request.Context(), constants.SettingKeyTwoFactorAuth, setting); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c SiteController) getPasswordPolicySetting(ctx *gin.Context) {
+	setting, err := c.siteService.GetPasswordPolicy(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c SiteController) setPasswordPolicySetting(ctx *gin.Context) {
+	var setting dtos.PasswordPolicySetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.siteService.SetSettingWithKey(ctx.Request.Context(), constants.SettingKeyPasswordPolicy, setting); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c SiteController) getLoginProtectionSetting(ctx *gin.Context) {
+	setting, err := c.siteService.GetLoginProtection(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c SiteController) setLoginProtectionSetting(ctx *gin.Context) {
+	var setting dtos.LoginProtectionSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.siteService.SetSettingWithKey(ctx.Request.Context(), constants.SettingKeyLoginProtection, setting); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c SiteController) getMemberAccessLogSetting(ctx *gin.Context) {
+	setting, err := c.siteService.GetMemberAccessLog(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c SiteController) setMemberAccessLogSetting(ctx *gin.Context) {
+	var setting dtos.MemberAccessLogSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.siteService.SetSettingWithKey(ctx.Request.Context(), constants.SettingKeyMemberAccessLog, setting); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
 func (c SiteController) getAppVersion(ctx *gin.Context) {
 	appVersion, err := c.siteService.GetAppVersion(ctx.Request.Context())
 	if err != nil {
@@ -162,3 +449,138 @@ func (c SiteController) increaseAppVersion(ctx *gin.Context) {
 
 	ctx.Status(http.StatusNoContent)
 }
+
+func (c SiteController) getSignUpSetting(ctx *gin.Context) {
+	setting, err := c.siteService.GetSignUp(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c SiteController) setSignUpSetting(ctx *gin.Context) {
+	var setting dtos.SignUpSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.siteService.SetSettingWithKey(ctx.Request.Context(), constants.SettingKeySignUp, setting); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c SiteController) getMemberProvisioningSetting(ctx *gin.Context) {
+	setting, err := c.siteService.GetMemberProvisioning(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, setting)
+}
+
+func (c SiteController) setMemberProvisioningSetting(ctx *gin.Context) {
+	var setting dtos.MemberProvisioningSetting
+
+	if err := ctx.BindJSON(&setting); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.siteService.SetSettingWithKey(ctx.Request.Context(), constants.SettingKeyMemberProvisioning, setting); err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (c SiteController) dryRunMemberProvisioning(ctx *gin.Context) {
+	var dryRun dtos.MemberProvisioningDryRun
+
+	if err := ctx.BindJSON(&dryRun); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	result, err := c.memberProvisioningService.DryRun(ctx.Request.Context(), dryRun)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			ctx.JSON(http.StatusBadRequest, err.Error())
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+func (c SiteController) getScimTokens(ctx *gin.Context) {
+	entities, err := c.scimTokenService.GetScimTokens(ctx.Request.Context())
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	scimTokens := make([]dtos.ScimToken, 0)
+	for _, entity := range entities {
+		scimTokens = append(scimTokens, newScimToken(entity))
+	}
+
+	ctx.JSON(http.StatusOK, scimTokens)
+}
+
+func (c SiteController) createScimToken(ctx *gin.Context) {
+	var creation dtos.ScimTokenCreation
+	if err := ctx.BindJSON(&creation); err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	entity, token, err := c.scimTokenService.CreateScimToken(ctx.Request.Context(), creation.Name)
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	scimToken := newScimToken(entity)
+	scimToken.Token = token
+	ctx.JSON(http.StatusCreated, scimToken)
+}
+
+func (c SiteController) deleteScimToken(ctx *gin.Context) {
+	tokenId, err := strconv.ParseInt(ctx.Param("tokenId"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err := c.scimTokenService.DeleteScimToken(ctx.Request.Context(), uint(tokenId)); err != nil {
+		if err == errors.ErrNotFound {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func newScimToken(entity authDomain.ScimTokenEntity) dtos.ScimToken {
+	return dtos.ScimToken{
+		Id:           entity.ID,
+		Name:         entity.Name,
+		DisplayToken: entity.DisplayToken,
+		CreatedAt:    entity.CreatedAt,
+		LastUsedAt:   entity.LastUsedAt,
+	}
+}
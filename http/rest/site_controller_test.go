@@ -1,6 +1,7 @@
 package rest
 
 import (
+	"better-admin-backend-service/dtos"
 	"better-admin-backend-service/testdata/testdb"
 	"encoding/json"
 	"fmt"
@@ -32,7 +33,11 @@ func TestSiteController_getSettingsSummary(t *testing.T) {
 	expected := map[string]any{
 		"doorayLoginUsed":          true,
 		"googleWorkspaceLoginUsed": true,
-		"googleWorkspaceOAuthUri":  "https://accounts.google.com/o/oauth2/auth?client_id=test-client-id&redirect_uri=http://localhost:2016&response_type=code&scope=https://www.googleapis.com/auth/userinfo.profile https://www.googleapis.com/auth/userinfo.email&approval_prompt=force&access_type=offline",
+		"webAuthnLoginUsed":        true,
+		"oidcLoginUsed":            false,
+		"signUpMode":               "open",
+		"oidcLoginProviderName":    "",
+		"ldapLoginUsed":            false,
 	}
 
 	assert.Equal(t, expected, actual)
@@ -238,6 +243,9 @@ func TestSiteController_getGoogleWorkspaceLoginSetting(t *testing.T) {
 		"clientId":     "test-client-id",
 		"clientSecret": "test-secret",
 		"redirectUri":  "http://localhost:2016",
+		"allowedRedirectUrls": []any{
+			"http://localhost:3000/login",
+		},
 	}
 
 	assert.Equal(t, expected, actual)
@@ -310,6 +318,95 @@ func TestSiteController_setGoogleWorkspaceLoginSetting_Bad_Request_필수_값_
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 }
 
+func TestSiteController_setGoogleWorkspaceLoginSetting_여러_도메인을_지정하는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{
+		"used": true,
+		"domains": [
+			{"domain": "bettercode.kr", "organizationId": 1, "roleIds": [2]},
+			{"domain": "sub.bettercode.kr", "organizationId": 4}
+		],
+		"clientId": "test-client-id",
+		"clientSecret": "test-secret",
+		"redirectUri": "http://localhost:2016"
+	}`
+
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/google-workspace-login", strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]any{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"site-settings.update",
+			"site-settings.read",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/site/settings/google-workspace-login", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"used": true,
+		"domain": "",
+		"domains": [
+			{"domain": "bettercode.kr", "organizationId": 1, "roleIds": [2]},
+			{"domain": "sub.bettercode.kr", "organizationId": 4, "roleIds": null}
+		],
+		"clientId": "test-client-id",
+		"clientSecret": "test-secret",
+		"redirectUri": "http://localhost:2016",
+		"allowedRedirectUrls": null
+	}`, rec.Body.String())
+}
+
+func TestSiteController_setGoogleWorkspaceLoginSetting_Bad_Request_도메인_확인(t *testing.T) {
+	// given
+	requestBody := `{
+		"used": true,
+		"clientId": "test-client-id",
+		"clientSecret": "test-secret",
+		"redirectUri": "http://localhost:2016"
+	}`
+
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/google-workspace-login", strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]any{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"site-settings.update",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
 func TestSiteController_setGoogleWorkspaceLoginSetting_Bad_Request_used_가_true_일_때_필수_값_확인(t *testing.T) {
 	// given
 	requestBody := `{
@@ -342,35 +439,285 @@ func TestSiteController_setGoogleWorkspaceLoginSetting_Bad_Request_used_가_true
 	fmt.Println(rec.Body.String())
 }
 
-func TestSiteController_getAppVersion(t *testing.T) {
+func TestSiteController_setGoogleWorkspaceLoginSetting_Bad_Request_리다이렉트_허용_주소_확인(t *testing.T) {
+	// given
+	requestBody := `{
+		"used": true,
+		"domain": "bettercode.kr",
+		"clientId": "test-client-id",
+		"clientSecret": "test-secret",
+		"redirectUri": "http://localhost:2016",
+		"allowedRedirectUrls": ["/login"]
+	}`
+
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/google-workspace-login", strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]any{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"site-settings.update",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSiteController_setOidcLoginSetting(t *testing.T) {
 	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
 
 	// given
-	req := httptest.NewRequest(http.MethodGet, "/api/site/settings/app-version", nil)
+	requestBody := `{
+		"used": true,
+		"providerName": "Keycloak",
+		"issuer": "https://keycloak.bettercode.kr/realms/better",
+		"clientId": "test-client-id",
+		"clientSecret": "test-secret",
+		"redirectUri": "http://localhost:2016/api/auth/oidc"
+	}`
+
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/oidc-login", strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]any{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"site-settings.update",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	summaryRec := httptest.NewRecorder()
+	ginApp.ServeHTTP(summaryRec, httptest.NewRequest(http.MethodGet, "/api/site/settings", nil))
+
+	var summary map[string]any
+	json.Unmarshal(summaryRec.Body.Bytes(), &summary)
+	assert.Equal(t, true, summary["oidcLoginUsed"])
+	assert.Equal(t, "Keycloak", summary["oidcLoginProviderName"])
+}
+
+func TestSiteController_setOidcLoginSetting_Bad_Request_used_가_true_일_때_필수_값_확인(t *testing.T) {
+	// given
+	requestBody := `{
+		"used": true,
+		"providerName": "Keycloak",
+		"clientId": "test-client-id"
+	}`
+
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/oidc-login", strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]any{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"site-settings.update",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSiteController_setLdapLoginSetting_필터는_원문_그대로_저장된다(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{
+		"used": true,
+		"url": "ldaps://ad.bettercode.kr:636",
+		"bindDn": "CN=svc-better-admin,OU=Service,DC=bettercode,DC=kr",
+		"bindPassword": "p&ss<word>",
+		"searchBase": "DC=bettercode,DC=kr",
+		"userFilter": "(&(objectClass=user)(sAMAccountName={username}))"
+	}`
+
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/ldap-login", strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]any{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"site-settings.update",
+			"site-settings.read",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	getReq := httptest.NewRequest(http.MethodGet, "/api/site/settings/ldap-login", nil)
+	getReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	getRec := httptest.NewRecorder()
+	ginApp.ServeHTTP(getRec, getReq)
+
+	var actual map[string]any
+	json.Unmarshal(getRec.Body.Bytes(), &actual)
+	assert.Equal(t, "(&(objectClass=user)(sAMAccountName={username}))", actual["userFilter"])
+	assert.Equal(t, "p&ss<word>", actual["bindPassword"])
+}
+
+func TestSiteController_setTwoFactorAuthSetting(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/two-factor-auth", strings.NewReader(`{"requiredForAllPermissions": true}`))
+	token, err := generateTestJWT(map[string]any{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"site-settings.update",
+			"site-settings.read",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	// when
 	ginApp.ServeHTTP(rec, req)
 
 	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/site/settings/two-factor-auth", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
 	assert.Equal(t, http.StatusOK, rec.Code)
-	fmt.Println(rec.Body.String())
 
 	var actual any
 	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, map[string]any{"requiredForAllPermissions": true}, actual)
+}
 
-	expected := map[string]any{
-		"version": float64(2),
+func TestSiteController_setTwoFactorAuthSetting_Bad_Request_필수값_확인(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/two-factor-auth", strings.NewReader(`{}`))
+	token, err := generateTestJWT(map[string]any{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"site-settings.update",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
 	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
 
-	assert.Equal(t, expected, actual)
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
 }
 
-func TestSiteController_increaseAppVersion(t *testing.T) {
+func TestSiteController_getPasswordPolicySetting_설정하지_않은_경우(t *testing.T) {
 	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
 
 	// given
-	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/app-version", nil)
+	req := httptest.NewRequest(http.MethodGet, "/api/site/settings/password-policy", nil)
+	token, err := generateTestJWT(map[string]any{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"site-settings.read",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual dtos.PasswordPolicySetting
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, dtos.PasswordPolicySetting{}, actual)
+}
+
+func TestSiteController_setPasswordPolicySetting(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	requestBody := `{
+		"minLength": 12,
+		"requireUppercase": true,
+		"requireLowercase": true,
+		"requireDigit": true,
+		"requireSpecial": false,
+		"historyCount": 5,
+		"maxAgeDays": 90,
+		"hashCost": 12
+	}`
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/password-policy", strings.NewReader(requestBody))
+	token, err := generateTestJWT(map[string]any{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"site-settings.update",
+			"site-settings.read",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
 	rec := httptest.NewRecorder()
 
 	// when
@@ -378,4 +725,508 @@ func TestSiteController_increaseAppVersion(t *testing.T) {
 
 	// then
 	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/site/settings/password-policy", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual dtos.PasswordPolicySetting
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, dtos.PasswordPolicySetting{
+		MinLength:        12,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		HistoryCount:     5,
+		MaxAgeDays:       90,
+		HashCost:         12,
+	}, actual)
+}
+
+func TestSiteController_setPasswordPolicySetting_Bad_Request_bcrypt_비용_확인(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/password-policy", strings.NewReader(`{"hashCost": 3}`))
+	token, err := generateTestJWT(map[string]any{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"site-settings.update",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSiteController_getLoginProtectionSetting_설정하지_않은_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/site/settings/login-protection", nil)
+	token, err := generateTestJWT(map[string]any{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"site-settings.read",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var actual dtos.LoginProtectionSetting
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, dtos.LoginProtectionSetting{MaxFailures: 5, IpMaxFailures: 20, LockoutMinutes: 15}, actual)
+}
+
+func TestSiteController_setLoginProtectionSetting(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/login-protection",
+		strings.NewReader(`{"maxFailures": 10, "ipMaxFailures": 0, "lockoutMinutes": 30}`))
+	token, err := generateTestJWT(map[string]any{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"site-settings.update",
+			"site-settings.read",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/site/settings/login-protection", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual dtos.LoginProtectionSetting
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, dtos.LoginProtectionSetting{MaxFailures: 10, IpMaxFailures: 0, LockoutMinutes: 30}, actual)
+}
+
+func TestSiteController_setLoginProtectionSetting_Bad_Request_잠금_시간_확인(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/login-protection",
+		strings.NewReader(`{"maxFailures": 5, "ipMaxFailures": 20, "lockoutMinutes": 0}`))
+	token, err := generateTestJWT(map[string]any{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"site-settings.update",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSiteController_getMemberAccessLogSetting_설정하지_않은_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/site/settings/member-access-log", nil)
+	token, err := generateTestJWT(map[string]any{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"site-settings.read",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"loginSuccessUsed": true, "loginFailureUsed": true, "tokenRefreshUsed": true}`, rec.Body.String())
+}
+
+func TestSiteController_setMemberAccessLogSetting(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/member-access-log",
+		strings.NewReader(`{"loginSuccessUsed": true, "loginFailureUsed": true, "tokenRefreshUsed": false}`))
+	token, err := generateTestJWT(map[string]any{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"site-settings.update",
+			"site-settings.read",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/site/settings/member-access-log", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"loginSuccessUsed": true, "loginFailureUsed": true, "tokenRefreshUsed": false}`, rec.Body.String())
+}
+
+func TestSiteController_setMemberAccessLogSetting_Bad_Request_필수_항목_확인(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/member-access-log",
+		strings.NewReader(`{"loginSuccessUsed": true}`))
+	token, err := generateTestJWT(map[string]any{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"site-settings.update",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSiteController_getAppVersion(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/site/settings/app-version", nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	fmt.Println(rec.Body.String())
+
+	var actual any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+
+	expected := map[string]any{
+		"version": float64(2),
+	}
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestSiteController_increaseAppVersion(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/app-version", nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
+
+func TestSiteController_getSignUpSetting_설정하지_않은_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/site/settings/sign-up", nil)
+	token, err := generateTestJWT(map[string]any{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"site-settings.read",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"mode": "open"}`, rec.Body.String())
+}
+
+func TestSiteController_setSignUpSetting_Bad_Request_가입_방식_확인(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPut, "/api/site/settings/sign-up", strings.NewReader(`{"mode": "unknown"}`))
+	token, err := generateTestJWT(map[string]any{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"site-settings.update",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSiteController_dryRunMemberProvisioning(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	setMemberProvisioningRules(t, `[
+		{"name": "두레이 멤버", "memberType": "dooray", "roleIds": [2]},
+		{"name": "팀 메일", "emailSuffix": "@team.example", "roleIds": [3], "organizationIds": [4]}
+	]`)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/site/settings/member-provisioning/dry-run",
+		strings.NewReader(`{"memberType": "google", "email": "ymyoo@team.example"}`))
+	token, err := generateTestJWT(map[string]any{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"site-settings.read",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"matchedRules": [
+			{"name": "팀 메일", "memberType": "", "emailSuffix": "@team.example", "ldapGroup": "", "roleIds": [3], "organizationIds": [4]}
+		],
+		"roleIds": [3],
+		"organizationIds": [4]
+	}`, rec.Body.String())
+
+	// 멤버를 지정하면 멤버의 유형으로 평가한다.
+	req = httptest.NewRequest(http.MethodPost, "/api/site/settings/member-provisioning/dry-run", strings.NewReader(`{"memberId": 2}`))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual dtos.MemberProvisioningDryRunResult
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, []uint{2}, actual.RoleIds)
+	assert.Equal(t, "두레이 멤버", actual.MatchedRules[0].Name)
+
+	// 저장하기 전의 규칙으로 평가할 수 있다.
+	req = httptest.NewRequest(http.MethodPost, "/api/site/settings/member-provisioning/dry-run",
+		strings.NewReader(`{"memberId": 2, "rules": []}`))
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"matchedRules": [], "roleIds": [], "organizationIds": []}`, rec.Body.String())
+}
+
+func TestSiteController_createScimToken(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodPost, "/api/site/settings/scim/tokens", strings.NewReader(`{"name": "Azure AD"}`))
+	token, err := generateTestJWT(map[string]any{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"site-settings.update",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var actual dtos.ScimToken
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "Azure AD", actual.Name)
+	assert.True(t, strings.HasPrefix(actual.Token, "bscim_"))
+	assert.Equal(t, actual.Token[:14], actual.DisplayToken)
+
+	// 발급한 토큰으로 SCIM API 를 호출할 수 있다.
+	req = httptest.NewRequest(http.MethodGet, "/scim/v2/Users", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", actual.Token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	// 멤버의 JWT 로는 SCIM API 를 호출할 수 없다.
+	req = httptest.NewRequest(http.MethodGet, "/scim/v2/Users", nil)
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestSiteController_getScimTokens(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/api/site/settings/scim/tokens", nil)
+	token, err := generateTestJWT(map[string]any{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"site-settings.read",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual []map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, 1, len(actual))
+	assert.Equal(t, "Okta", actual[0]["name"])
+	assert.Equal(t, "bscim_test-tok", actual[0]["displayToken"])
+	assert.Nil(t, actual[0]["token"])
+}
+
+func TestSiteController_deleteScimToken(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodDelete, "/api/site/settings/scim/tokens/1", nil)
+	token, err := generateTestJWT(map[string]any{
+		"Id":    1,
+		"Roles": []string{},
+		"Permissions": []string{
+			"site-settings.update",
+		},
+	}, time.Minute*15)
+
+	if err != nil {
+		t.Failed()
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// 삭제한 토큰으로는 SCIM API 를 호출할 수 없다.
+	req = httptest.NewRequest(http.MethodGet, "/scim/v2/Users", nil)
+	req.Header.Set("Authorization", "Bearer bscim_test-token")
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, "application/scim+json", rec.Header().Get("Content-Type"))
 }
@@ -0,0 +1,40 @@
+package scim_test
+
+import (
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/http/rest"
+	"better-admin-backend-service/testdata/testserver"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"io"
+	"net/http"
+	"net/http/httptest"
+)
+
+// app 이 scim 패키지를 사용하므로 순환 참조를 피하기 위해 외부 테스트 패키지로 작성한다.
+
+// 테스트 픽스처(scim_tokens.yml)에 등록된 SCIM 토큰
+const testScimToken = "bscim_test-token"
+
+var (
+	gormDB *gorm.DB
+	ginApp *gin.Engine
+)
+
+func init() {
+	err := config.InitConfig("../../config/config.json")
+	if err != nil {
+		panic(err)
+	}
+
+	testAppServer := testserver.NewTestAppServer(rest.NewRouter())
+	gormDB = testAppServer.GetDB()
+	ginApp = testAppServer.GetGin()
+}
+
+func newScimRequest(method, target string, body io.Reader) *http.Request {
+	req := httptest.NewRequest(method, target, body)
+	req.Header.Set("Authorization", "Bearer "+testScimToken)
+	req.Header.Set("Content-Type", "application/scim+json")
+	return req
+}
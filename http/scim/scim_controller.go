@@ -0,0 +1,577 @@
+package scim
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	memberDomain "better-admin-backend-service/member/domain"
+	organizationDomain "better-admin-backend-service/organization/domain"
+	"better-admin-backend-service/services"
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	scimResourceTypeUser  = "User"
+	scimResourceTypeGroup = "Group"
+	scimBulkMaxOperations = 1000
+	scimBulkMaxPayload    = 1048576
+)
+
+var scimBulkIdPattern = regexp.MustCompile(`bulkId:([^"/\s]+)`)
+
+type ScimController struct {
+	routerGroup *gin.RouterGroup
+	scimService *services.ScimService
+}
+
+func NewScimController(
+	routerGroup *gin.RouterGroup,
+	scimService *services.ScimService) *ScimController {
+
+	return &ScimController{
+		routerGroup: routerGroup,
+		scimService: scimService,
+	}
+}
+
+func (c ScimController) MapRoutes() {
+	c.routerGroup.GET("/ServiceProviderConfig", c.getServiceProviderConfig)
+	c.routerGroup.GET("/ResourceTypes", c.getResourceTypes)
+
+	c.routerGroup.GET("/Users", c.getUsers)
+	c.routerGroup.POST("/Users", c.createUser)
+	c.routerGroup.GET("/Users/:id", c.getUser)
+	c.routerGroup.PUT("/Users/:id", c.replaceUser)
+	c.routerGroup.PATCH("/Users/:id", c.patchUser)
+	c.routerGroup.DELETE("/Users/:id", c.deleteUser)
+
+	c.routerGroup.GET("/Groups", c.getGroups)
+	c.routerGroup.POST("/Groups", c.createGroup)
+	c.routerGroup.GET("/Groups/:id", c.getGroup)
+	c.routerGroup.PUT("/Groups/:id", c.replaceGroup)
+	c.routerGroup.PATCH("/Groups/:id", c.patchGroup)
+	c.routerGroup.DELETE("/Groups/:id", c.deleteGroup)
+
+	c.routerGroup.POST("/Bulk", c.bulk)
+}
+
+// scimResponse 단일 요청과 Bulk 요청의 각 작업이 같은 처리 결과를 사용한다.
+type scimResponse struct {
+	status   int
+	id       string
+	resource any
+}
+
+func newScimErrorResponse(status int, scimType string, detail string) scimResponse {
+	return scimResponse{
+		status: status,
+		resource: dtos.ScimError{
+			Schemas:  []string{constants.ScimSchemaError},
+			Status:   strconv.Itoa(status),
+			ScimType: scimType,
+			Detail:   detail,
+		},
+	}
+}
+
+// toScimErrorResponse SCIM 오류로 응답할 수 없는 오류는 그대로 반환한다.
+func toScimErrorResponse(err error) (scimResponse, error) {
+	switch err {
+	case errors.ErrNotFound:
+		return newScimErrorResponse(http.StatusNotFound, "", "resource not found"), nil
+	case errors.ErrDuplicated:
+		return newScimErrorResponse(http.StatusConflict, "uniqueness", err.Error()), nil
+	case errors.ErrInvalidScimFilter:
+		return newScimErrorResponse(http.StatusBadRequest, "invalidFilter", err.Error()), nil
+	case errors.ErrInvalidScimPath:
+		return newScimErrorResponse(http.StatusBadRequest, "invalidPath", err.Error()), nil
+	case errors.ErrInvalidScimValue:
+		return newScimErrorResponse(http.StatusBadRequest, "invalidValue", err.Error()), nil
+	}
+
+	return scimResponse{}, err
+}
+
+func (c ScimController) writeResponse(ctx *gin.Context, response scimResponse, err error) {
+	if err != nil {
+		helpers.ErrorHelper().InternalServerError(ctx, err)
+		return
+	}
+
+	if response.resource == nil {
+		ctx.Status(response.status)
+		return
+	}
+
+	ctx.Header("Content-Type", constants.ScimContentType)
+	ctx.JSON(response.status, response.resource)
+}
+
+func (c ScimController) getBaseUrl(ctx *gin.Context) string {
+	return fmt.Sprintf("%v://%v%v", strings.ToLower(strings.Split(ctx.Request.Proto, "/")[0]), ctx.Request.Host, c.routerGroup.BasePath())
+}
+
+func (c ScimController) getServiceProviderConfig(ctx *gin.Context) {
+	c.writeResponse(ctx, scimResponse{
+		status: http.StatusOK,
+		resource: map[string]any{
+			"schemas": []string{constants.ScimSchemaServiceProviderConfig},
+			"patch":   map[string]any{"supported": true},
+			"bulk": map[string]any{
+				"supported":      true,
+				"maxOperations":  scimBulkMaxOperations,
+				"maxPayloadSize": scimBulkMaxPayload,
+			},
+			"filter":         map[string]any{"supported": true, "maxResults": 100},
+			"changePassword": map[string]any{"supported": false},
+			"sort":           map[string]any{"supported": false},
+			"etag":           map[string]any{"supported": false},
+			"authenticationSchemes": []map[string]any{{
+				"type":        "oauthbearertoken",
+				"name":        "SCIM Token",
+				"description": "사이트 설정에서 발급한 SCIM 토큰을 Bearer 토큰으로 전달한다.",
+				"primary":     true,
+			}},
+		},
+	}, nil)
+}
+
+func (c ScimController) getResourceTypes(ctx *gin.Context) {
+	resourceTypes := []map[string]any{
+		{
+			"schemas":  []string{constants.ScimSchemaResourceType},
+			"id":       scimResourceTypeUser,
+			"name":     scimResourceTypeUser,
+			"endpoint": "/Users",
+			"schema":   constants.ScimSchemaUser,
+		},
+		{
+			"schemas":  []string{constants.ScimSchemaResourceType},
+			"id":       scimResourceTypeGroup,
+			"name":     scimResourceTypeGroup,
+			"endpoint": "/Groups",
+			"schema":   constants.ScimSchemaGroup,
+		},
+	}
+
+	c.writeResponse(ctx, scimResponse{
+		status: http.StatusOK,
+		resource: dtos.ScimListResponse{
+			Schemas:      []string{constants.ScimSchemaListResponse},
+			TotalResults: int64(len(resourceTypes)),
+			StartIndex:   1,
+			ItemsPerPage: len(resourceTypes),
+			Resources:    resourceTypes,
+		},
+	}, nil)
+}
+
+func (c ScimController) getUsers(ctx *gin.Context) {
+	startIndex, _ := strconv.Atoi(ctx.Query("startIndex"))
+	count, _ := strconv.Atoi(ctx.Query("count"))
+
+	memberEntities, totalCount, actualStartIndex, err := c.scimService.GetUsers(ctx.Request.Context(), ctx.Query("filter"), startIndex, count)
+	if err != nil {
+		response, err := toScimErrorResponse(err)
+		c.writeResponse(ctx, response, err)
+		return
+	}
+
+	scimUsers := make([]dtos.ScimUser, 0)
+	for _, memberEntity := range memberEntities {
+		scimUsers = append(scimUsers, newScimUser(c.getBaseUrl(ctx), memberEntity))
+	}
+
+	c.writeResponse(ctx, scimResponse{
+		status: http.StatusOK,
+		resource: dtos.ScimListResponse{
+			Schemas:      []string{constants.ScimSchemaListResponse},
+			TotalResults: totalCount,
+			StartIndex:   actualStartIndex,
+			ItemsPerPage: len(scimUsers),
+			Resources:    scimUsers,
+		},
+	}, nil)
+}
+
+func (c ScimController) getUser(ctx *gin.Context) {
+	memberEntity, err := c.scimService.GetUser(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		response, err := toScimErrorResponse(err)
+		c.writeResponse(ctx, response, err)
+		return
+	}
+
+	c.writeResponse(ctx, newScimUserResponse(http.StatusOK, c.getBaseUrl(ctx), memberEntity), nil)
+}
+
+func (c ScimController) createUser(ctx *gin.Context) {
+	data, err := ctx.GetRawData()
+	if err != nil {
+		c.writeResponse(ctx, newScimErrorResponse(http.StatusBadRequest, "invalidSyntax", err.Error()), nil)
+		return
+	}
+
+	response, err := c.executeCreateUser(ctx.Request.Context(), c.getBaseUrl(ctx), data)
+	c.writeResponse(ctx, response, err)
+}
+
+func (c ScimController) replaceUser(ctx *gin.Context) {
+	data, err := ctx.GetRawData()
+	if err != nil {
+		c.writeResponse(ctx, newScimErrorResponse(http.StatusBadRequest, "invalidSyntax", err.Error()), nil)
+		return
+	}
+
+	response, err := c.executeReplaceUser(ctx.Request.Context(), c.getBaseUrl(ctx), ctx.Param("id"), data)
+	c.writeResponse(ctx, response, err)
+}
+
+func (c ScimController) patchUser(ctx *gin.Context) {
+	data, err := ctx.GetRawData()
+	if err != nil {
+		c.writeResponse(ctx, newScimErrorResponse(http.StatusBadRequest, "invalidSyntax", err.Error()), nil)
+		return
+	}
+
+	response, err := c.executePatchUser(ctx.Request.Context(), c.getBaseUrl(ctx), ctx.Param("id"), data)
+	c.writeResponse(ctx, response, err)
+}
+
+func (c ScimController) deleteUser(ctx *gin.Context) {
+	response, err := c.executeDeleteUser(ctx.Request.Context(), ctx.Param("id"))
+	c.writeResponse(ctx, response, err)
+}
+
+func (c ScimController) executeCreateUser(ctx context.Context, baseUrl string, data []byte) (scimResponse, error) {
+	var scimUser dtos.ScimUser
+	if err := binding.JSON.BindBody(data, &scimUser); err != nil {
+		return newScimErrorResponse(http.StatusBadRequest, "invalidSyntax", err.Error()), nil
+	}
+
+	memberEntity, err := c.scimService.CreateUser(ctx, scimUser)
+	if err != nil {
+		return toScimErrorResponse(err)
+	}
+
+	return newScimUserResponse(http.StatusCreated, baseUrl, memberEntity), nil
+}
+
+func (c ScimController) executeReplaceUser(ctx context.Context, baseUrl string, id string, data []byte) (scimResponse, error) {
+	var scimUser dtos.ScimUser
+	if err := binding.JSON.BindBody(data, &scimUser); err != nil {
+		return newScimErrorResponse(http.StatusBadRequest, "invalidSyntax", err.Error()), nil
+	}
+
+	memberEntity, err := c.scimService.ReplaceUser(ctx, id, scimUser)
+	if err != nil {
+		return toScimErrorResponse(err)
+	}
+
+	return newScimUserResponse(http.StatusOK, baseUrl, memberEntity), nil
+}
+
+func (c ScimController) executePatchUser(ctx context.Context, baseUrl string, id string, data []byte) (scimResponse, error) {
+	var patchRequest dtos.ScimPatchRequest
+	if err := binding.JSON.BindBody(data, &patchRequest); err != nil {
+		return newScimErrorResponse(http.StatusBadRequest, "invalidSyntax", err.Error()), nil
+	}
+
+	memberEntity, err := c.scimService.PatchUser(ctx, id, patchRequest)
+	if err != nil {
+		return toScimErrorResponse(err)
+	}
+
+	return newScimUserResponse(http.StatusOK, baseUrl, memberEntity), nil
+}
+
+func (c ScimController) executeDeleteUser(ctx context.Context, id string) (scimResponse, error) {
+	if err := c.scimService.DeactivateUser(ctx, id); err != nil {
+		return toScimErrorResponse(err)
+	}
+
+	return scimResponse{status: http.StatusNoContent, id: id}, nil
+}
+
+func newScimUserResponse(status int, baseUrl string, memberEntity memberDomain.MemberEntity) scimResponse {
+	scimUser := newScimUser(baseUrl, memberEntity)
+	return scimResponse{status: status, id: scimUser.Id, resource: scimUser}
+}
+
+func newScimUser(baseUrl string, memberEntity memberDomain.MemberEntity) dtos.ScimUser {
+	scimUser := memberEntity.GetScimUser()
+	scimUser.Meta = &dtos.ScimMeta{
+		ResourceType: scimResourceTypeUser,
+		Created:      memberEntity.CreatedAt,
+		LastModified: memberEntity.UpdatedAt,
+		Location:     fmt.Sprintf("%v/Users/%v", baseUrl, scimUser.Id),
+	}
+	return scimUser
+}
+
+func (c ScimController) getGroups(ctx *gin.Context) {
+	startIndex, _ := strconv.Atoi(ctx.Query("startIndex"))
+	count, _ := strconv.Atoi(ctx.Query("count"))
+
+	organizationEntities, totalCount, actualStartIndex, err := c.scimService.GetGroups(ctx.Request.Context(), ctx.Query("filter"), startIndex, count)
+	if err != nil {
+		response, err := toScimErrorResponse(err)
+		c.writeResponse(ctx, response, err)
+		return
+	}
+
+	scimGroups := make([]dtos.ScimGroup, 0)
+	for _, organizationEntity := range organizationEntities {
+		scimGroups = append(scimGroups, newScimGroup(c.getBaseUrl(ctx), organizationEntity))
+	}
+
+	c.writeResponse(ctx, scimResponse{
+		status: http.StatusOK,
+		resource: dtos.ScimListResponse{
+			Schemas:      []string{constants.ScimSchemaListResponse},
+			TotalResults: totalCount,
+			StartIndex:   actualStartIndex,
+			ItemsPerPage: len(scimGroups),
+			Resources:    scimGroups,
+		},
+	}, nil)
+}
+
+func (c ScimController) getGroup(ctx *gin.Context) {
+	organizationEntity, err := c.scimService.GetGroup(ctx.Request.Context(), ctx.Param("id"))
+	if err != nil {
+		response, err := toScimErrorResponse(err)
+		c.writeResponse(ctx, response, err)
+		return
+	}
+
+	c.writeResponse(ctx, newScimGroupResponse(http.StatusOK, c.getBaseUrl(ctx), organizationEntity), nil)
+}
+
+func (c ScimController) createGroup(ctx *gin.Context) {
+	data, err := ctx.GetRawData()
+	if err != nil {
+		c.writeResponse(ctx, newScimErrorResponse(http.StatusBadRequest, "invalidSyntax", err.Error()), nil)
+		return
+	}
+
+	response, err := c.executeCreateGroup(ctx.Request.Context(), c.getBaseUrl(ctx), data)
+	c.writeResponse(ctx, response, err)
+}
+
+func (c ScimController) replaceGroup(ctx *gin.Context) {
+	data, err := ctx.GetRawData()
+	if err != nil {
+		c.writeResponse(ctx, newScimErrorResponse(http.StatusBadRequest, "invalidSyntax", err.Error()), nil)
+		return
+	}
+
+	response, err := c.executeReplaceGroup(ctx.Request.Context(), c.getBaseUrl(ctx), ctx.Param("id"), data)
+	c.writeResponse(ctx, response, err)
+}
+
+func (c ScimController) patchGroup(ctx *gin.Context) {
+	data, err := ctx.GetRawData()
+	if err != nil {
+		c.writeResponse(ctx, newScimErrorResponse(http.StatusBadRequest, "invalidSyntax", err.Error()), nil)
+		return
+	}
+
+	response, err := c.executePatchGroup(ctx.Request.Context(), c.getBaseUrl(ctx), ctx.Param("id"), data)
+	c.writeResponse(ctx, response, err)
+}
+
+func (c ScimController) deleteGroup(ctx *gin.Context) {
+	response, err := c.executeDeleteGroup(ctx.Request.Context(), ctx.Param("id"))
+	c.writeResponse(ctx, response, err)
+}
+
+func (c ScimController) executeCreateGroup(ctx context.Context, baseUrl string, data []byte) (scimResponse, error) {
+	var scimGroup dtos.ScimGroup
+	if err := binding.JSON.BindBody(data, &scimGroup); err != nil {
+		return newScimErrorResponse(http.StatusBadRequest, "invalidSyntax", err.Error()), nil
+	}
+
+	organizationEntity, err := c.scimService.CreateGroup(ctx, scimGroup)
+	if err != nil {
+		return toScimErrorResponse(err)
+	}
+
+	return newScimGroupResponse(http.StatusCreated, baseUrl, organizationEntity), nil
+}
+
+func (c ScimController) executeReplaceGroup(ctx context.Context, baseUrl string, id string, data []byte) (scimResponse, error) {
+	var scimGroup dtos.ScimGroup
+	if err := binding.JSON.BindBody(data, &scimGroup); err != nil {
+		return newScimErrorResponse(http.StatusBadRequest, "invalidSyntax", err.Error()), nil
+	}
+
+	organizationEntity, err := c.scimService.ReplaceGroup(ctx, id, scimGroup)
+	if err != nil {
+		return toScimErrorResponse(err)
+	}
+
+	return newScimGroupResponse(http.StatusOK, baseUrl, organizationEntity), nil
+}
+
+func (c ScimController) executePatchGroup(ctx context.Context, baseUrl string, id string, data []byte) (scimResponse, error) {
+	var patchRequest dtos.ScimPatchRequest
+	if err := binding.JSON.BindBody(data, &patchRequest); err != nil {
+		return newScimErrorResponse(http.StatusBadRequest, "invalidSyntax", err.Error()), nil
+	}
+
+	organizationEntity, err := c.scimService.PatchGroup(ctx, id, patchRequest)
+	if err != nil {
+		return toScimErrorResponse(err)
+	}
+
+	return newScimGroupResponse(http.StatusOK, baseUrl, organizationEntity), nil
+}
+
+func (c ScimController) executeDeleteGroup(ctx context.Context, id string) (scimResponse, error) {
+	if err := c.scimService.DeleteGroup(ctx, id); err != nil {
+		return toScimErrorResponse(err)
+	}
+
+	return scimResponse{status: http.StatusNoContent, id: id}, nil
+}
+
+func newScimGroupResponse(status int, baseUrl string, organizationEntity organizationDomain.OrganizationEntity) scimResponse {
+	scimGroup := newScimGroup(baseUrl, organizationEntity)
+	return scimResponse{status: status, id: scimGroup.Id, resource: scimGroup}
+}
+
+func newScimGroup(baseUrl string, organizationEntity organizationDomain.OrganizationEntity) dtos.ScimGroup {
+	scimGroup := organizationEntity.GetScimGroup()
+	scimGroup.Meta = &dtos.ScimMeta{
+		ResourceType: scimResourceTypeGroup,
+		Created:      organizationEntity.CreatedAt,
+		LastModified: organizationEntity.UpdatedAt,
+		Location:     fmt.Sprintf("%v/Groups/%v", baseUrl, scimGroup.Id),
+	}
+	return scimGroup
+}
+
+// bulk 모든 작업은 하나의 트랜잭션에서 처리하며, 실패한 작업은 작업별 결과로 반환한다.
+// 앞선 작업에서 생성한 리소스는 bulkId:{bulkId} 로 참조할 수 있다.
+func (c ScimController) bulk(ctx *gin.Context) {
+	data, err := ctx.GetRawData()
+	if err != nil {
+		c.writeResponse(ctx, newScimErrorResponse(http.StatusBadRequest, "invalidSyntax", err.Error()), nil)
+		return
+	}
+
+	if len(data) > scimBulkMaxPayload {
+		c.writeResponse(ctx, newScimErrorResponse(http.StatusRequestEntityTooLarge, "", "payload too large"), nil)
+		return
+	}
+
+	var bulkRequest dtos.ScimBulkRequest
+	if err := binding.JSON.BindBody(data, &bulkRequest); err != nil {
+		c.writeResponse(ctx, newScimErrorResponse(http.StatusBadRequest, "invalidSyntax", err.Error()), nil)
+		return
+	}
+
+	baseUrl := c.getBaseUrl(ctx)
+	bulkIds := map[string]string{}
+	results := make([]dtos.ScimBulkOperationResult, 0)
+	errorCount := 0
+
+	for _, operation := range bulkRequest.Operations {
+		response, err := c.executeBulkOperation(ctx.Request.Context(), baseUrl, operation, bulkIds)
+		if err != nil {
+			helpers.ErrorHelper().InternalServerError(ctx, err)
+			return
+		}
+
+		result := dtos.ScimBulkOperationResult{
+			Method: operation.Method,
+			BulkId: operation.BulkId,
+			Status: strconv.Itoa(response.status),
+		}
+
+		if response.status >= http.StatusBadRequest {
+			result.Response = response.resource
+			errorCount++
+		} else {
+			resourceType, _, _ := strings.Cut(strings.Trim(operation.Path, "/"), "/")
+			result.Location = fmt.Sprintf("%v/%v/%v", baseUrl, resourceType, response.id)
+			if len(operation.BulkId) > 0 {
+				bulkIds[operation.BulkId] = response.id
+			}
+		}
+
+		results = append(results, result)
+
+		if bulkRequest.FailOnErrors > 0 && errorCount >= bulkRequest.FailOnErrors {
+			break
+		}
+	}
+
+	c.writeResponse(ctx, scimResponse{
+		status: http.StatusOK,
+		resource: dtos.ScimBulkResponse{
+			Schemas:    []string{constants.ScimSchemaBulkResponse},
+			Operations: results,
+		},
+	}, nil)
+}
+
+func (c ScimController) executeBulkOperation(ctx context.Context, baseUrl string, operation dtos.ScimBulkOperation, bulkIds map[string]string) (scimResponse, error) {
+	resolved := true
+	resolveBulkId := func(value string) string {
+		return scimBulkIdPattern.ReplaceAllStringFunc(value, func(reference string) string {
+			id, ok := bulkIds[strings.TrimPrefix(reference, "bulkId:")]
+			if ok == false {
+				resolved = false
+				return reference
+			}
+			return id
+		})
+	}
+
+	path := resolveBulkId(operation.Path)
+	data := []byte(resolveBulkId(string(operation.Data)))
+	if resolved == false {
+		return newScimErrorResponse(http.StatusConflict, "invalidValue", "unresolved bulkId reference"), nil
+	}
+
+	resourceType, id, _ := strings.Cut(strings.Trim(path, "/"), "/")
+	method := strings.ToUpper(operation.Method)
+
+	if resourceType == "Users" {
+		switch {
+		case method == http.MethodPost && len(id) == 0:
+			return c.executeCreateUser(ctx, baseUrl, data)
+		case method == http.MethodPut && len(id) > 0:
+			return c.executeReplaceUser(ctx, baseUrl, id, data)
+		case method == http.MethodPatch && len(id) > 0:
+			return c.executePatchUser(ctx, baseUrl, id, data)
+		case method == http.MethodDelete && len(id) > 0:
+			return c.executeDeleteUser(ctx, id)
+		}
+	}
+
+	if resourceType == "Groups" {
+		switch {
+		case method == http.MethodPost && len(id) == 0:
+			return c.executeCreateGroup(ctx, baseUrl, data)
+		case method == http.MethodPut && len(id) > 0:
+			return c.executeReplaceGroup(ctx, baseUrl, id, data)
+		case method == http.MethodPatch && len(id) > 0:
+			return c.executePatchGroup(ctx, baseUrl, id, data)
+		case method == http.MethodDelete && len(id) > 0:
+			return c.executeDeleteGroup(ctx, id)
+		}
+	}
+
+	return newScimErrorResponse(http.StatusBadRequest, "invalidPath", "unsupported bulk operation"), nil
+}
@@ -0,0 +1,632 @@
+package scim_test
+
+import (
+	authDomain "better-admin-backend-service/auth/domain"
+	memberDomain "better-admin-backend-service/member/domain"
+	organizationDomain "better-admin-backend-service/organization/domain"
+	"better-admin-backend-service/testdata/testdb"
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func createUser(t *testing.T, userName string) string {
+	req := newScimRequest(http.MethodPost, "/scim/v2/Users", strings.NewReader(fmt.Sprintf(`{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+		"userName": "%v",
+		"externalId": "ext-%v",
+		"name": {"givenName": "영모", "familyName": "유"},
+		"emails": [{"value": "%v", "type": "work", "primary": true}],
+		"phoneNumbers": [{"value": "010-1234-5678", "type": "work"}]
+	}`, userName, userName, userName)))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create user failed: %v", rec.Body.String())
+	}
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	return actual["id"].(string)
+}
+
+func createGroup(t *testing.T, displayName string, memberIds ...string) string {
+	members := make([]string, 0)
+	for _, memberId := range memberIds {
+		members = append(members, fmt.Sprintf(`{"value": "%v"}`, memberId))
+	}
+
+	req := newScimRequest(http.MethodPost, "/scim/v2/Groups", strings.NewReader(fmt.Sprintf(`{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:Group"],
+		"displayName": "%v",
+		"members": [%v]
+	}`, displayName, strings.Join(members, ","))))
+	rec := httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create group failed: %v", rec.Body.String())
+	}
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	return actual["id"].(string)
+}
+
+func getMemberEntity(id string) memberDomain.MemberEntity {
+	memberId, _ := strconv.Atoi(id)
+	memberEntity := memberDomain.MemberEntity{}
+	gormDB.First(&memberEntity, memberId)
+	return memberEntity
+}
+
+func TestScimController_토큰이_없는_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := httptest.NewRequest(http.MethodGet, "/scim/v2/Users", nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.JSONEq(t, `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:Error"],
+		"status": "401",
+		"detail": "invalid access token"
+	}`, rec.Body.String())
+}
+
+func TestScimController_getServiceProviderConfig(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := newScimRequest(http.MethodGet, "/scim/v2/ServiceProviderConfig", nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/scim+json", rec.Header().Get("Content-Type"))
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, map[string]any{"supported": true}, actual["patch"])
+	assert.Equal(t, true, actual["bulk"].(map[string]any)["supported"])
+
+	// 토큰을 사용한 시간을 기록한다.
+	tokenEntity := authDomain.ScimTokenEntity{}
+	gormDB.First(&tokenEntity, 1)
+	assert.NotNil(t, tokenEntity.LastUsedAt)
+}
+
+func TestScimController_createUser(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := newScimRequest(http.MethodPost, "/scim/v2/Users", strings.NewReader(`{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+		"userName": "ymyoo@bettercode.kr",
+		"externalId": "00u1",
+		"name": {"givenName": "영모", "familyName": "유"},
+		"emails": [{"value": "ymyoo@bettercode.kr", "type": "work", "primary": true}],
+		"active": true
+	}`))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "ymyoo@bettercode.kr", actual["userName"])
+	assert.Equal(t, "00u1", actual["externalId"])
+	assert.Equal(t, "영모 유", actual["displayName"])
+	assert.Equal(t, true, actual["active"])
+	assert.Equal(t, "User", actual["meta"].(map[string]any)["resourceType"])
+	assert.Equal(t, "http://example.com/scim/v2/Users/"+actual["id"].(string), actual["meta"].(map[string]any)["location"])
+
+	memberEntity := getMemberEntity(actual["id"].(string))
+	assert.Equal(t, "scim", memberEntity.Type)
+	assert.Equal(t, "approved", memberEntity.Status)
+	assert.Equal(t, "ymyoo@bettercode.kr", memberEntity.Email)
+	assert.True(t, memberEntity.IsEmailVerified())
+}
+
+func TestScimController_createUser_사용자_이름이_중복된_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	createUser(t, "ymyoo@bettercode.kr")
+
+	req := newScimRequest(http.MethodPost, "/scim/v2/Users", strings.NewReader(`{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+		"userName": "YMYOO@bettercode.kr"
+	}`))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.JSONEq(t, `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:Error"],
+		"status": "409",
+		"scimType": "uniqueness",
+		"detail": "duplicated"
+	}`, rec.Body.String())
+}
+
+func TestScimController_getUsers(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	createUser(t, "ymyoo@bettercode.kr")
+	createUser(t, "dooray@bettercode.kr")
+	req := newScimRequest(http.MethodGet, `/scim/v2/Users?filter=userName+eq+%22YMYOO@bettercode.kr%22`, nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, float64(1), actual["totalResults"])
+	assert.Equal(t, "ymyoo@bettercode.kr", actual["Resources"].([]any)[0].(map[string]any)["userName"])
+
+	// SCIM 으로 생성하지 않은 멤버는 조회하지 않는다.
+	req = newScimRequest(http.MethodGet, "/scim/v2/Users?startIndex=2&count=1", nil)
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, float64(2), actual["totalResults"])
+	assert.Equal(t, float64(2), actual["startIndex"])
+	assert.Equal(t, float64(1), actual["itemsPerPage"])
+	assert.Equal(t, "dooray@bettercode.kr", actual["Resources"].([]any)[0].(map[string]any)["userName"])
+}
+
+func TestScimController_getUsers_startIndex_가_페이지의_시작이_아닌_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	createUser(t, "ymyoo@bettercode.kr")
+	createUser(t, "dooray@bettercode.kr")
+	createUser(t, "siteadm@bettercode.kr")
+	req := newScimRequest(http.MethodGet, "/scim/v2/Users?startIndex=2&count=2", nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	// startIndex 는 페이지가 아닌 1부터 시작하는 항목 위치이다.
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, float64(3), actual["totalResults"])
+	assert.Equal(t, float64(2), actual["startIndex"])
+	assert.Equal(t, float64(2), actual["itemsPerPage"])
+	resources := actual["Resources"].([]any)
+	assert.Equal(t, "dooray@bettercode.kr", resources[0].(map[string]any)["userName"])
+	assert.Equal(t, "siteadm@bettercode.kr", resources[1].(map[string]any)["userName"])
+}
+
+func TestScimController_getUsers_지원하지_않는_필터인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := newScimRequest(http.MethodGet, `/scim/v2/Users?filter=title+eq+%22Manager%22`, nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "invalidFilter", actual["scimType"])
+}
+
+func TestScimController_getUser_SCIM_멤버가_아닌_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := newScimRequest(http.MethodGet, "/scim/v2/Users/1", nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestScimController_replaceUser(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	id := createUser(t, "ymyoo@bettercode.kr")
+	req := newScimRequest(http.MethodPut, "/scim/v2/Users/"+id, strings.NewReader(`{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+		"userName": "ymyoo@bettercode.kr",
+		"displayName": "유영모",
+		"emails": [{"value": "ymyoo2@bettercode.kr", "primary": true}]
+	}`))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	memberEntity := getMemberEntity(id)
+	assert.Equal(t, "유영모", memberEntity.Name)
+	assert.Equal(t, "ymyoo2@bettercode.kr", memberEntity.Email)
+	assert.Empty(t, memberEntity.PhoneNumber)
+}
+
+func TestScimController_patchUser(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	id := createUser(t, "ymyoo@bettercode.kr")
+	// Azure AD 는 active 를 문자열로 보낸다.
+	req := newScimRequest(http.MethodPatch, "/scim/v2/Users/"+id, strings.NewReader(`{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [
+			{"op": "Replace", "path": "displayName", "value": "유영모"},
+			{"op": "replace", "path": "phoneNumbers[type eq \"work\"].value", "value": "010-0000-0000"},
+			{"op": "Replace", "path": "active", "value": "False"}
+		]
+	}`))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, false, actual["active"])
+
+	memberEntity := getMemberEntity(id)
+	assert.Equal(t, "유영모", memberEntity.Name)
+	assert.Equal(t, "010-0000-0000", memberEntity.PhoneNumber)
+	assert.Equal(t, "deactivated", memberEntity.Status)
+
+	// 다시 활성화할 수 있다.
+	req = newScimRequest(http.MethodPatch, "/scim/v2/Users/"+id, strings.NewReader(`{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [{"op": "replace", "value": {"active": true}}]
+	}`))
+	rec = httptest.NewRecorder()
+	ginApp.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "approved", getMemberEntity(id).Status)
+}
+
+func TestScimController_patchUser_잘못된_경로인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	id := createUser(t, "ymyoo@bettercode.kr")
+	req := newScimRequest(http.MethodPatch, "/scim/v2/Users/"+id, strings.NewReader(`{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [{"op": "replace", "path": "emails[type eq]", "value": "a@bettercode.kr"}]
+	}`))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "invalidPath", actual["scimType"])
+}
+
+func TestScimController_deleteUser(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	id := createUser(t, "ymyoo@bettercode.kr")
+	req := newScimRequest(http.MethodDelete, "/scim/v2/Users/"+id, nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// 멤버를 삭제하지 않고 비활성화한다.
+	memberEntity := getMemberEntity(id)
+	assert.Equal(t, "deactivated", memberEntity.Status)
+	assert.Equal(t, uint(1), memberEntity.TokenEpoch)
+}
+
+func TestScimController_createGroup(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	id := createUser(t, "ymyoo@bettercode.kr")
+	req := newScimRequest(http.MethodPost, "/scim/v2/Groups", strings.NewReader(fmt.Sprintf(`{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:Group"],
+		"displayName": "개발팀",
+		"members": [{"value": "%v"}]
+	}`, id)))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, "개발팀", actual["displayName"])
+	assert.Equal(t, id, actual["members"].([]any)[0].(map[string]any)["value"])
+
+	organizationId, _ := strconv.Atoi(actual["id"].(string))
+	organizationEntity := organizationDomain.OrganizationEntity{}
+	gormDB.Preload("Members").First(&organizationEntity, organizationId)
+	assert.Equal(t, "개발팀", organizationEntity.Name)
+	assert.Equal(t, 1, len(organizationEntity.Members))
+}
+
+func TestScimController_createGroup_없는_멤버인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := newScimRequest(http.MethodPost, "/scim/v2/Groups", strings.NewReader(`{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:Group"],
+		"displayName": "개발팀",
+		"members": [{"value": "9999"}]
+	}`))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	var count int64
+	gormDB.Model(&organizationDomain.OrganizationEntity{}).Where("name = ?", "개발팀").Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestScimController_getGroups(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := newScimRequest(http.MethodGet, `/scim/v2/Groups?filter=displayName+eq+%22%EB%B6%80%EC%84%9CB%22`, nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, float64(1), actual["totalResults"])
+	assert.Equal(t, "3", actual["Resources"].([]any)[0].(map[string]any)["id"])
+}
+
+func TestScimController_patchGroup(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	id := createUser(t, "ymyoo@bettercode.kr")
+	groupId := createGroup(t, "개발팀", "1", "2")
+	req := newScimRequest(http.MethodPatch, "/scim/v2/Groups/"+groupId, strings.NewReader(fmt.Sprintf(`{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [
+			{"op": "add", "path": "members", "value": [{"value": "%v"}]},
+			{"op": "remove", "path": "members[value eq \"2\"]"},
+			{"op": "replace", "path": "displayName", "value": "플랫폼팀"}
+		]
+	}`, id)))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	organizationId, _ := strconv.Atoi(groupId)
+	organizationEntity := organizationDomain.OrganizationEntity{}
+	gormDB.Preload("Members").First(&organizationEntity, organizationId)
+	assert.Equal(t, "플랫폼팀", organizationEntity.Name)
+	assert.ElementsMatch(t, []uint{1, uint(getMemberEntity(id).ID)}, organizationEntity.GetMemberIds())
+}
+
+func TestScimController_patchGroup_SCIM_으로_생성하지_않은_조직인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := newScimRequest(http.MethodPatch, "/scim/v2/Groups/1", strings.NewReader(`{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [
+			{"op": "replace", "path": "displayName", "value": "베터코드"}
+		]
+	}`))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	organizationEntity := organizationDomain.OrganizationEntity{}
+	gormDB.First(&organizationEntity, 1)
+	assert.Equal(t, "베터코드 연구소", organizationEntity.Name)
+}
+
+func TestScimController_deleteGroup(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	groupId := createGroup(t, "개발팀")
+	req := newScimRequest(http.MethodDelete, "/scim/v2/Groups/"+groupId, nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	var count int64
+	gormDB.Model(&organizationDomain.OrganizationEntity{}).Where("id = ?", groupId).Count(&count)
+	assert.Equal(t, int64(0), count)
+}
+
+func TestScimController_deleteGroup_SCIM_으로_생성하지_않은_조직인_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := newScimRequest(http.MethodDelete, "/scim/v2/Groups/5", nil)
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	var count int64
+	gormDB.Model(&organizationDomain.OrganizationEntity{}).Where("id IN ?", []uint{2, 5}).Count(&count)
+	assert.Equal(t, int64(2), count)
+}
+
+func TestScimController_bulk(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := newScimRequest(http.MethodPost, "/scim/v2/Bulk", strings.NewReader(`{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:BulkRequest"],
+		"Operations": [
+			{
+				"method": "POST",
+				"path": "/Users",
+				"bulkId": "user1",
+				"data": {"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"], "userName": "ymyoo@bettercode.kr"}
+			},
+			{
+				"method": "POST",
+				"path": "/Groups",
+				"bulkId": "group1",
+				"data": {
+					"schemas": ["urn:ietf:params:scim:schemas:core:2.0:Group"],
+					"displayName": "개발팀",
+					"members": [{"value": "bulkId:user1"}]
+				}
+			},
+			{
+				"method": "DELETE",
+				"path": "/Users/9999"
+			},
+			{
+				"method": "PATCH",
+				"path": "/Users/bulkId:user1",
+				"data": {
+					"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+					"Operations": [{"op": "replace", "path": "displayName", "value": "유영모"}]
+				}
+			}
+		]
+	}`))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	fmt.Println(rec.Body.String())
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	operations := actual["Operations"].([]any)
+	assert.Equal(t, 4, len(operations))
+	assert.Equal(t, "201", operations[0].(map[string]any)["status"])
+	assert.Equal(t, "201", operations[1].(map[string]any)["status"])
+	assert.Equal(t, "404", operations[2].(map[string]any)["status"])
+	assert.Equal(t, "200", operations[3].(map[string]any)["status"])
+
+	location := operations[0].(map[string]any)["location"].(string)
+	id := location[strings.LastIndex(location, "/")+1:]
+	assert.Equal(t, "유영모", getMemberEntity(id).Name)
+
+	organizationEntity := organizationDomain.OrganizationEntity{}
+	gormDB.Preload("Members").Where("name = ?", "개발팀").First(&organizationEntity)
+	assert.Equal(t, []uint{getMemberEntity(id).ID}, organizationEntity.GetMemberIds())
+}
+
+func TestScimController_bulk_오류가_failOnErrors_만큼_발생한_경우(t *testing.T) {
+	testdb.DatabaseFixture{}.SetUpDefault(gormDB)
+
+	// given
+	req := newScimRequest(http.MethodPost, "/scim/v2/Bulk", strings.NewReader(`{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:BulkRequest"],
+		"failOnErrors": 1,
+		"Operations": [
+			{"method": "DELETE", "path": "/Users/9999"},
+			{
+				"method": "POST",
+				"path": "/Users",
+				"data": {"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"], "userName": "ymyoo@bettercode.kr"}
+			}
+		]
+	}`))
+	rec := httptest.NewRecorder()
+
+	// when
+	ginApp.ServeHTTP(rec, req)
+
+	// then
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var actual map[string]any
+	json.Unmarshal(rec.Body.Bytes(), &actual)
+	assert.Equal(t, 1, len(actual["Operations"].([]any)))
+
+	var count int64
+	gormDB.Model(&memberDomain.MemberEntity{}).Where("type = ?", "scim").Count(&count)
+	assert.Equal(t, int64(0), count)
+}
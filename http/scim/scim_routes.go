@@ -0,0 +1,28 @@
+package scim
+
+import (
+	memberRepository "better-admin-backend-service/member/repository"
+	organizationRepository "better-admin-backend-service/organization/repository"
+	rbacRepository "better-admin-backend-service/rbac/repository"
+	"better-admin-backend-service/services"
+	siteRepository "better-admin-backend-service/site/repository"
+	"github.com/gin-gonic/gin"
+)
+
+// Router SCIM API 는 REST API 와 인증 방식(SCIM 토큰)이 달라서 별도로 라우팅한다.
+type Router struct {
+}
+
+func (Router) MapRoutes(routerGroup *gin.RouterGroup) {
+	rbacService := services.NewRoleBasedAccessControlService(&rbacRepository.PermissionRepository{}, &rbacRepository.RoleRepository{})
+	siteService := services.NewSiteService(&siteRepository.SiteSettingRepository{})
+	memberService := services.NewMemberService(rbacService, siteService, &memberRepository.MemberRepository{},
+		&memberRepository.MemberIdentityRepository{}, &memberRepository.MemberStatusHistoryRepository{})
+	organizationService := services.NewOrganizationService(rbacService, &organizationRepository.OrganizationRepository{}, memberService)
+	scimService := services.NewScimService(memberService, organizationService)
+
+	NewScimController(
+		routerGroup,
+		scimService,
+	).MapRoutes()
+}
@@ -0,0 +1,17 @@
+package wellknown
+
+import (
+	"better-admin-backend-service/security"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+func JwksHandler() gin.HandlerFunc {
+	fn := func(ctx *gin.Context) {
+		// 키 교체 시 다른 서비스가 새로운 키를 받아갈 수 있도록 캐시 시간을 짧게 유지한다.
+		ctx.Header("Cache-Control", "public, max-age=300")
+		ctx.JSON(http.StatusOK, security.JwtAuthentication{}.GetJwks())
+	}
+
+	return gin.HandlerFunc(fn)
+}
@@ -0,0 +1,24 @@
+package wellknown
+
+import (
+	"better-admin-backend-service/security"
+	"better-admin-backend-service/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// OpenIdConfigurationHandler 내부 앱이 OpenID Connect 제공자의 엔드포인트와 지원 기능을 찾을 수 있도록 디스커버리 문서를 제공한다.
+// 비대칭 서명 키가 설정되지 않은 경우 OpenID Connect 제공자를 사용할 수 없으므로 제공하지 않는다.
+func OpenIdConfigurationHandler() gin.HandlerFunc {
+	fn := func(ctx *gin.Context) {
+		if (security.JwtAuthentication{}).HasAsymmetricSigningKey() == false {
+			ctx.Status(http.StatusNotFound)
+			return
+		}
+
+		ctx.Header("Cache-Control", "public, max-age=300")
+		ctx.JSON(http.StatusOK, services.OAuthProviderService{}.GetOpenIdConfiguration())
+	}
+
+	return gin.HandlerFunc(fn)
+}
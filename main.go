@@ -5,6 +5,7 @@ import (
 	"better-admin-backend-service/app/db"
 	"better-admin-backend-service/config"
 	"better-admin-backend-service/http/rest"
+	"better-admin-backend-service/security"
 	"context"
 	filename "github.com/keepeye/logrus-filename"
 	"github.com/open-policy-agent/opa/rego"
@@ -19,6 +20,10 @@ func main() {
 		log.Fatal(err)
 	}
 
+	if err := security.InitSigningKeys(); err != nil {
+		log.Fatal(err)
+	}
+
 	regoQuery, err := rego.New(rego.Query("data.rest.allowed"), rego.Load([]string{
 		"authorization/rest/policy.rego", "authorization/rest/data.json",
 	}, nil)).PrepareForEval(context.TODO())
@@ -27,7 +32,7 @@ func main() {
 		log.Fatal("rego error", err)
 	}
 
-	log.Fatal(app.NewApp(rest.Router{}, db.ProductionDbConnector{}, &regoQuery).Run())
+	log.Fatal(app.NewApp(rest.NewRouter(), db.ProductionDbConnector{}, &regoQuery).Run())
 }
 
 func setUpLogFormatter() {
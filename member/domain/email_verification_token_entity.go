@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"better-admin-backend-service/security"
+	"gorm.io/gorm"
+	"time"
+)
+
+// EmailVerificationTokenEntity 이메일 인증 메일로 보낸 토큰. 토큰을 보낸 이메일이 멤버의 현재 이메일과 같을 때만 인증된다.
+type EmailVerificationTokenEntity struct {
+	gorm.Model
+	MemberId  uint      `gorm:"not null;index"`
+	Email     string    `gorm:"type:varchar(100);not null"`
+	TokenHash string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+}
+
+func (EmailVerificationTokenEntity) TableName() string {
+	return "email_verification_tokens"
+}
+
+func (e EmailVerificationTokenEntity) IsAvailable() bool {
+	return e.UsedAt == nil && time.Now().Before(e.ExpiresAt)
+}
+
+func (e *EmailVerificationTokenEntity) Use() {
+	now := time.Now()
+	e.UsedAt = &now
+}
+
+func NewEmailVerificationTokenEntity(memberId uint, email, token string, expiresAt time.Time) EmailVerificationTokenEntity {
+	return EmailVerificationTokenEntity{
+		MemberId:  memberId,
+		Email:     email,
+		TokenHash: security.HashToken(token),
+		ExpiresAt: expiresAt,
+	}
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/helpers"
+	"context"
+	"gorm.io/gorm"
+)
+
+const (
+	maxAccessLogLoginIdLength       = 100
+	maxAccessLogFailureReasonLength = 255
+	maxAccessLogUserAgentLength     = 255
+)
+
+// MemberAccessLogEntity 로그인, 토큰 갱신의 성공/실패 기록. 존재하지 않는 아이디로 로그인에 실패한 경우 MemberId 는 0 이다.
+type MemberAccessLogEntity struct {
+	gorm.Model
+	MemberId      uint   `gorm:"not null;index"`
+	LoginId       string `gorm:"type:varchar(100)"`
+	Type          string `gorm:"type:varchar(20);not null"`
+	AuthType      string `gorm:"type:varchar(20);not null"`
+	Result        string `gorm:"type:varchar(20);not null"`
+	FailureReason string `gorm:"type:varchar(255)"`
+	IpAddress     string `gorm:"type:varchar(50)"`
+	UserAgent     string `gorm:"type:varchar(255)"`
+}
+
+func (MemberAccessLogEntity) TableName() string {
+	return "member_access_logs"
+}
+
+func (e MemberAccessLogEntity) IsSuccess() bool {
+	return e.Result == constants.MemberAccessResultSuccess
+}
+
+// NewMemberAccessLogEntity failure 가 nil 이면 성공으로 기록한다.
+func NewMemberAccessLogEntity(ctx context.Context, memberId uint, loginId, accessType, authType string, failure error) MemberAccessLogEntity {
+	entity := MemberAccessLogEntity{
+		MemberId:  memberId,
+		LoginId:   truncate(loginId, maxAccessLogLoginIdLength),
+		Type:      accessType,
+		AuthType:  authType,
+		Result:    constants.MemberAccessResultSuccess,
+		IpAddress: helpers.ContextHelper().GetClientIp(ctx),
+		UserAgent: truncate(helpers.ContextHelper().GetClientUserAgent(ctx), maxAccessLogUserAgentLength),
+	}
+
+	if failure != nil {
+		entity.Result = constants.MemberAccessResultFailure
+		entity.FailureReason = truncate(failure.Error(), maxAccessLogFailureReasonLength)
+	}
+
+	return entity
+}
+
+func truncate(value string, maxLength int) string {
+	if runes := []rune(value); len(runes) > maxLength {
+		return string(runes[:maxLength])
+	}
+	return value
+}
@@ -6,13 +6,54 @@ import (
 	"better-admin-backend-service/errors"
 	"better-admin-backend-service/helpers"
 	"better-admin-backend-service/rbac/domain"
+	"better-admin-backend-service/security"
 	"context"
+	"crypto/subtle"
 	pkgerrors "github.com/pkg/errors"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
+	"strconv"
+	"strings"
 	"time"
 )
 
+const (
+	recoveryCodeCount       = 10
+	temporaryPasswordLength = 16
+)
+
+// memberStatusTransitions 관리자가 변경할 수 있는 상태. 신청한 멤버의 승인(Approve)과
+// SCIM 프로비저닝에 의한 비활성화(Deactivate)/활성화(Activate)는 각각의 함수에서 처리한다.
+var memberStatusTransitions = map[string][]string{
+	constants.StatusMemberApplied:     {constants.StatusMemberRejected, constants.StatusMemberWithdrawn},
+	constants.StatusMemberApproved:    {constants.StatusMemberSuspended, constants.StatusMemberLocked, constants.StatusMemberWithdrawn, constants.StatusMemberExpired},
+	constants.StatusMemberSuspended:   {constants.StatusMemberApproved, constants.StatusMemberLocked, constants.StatusMemberWithdrawn, constants.StatusMemberExpired},
+	constants.StatusMemberLocked:      {constants.StatusMemberApproved, constants.StatusMemberSuspended, constants.StatusMemberWithdrawn, constants.StatusMemberExpired},
+	constants.StatusMemberExpired:     {constants.StatusMemberApproved, constants.StatusMemberSuspended, constants.StatusMemberLocked, constants.StatusMemberWithdrawn},
+	constants.StatusMemberDeactivated: {constants.StatusMemberWithdrawn},
+}
+
+var memberStatusNames = map[string]string{
+	constants.StatusMemberApplied:     constants.StatusMemberAppliedName,
+	constants.StatusMemberApproved:    constants.StatusMemberApprovedName,
+	constants.StatusMemberRejected:    constants.StatusMemberRejectedName,
+	constants.StatusMemberDeactivated: constants.StatusMemberDeactivatedName,
+	constants.StatusMemberSuspended:   constants.StatusMemberSuspendedName,
+	constants.StatusMemberLocked:      constants.StatusMemberLockedName,
+	constants.StatusMemberWithdrawn:   constants.StatusMemberWithdrawnName,
+	constants.StatusMemberExpired:     constants.StatusMemberExpiredName,
+}
+
+// providerManagedProfileFields 외부 인증 제공자가 원본(source of truth)인 프로필 항목.
+// 멤버가 변경할 수 없으며 로그인 할 때마다 제공자의 값으로 갱신한다.
+var providerManagedProfileFields = map[string][]string{
+	constants.TypeMemberDooray: {constants.ProfileFieldName},
+	constants.TypeMemberGoogle: {constants.ProfileFieldName, constants.ProfileFieldPicture},
+	constants.TypeMemberOidc:   {constants.ProfileFieldName, constants.ProfileFieldPicture},
+	constants.TypeMemberLdap:   {constants.ProfileFieldName},
+	constants.TypeMemberScim:   {constants.ProfileFieldName, constants.ProfileFieldPhoneNumber},
+}
+
 type MemberEntity struct {
 	gorm.Model
 	Type           string `gorm:"type:varchar(20);not null"`
@@ -24,10 +65,41 @@ type MemberEntity struct {
 	DoorayUserCode string `gorm:"type:varchar(50)"`
 	GoogleId       string `gorm:"type:varchar(50)"`
 	GoogleMail     string `gorm:"type:varchar(50)"`
-	Picture        string `gorm:"type:varchar(1000)"`
-	UpdatedBy      uint
-	LastAccessAt   *time.Time
-	Roles          []domain.RoleEntity `gorm:"many2many:member_roles;"`
+	OidcIssuer     string `gorm:"type:varchar(200)"`
+	OidcSubject    string `gorm:"type:varchar(255)"`
+	OidcMail       string `gorm:"type:varchar(100)"`
+	// IdP 가 메일 주소를 확인했는지 여부(email_verified 클레임)
+	OidcMailVerified bool
+	LdapUserId       string `gorm:"type:varchar(100)"`
+	LdapDn           string `gorm:"type:varchar(500)"`
+	LdapMail         string `gorm:"type:varchar(100)"`
+	LdapGroups       string `gorm:"type:text"` // 그룹 DN(줄바꿈 구분)
+	ScimUserName     string `gorm:"type:varchar(100)"`
+	ScimExternalId   string `gorm:"type:varchar(255)"`
+	Picture          string `gorm:"type:varchar(1000)"`
+	PhoneNumber      string `gorm:"type:varchar(20)"`
+	Email            string `gorm:"type:varchar(100)"`
+	// 이메일 인증(또는 초대 수락, 외부 인증)으로 확인한 시각. 이메일이 변경되면 초기화된다.
+	EmailVerifiedAt *time.Time
+	UpdatedBy       uint
+	LastAccessAt    *time.Time
+	TokenEpoch      uint `gorm:"not null;default:0"`
+	// 마지막으로 상태를 변경한 사유, 멤버, 시각
+	StatusReason    string `gorm:"type:varchar(500)"`
+	StatusChangedBy uint
+	StatusChangedAt *time.Time
+	// 역할/권한이 변경될 때마다 증가하며, 이전 버전으로 발급된 액세스 토큰은 거부된다.
+	PermissionVersion uint                `gorm:"not null;default:0"`
+	Roles             []domain.RoleEntity `gorm:"many2many:member_roles;"`
+	// 2단계 인증(TOTP)
+	TwoFactorEnabled       bool   `gorm:"not null;default:false"`
+	TwoFactorSecret        string `gorm:"type:varchar(100)"`
+	TwoFactorLastUsedStep  int64  `gorm:"not null;default:0"`
+	TwoFactorRecoveryCodes string `gorm:"type:text"` // 복구 코드의 해시 값(콤마 구분)
+	// 비밀번호 정책
+	PasswordChangedAt      *time.Time
+	PasswordChangeRequired bool   `gorm:"not null;default:false"`
+	PasswordHistory        string `gorm:"type:text"` // 이전 비밀번호의 해시 값(콤마 구분, 최근 순)
 }
 
 func (MemberEntity) TableName() string {
@@ -42,13 +114,11 @@ func (m MemberEntity) ValidatePassword(password string) error {
 	return nil
 }
 
-func (m MemberEntity) hashAndSalt(pwd string) (string, error) {
+func (m MemberEntity) hashAndSalt(pwd string, cost int) (string, error) {
 	// Use GenerateFromPassword to hash & salt pwd.
-	// MinCost is just an integer constant provided by the bcrypt
-	// package along with DefaultCost & MaxCost.
 	// The cost can be any value you want provided it isn't lower
 	// than the MinCost (4)
-	hash, err := bcrypt.GenerateFromPassword([]byte(pwd), bcrypt.MinCost)
+	hash, err := bcrypt.GenerateFromPassword([]byte(pwd), cost)
 	if err != nil {
 		return "", err
 	}
@@ -69,6 +139,169 @@ func (m MemberEntity) comparePasswords(hashedPwd string, plainPwd string) bool {
 	return true
 }
 
+// ChangePassword 현재 비밀번호를 확인한 뒤 비밀번호 정책을 만족하는 새 비밀번호로 변경한다.
+func (m *MemberEntity) ChangePassword(currentPassword, newPassword string, policy dtos.PasswordPolicySetting) error {
+	if m.Type != constants.TypeMemberSite {
+		return errors.ErrNotSupportedPassword
+	}
+
+	if m.ValidatePassword(currentPassword) != nil {
+		return errors.ErrAuthentication
+	}
+
+	return m.changePassword(newPassword, policy)
+}
+
+// ChangeRequiredPassword 로그인 과정에서 비밀번호를 변경해야 하는 멤버의 비밀번호를 변경한다(현재 비밀번호는 로그인 할 때 확인한다).
+func (m *MemberEntity) ChangeRequiredPassword(newPassword string, policy dtos.PasswordPolicySetting) error {
+	if m.Type != constants.TypeMemberSite {
+		return errors.ErrNotSupportedPassword
+	}
+
+	return m.changePassword(newPassword, policy)
+}
+
+func (m *MemberEntity) changePassword(newPassword string, policy dtos.PasswordPolicySetting) error {
+	if violations := policy.Validate(newPassword); len(violations) > 0 {
+		return &errors.ErrPasswordPolicyViolation{Violations: violations}
+	}
+
+	if m.isPasswordReused(newPassword, policy.HistoryCount) {
+		return errors.ErrPasswordReused
+	}
+
+	return m.setPassword(newPassword, policy)
+}
+
+func (m MemberEntity) isPasswordReused(password string, historyCount int) bool {
+	if historyCount <= 0 {
+		return false
+	}
+
+	hashes := append([]string{m.Password}, m.getPasswordHistory()...)
+	if len(hashes) > historyCount {
+		hashes = hashes[:historyCount]
+	}
+
+	for _, hash := range hashes {
+		if len(hash) > 0 && m.comparePasswords(hash, password) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m MemberEntity) getPasswordHistory() []string {
+	if len(m.PasswordHistory) == 0 {
+		return []string{}
+	}
+	return strings.Split(m.PasswordHistory, ",")
+}
+
+func (m *MemberEntity) setPassword(password string, policy dtos.PasswordPolicySetting) error {
+	hashedPassword, err := m.hashAndSalt(password, policy.GetHashCost())
+	if err != nil {
+		return err
+	}
+
+	// 현재 비밀번호는 별도로 비교하므로 이전 비밀번호는 HistoryCount - 1 개 까지만 보관한다.
+	history := []string{}
+	if policy.HistoryCount > 1 && len(m.Password) > 0 {
+		history = append([]string{m.Password}, m.getPasswordHistory()...)
+		if len(history) > policy.HistoryCount-1 {
+			history = history[:policy.HistoryCount-1]
+		}
+	}
+
+	now := time.Now()
+	m.Password = hashedPassword
+	m.PasswordHistory = strings.Join(history, ",")
+	m.PasswordChangedAt = &now
+	m.PasswordChangeRequired = false
+
+	return nil
+}
+
+// ResetPassword 임시 비밀번호로 초기화하고 다음 로그인 할 때 비밀번호를 변경하도록 한다.
+func (m *MemberEntity) ResetPassword(ctx context.Context, policy dtos.PasswordPolicySetting) (string, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if m.Type != constants.TypeMemberSite {
+		return "", errors.ErrNotSupportedPassword
+	}
+
+	length := policy.MinLength
+	if length < temporaryPasswordLength {
+		length = temporaryPasswordLength
+	}
+
+	temporaryPassword, err := security.GenerateTemporaryPassword(length)
+	if err != nil {
+		return "", err
+	}
+
+	if err := m.setPassword(temporaryPassword, policy); err != nil {
+		return "", err
+	}
+
+	m.PasswordChangeRequired = true
+	m.UpdatedBy = userClaim.Id
+
+	// 초기화 전의 비밀번호로 발급된 모든 토큰을 무효화한다.
+	if err := m.RevokeAllTokens(ctx); err != nil {
+		return "", err
+	}
+
+	return temporaryPassword, nil
+}
+
+// IsPasswordChangeRequired 관리자가 비밀번호를 초기화했거나 비밀번호 사용 기간이 지난 경우 비밀번호를 변경해야 한다.
+func (m MemberEntity) IsPasswordChangeRequired(policy dtos.PasswordPolicySetting) bool {
+	if m.Type != constants.TypeMemberSite {
+		return false
+	}
+
+	if m.PasswordChangeRequired {
+		return true
+	}
+
+	if policy.MaxAgeDays <= 0 {
+		return false
+	}
+
+	// 비밀번호 정책 이전에 가입한 멤버는 가입일을 기준으로 한다.
+	changedAt := m.CreatedAt
+	if m.PasswordChangedAt != nil {
+		changedAt = *m.PasswordChangedAt
+	}
+
+	return time.Now().After(changedAt.AddDate(0, 0, policy.MaxAgeDays))
+}
+
+// UpgradePasswordHash 비밀번호가 정책보다 낮은 bcrypt 비용으로 저장된 경우 다시 해시한다. 다시 해시한 경우 true 를 반환한다.
+func (m *MemberEntity) UpgradePasswordHash(password string, cost int) (bool, error) {
+	currentCost, err := bcrypt.Cost([]byte(m.Password))
+	if err != nil || currentCost >= cost {
+		return false, nil
+	}
+
+	if m.ValidatePassword(password) != nil {
+		return false, errors.ErrAuthentication
+	}
+
+	hashedPassword, err := m.hashAndSalt(password, cost)
+	if err != nil {
+		return false, err
+	}
+	m.Password = hashedPassword
+
+	return true, nil
+}
+
 func (m MemberEntity) GetTypeName() string {
 	if m.Type == constants.TypeMemberSite {
 		return constants.TypeMemberSiteName
@@ -82,6 +315,18 @@ func (m MemberEntity) GetTypeName() string {
 		return constants.TypeMemberGoogleName
 	}
 
+	if m.Type == constants.TypeMemberOidc {
+		return constants.TypeMemberOidcName
+	}
+
+	if m.Type == constants.TypeMemberLdap {
+		return constants.TypeMemberLdapName
+	}
+
+	if m.Type == constants.TypeMemberScim {
+		return constants.TypeMemberScimName
+	}
+
 	return ""
 }
 
@@ -93,6 +338,7 @@ func (m *MemberEntity) AssignRole(ctx context.Context, roleEntities []domain.Rol
 
 	// 기존 역할을 덮어쓰기
 	m.Roles = roleEntities
+	m.PermissionVersion = m.PermissionVersion + 1
 	m.UpdatedBy = userClaim.Id
 
 	return nil
@@ -137,17 +383,15 @@ func (m MemberEntity) GetPermissionNames() []string {
 }
 
 func (m *MemberEntity) Approve(ctx context.Context) error {
-	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
-	if err != nil {
-		return err
-	}
-
 	if m.Status == constants.StatusMemberApproved {
 		return errors.ErrAlreadyApproved
 	}
-	m.Status = constants.StatusMemberApproved
-	m.UpdatedBy = userClaim.Id
-	return nil
+
+	if m.Status != constants.StatusMemberApplied {
+		return errors.ErrInvalidStatusTransition
+	}
+
+	return m.setStatus(ctx, constants.StatusMemberApproved, "")
 }
 
 func (m MemberEntity) IsApproved() bool {
@@ -157,6 +401,86 @@ func (m MemberEntity) IsApproved() bool {
 	return false
 }
 
+func (m MemberEntity) IsDeactivated() bool {
+	return m.Status == constants.StatusMemberDeactivated
+}
+
+// ValidateLoginStatus 승인된 멤버만 로그인할 수 있다.
+func (m MemberEntity) ValidateLoginStatus() error {
+	if m.IsApproved() {
+		return nil
+	}
+
+	if m.Status == constants.StatusMemberApplied {
+		return errors.ErrUnApproved
+	}
+
+	return &errors.ErrInactiveMember{Status: m.Status}
+}
+
+func (m MemberEntity) GetStatusName() string {
+	return memberStatusNames[m.Status]
+}
+
+// ChangeStatus 가입 거부, 정지, 잠금, 탈퇴, 만료, 재활성화(승인). 로그인할 수 없는 상태가 되면 발급된 모든 토큰도 무효화한다.
+func (m *MemberEntity) ChangeStatus(ctx context.Context, status, reason string) error {
+	changeable := false
+	for _, nextStatus := range memberStatusTransitions[m.Status] {
+		if nextStatus == status {
+			changeable = true
+			break
+		}
+	}
+	if changeable == false {
+		return errors.ErrInvalidStatusTransition
+	}
+
+	if status != constants.StatusMemberApproved {
+		if err := m.RevokeAllTokens(ctx); err != nil {
+			return err
+		}
+	}
+
+	return m.setStatus(ctx, status, reason)
+}
+
+// Deactivate 프로비저닝이 해제된 멤버는 삭제하지 않고 로그인과 발급된 모든 토큰을 막는다.
+func (m *MemberEntity) Deactivate(ctx context.Context) error {
+	if m.IsDeactivated() || m.Status == constants.StatusMemberWithdrawn {
+		return nil
+	}
+
+	if err := m.RevokeAllTokens(ctx); err != nil {
+		return err
+	}
+
+	return m.setStatus(ctx, constants.StatusMemberDeactivated, "")
+}
+
+// Activate 프로비저닝으로 비활성화된 멤버만 다시 활성화한다. 관리자가 정지하거나 잠근 멤버는 관리자가 재활성화해야 한다.
+func (m *MemberEntity) Activate(ctx context.Context) error {
+	if m.IsDeactivated() == false {
+		return nil
+	}
+
+	return m.setStatus(ctx, constants.StatusMemberApproved, "")
+}
+
+func (m *MemberEntity) setStatus(ctx context.Context, status, reason string) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	m.Status = status
+	m.StatusReason = reason
+	m.StatusChangedBy = userClaim.Id
+	m.StatusChangedAt = &now
+	m.UpdatedBy = userClaim.Id
+	return nil
+}
+
 func (m MemberEntity) GetCandidateId() string {
 	if m.Type == constants.TypeMemberSite {
 		return m.SignId
@@ -164,50 +488,489 @@ func (m MemberEntity) GetCandidateId() string {
 		return m.DoorayUserCode
 	} else if m.Type == constants.TypeMemberGoogle {
 		return m.GoogleMail
+	} else if m.Type == constants.TypeMemberOidc {
+		if len(m.OidcMail) > 0 {
+			return m.OidcMail
+		}
+		return m.OidcSubject
+	} else if m.Type == constants.TypeMemberLdap {
+		return m.LdapUserId
+	} else if m.Type == constants.TypeMemberScim {
+		return m.ScimUserName
 	} else {
 		return ""
 	}
 }
 
+func (m *MemberEntity) RevokeAllTokens(ctx context.Context) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	// 토큰 에포크를 증가시키면 이전 에포크로 발급된 모든 토큰은 유효하지 않게 된다.
+	m.TokenEpoch = m.TokenEpoch + 1
+	m.UpdatedBy = userClaim.Id
+
+	return nil
+}
+
+func (m MemberEntity) IsTokenEpochExpired(tokenEpoch uint) bool {
+	return tokenEpoch < m.TokenEpoch
+}
+
+func (m MemberEntity) IsPermissionVersionExpired(permissionVersion uint) bool {
+	return permissionVersion < m.PermissionVersion
+}
+
+func (m *MemberEntity) StartTwoFactorEnrollment() (string, error) {
+	if m.Type != constants.TypeMemberSite {
+		return "", errors.ErrNotSupportedTwoFactor
+	}
+
+	if m.TwoFactorEnabled {
+		return "", errors.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := security.GenerateTotpSecret()
+	if err != nil {
+		return "", err
+	}
+
+	// 활성화 되기 전까지는 비밀 키만 저장해 둔다.
+	m.TwoFactorSecret = secret
+	m.TwoFactorLastUsedStep = 0
+	return secret, nil
+}
+
+func (m *MemberEntity) ActivateTwoFactor(code string) ([]string, error) {
+	if m.TwoFactorEnabled {
+		return nil, errors.ErrTwoFactorAlreadyEnabled
+	}
+
+	if len(m.TwoFactorSecret) == 0 {
+		return nil, errors.ErrTwoFactorNotEnabled
+	}
+
+	step, valid := security.ValidateTotpCode(m.TwoFactorSecret, code, time.Now(), m.TwoFactorLastUsedStep)
+	if !valid {
+		return nil, errors.ErrInvalidTwoFactorCode
+	}
+
+	recoveryCodes, err := security.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	hashedRecoveryCodes := make([]string, 0, len(recoveryCodes))
+	for _, recoveryCode := range recoveryCodes {
+		hashedRecoveryCodes = append(hashedRecoveryCodes, security.HashToken(recoveryCode))
+	}
+
+	m.TwoFactorEnabled = true
+	m.TwoFactorLastUsedStep = step
+	m.TwoFactorRecoveryCodes = strings.Join(hashedRecoveryCodes, ",")
+	return recoveryCodes, nil
+}
+
+// VerifyTwoFactorCode 인증 앱의 코드 또는 복구 코드를 검증한다. 사용된 코드는 다시 사용할 수 없다.
+func (m *MemberEntity) VerifyTwoFactorCode(code string) error {
+	if !m.TwoFactorEnabled {
+		return errors.ErrTwoFactorNotEnabled
+	}
+
+	if step, valid := security.ValidateTotpCode(m.TwoFactorSecret, code, time.Now(), m.TwoFactorLastUsedStep); valid {
+		m.TwoFactorLastUsedStep = step
+		return nil
+	}
+
+	hashedCode := security.HashToken(strings.ToLower(strings.TrimSpace(code)))
+	hashedRecoveryCodes := strings.Split(m.TwoFactorRecoveryCodes, ",")
+	for i, hashedRecoveryCode := range hashedRecoveryCodes {
+		if subtle.ConstantTimeCompare([]byte(hashedRecoveryCode), []byte(hashedCode)) == 1 {
+			m.TwoFactorRecoveryCodes = strings.Join(append(hashedRecoveryCodes[:i], hashedRecoveryCodes[i+1:]...), ",")
+			return nil
+		}
+	}
+
+	return errors.ErrInvalidTwoFactorCode
+}
+
+func (m *MemberEntity) DeactivateTwoFactor(code string) error {
+	if err := m.VerifyTwoFactorCode(code); err != nil {
+		return err
+	}
+
+	m.TwoFactorEnabled = false
+	m.TwoFactorSecret = ""
+	m.TwoFactorLastUsedStep = 0
+	m.TwoFactorRecoveryCodes = ""
+	return nil
+}
+
+func (m MemberEntity) GetLdapGroups() []string {
+	if len(m.LdapGroups) == 0 {
+		return []string{}
+	}
+	return strings.Split(m.LdapGroups, "\n")
+}
+
+// UpdateFromLdapMember 이름, 메일, 그룹은 디렉터리가 원본이므로 로그인 할 때마다 디렉터리의 값으로 갱신한다.
+// 연결된 로그인으로 로그인한 경우에는 멤버가 처음 가입한 로그인이 원본이므로 갱신하지 않는다.
+func (m *MemberEntity) UpdateFromLdapMember(ldapMember dtos.LdapMember) {
+	if m.Type != constants.TypeMemberLdap || m.LdapUserId != ldapMember.UserId {
+		return
+	}
+	m.LdapDn = ldapMember.Dn
+	m.LdapMail = ldapMember.Email
+	m.LdapGroups = strings.Join(ldapMember.Groups, "\n")
+	if len(ldapMember.Name) > 0 {
+		m.Name = ldapMember.Name
+	}
+}
+
+// UpdateFromDoorayMember 이름은 두레이가 원본이므로 로그인 할 때마다 두레이의 값으로 갱신한다.
+func (m *MemberEntity) UpdateFromDoorayMember(doorayMember dtos.DoorayMember) {
+	if m.Type != constants.TypeMemberDooray || m.DoorayId != doorayMember.Id {
+		return
+	}
+	if len(doorayMember.Name) > 0 {
+		m.Name = doorayMember.Name
+	}
+}
+
+// UpdateFromGoogleMember 이름, 사진은 구글 워크스페이스가 원본이므로 로그인 할 때마다 구글의 값으로 갱신한다.
+func (m *MemberEntity) UpdateFromGoogleMember(googleMember dtos.GoogleMember) {
+	if m.Type != constants.TypeMemberGoogle || m.GoogleId != googleMember.Id {
+		return
+	}
+	if len(googleMember.Name) > 0 {
+		m.Name = googleMember.Name
+	}
+	m.Picture = googleMember.Picture
+}
+
+// UpdateFromOidcMember 이름, 사진은 IdP 가 원본이므로 로그인 할 때마다 IdP 의 값으로 갱신한다.
+func (m *MemberEntity) UpdateFromOidcMember(oidcMember dtos.OidcMember) {
+	if m.Type != constants.TypeMemberOidc || m.OidcIssuer != oidcMember.Issuer || m.OidcSubject != oidcMember.Subject {
+		return
+	}
+	if len(oidcMember.Name) > 0 {
+		m.Name = oidcMember.Name
+	}
+	m.Picture = oidcMember.Picture
+	m.OidcMail = oidcMember.Email
+	m.OidcMailVerified = oidcMember.EmailVerified
+}
+
+func (m MemberEntity) GetProviderManagedProfileFields() []string {
+	if fields, ok := providerManagedProfileFields[m.Type]; ok {
+		return fields
+	}
+	return []string{}
+}
+
+func (m MemberEntity) isProviderManagedProfileField(field string) bool {
+	for _, f := range m.GetProviderManagedProfileFields() {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// UpdateProfile 외부 인증 제공자가 원본인 항목은 현재 값과 같은 경우에만 허용한다.
+func (m *MemberEntity) UpdateProfile(ctx context.Context, profileUpdate dtos.MemberProfileUpdate) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	changes := map[string]bool{
+		constants.ProfileFieldName:        m.Name != profileUpdate.Name,
+		constants.ProfileFieldPhoneNumber: m.PhoneNumber != profileUpdate.PhoneNumber,
+		constants.ProfileFieldPicture:     m.Picture != profileUpdate.Picture,
+	}
+
+	managedFields := make([]string, 0)
+	for _, field := range []string{constants.ProfileFieldName, constants.ProfileFieldPhoneNumber, constants.ProfileFieldPicture} {
+		if changes[field] && m.isProviderManagedProfileField(field) {
+			managedFields = append(managedFields, field)
+		}
+	}
+	if len(managedFields) > 0 {
+		return &errors.ErrProfileManagedByProvider{Fields: managedFields}
+	}
+
+	m.Name = profileUpdate.Name
+	m.PhoneNumber = profileUpdate.PhoneNumber
+	m.Picture = profileUpdate.Picture
+	m.UpdatedBy = userClaim.Id
+
+	return nil
+}
+
 func (m *MemberEntity) UpdateLastAccessAt() {
 	now := time.Now()
 	m.LastAccessAt = &now
 }
 
-func NewMemberEntityFromSignUp(signUp dtos.MemberSignUp) (MemberEntity, error) {
-	hashedPassword, err := MemberEntity{}.hashAndSalt(signUp.Password)
-	if err != nil {
+func (m MemberEntity) IsEmailVerified() bool {
+	return len(m.Email) > 0 && m.EmailVerifiedAt != nil
+}
+
+// ChangeEmail 다른 이메일로 변경하면 다시 인증해야 한다.
+func (m *MemberEntity) ChangeEmail(email string) {
+	if m.Email == email {
+		return
+	}
+
+	m.Email = email
+	m.EmailVerifiedAt = nil
+}
+
+// VerifyEmail 인증 메일을 보낸 뒤 이메일이 변경되었으면 인증할 수 없다.
+func (m *MemberEntity) VerifyEmail(email string) error {
+	if len(m.Email) == 0 || m.Email != email {
+		return errors.ErrInvalidEmailVerification
+	}
+
+	if m.EmailVerifiedAt == nil {
+		now := time.Now()
+		m.EmailVerifiedAt = &now
+	}
+
+	return nil
+}
+
+// AssignDefaultRoles 처음 가입(로그인)한 멤버에게 설정에 지정된 기본 역할을 할당한다.
+func (m *MemberEntity) AssignDefaultRoles(roleEntities []domain.RoleEntity) {
+	m.Roles = roleEntities
+}
+
+// AddRoles 자동 할당 규칙처럼 기존 역할은 유지하고 없는 역할만 추가한다.
+func (m *MemberEntity) AddRoles(roleEntities []domain.RoleEntity) {
+	added := false
+	for _, roleEntity := range roleEntities {
+		if m.hasRole(roleEntity.ID) == false {
+			m.Roles = append(m.Roles, roleEntity)
+			added = true
+		}
+	}
+
+	if added {
+		m.PermissionVersion = m.PermissionVersion + 1
+	}
+}
+
+func (m MemberEntity) hasRole(roleId uint) bool {
+	for _, role := range m.Roles {
+		if role.ID == roleId {
+			return true
+		}
+	}
+	return false
+}
+
+// GetVerifiedEmails 인증한 이메일과 외부 인증 제공자가 확인한 이메일 주소(중복 제외)
+// 구글 워크스페이스, LDAP 의 메일은 관리자가 관리하는 주소이므로 확인된 주소로 본다.
+func (m MemberEntity) GetVerifiedEmails() []string {
+	candidates := []string{m.GoogleMail, m.LdapMail}
+	if m.IsEmailVerified() {
+		candidates = append(candidates, m.Email)
+	}
+	if m.OidcMailVerified {
+		candidates = append(candidates, m.OidcMail)
+	}
+
+	emails := make([]string, 0)
+	for _, email := range candidates {
+		if len(email) == 0 {
+			continue
+		}
+
+		duplicated := false
+		for _, e := range emails {
+			if e == email {
+				duplicated = true
+				break
+			}
+		}
+		if duplicated == false {
+			emails = append(emails, email)
+		}
+	}
+	return emails
+}
+
+// AcceptInvitation 초대 메일의 링크로 가입했으므로 이메일은 인증된 것으로 보고, 초대할 때 지정한 역할로 승인한다.
+func (m *MemberEntity) AcceptInvitation(email string, roleEntities []domain.RoleEntity) {
+	now := time.Now()
+	m.Email = email
+	m.EmailVerifiedAt = &now
+	m.Status = constants.StatusMemberApproved
+	m.Roles = roleEntities
+}
+
+func NewMemberEntityFromSignUp(signUp dtos.MemberSignUp, policy dtos.PasswordPolicySetting) (MemberEntity, error) {
+	if violations := policy.Validate(signUp.Password); len(violations) > 0 {
+		return MemberEntity{}, &errors.ErrPasswordPolicyViolation{Violations: violations}
+	}
+
+	memberEntity := MemberEntity{
+		Type:   constants.TypeMemberSite,
+		SignId: signUp.SignId,
+		Name:   signUp.Name,
+		Email:  signUp.Email,
+		Status: constants.StatusMemberApplied,
+	}
+	if err := memberEntity.setPassword(signUp.Password, policy); err != nil {
 		return MemberEntity{}, err
 	}
 
-	return MemberEntity{
-		Type:     constants.TypeMemberSite,
-		SignId:   signUp.SignId,
-		Name:     signUp.Name,
-		Password: hashedPassword,
-		Status:   constants.StatusMemberApplied,
-	}, nil
+	return memberEntity, nil
 }
 
 func NewMemberEntityFromDoorayMember(doorayMember dtos.DoorayMember) MemberEntity {
 	// 두레이 사용자의 경우 이미 두레이를 통해 인증된 사용자 이기 때문에 상태를 '승인' 설정
-	return MemberEntity{
+	memberEntity := MemberEntity{
 		Type:           constants.TypeMemberDooray,
 		DoorayId:       doorayMember.Id,
 		DoorayUserCode: doorayMember.UserCode,
 		Name:           doorayMember.Name,
 		Status:         constants.StatusMemberApproved,
 	}
+
+	// 두레이에 등록된 외부 이메일은 두레이에서 확인된 주소로 본다.
+	if len(doorayMember.ExternalEmailAddress) > 0 {
+		now := time.Now()
+		memberEntity.Email = doorayMember.ExternalEmailAddress
+		memberEntity.EmailVerifiedAt = &now
+	}
+
+	return memberEntity
 }
 
 func NewMemberEntityFromGoogleMember(googleMember dtos.GoogleMember) MemberEntity {
 	// 구글 워크스페이스 사용자의 경우 이미 구글 워크스페이스를 통해 인증된 사용자 이기 때문에 상태를 '승인' 설정
+	now := time.Now()
 	return MemberEntity{
-		Type:       constants.TypeMemberGoogle,
-		GoogleId:   googleMember.Id,
-		GoogleMail: googleMember.Email,
-		Name:       googleMember.Name,
-		Picture:    googleMember.Picture,
-		Status:     constants.StatusMemberApproved,
+		Type:            constants.TypeMemberGoogle,
+		GoogleId:        googleMember.Id,
+		GoogleMail:      googleMember.Email,
+		Name:            googleMember.Name,
+		Picture:         googleMember.Picture,
+		Email:           googleMember.Email,
+		EmailVerifiedAt: &now,
+		Status:          constants.StatusMemberApproved,
+	}
+}
+
+func NewMemberEntityFromOidcMember(oidcMember dtos.OidcMember, autoApproval bool) MemberEntity {
+	// IdP 로 인증은 되었지만 IdP 가 조직 외부 사용자에게도 열려 있을 수 있으므로 자동 승인 설정을 따른다.
+	status := constants.StatusMemberApplied
+	if autoApproval {
+		status = constants.StatusMemberApproved
+	}
+
+	return MemberEntity{
+		Type:             constants.TypeMemberOidc,
+		OidcIssuer:       oidcMember.Issuer,
+		OidcSubject:      oidcMember.Subject,
+		OidcMail:         oidcMember.Email,
+		OidcMailVerified: oidcMember.EmailVerified,
+		Name:             oidcMember.Name,
+		Picture:          oidcMember.Picture,
+		Status:           status,
+	}
+}
+
+// NewMemberEntityFromScimUser 인사 시스템, IdP 가 프로비저닝한 멤버는 이미 확인된 사용자이므로 상태를 '승인' 설정
+func NewMemberEntityFromScimUser(scimUser dtos.ScimUser) MemberEntity {
+	status := constants.StatusMemberApproved
+	if scimUser.IsActive() == false {
+		status = constants.StatusMemberDeactivated
+	}
+
+	memberEntity := MemberEntity{
+		Type:   constants.TypeMemberScim,
+		Status: status,
+	}
+	memberEntity.updateFromScimUser(scimUser)
+
+	return memberEntity
+}
+
+// UpdateFromScimUser 이름, 메일, 전화번호는 인사 시스템(IdP)이 원본이므로 프로비저닝 할 때마다 갱신한다.
+func (m *MemberEntity) UpdateFromScimUser(ctx context.Context, scimUser dtos.ScimUser) error {
+	if scimUser.IsActive() {
+		if err := m.Activate(ctx); err != nil {
+			return err
+		}
+	} else {
+		if err := m.Deactivate(ctx); err != nil {
+			return err
+		}
 	}
+
+	m.updateFromScimUser(scimUser)
+	return nil
+}
+
+// GetScimUser SCIM 사용자 리소스로 변환한다.(meta 는 요청한 주소에 따라 달라지므로 포함하지 않는다.)
+func (m MemberEntity) GetScimUser() dtos.ScimUser {
+	active := m.IsDeactivated() == false
+	scimUser := dtos.ScimUser{
+		Schemas:     []string{constants.ScimSchemaUser},
+		Id:          strconv.FormatUint(uint64(m.ID), 10),
+		ExternalId:  m.ScimExternalId,
+		UserName:    m.ScimUserName,
+		Name:        &dtos.ScimName{Formatted: m.Name},
+		DisplayName: m.Name,
+		Active:      &active,
+	}
+
+	if len(m.Email) > 0 {
+		scimUser.Emails = []dtos.ScimMultiValuedAttribute{{Value: m.Email, Type: "work", Primary: true}}
+	}
+
+	if len(m.PhoneNumber) > 0 {
+		scimUser.PhoneNumbers = []dtos.ScimMultiValuedAttribute{{Value: m.PhoneNumber, Type: "work", Primary: true}}
+	}
+
+	return scimUser
+}
+
+func (m *MemberEntity) updateFromScimUser(scimUser dtos.ScimUser) {
+	m.ScimUserName = scimUser.UserName
+	m.ScimExternalId = scimUser.ExternalId
+	m.Name = scimUser.GetName()
+	m.PhoneNumber = scimUser.GetPhoneNumber()
+
+	// 인사 시스템(IdP)에 등록된 메일은 확인된 주소로 본다.
+	if email := scimUser.GetEmail(); email != m.Email {
+		m.Email = email
+		m.EmailVerifiedAt = nil
+		if len(email) > 0 {
+			now := time.Now()
+			m.EmailVerifiedAt = &now
+		}
+	}
+}
+
+func NewMemberEntityFromLdapMember(ldapMember dtos.LdapMember, autoApproval bool) MemberEntity {
+	status := constants.StatusMemberApplied
+	if autoApproval {
+		status = constants.StatusMemberApproved
+	}
+
+	memberEntity := MemberEntity{
+		Type:       constants.TypeMemberLdap,
+		LdapUserId: ldapMember.UserId,
+		Name:       ldapMember.UserId,
+		Status:     status,
+	}
+	memberEntity.UpdateFromLdapMember(ldapMember)
+
+	return memberEntity
 }
@@ -1,8 +1,15 @@
 package domain
 
 import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
 	"better-admin-backend-service/rbac/domain"
+	"better-admin-backend-service/security"
+	"context"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 	"testing"
 	"time"
@@ -59,3 +66,292 @@ func TestMemberEntity_GetPermissionNames(t *testing.T) {
 	// then
 	assert.Equal(t, []string{"권한1", "권한2", "권한3"}, permissionNames)
 }
+
+func TestMemberEntity_TwoFactor(t *testing.T) {
+	// given
+	entity := MemberEntity{
+		Model:  gorm.Model{ID: 1},
+		Type:   "site",
+		SignId: "ymyoo",
+	}
+	secret, err := entity.StartTwoFactorEnrollment()
+	assert.NoError(t, err)
+	assert.False(t, entity.TwoFactorEnabled)
+
+	code, err := security.GenerateTotpCode(secret, time.Now())
+	assert.NoError(t, err)
+
+	// when
+	recoveryCodes, err := entity.ActivateTwoFactor(code)
+
+	// then
+	assert.NoError(t, err)
+	assert.True(t, entity.TwoFactorEnabled)
+	assert.Equal(t, 10, len(recoveryCodes))
+
+	// 활성화에 사용한 코드는 다시 사용할 수 없다.
+	assert.Equal(t, errors.ErrInvalidTwoFactorCode, entity.VerifyTwoFactorCode(code))
+
+	// 복구 코드는 한 번만 사용할 수 있다.
+	assert.NoError(t, entity.VerifyTwoFactorCode(recoveryCodes[0]))
+	assert.Equal(t, errors.ErrInvalidTwoFactorCode, entity.VerifyTwoFactorCode(recoveryCodes[0]))
+
+	assert.NoError(t, entity.DeactivateTwoFactor(recoveryCodes[1]))
+	assert.False(t, entity.TwoFactorEnabled)
+	assert.Empty(t, entity.TwoFactorSecret)
+}
+
+func TestMemberEntity_StartTwoFactorEnrollment_사이트_멤버가_아닌_경우(t *testing.T) {
+	// given
+	entity := MemberEntity{
+		Model: gorm.Model{ID: 2},
+		Type:  "dooray",
+	}
+
+	// when
+	_, err := entity.StartTwoFactorEnrollment()
+
+	// then
+	assert.Equal(t, errors.ErrNotSupportedTwoFactor, err)
+}
+
+func TestMemberEntity_ChangePassword(t *testing.T) {
+	// given
+	entity, err := NewMemberEntityFromSignUp(dtos.MemberSignUp{SignId: "ymyoo", Name: "유영모", Password: "Password1!"},
+		dtos.PasswordPolicySetting{HashCost: bcrypt.MinCost})
+	assert.NoError(t, err)
+
+	policy := dtos.PasswordPolicySetting{
+		MinLength:        8,
+		RequireUppercase: true,
+		RequireLowercase: true,
+		RequireDigit:     true,
+		RequireSpecial:   true,
+		HistoryCount:     3,
+		HashCost:         bcrypt.MinCost,
+	}
+
+	// when
+	err = entity.ChangePassword("Password1!", "Password2!", policy)
+
+	// then
+	assert.NoError(t, err)
+	assert.NoError(t, entity.ValidatePassword("Password2!"))
+	assert.NotNil(t, entity.PasswordChangedAt)
+
+	assert.Equal(t, errors.ErrAuthentication, entity.ChangePassword("Password1!", "Password3!", policy))
+
+	// 최근 3개의 비밀번호는 다시 사용할 수 없다.
+	assert.Equal(t, errors.ErrPasswordReused, entity.ChangePassword("Password2!", "Password2!", policy))
+	assert.Equal(t, errors.ErrPasswordReused, entity.ChangePassword("Password2!", "Password1!", policy))
+	assert.NoError(t, entity.ChangePassword("Password2!", "Password3!", policy))
+	assert.NoError(t, entity.ChangePassword("Password3!", "Password4!", policy))
+	assert.NoError(t, entity.ChangePassword("Password4!", "Password1!", policy))
+}
+
+func TestMemberEntity_ChangePassword_비밀번호_정책을_만족하지_않는_경우(t *testing.T) {
+	// given
+	entity, err := NewMemberEntityFromSignUp(dtos.MemberSignUp{SignId: "ymyoo", Name: "유영모", Password: "1111"},
+		dtos.PasswordPolicySetting{HashCost: bcrypt.MinCost})
+	assert.NoError(t, err)
+
+	policy := dtos.PasswordPolicySetting{
+		MinLength:        8,
+		RequireUppercase: true,
+		RequireDigit:     true,
+		RequireSpecial:   true,
+		HashCost:         bcrypt.MinCost,
+	}
+
+	// when
+	err = entity.ChangePassword("1111", "password", policy)
+
+	// then
+	violation, ok := err.(*errors.ErrPasswordPolicyViolation)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"uppercase", "digit", "special"}, violation.Violations)
+}
+
+func TestMemberEntity_ChangePassword_사이트_멤버가_아닌_경우(t *testing.T) {
+	// given
+	entity := MemberEntity{
+		Model: gorm.Model{ID: 2},
+		Type:  "dooray",
+	}
+
+	// when
+	err := entity.ChangePassword("", "Password1!", dtos.PasswordPolicySetting{})
+
+	// then
+	assert.Equal(t, errors.ErrNotSupportedPassword, err)
+}
+
+func TestMemberEntity_IsPasswordChangeRequired(t *testing.T) {
+	// given
+	changedAt := time.Now().AddDate(0, 0, -31)
+	entity := MemberEntity{
+		Model:             gorm.Model{ID: 1, CreatedAt: time.Now().AddDate(-1, 0, 0)},
+		Type:              "site",
+		PasswordChangedAt: &changedAt,
+	}
+
+	// when
+	// then
+	assert.False(t, entity.IsPasswordChangeRequired(dtos.PasswordPolicySetting{}))
+	assert.False(t, entity.IsPasswordChangeRequired(dtos.PasswordPolicySetting{MaxAgeDays: 90}))
+	assert.True(t, entity.IsPasswordChangeRequired(dtos.PasswordPolicySetting{MaxAgeDays: 30}))
+
+	// 비밀번호를 변경한 적이 없는 경우 가입일을 기준으로 한다.
+	entity.PasswordChangedAt = nil
+	assert.True(t, entity.IsPasswordChangeRequired(dtos.PasswordPolicySetting{MaxAgeDays: 90}))
+
+	entity.PasswordChangedAt = &changedAt
+	entity.PasswordChangeRequired = true
+	assert.True(t, entity.IsPasswordChangeRequired(dtos.PasswordPolicySetting{}))
+}
+
+func TestMemberEntity_UpgradePasswordHash(t *testing.T) {
+	// given
+	entity, err := NewMemberEntityFromSignUp(dtos.MemberSignUp{SignId: "ymyoo", Name: "유영모", Password: "1111"},
+		dtos.PasswordPolicySetting{HashCost: bcrypt.MinCost})
+	assert.NoError(t, err)
+
+	// when
+	upgraded, err := entity.UpgradePasswordHash("1111", bcrypt.MinCost+1)
+
+	// then
+	assert.NoError(t, err)
+	assert.True(t, upgraded)
+	cost, _ := bcrypt.Cost([]byte(entity.Password))
+	assert.Equal(t, bcrypt.MinCost+1, cost)
+	assert.NoError(t, entity.ValidatePassword("1111"))
+
+	// 이미 정책의 비용 이상으로 저장된 경우 다시 해시하지 않는다.
+	upgraded, err = entity.UpgradePasswordHash("1111", bcrypt.MinCost)
+	assert.NoError(t, err)
+	assert.False(t, upgraded)
+}
+
+func TestMemberEntity_UpdateProfile_외부_인증_제공자가_원본인_항목을_변경하는_경우(t *testing.T) {
+	// given
+	entity := MemberEntity{
+		Model:   gorm.Model{ID: 2},
+		Type:    "google",
+		Name:    "유영모",
+		Picture: "https://example.com/picture.png",
+	}
+	ctx := helpers.ContextHelper().SetUserClaim(context.Background(), &security.UserClaim{Id: 2})
+
+	// when
+	err := entity.UpdateProfile(ctx, dtos.MemberProfileUpdate{
+		Name:        "유영모",
+		PhoneNumber: "010-1234-5678",
+		Picture:     "https://example.com/new-picture.png",
+	})
+
+	// then
+	assert.Equal(t, &errors.ErrProfileManagedByProvider{Fields: []string{"picture"}}, err)
+	assert.Equal(t, "", entity.PhoneNumber)
+
+	err = entity.UpdateProfile(ctx, dtos.MemberProfileUpdate{
+		Name:        "유영모",
+		PhoneNumber: "010-1234-5678",
+		Picture:     "https://example.com/picture.png",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "010-1234-5678", entity.PhoneNumber)
+}
+
+func TestMemberEntity_UpdateFromGoogleMember_연결된_로그인인_경우(t *testing.T) {
+	// given
+	entity := MemberEntity{
+		Type:     "google",
+		GoogleId: "123456",
+		Name:     "유영모",
+	}
+
+	// when
+	entity.UpdateFromGoogleMember(dtos.GoogleMember{Id: "654321", Name: "다른 이름", Picture: "https://example.com/picture.png"})
+
+	// then
+	assert.Equal(t, "유영모", entity.Name)
+	assert.Equal(t, "", entity.Picture)
+
+	entity.UpdateFromGoogleMember(dtos.GoogleMember{Id: "123456", Name: "유영모2", Picture: "https://example.com/picture.png"})
+	assert.Equal(t, "유영모2", entity.Name)
+	assert.Equal(t, "https://example.com/picture.png", entity.Picture)
+}
+
+func TestMemberEntity_ChangeStatus(t *testing.T) {
+	// given
+	ctx := helpers.ContextHelper().SetUserClaim(context.Background(), &security.UserClaim{Id: 1})
+	entity := MemberEntity{Status: constants.StatusMemberApproved}
+
+	// when
+	err := entity.ChangeStatus(ctx, constants.StatusMemberSuspended, "보안 점검")
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, constants.StatusMemberSuspended, entity.Status)
+	assert.Equal(t, "보안 점검", entity.StatusReason)
+	assert.Equal(t, uint(1), entity.StatusChangedBy)
+	assert.NotNil(t, entity.StatusChangedAt)
+	assert.Equal(t, uint(1), entity.TokenEpoch)
+	assert.Equal(t, &errors.ErrInactiveMember{Status: constants.StatusMemberSuspended}, entity.ValidateLoginStatus())
+
+	assert.NoError(t, entity.ChangeStatus(ctx, constants.StatusMemberApproved, "점검 완료"))
+	assert.Equal(t, uint(1), entity.TokenEpoch)
+	assert.NoError(t, entity.ValidateLoginStatus())
+
+	// 탈퇴한 멤버는 다시 활성화할 수 없다.
+	assert.NoError(t, entity.ChangeStatus(ctx, constants.StatusMemberWithdrawn, "탈퇴 요청"))
+	assert.Equal(t, errors.ErrInvalidStatusTransition, entity.ChangeStatus(ctx, constants.StatusMemberApproved, "재가입"))
+}
+
+func TestMemberEntity_ChangeStatus_신청한_멤버를_재활성화하는_경우(t *testing.T) {
+	// given
+	ctx := helpers.ContextHelper().SetUserClaim(context.Background(), &security.UserClaim{Id: 1})
+	entity := MemberEntity{Status: constants.StatusMemberApplied}
+
+	// when
+	err := entity.ChangeStatus(ctx, constants.StatusMemberApproved, "승인")
+
+	// then
+	// 신청한 멤버는 승인(Approve)해야 한다.
+	assert.Equal(t, errors.ErrInvalidStatusTransition, err)
+	assert.Equal(t, errors.ErrUnApproved, entity.ValidateLoginStatus())
+}
+
+func TestMemberEntity_Activate_관리자가_정지한_경우(t *testing.T) {
+	// given
+	ctx := helpers.ContextHelper().SetUserClaim(context.Background(), &security.UserClaim{Id: 1})
+	entity := MemberEntity{Status: constants.StatusMemberSuspended}
+
+	// when
+	err := entity.Activate(ctx)
+
+	// then
+	// 프로비저닝으로는 관리자가 정지한 멤버를 활성화하지 않는다.
+	assert.NoError(t, err)
+	assert.Equal(t, constants.StatusMemberSuspended, entity.Status)
+}
+
+func TestMemberEntity_GetVerifiedEmails(t *testing.T) {
+	// given
+	now := time.Now()
+	entity := MemberEntity{
+		Type:             constants.TypeMemberOidc,
+		Email:            "ymyoo@bettercode.kr",
+		OidcMail:         "ymyoo@team.example",
+		OidcMailVerified: false,
+		LdapMail:         "ymyoo@ldap.example",
+	}
+
+	// when, then
+	// 인증하지 않은 이메일과 IdP 가 확인하지 않은 메일은 제외한다.
+	assert.Equal(t, []string{"ymyoo@ldap.example"}, entity.GetVerifiedEmails())
+
+	entity.EmailVerifiedAt = &now
+	entity.OidcMailVerified = true
+	assert.Equal(t, []string{"ymyoo@ldap.example", "ymyoo@bettercode.kr", "ymyoo@team.example"}, entity.GetVerifiedEmails())
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"better-admin-backend-service/constants"
+	"gorm.io/gorm"
+)
+
+// MemberIdentityEntity 멤버에 연결된 다른 로그인(두레이, 구글 등). 멤버가 처음 가입한 로그인은 멤버의 유형별 컬럼에 있다.
+type MemberIdentityEntity struct {
+	gorm.Model
+	MemberId uint   `gorm:"not null;index"`
+	Type     string `gorm:"type:varchar(20);not null"`
+	// OIDC 의 경우에만 사용한다.
+	Issuer string `gorm:"type:varchar(200)"`
+	// 인증 제공자의 사용자 식별자(두레이 아이디, 구글 아이디, OIDC subject, LDAP 아이디)
+	Subject string `gorm:"type:varchar(255);not null"`
+	// 목록에 표시할 아이디(두레이 사용자 코드, 메일 주소 등)
+	DisplayId string `gorm:"type:varchar(100)"`
+}
+
+func (MemberIdentityEntity) TableName() string {
+	return "member_identities"
+}
+
+func (i MemberIdentityEntity) GetTypeName() string {
+	return MemberEntity{Type: i.Type}.GetTypeName()
+}
+
+func NewMemberIdentityEntity(identityType, issuer, subject, displayId string) MemberIdentityEntity {
+	return MemberIdentityEntity{
+		Type:      identityType,
+		Issuer:    issuer,
+		Subject:   subject,
+		DisplayId: displayId,
+	}
+}
+
+// NewMemberIdentityEntityFromMember 연결하거나 병합하는 멤버가 처음 가입한 로그인을 다른 멤버에 연결한다.
+// 사이트 멤버는 비밀번호를 옮길 수 없으므로 연결할 수 없다.
+func NewMemberIdentityEntityFromMember(memberId uint, memberEntity MemberEntity) (MemberIdentityEntity, bool) {
+	identityEntity := MemberIdentityEntity{
+		MemberId:  memberId,
+		Type:      memberEntity.Type,
+		DisplayId: memberEntity.GetCandidateId(),
+	}
+
+	switch memberEntity.Type {
+	case constants.TypeMemberDooray:
+		identityEntity.Subject = memberEntity.DoorayId
+	case constants.TypeMemberGoogle:
+		identityEntity.Subject = memberEntity.GoogleId
+	case constants.TypeMemberOidc:
+		identityEntity.Issuer = memberEntity.OidcIssuer
+		identityEntity.Subject = memberEntity.OidcSubject
+	case constants.TypeMemberLdap:
+		identityEntity.Subject = memberEntity.LdapUserId
+	default:
+		return MemberIdentityEntity{}, false
+	}
+
+	return identityEntity, true
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"better-admin-backend-service/security"
+	"gorm.io/gorm"
+	"time"
+)
+
+// MemberIdentityLinkTokenEntity 다른 로그인을 연결하기 위해 발급한 토큰.
+// 멤버는 연결할 로그인으로 다시 로그인한 뒤 이 토큰을 제출해서 두 로그인을 모두 가지고 있음을 증명한다.
+type MemberIdentityLinkTokenEntity struct {
+	gorm.Model
+	MemberId  uint      `gorm:"not null;index"`
+	TokenHash string    `gorm:"type:varchar(64);not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	// 토큰으로 연결된(삭제된) 멤버
+	LinkedMemberId uint
+}
+
+func (MemberIdentityLinkTokenEntity) TableName() string {
+	return "member_identity_link_tokens"
+}
+
+func (e MemberIdentityLinkTokenEntity) IsAvailable() bool {
+	return e.UsedAt == nil && time.Now().Before(e.ExpiresAt)
+}
+
+func (e *MemberIdentityLinkTokenEntity) Use(linkedMemberId uint) {
+	now := time.Now()
+	e.UsedAt = &now
+	e.LinkedMemberId = linkedMemberId
+}
+
+func NewMemberIdentityLinkTokenEntity(memberId uint, token string, expiresAt time.Time) MemberIdentityLinkTokenEntity {
+	return MemberIdentityLinkTokenEntity{
+		MemberId:  memberId,
+		TokenHash: security.HashToken(token),
+		ExpiresAt: expiresAt,
+	}
+}
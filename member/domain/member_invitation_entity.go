@@ -0,0 +1,93 @@
+package domain
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/security"
+	"context"
+	"gorm.io/gorm"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MemberInvitationEntity 관리자가 이메일로 보낸 가입 초대. 초대를 받아 가입하면 지정한 역할, 조직으로 바로 승인된다.
+type MemberInvitationEntity struct {
+	gorm.Model
+	Email     string `gorm:"type:varchar(100);not null;index"`
+	TokenHash string `gorm:"type:varchar(64);not null;uniqueIndex"`
+	// 쉼표로 구분한 역할, 조직 아이디
+	RoleIds          string    `gorm:"type:varchar(1000)"`
+	OrganizationIds  string    `gorm:"type:varchar(1000)"`
+	ExpiresAt        time.Time `gorm:"not null"`
+	AcceptedAt       *time.Time
+	AcceptedMemberId uint
+	CreatedBy        uint
+}
+
+func (MemberInvitationEntity) TableName() string {
+	return "member_invitations"
+}
+
+func (e MemberInvitationEntity) GetRoleIds() []uint {
+	return splitIds(e.RoleIds)
+}
+
+func (e MemberInvitationEntity) GetOrganizationIds() []uint {
+	return splitIds(e.OrganizationIds)
+}
+
+func (e MemberInvitationEntity) IsAccepted() bool {
+	return e.AcceptedAt != nil
+}
+
+// IsAvailable 수락하지 않았고 만료되지 않은 초대만 사용할 수 있다.
+func (e MemberInvitationEntity) IsAvailable() bool {
+	return e.IsAccepted() == false && time.Now().Before(e.ExpiresAt)
+}
+
+func (e *MemberInvitationEntity) Accept(memberId uint) {
+	now := time.Now()
+	e.AcceptedAt = &now
+	e.AcceptedMemberId = memberId
+}
+
+func NewMemberInvitationEntity(ctx context.Context, token string, creation dtos.MemberInvitationCreation) (MemberInvitationEntity, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return MemberInvitationEntity{}, err
+	}
+
+	return MemberInvitationEntity{
+		Email:           creation.Email,
+		TokenHash:       security.HashToken(token),
+		RoleIds:         joinIds(creation.RoleIds),
+		OrganizationIds: joinIds(creation.OrganizationIds),
+		ExpiresAt:       time.Now().AddDate(0, 0, creation.ExpiresInDays),
+		CreatedBy:       userClaim.Id,
+	}, nil
+}
+
+func joinIds(ids []uint) string {
+	values := make([]string, 0, len(ids))
+	for _, id := range ids {
+		values = append(values, strconv.FormatUint(uint64(id), 10))
+	}
+
+	return strings.Join(values, ",")
+}
+
+func splitIds(value string) []uint {
+	ids := make([]uint, 0)
+	if len(value) == 0 {
+		return ids
+	}
+
+	for _, id := range strings.Split(value, ",") {
+		if parsedId, err := strconv.ParseUint(id, 10, 64); err == nil {
+			ids = append(ids, uint(parsedId))
+		}
+	}
+
+	return ids
+}
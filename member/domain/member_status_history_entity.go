@@ -0,0 +1,27 @@
+package domain
+
+import "gorm.io/gorm"
+
+// MemberStatusHistoryEntity 멤버 상태 변경 기록. ActorId 는 상태를 변경한 멤버(SCIM 프로비저닝인 경우 클라이언트)이다.
+type MemberStatusHistoryEntity struct {
+	gorm.Model
+	MemberId   uint   `gorm:"not null;index"`
+	FromStatus string `gorm:"type:varchar(20);not null"`
+	ToStatus   string `gorm:"type:varchar(20);not null"`
+	Reason     string `gorm:"type:varchar(500)"`
+	ActorId    uint
+}
+
+func (MemberStatusHistoryEntity) TableName() string {
+	return "member_status_histories"
+}
+
+func NewMemberStatusHistoryEntity(memberEntity MemberEntity, fromStatus string) MemberStatusHistoryEntity {
+	return MemberStatusHistoryEntity{
+		MemberId:   memberEntity.ID,
+		FromStatus: fromStatus,
+		ToStatus:   memberEntity.Status,
+		Reason:     memberEntity.StatusReason,
+		ActorId:    memberEntity.StatusChangedBy,
+	}
+}
@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"encoding/base64"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"gorm.io/gorm"
+	"strings"
+	"time"
+)
+
+// WebAuthnCredentialEntity 멤버가 등록한 패스키(WebAuthn 자격 증명)
+type WebAuthnCredentialEntity struct {
+	gorm.Model
+	MemberId        uint   `gorm:"not null;index"`
+	Name            string `gorm:"type:varchar(50)"`
+	CredentialId    string `gorm:"type:varchar(255);not null;uniqueIndex"` // base64url
+	PublicKey       []byte `gorm:"not null"`
+	AttestationType string `gorm:"type:varchar(20)"`
+	Transports      string `gorm:"type:varchar(100)"`
+	Aaguid          []byte
+	SignCount       uint32 `gorm:"not null;default:0"`
+	BackupEligible  bool   `gorm:"not null;default:false"`
+	BackupState     bool   `gorm:"not null;default:false"`
+	LastUsedAt      *time.Time
+}
+
+func (WebAuthnCredentialEntity) TableName() string {
+	return "member_webauthn_credentials"
+}
+
+func (e WebAuthnCredentialEntity) ToCredential() webauthn.Credential {
+	credentialId, _ := base64.RawURLEncoding.DecodeString(e.CredentialId)
+
+	transports := make([]protocol.AuthenticatorTransport, 0)
+	if len(e.Transports) > 0 {
+		for _, transport := range strings.Split(e.Transports, ",") {
+			transports = append(transports, protocol.AuthenticatorTransport(transport))
+		}
+	}
+
+	return webauthn.Credential{
+		ID:              credentialId,
+		PublicKey:       e.PublicKey,
+		AttestationType: e.AttestationType,
+		Transport:       transports,
+		Flags: webauthn.CredentialFlags{
+			BackupEligible: e.BackupEligible,
+			BackupState:    e.BackupState,
+		},
+		Authenticator: webauthn.Authenticator{
+			AAGUID:    e.Aaguid,
+			SignCount: e.SignCount,
+		},
+	}
+}
+
+// UpdateUsage 로그인에 사용된 결과(서명 카운터 등)를 반영한다.
+func (e *WebAuthnCredentialEntity) UpdateUsage(credential webauthn.Credential) {
+	now := time.Now()
+	e.SignCount = credential.Authenticator.SignCount
+	e.BackupState = credential.Flags.BackupState
+	e.LastUsedAt = &now
+}
+
+func NewWebAuthnCredentialEntity(memberId uint, name string, credential webauthn.Credential) WebAuthnCredentialEntity {
+	transports := make([]string, 0)
+	for _, transport := range credential.Transport {
+		transports = append(transports, string(transport))
+	}
+
+	return WebAuthnCredentialEntity{
+		MemberId:        memberId,
+		Name:            name,
+		CredentialId:    base64.RawURLEncoding.EncodeToString(credential.ID),
+		PublicKey:       credential.PublicKey,
+		AttestationType: credential.AttestationType,
+		Transports:      strings.Join(transports, ","),
+		Aaguid:          credential.Authenticator.AAGUID,
+		SignCount:       credential.Authenticator.SignCount,
+		BackupEligible:  credential.Flags.BackupEligible,
+		BackupState:     credential.Flags.BackupState,
+	}
+}
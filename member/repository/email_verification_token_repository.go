@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/member/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type EmailVerificationTokenRepository struct {
+}
+
+func (EmailVerificationTokenRepository) Create(ctx context.Context, entity *domain.EmailVerificationTokenEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+	return nil
+}
+
+func (EmailVerificationTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (domain.EmailVerificationTokenEntity, error) {
+	var entity domain.EmailVerificationTokenEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.EmailVerificationTokenEntity{TokenHash: tokenHash}).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (EmailVerificationTokenRepository) Save(ctx context.Context, entity *domain.EmailVerificationTokenEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/member/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+)
+
+type MemberAccessLogRepository struct {
+}
+
+func (MemberAccessLogRepository) Create(ctx context.Context, entity *domain.MemberAccessLogEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+	return nil
+}
+
+func (MemberAccessLogRepository) FindAll(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.MemberAccessLogEntity, int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.MemberAccessLogEntity{})
+
+	if filters != nil {
+		for key, value := range filters {
+			if key == "memberId" {
+				db.Where("member_id = ?", value)
+			}
+
+			if key == "loginId" {
+				db.Where("login_id = ?", value)
+			}
+
+			if key == "types" {
+				db.Where("type IN ?", value)
+			}
+
+			if key == "authTypes" {
+				db.Where("auth_type IN ?", value)
+			}
+
+			if key == "result" {
+				db.Where("result = ?", value)
+			}
+
+			if key == "ipAddress" {
+				db.Where("ip_address = ?", value)
+			}
+
+			if key == "from" {
+				db.Where("created_at >= ?", value)
+			}
+
+			if key == "to" {
+				db.Where("created_at < ?", value)
+			}
+		}
+	}
+
+	var entities = make([]domain.MemberAccessLogEntity, 0)
+	var totalCount int64
+
+	if err := db.Count(&totalCount).Scopes(helpers.GormHelper().Pageable(pageable)).
+		Order("id desc").Find(&entities).Error; err != nil {
+		return entities, totalCount, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, totalCount, nil
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/member/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type MemberIdentityLinkTokenRepository struct {
+}
+
+func (MemberIdentityLinkTokenRepository) Create(ctx context.Context, entity *domain.MemberIdentityLinkTokenEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+	return nil
+}
+
+func (MemberIdentityLinkTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (domain.MemberIdentityLinkTokenEntity, error) {
+	var entity domain.MemberIdentityLinkTokenEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.MemberIdentityLinkTokenEntity{TokenHash: tokenHash}).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (MemberIdentityLinkTokenRepository) Save(ctx context.Context, entity *domain.MemberIdentityLinkTokenEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/member/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type MemberIdentityRepository struct {
+}
+
+func (MemberIdentityRepository) Create(ctx context.Context, entity *domain.MemberIdentityEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+	return nil
+}
+
+func (MemberIdentityRepository) FindById(ctx context.Context, id uint) (domain.MemberIdentityEntity, error) {
+	var entity domain.MemberIdentityEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (MemberIdentityRepository) FindByIdentity(ctx context.Context, identityType, issuer, subject string) (domain.MemberIdentityEntity, error) {
+	var entity domain.MemberIdentityEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where("type = ? AND issuer = ? AND subject = ?", identityType, issuer, subject).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (MemberIdentityRepository) FindAllByMemberId(ctx context.Context, memberId uint) ([]domain.MemberIdentityEntity, error) {
+	entities := make([]domain.MemberIdentityEntity, 0)
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.MemberIdentityEntity{MemberId: memberId}).Order("id").Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (MemberIdentityRepository) Save(ctx context.Context, entity *domain.MemberIdentityEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+	return nil
+}
+
+func (MemberIdentityRepository) Delete(ctx context.Context, entity domain.MemberIdentityEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Delete(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/member/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type MemberInvitationRepository struct {
+}
+
+func (MemberInvitationRepository) Create(ctx context.Context, entity *domain.MemberInvitationEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+	return nil
+}
+
+func (MemberInvitationRepository) FindById(ctx context.Context, id uint) (domain.MemberInvitationEntity, error) {
+	var entity domain.MemberInvitationEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (MemberInvitationRepository) FindByTokenHash(ctx context.Context, tokenHash string) (domain.MemberInvitationEntity, error) {
+	var entity domain.MemberInvitationEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.MemberInvitationEntity{TokenHash: tokenHash}).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (MemberInvitationRepository) FindAll(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]domain.MemberInvitationEntity, int64, error) {
+	db := helpers.ContextHelper().GetDB(ctx).Model(&domain.MemberInvitationEntity{})
+
+	if filters != nil {
+		for key, value := range filters {
+			if key == "email" {
+				db.Where("email = ?", value)
+			}
+
+			if key == "accepted" {
+				if value == true {
+					db.Where("accepted_at IS NOT NULL")
+				} else {
+					db.Where("accepted_at IS NULL")
+				}
+			}
+		}
+	}
+
+	var entities = make([]domain.MemberInvitationEntity, 0)
+	var totalCount int64
+
+	if err := db.Count(&totalCount).Scopes(helpers.GormHelper().Pageable(pageable)).
+		Order("id desc").Find(&entities).Error; err != nil {
+		return entities, totalCount, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, totalCount, nil
+}
+
+func (MemberInvitationRepository) Save(ctx context.Context, entity *domain.MemberInvitationEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+	return nil
+}
+
+func (MemberInvitationRepository) Delete(ctx context.Context, entity domain.MemberInvitationEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Delete(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+	return nil
+}
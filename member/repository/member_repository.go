@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"better-admin-backend-service/constants"
 	"better-admin-backend-service/dtos"
 	"better-admin-backend-service/errors"
 	"better-admin-backend-service/helpers"
@@ -84,8 +85,8 @@ func (MemberRepository) FindAll(ctx context.Context, filters map[string]interfac
 				db.Where("id IN ?", value)
 			}
 
-			if key == "status" {
-				db.Where("status = ?", value)
+			if key == "statuses" {
+				db.Where("status IN ?", value)
 			}
 
 			if key == "name" {
@@ -96,6 +97,19 @@ func (MemberRepository) FindAll(ctx context.Context, filters map[string]interfac
 				db.Where("type IN ?", value)
 			}
 
+			if key == "scimUserName" {
+				// SCIM 사용자 이름은 대소문자를 구분하지 않는다.
+				db.Where("LOWER(scim_user_name) = LOWER(?)", value)
+			}
+
+			if key == "scimExternalId" {
+				db.Where("scim_external_id = ?", value)
+			}
+
+			if key == "email" {
+				db.Where("LOWER(email) = LOWER(?)", value)
+			}
+
 			if key == "roleIds" {
 				// member_roles 테이블을 조인하여 members 테이블 조회 시 필터링 한다.
 				db.Joins("INNER JOIN member_roles ON member_roles.member_entity_id = members.id").
@@ -130,6 +144,22 @@ func (MemberRepository) Save(ctx context.Context, entity *domain.MemberEntity) e
 	return nil
 }
 
+// IncreasePermissionVersion 조직의 역할/멤버 변경처럼 여러 멤버의 권한이 한 번에 바뀌는 경우에 사용한다.
+func (MemberRepository) IncreasePermissionVersion(ctx context.Context, memberIds []uint) error {
+	if len(memberIds) == 0 {
+		return nil
+	}
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Model(&domain.MemberEntity{}).Where("id IN ?", memberIds).
+		UpdateColumn("permission_version", gorm.Expr("permission_version + 1")).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
 func (MemberRepository) FindByGoogleId(ctx context.Context, googleId string) (domain.MemberEntity, error) {
 	var memberEntity domain.MemberEntity
 
@@ -148,6 +178,42 @@ func (MemberRepository) FindByGoogleId(ctx context.Context, googleId string) (do
 	return memberEntity, nil
 }
 
+func (MemberRepository) FindByOidcSubject(ctx context.Context, issuer, subject string) (domain.MemberEntity, error) {
+	var memberEntity domain.MemberEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.MemberEntity{OidcIssuer: issuer, OidcSubject: subject}).
+		Preload("Roles.Permissions").Preload(clause.Associations).
+		First(&memberEntity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return memberEntity, errors.ErrNotFound
+		}
+
+		return memberEntity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return memberEntity, nil
+}
+
+func (MemberRepository) FindByLdapUserId(ctx context.Context, ldapUserId string) (domain.MemberEntity, error) {
+	var memberEntity domain.MemberEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.MemberEntity{LdapUserId: ldapUserId}).
+		Preload("Roles.Permissions").Preload(clause.Associations).
+		First(&memberEntity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return memberEntity, errors.ErrNotFound
+		}
+
+		return memberEntity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return memberEntity, nil
+}
+
 func (MemberRepository) Delete(ctx context.Context, entity domain.MemberEntity) error {
 	db := helpers.ContextHelper().GetDB(ctx)
 
@@ -161,3 +227,22 @@ func (MemberRepository) Delete(ctx context.Context, entity domain.MemberEntity)
 
 	return nil
 }
+
+// FindScimMemberByEmail SCIM 으로 프로비저닝된 멤버 중 사용자 이름이나 메일이 같은 멤버를 찾는다.
+func (MemberRepository) FindScimMemberByEmail(ctx context.Context, email string) (domain.MemberEntity, error) {
+	var memberEntity domain.MemberEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where("type = ? AND (LOWER(scim_user_name) = LOWER(?) OR LOWER(email) = LOWER(?))", constants.TypeMemberScim, email, email).
+		Preload("Roles.Permissions").Preload(clause.Associations).
+		First(&memberEntity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return memberEntity, errors.ErrNotFound
+		}
+
+		return memberEntity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return memberEntity, nil
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/member/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+)
+
+type MemberStatusHistoryRepository struct {
+}
+
+func (MemberStatusHistoryRepository) Create(ctx context.Context, entity *domain.MemberStatusHistoryEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+	return nil
+}
+
+func (MemberStatusHistoryRepository) FindAllByMemberId(ctx context.Context, memberId uint) ([]domain.MemberStatusHistoryEntity, error) {
+	entities := make([]domain.MemberStatusHistoryEntity, 0)
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.MemberStatusHistoryEntity{MemberId: memberId}).Order("id desc").Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/member/domain"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+type WebAuthnCredentialRepository struct {
+}
+
+func (WebAuthnCredentialRepository) Create(ctx context.Context, entity *domain.WebAuthnCredentialEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+	if err := db.Create(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (WebAuthnCredentialRepository) FindAllByMemberId(ctx context.Context, memberId uint) ([]domain.WebAuthnCredentialEntity, error) {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	var entities = make([]domain.WebAuthnCredentialEntity, 0)
+	if err := db.Where(&domain.WebAuthnCredentialEntity{MemberId: memberId}).Order("id").Find(&entities).Error; err != nil {
+		return entities, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entities, nil
+}
+
+func (WebAuthnCredentialRepository) FindByCredentialId(ctx context.Context, credentialId string) (domain.WebAuthnCredentialEntity, error) {
+	var entity domain.WebAuthnCredentialEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.WebAuthnCredentialEntity{CredentialId: credentialId}).First(&entity).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (WebAuthnCredentialRepository) FindByMemberIdAndId(ctx context.Context, memberId uint, id uint) (domain.WebAuthnCredentialEntity, error) {
+	var entity domain.WebAuthnCredentialEntity
+
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Where(&domain.WebAuthnCredentialEntity{MemberId: memberId}).First(&entity, id).Error; err != nil {
+		if pkgerrors.Is(err, gorm.ErrRecordNotFound) {
+			return entity, errors.ErrNotFound
+		}
+
+		return entity, pkgerrors.Wrap(err, "db error")
+	}
+
+	return entity, nil
+}
+
+func (WebAuthnCredentialRepository) Save(ctx context.Context, entity *domain.WebAuthnCredentialEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Save(entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
+
+func (WebAuthnCredentialRepository) Delete(ctx context.Context, entity domain.WebAuthnCredentialEntity) error {
+	db := helpers.ContextHelper().GetDB(ctx)
+
+	if err := db.Delete(&entity).Error; err != nil {
+		return pkgerrors.Wrap(err, "db error")
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"better-admin-backend-service/constants"
 	"better-admin-backend-service/dtos"
 	"better-admin-backend-service/helpers"
 	memberDomain "better-admin-backend-service/member/domain"
@@ -21,6 +22,7 @@ type OrganizationEntity struct {
 	Path                 string                      `gorm:"-"`
 	Roles                []domain.RoleEntity         `gorm:"many2many:organization_roles;"`
 	Members              []memberDomain.MemberEntity `gorm:"many2many:organization_members;"`
+	ScimManaged          bool                        `gorm:"not null;default:false"`
 	CreatedBy            uint
 	UpdatedBy            uint
 }
@@ -101,6 +103,15 @@ func (o *OrganizationEntity) ChangeName(ctx context.Context, name string) error
 	return nil
 }
 
+func (o OrganizationEntity) GetMemberIds() []uint {
+	memberIds := make([]uint, 0)
+	for _, member := range o.Members {
+		memberIds = append(memberIds, member.ID)
+	}
+
+	return memberIds
+}
+
 func (o OrganizationEntity) ExistMember(memberId uint) bool {
 	for _, member := range o.Members {
 		if member.ID == memberId {
@@ -124,3 +135,21 @@ func NewOrganizationEntity(ctx context.Context, information dtos.OrganizationInf
 		UpdatedBy:            userClaim.Id,
 	}, nil
 }
+
+// GetScimGroup SCIM 그룹 리소스로 변환한다.(meta 는 요청한 주소에 따라 달라지므로 포함하지 않는다.)
+func (o OrganizationEntity) GetScimGroup() dtos.ScimGroup {
+	members := make([]dtos.ScimGroupMember, 0)
+	for _, member := range o.Members {
+		members = append(members, dtos.ScimGroupMember{
+			Value:   strconv.FormatUint(uint64(member.ID), 10),
+			Display: member.Name,
+		})
+	}
+
+	return dtos.ScimGroup{
+		Schemas:     []string{constants.ScimSchemaGroup},
+		Id:          strconv.FormatUint(uint64(o.ID), 10),
+		DisplayName: o.Name,
+		Members:     members,
+	}
+}
@@ -13,9 +13,9 @@ import (
 type OrganizationRepository struct {
 }
 
-func (OrganizationRepository) Create(ctx context.Context, entity domain.OrganizationEntity) error {
+func (OrganizationRepository) Create(ctx context.Context, entity *domain.OrganizationEntity) error {
 	db := helpers.ContextHelper().GetDB(ctx)
-	if err := db.Create(&entity).Error; err != nil {
+	if err := db.Create(entity).Error; err != nil {
 		return pkgerrors.Wrap(err, "db error")
 	}
 
@@ -1,9 +1,7 @@
 package security
 
 import (
-	"better-admin-backend-service/config"
 	"encoding/json"
-	"fmt"
 	"github.com/golang-jwt/jwt"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
@@ -13,6 +11,11 @@ import (
 // https://docs.apigee.com/api-platform/reference/policies/oauth-http-status-code-reference
 var InvalidAccessToken = errors.New("invalid access token")
 var AccessTokenExpired = errors.New("access token expired")
+var AccessTokenRevoked = errors.New("access token revoked")
+var AccessTokenPermissionChanged = errors.New("access token permission changed")
+
+// 리프레시 토큰은 typ 클레임이 있으므로 액세스 토큰으로 사용할 수 없고 토큰 갱신에만 사용할 수 있다.
+const tokenTypeRefresh = "refresh"
 
 type JwtAuthentication struct {
 }
@@ -28,9 +31,17 @@ func (JwtAuthentication) GenerateJwtToken(claim UserClaim) (JwtToken, error) {
 		accessTokenClaims[key] = value
 	}
 
-	expiresAt := time.Now().Add(time.Minute * 15).Unix()
+	accessTokenId, err := NewRandomId()
+	if err != nil {
+		return JwtToken{}, err
+	}
+
+	issuedAt := time.Now()
+	expiresAt := issuedAt.Add(time.Minute * 15).Unix()
 	accessTokenClaims["exp"] = expiresAt
-	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, accessTokenClaims).SignedString([]byte(config.Config.JwtSecret))
+	accessTokenClaims["iat"] = issuedAt.Unix()
+	accessTokenClaims["jti"] = accessTokenId
+	accessToken, err := signToken(accessTokenClaims)
 
 	if err != nil {
 		return JwtToken{}, errors.Wrap(err, "create accessToken error")
@@ -41,9 +52,18 @@ func (JwtAuthentication) GenerateJwtToken(claim UserClaim) (JwtToken, error) {
 		refreshTokenClaims[key] = value
 	}
 
-	refreshTokenExpires := time.Now().Add(time.Hour * 24 * 7)
+	// 리프레시 토큰은 서버에 저장(해시)되어 회전(rotation) 되기 때문에 같은 클레임이라도 매번 다른 토큰이 생성되어야 한다.
+	refreshTokenId, err := NewRandomId()
+	if err != nil {
+		return JwtToken{}, err
+	}
+
+	refreshTokenExpires := issuedAt.Add(time.Hour * 24 * 7)
 	refreshTokenClaims["exp"] = refreshTokenExpires.Unix()
-	refreshToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshTokenClaims).SignedString([]byte(config.Config.JwtSecret))
+	refreshTokenClaims["iat"] = issuedAt.Unix()
+	refreshTokenClaims["jti"] = refreshTokenId
+	refreshTokenClaims["typ"] = tokenTypeRefresh
+	refreshToken, err := signToken(refreshTokenClaims)
 
 	if err != nil {
 		return JwtToken{}, errors.Wrap(err, "create refreshToken error")
@@ -58,6 +78,31 @@ func (JwtAuthentication) GenerateJwtToken(claim UserClaim) (JwtToken, error) {
 	}, nil
 }
 
+// GenerateImpersonationToken 대리 로그인 토큰은 갱신할 수 없도록 리프레시 토큰 없이 짧은 시간 동안만 유효한 액세스 토큰으로 발급한다.
+// 대리 로그인 기록과 연결하기 위해 claim 의 jti 를 그대로 사용한다.
+func (JwtAuthentication) GenerateImpersonationToken(claim UserClaim) (string, int64, error) {
+	claimMap, err := claim.ConvertMap()
+	if err != nil {
+		return "", 0, err
+	}
+
+	accessTokenClaims := jwt.MapClaims{}
+	for key, value := range claimMap {
+		accessTokenClaims[key] = value
+	}
+
+	issuedAt := time.Now()
+	expiresAt := issuedAt.Add(time.Minute * 15).Unix()
+	accessTokenClaims["exp"] = expiresAt
+	accessTokenClaims["iat"] = issuedAt.Unix()
+	accessToken, err := signToken(accessTokenClaims)
+	if err != nil {
+		return "", 0, errors.Wrap(err, "create impersonation token error")
+	}
+
+	return accessToken, expiresAt, nil
+}
+
 func (JwtAuthentication) GenerateJwtAccessTokenNeverExpired(claim UserClaim) (string, error) {
 	claimMap, err := claim.ConvertMap()
 	if err != nil {
@@ -69,7 +114,7 @@ func (JwtAuthentication) GenerateJwtAccessTokenNeverExpired(claim UserClaim) (st
 		accessTokenClaims[key] = value
 	}
 
-	accessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, accessTokenClaims).SignedString([]byte(config.Config.JwtSecret))
+	accessToken, err := signToken(accessTokenClaims)
 
 	if err != nil {
 		return "", errors.Wrap(err, "create accessToken error")
@@ -78,8 +123,18 @@ func (JwtAuthentication) GenerateJwtAccessTokenNeverExpired(claim UserClaim) (st
 	return accessToken, nil
 }
 
+// ConvertTokenUserClaim 2단계 인증 대기 토큰, 리프레시 토큰 등 용도가 지정된 토큰은 액세스 토큰으로 사용할 수 없다.
 func (JwtAuthentication) ConvertTokenUserClaim(token string) (*UserClaim, error) {
-	parsedToken, err := jwt.Parse(token, func(token *jwt.Token) (interface{}, error) { return []byte(config.Config.JwtSecret), nil })
+	return convertUserClaim(token, "")
+}
+
+// ConvertRefreshTokenUserClaim 토큰 갱신에서만 사용한다.
+func (JwtAuthentication) ConvertRefreshTokenUserClaim(token string) (*UserClaim, error) {
+	return convertUserClaim(token, tokenTypeRefresh)
+}
+
+func convertUserClaim(token string, tokenType string) (*UserClaim, error) {
+	parsedToken, err := jwt.Parse(token, findVerificationKey)
 
 	if err != nil {
 		log.Error("JWT parsing error: " + err.Error())
@@ -91,12 +146,6 @@ func (JwtAuthentication) ConvertTokenUserClaim(token string) (*UserClaim, error)
 		return nil, InvalidAccessToken
 	}
 
-	if jwt.SigningMethodHS256.Alg() != parsedToken.Header["alg"] {
-		log.Error(fmt.Sprintf("Error: jwt token is expected %s signing method but token specified %s",
-			jwt.SigningMethodHS256.Alg(), parsedToken.Header["alg"]))
-		return nil, InvalidAccessToken
-	}
-
 	if !parsedToken.Valid {
 		return nil, InvalidAccessToken
 	}
@@ -107,6 +156,10 @@ func (JwtAuthentication) ConvertTokenUserClaim(token string) (*UserClaim, error)
 		return nil, InvalidAccessToken
 	}
 
+	if claimTokenType, _ := claimInfo["typ"].(string); claimTokenType != tokenType {
+		return nil, InvalidAccessToken
+	}
+
 	userClaim, err := NewUserClaim(claimInfo)
 	if err != nil {
 		return nil, err
@@ -116,7 +169,7 @@ func (JwtAuthentication) ConvertTokenUserClaim(token string) (*UserClaim, error)
 }
 
 func (jwtAuthentication JwtAuthentication) RefreshAccessToken(refreshToken string) (string, int64, error) {
-	userClaim, err := jwtAuthentication.ConvertTokenUserClaim(refreshToken)
+	userClaim, err := jwtAuthentication.ConvertRefreshTokenUserClaim(refreshToken)
 	if err != nil {
 		return "", 0, err
 	}
@@ -153,6 +206,38 @@ type UserClaim struct {
 	Id          uint     `json:"id"`
 	Roles       []string `json:"roles"`
 	Permissions []string `json:"permissions"`
+	// 로그인을 통해 발급된 토큰에만 존재한다.(웹훅 토큰에는 없음)
+	Jti        string `json:"jti,omitempty"`
+	ExpiresAt  int64  `json:"exp,omitempty"`
+	TokenEpoch uint   `json:"tokenEpoch,omitempty"`
+	// 토큰 발급 시점의 멤버 권한 버전
+	PermissionVersion uint `json:"permissionVersion,omitempty"`
+	// 로그인할 때 만들어진 세션(리프레시 토큰 패밀리)의 아이디
+	SessionId string `json:"sid,omitempty"`
+	// 개인 액세스 토큰으로 인증한 경우에만 존재한다.(JWT 로 발급하지 않음)
+	PersonalAccessTokenId uint `json:"-"`
+	// SCIM 토큰으로 인증한 경우에만 존재한다.(멤버가 아니므로 Id 는 0)
+	ScimTokenId uint `json:"-"`
+	// 대리 로그인 토큰에만 존재한다.(RFC 8693 act 클레임)
+	Impersonator *ImpersonatorClaim `json:"act,omitempty"`
+}
+
+type ImpersonatorClaim struct {
+	Id uint `json:"id"`
+	// 대리 로그인을 시작한 시점의 관리자 토큰 에포크
+	TokenEpoch uint `json:"tokenEpoch,omitempty"`
+}
+
+func (c UserClaim) IsIssuedBySignIn() bool {
+	return len(c.Jti) > 0
+}
+
+func (c UserClaim) IsPersonalAccessToken() bool {
+	return c.PersonalAccessTokenId > 0
+}
+
+func (c UserClaim) IsImpersonation() bool {
+	return c.Impersonator != nil
 }
 
 func (c UserClaim) ConvertMap() (map[string]interface{}, error) {
@@ -0,0 +1,286 @@
+package security
+
+import (
+	"better-admin-backend-service/config"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+	"math/big"
+	"os"
+	"sort"
+)
+
+type signingKey struct {
+	keyId      string
+	method     jwt.SigningMethod
+	privateKey interface{}
+	publicKey  interface{}
+}
+
+type signingKeySet struct {
+	activeKey *signingKey
+	keys      map[string]*signingKey
+}
+
+// 서명 키가 설정되지 않은 경우 JwtSecret 을 사용하는 HS256 으로 서명한다.
+var signingKeys = signingKeySet{keys: map[string]*signingKey{}}
+
+// InitSigningKeys 설정(config.Config.JwtSigning)에 정의된 비대칭 서명 키를 읽어 들인다.
+// ActiveKeyId 로 지정된 키로 토큰을 서명하고, 나머지 키들은 키 교체 기간 동안 토큰 검증과 JWKS 공개에만 사용된다.
+func InitSigningKeys() error {
+	keySet := signingKeySet{keys: map[string]*signingKey{}}
+
+	for _, keyConfig := range config.Config.JwtSigning.Keys {
+		key, err := newSigningKey(keyConfig)
+		if err != nil {
+			return err
+		}
+
+		if _, exists := keySet.keys[key.keyId]; exists {
+			return errors.New(fmt.Sprintf("duplicated jwt signing key id: %v", key.keyId))
+		}
+		keySet.keys[key.keyId] = key
+	}
+
+	activeKeyId := config.Config.JwtSigning.ActiveKeyId
+	if len(activeKeyId) > 0 {
+		activeKey, ok := keySet.keys[activeKeyId]
+		if !ok {
+			return errors.New(fmt.Sprintf("active jwt signing key not found: %v", activeKeyId))
+		}
+
+		if activeKey.privateKey == nil {
+			return errors.New(fmt.Sprintf("active jwt signing key has no private key: %v", activeKeyId))
+		}
+		keySet.activeKey = activeKey
+	}
+
+	signingKeys = keySet
+	return nil
+}
+
+func newSigningKey(keyConfig config.JwtSigningKey) (*signingKey, error) {
+	if len(keyConfig.KeyId) == 0 {
+		return nil, errors.New("jwt signing key id is required")
+	}
+
+	method := jwt.GetSigningMethod(keyConfig.Algorithm)
+	if method == nil {
+		return nil, errors.New(fmt.Sprintf("not supported jwt signing algorithm: %v", keyConfig.Algorithm))
+	}
+
+	key := &signingKey{keyId: keyConfig.KeyId, method: method}
+
+	if len(keyConfig.PrivateKeyFile) > 0 {
+		pemBytes, err := os.ReadFile(keyConfig.PrivateKeyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "read jwt private key error")
+		}
+
+		switch method.(type) {
+		case *jwt.SigningMethodRSA:
+			privateKey, err := jwt.ParseRSAPrivateKeyFromPEM(pemBytes)
+			if err != nil {
+				return nil, errors.Wrap(err, "parse jwt private key error")
+			}
+			key.privateKey, key.publicKey = privateKey, &privateKey.PublicKey
+		case *jwt.SigningMethodECDSA:
+			privateKey, err := jwt.ParseECPrivateKeyFromPEM(pemBytes)
+			if err != nil {
+				return nil, errors.Wrap(err, "parse jwt private key error")
+			}
+			key.privateKey, key.publicKey = privateKey, &privateKey.PublicKey
+		case *jwt.SigningMethodEd25519:
+			privateKey, err := jwt.ParseEdPrivateKeyFromPEM(pemBytes)
+			if err != nil {
+				return nil, errors.Wrap(err, "parse jwt private key error")
+			}
+			edPrivateKey := privateKey.(ed25519.PrivateKey)
+			key.privateKey, key.publicKey = edPrivateKey, edPrivateKey.Public().(ed25519.PublicKey)
+		default:
+			return nil, errors.New(fmt.Sprintf("not supported jwt signing algorithm: %v", keyConfig.Algorithm))
+		}
+
+		return key, nil
+	}
+
+	if len(keyConfig.PublicKeyFile) == 0 {
+		return nil, errors.New(fmt.Sprintf("jwt signing key file is required: %v", keyConfig.KeyId))
+	}
+
+	pemBytes, err := os.ReadFile(keyConfig.PublicKeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "read jwt public key error")
+	}
+
+	switch method.(type) {
+	case *jwt.SigningMethodRSA:
+		key.publicKey, err = jwt.ParseRSAPublicKeyFromPEM(pemBytes)
+	case *jwt.SigningMethodECDSA:
+		key.publicKey, err = jwt.ParseECPublicKeyFromPEM(pemBytes)
+	case *jwt.SigningMethodEd25519:
+		key.publicKey, err = jwt.ParseEdPublicKeyFromPEM(pemBytes)
+	default:
+		return nil, errors.New(fmt.Sprintf("not supported jwt signing algorithm: %v", keyConfig.Algorithm))
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "parse jwt public key error")
+	}
+
+	return key, nil
+}
+
+func signToken(claims jwt.MapClaims) (string, error) {
+	activeKey := signingKeys.activeKey
+	if activeKey == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(config.Config.JwtSecret))
+	}
+
+	token := jwt.NewWithClaims(activeKey.method, claims)
+	token.Header["kid"] = activeKey.keyId
+	return token.SignedString(activeKey.privateKey)
+}
+
+func findVerificationKey(token *jwt.Token) (interface{}, error) {
+	keyId, _ := token.Header["kid"].(string)
+	if len(keyId) == 0 {
+		// kid 가 없는 토큰은 JwtSecret 으로 서명된 토큰(비대칭 키 도입 이전에 발급된 웹훅 토큰 등)이다.
+		if token.Method.Alg() != jwt.SigningMethodHS256.Alg() {
+			return nil, errors.New(fmt.Sprintf("jwt token is expected %s signing method but token specified %s",
+				jwt.SigningMethodHS256.Alg(), token.Method.Alg()))
+		}
+
+		// 비대칭 키를 설정한 뒤에는 jti 가 없는 웹훅 토큰만 JwtSecret 으로 검증하고, 로그인으로 발급된 토큰(jti 있음)은 거부한다.
+		if len(signingKeys.keys) > 0 {
+			if claims, ok := token.Claims.(jwt.MapClaims); !ok || claims["jti"] != nil {
+				return nil, errors.New("jwt token without kid is allowed only for web hook")
+			}
+		}
+		return []byte(config.Config.JwtSecret), nil
+	}
+
+	key, ok := signingKeys.keys[keyId]
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("unknown jwt signing key: %v", keyId))
+	}
+
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, errors.New(fmt.Sprintf("jwt token is expected %s signing method but token specified %s",
+			key.method.Alg(), token.Method.Alg()))
+	}
+
+	return key.publicKey, nil
+}
+
+// Jwks https://datatracker.ietf.org/doc/html/rfc7517
+type Jwks struct {
+	Keys []Jwk `json:"keys"`
+}
+
+type Jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+func (JwtAuthentication) GetJwks() Jwks {
+	keyIds := make([]string, 0, len(signingKeys.keys))
+	for keyId := range signingKeys.keys {
+		keyIds = append(keyIds, keyId)
+	}
+	sort.Strings(keyIds)
+
+	jwks := Jwks{Keys: make([]Jwk, 0)}
+	for _, keyId := range keyIds {
+		key := signingKeys.keys[keyId]
+		jwk := Jwk{Kid: key.keyId, Use: "sig", Alg: key.method.Alg()}
+
+		switch publicKey := key.publicKey.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+		case *ecdsa.PublicKey:
+			byteSize := (publicKey.Curve.Params().BitSize + 7) / 8
+			jwk.Kty = "EC"
+			jwk.Crv = publicKey.Curve.Params().Name
+			jwk.X = base64.RawURLEncoding.EncodeToString(publicKey.X.FillBytes(make([]byte, byteSize)))
+			jwk.Y = base64.RawURLEncoding.EncodeToString(publicKey.Y.FillBytes(make([]byte, byteSize)))
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+		default:
+			continue
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks
+}
+
+// GetPublicKey 외부 IdP 가 공개한 JWK 를 서명 검증에 사용할 수 있는 공개 키로 변환한다.
+func (k Jwk) GetPublicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, errors.Wrap(err, "decode jwk error")
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, errors.Wrap(err, "decode jwk error")
+		}
+
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, errors.New(fmt.Sprintf("not supported jwk curve: %v", k.Crv))
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, errors.Wrap(err, "decode jwk error")
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, errors.Wrap(err, "decode jwk error")
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, errors.New(fmt.Sprintf("not supported jwk curve: %v", k.Crv))
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, errors.Wrap(err, "decode jwk error")
+		}
+
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, errors.New(fmt.Sprintf("not supported jwk key type: %v", k.Kty))
+	}
+}
@@ -0,0 +1,237 @@
+package security
+
+import (
+	"better-admin-backend-service/config"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJwtAuthentication_비대칭키로_서명하고_검증한다(t *testing.T) {
+	for _, algorithm := range []string{"RS256", "ES256", "EdDSA"} {
+		t.Run(algorithm, func(t *testing.T) {
+			// given
+			setUpSigningKeys(t, "key-1", config.JwtSigningKey{
+				KeyId:          "key-1",
+				Algorithm:      algorithm,
+				PrivateKeyFile: writeTestPrivateKey(t, algorithm),
+			})
+
+			// when
+			token, err := JwtAuthentication{}.GenerateJwtToken(UserClaim{Id: 1, Roles: []string{"SYSTEM MANAGER"}, Permissions: []string{"member.read"}})
+
+			// then
+			assert.NoError(t, err)
+			parsedToken, _ := jwt.Parse(token.AccessToken, nil)
+			assert.Equal(t, algorithm, parsedToken.Header["alg"])
+			assert.Equal(t, "key-1", parsedToken.Header["kid"])
+
+			userClaim, err := JwtAuthentication{}.ConvertTokenUserClaim(token.AccessToken)
+			assert.NoError(t, err)
+			assert.Equal(t, uint(1), userClaim.Id)
+			assert.Equal(t, []string{"member.read"}, userClaim.Permissions)
+		})
+	}
+}
+
+func TestJwtAuthentication_교체된_키로_서명된_토큰은_공개키가_남아있는_동안_검증된다(t *testing.T) {
+	// given
+	oldPrivateKeyFile := writeTestPrivateKey(t, "RS256")
+	setUpSigningKeys(t, "old", config.JwtSigningKey{KeyId: "old", Algorithm: "RS256", PrivateKeyFile: oldPrivateKeyFile})
+	token, err := JwtAuthentication{}.GenerateJwtToken(UserClaim{Id: 1})
+	assert.NoError(t, err)
+
+	oldPublicKeyFile := writeTestPublicKey(t, oldPrivateKeyFile)
+	setUpSigningKeys(t, "new",
+		config.JwtSigningKey{KeyId: "new", Algorithm: "ES256", PrivateKeyFile: writeTestPrivateKey(t, "ES256")},
+		config.JwtSigningKey{KeyId: "old", Algorithm: "RS256", PublicKeyFile: oldPublicKeyFile})
+
+	// when
+	_, err = JwtAuthentication{}.ConvertTokenUserClaim(token.AccessToken)
+
+	// then
+	assert.NoError(t, err)
+
+	newToken, err := JwtAuthentication{}.GenerateJwtToken(UserClaim{Id: 1})
+	assert.NoError(t, err)
+	parsedToken, _ := jwt.Parse(newToken.AccessToken, nil)
+	assert.Equal(t, "new", parsedToken.Header["kid"])
+
+	// 은퇴한 키를 제거하면 더 이상 검증되지 않는다.
+	setUpSigningKeys(t, "new", config.JwtSigningKey{KeyId: "new", Algorithm: "ES256", PrivateKeyFile: writeTestPrivateKey(t, "ES256")})
+	_, err = JwtAuthentication{}.ConvertTokenUserClaim(token.AccessToken)
+	assert.Equal(t, InvalidAccessToken, err)
+}
+
+func TestJwtAuthentication_kid가_없는_HS256_토큰은_JwtSecret으로_검증된다(t *testing.T) {
+	// given
+	setUpSigningKeys(t, "key-1", config.JwtSigningKey{KeyId: "key-1", Algorithm: "RS256", PrivateKeyFile: writeTestPrivateKey(t, "RS256")})
+	config.Config.JwtSecret = "test-secret"
+	webHookToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"id": 1}).SignedString([]byte("test-secret"))
+	assert.NoError(t, err)
+
+	// when
+	userClaim, err := JwtAuthentication{}.ConvertTokenUserClaim(webHookToken)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, uint(1), userClaim.Id)
+}
+
+func TestJwtAuthentication_비대칭키를_설정하면_kid가_없는_로그인_토큰은_거부한다(t *testing.T) {
+	// given
+	setUpSigningKeys(t, "key-1", config.JwtSigningKey{KeyId: "key-1", Algorithm: "RS256", PrivateKeyFile: writeTestPrivateKey(t, "RS256")})
+	config.Config.JwtSecret = "test-secret"
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"id": 1, "jti": "token-1", "exp": time.Now().Add(time.Minute).Unix()}).
+		SignedString([]byte("test-secret"))
+	assert.NoError(t, err)
+
+	// when
+	_, err = JwtAuthentication{}.ConvertTokenUserClaim(token)
+
+	// then
+	assert.Equal(t, InvalidAccessToken, err)
+}
+
+func TestJwtAuthentication_kid와_알고리즘이_일치하지_않는_토큰은_거부한다(t *testing.T) {
+	// given
+	setUpSigningKeys(t, "key-1", config.JwtSigningKey{KeyId: "key-1", Algorithm: "RS256", PrivateKeyFile: writeTestPrivateKey(t, "RS256")})
+	config.Config.JwtSecret = "test-secret"
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"id": 1, "exp": time.Now().Add(time.Minute).Unix()})
+	token.Header["kid"] = "key-1"
+	forgedToken, err := token.SignedString([]byte("test-secret"))
+	assert.NoError(t, err)
+
+	// when
+	_, err = JwtAuthentication{}.ConvertTokenUserClaim(forgedToken)
+
+	// then
+	assert.Equal(t, InvalidAccessToken, err)
+}
+
+func TestJwtAuthentication_GetJwks(t *testing.T) {
+	// given
+	setUpSigningKeys(t, "rsa",
+		config.JwtSigningKey{KeyId: "rsa", Algorithm: "RS256", PrivateKeyFile: writeTestPrivateKey(t, "RS256")},
+		config.JwtSigningKey{KeyId: "ec", Algorithm: "ES256", PrivateKeyFile: writeTestPrivateKey(t, "ES256")},
+		config.JwtSigningKey{KeyId: "ed", Algorithm: "EdDSA", PrivateKeyFile: writeTestPrivateKey(t, "EdDSA")})
+
+	// when
+	jwks := JwtAuthentication{}.GetJwks()
+
+	// then
+	assert.Equal(t, 3, len(jwks.Keys))
+	assert.Equal(t, Jwk{Kty: "EC", Kid: "ec", Use: "sig", Alg: "ES256", Crv: "P-256", X: jwks.Keys[0].X, Y: jwks.Keys[0].Y}, jwks.Keys[0])
+	assert.Equal(t, 43, len(jwks.Keys[0].X))
+	assert.Equal(t, "OKP", jwks.Keys[1].Kty)
+	assert.Equal(t, "Ed25519", jwks.Keys[1].Crv)
+	assert.Equal(t, "RSA", jwks.Keys[2].Kty)
+	assert.Equal(t, "AQAB", jwks.Keys[2].E)
+	assert.NotEmpty(t, jwks.Keys[2].N)
+}
+
+func TestJwk_GetPublicKey_공개한_키로_서명을_검증한다(t *testing.T) {
+	// given
+	setUpSigningKeys(t, "rsa",
+		config.JwtSigningKey{KeyId: "rsa", Algorithm: "RS256", PrivateKeyFile: writeTestPrivateKey(t, "RS256")},
+		config.JwtSigningKey{KeyId: "ec", Algorithm: "ES256", PrivateKeyFile: writeTestPrivateKey(t, "ES256")},
+		config.JwtSigningKey{KeyId: "ed", Algorithm: "EdDSA", PrivateKeyFile: writeTestPrivateKey(t, "EdDSA")})
+
+	for _, jwk := range (JwtAuthentication{}).GetJwks().Keys {
+		// when
+		publicKey, err := jwk.GetPublicKey()
+
+		// then
+		assert.NoError(t, err)
+		assert.Equal(t, signingKeys.keys[jwk.Kid].publicKey, publicKey)
+	}
+}
+
+func TestJwk_GetPublicKey_지원하지_않는_키_타입인_경우(t *testing.T) {
+	// when
+	_, err := Jwk{Kty: "oct"}.GetPublicKey()
+
+	// then
+	assert.Error(t, err)
+}
+
+func setUpSigningKeys(t *testing.T, activeKeyId string, keys ...config.JwtSigningKey) {
+	originalSecret := config.Config.JwtSecret
+	t.Cleanup(func() {
+		config.Config.JwtSecret = originalSecret
+		config.Config.JwtSigning.ActiveKeyId = ""
+		config.Config.JwtSigning.Keys = nil
+		InitSigningKeys()
+	})
+
+	config.Config.JwtSigning.ActiveKeyId = activeKeyId
+	config.Config.JwtSigning.Keys = keys
+	if err := InitSigningKeys(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeTestPrivateKey(t *testing.T, algorithm string) string {
+	var privateKey interface{}
+	var err error
+	switch algorithm {
+	case "RS256":
+		privateKey, err = rsa.GenerateKey(rand.Reader, 2048)
+	case "ES256":
+		privateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case "EdDSA":
+		_, privateKey, err = ed25519.GenerateKey(rand.Reader)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return writeTestPem(t, "PRIVATE KEY", der)
+}
+
+func writeTestPublicKey(t *testing.T, privateKeyFile string) string {
+	pemBytes, _ := os.ReadFile(privateKeyFile)
+	block, _ := pem.Decode(pemBytes)
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.MarshalPKIXPublicKey(privateKey.(interface{ Public() crypto.PublicKey }).Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return writeTestPem(t, "PUBLIC KEY", der)
+}
+
+func writeTestPem(t *testing.T, blockType string, der []byte) string {
+	file, err := os.CreateTemp(t.TempDir(), strings.ReplaceAll(blockType, " ", "-")+"*.pem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	if err := pem.Encode(file, &pem.Block{Type: blockType, Bytes: der}); err != nil {
+		t.Fatal(err)
+	}
+
+	return filepath.Clean(file.Name())
+}
@@ -0,0 +1,67 @@
+package security
+
+import (
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+	"time"
+)
+
+// 외부 IdP 로그인의 state 는 로그인 후 돌아갈 주소와 nonce 를 담아 서명한 토큰이다. typ 클레임이 있으므로 API 호출에 사용할 수 없다.
+const tokenTypeLoginState = "login-state"
+
+const loginStateExpiration = time.Minute * 10
+
+var InvalidLoginState = errors.New("invalid login state")
+
+type LoginState struct {
+	Redirect string
+	// 로그인을 시작한 브라우저의 쿠키에 보관한 값과 비교하여 다른 브라우저에서 시작한 로그인을 거부한다.
+	Nonce string
+}
+
+// GenerateLoginState 서명한 state 와 브라우저에 보관할 nonce 를 반환한다.
+func (JwtAuthentication) GenerateLoginState(redirect string) (string, string, error) {
+	nonce, err := NewRandomId()
+	if err != nil {
+		return "", "", err
+	}
+
+	issuedAt := time.Now()
+	state, err := signToken(jwt.MapClaims{
+		"typ":      tokenTypeLoginState,
+		"redirect": redirect,
+		"nonce":    nonce,
+		"iat":      issuedAt.Unix(),
+		"exp":      issuedAt.Add(loginStateExpiration).Unix(),
+	})
+	if err != nil {
+		return "", "", errors.Wrap(err, "create login state error")
+	}
+
+	return state, nonce, nil
+}
+
+func (JwtAuthentication) ConvertLoginState(state string) (LoginState, error) {
+	parsedToken, err := jwt.Parse(state, findVerificationKey)
+	if err != nil || !parsedToken.Valid {
+		return LoginState{}, InvalidLoginState
+	}
+
+	claimInfo, ok := parsedToken.Claims.(jwt.MapClaims)
+	if !ok || claimInfo["typ"] != tokenTypeLoginState {
+		return LoginState{}, InvalidLoginState
+	}
+
+	redirect, _ := claimInfo["redirect"].(string)
+	nonce, _ := claimInfo["nonce"].(string)
+	if len(redirect) == 0 || len(nonce) == 0 {
+		return LoginState{}, InvalidLoginState
+	}
+
+	return LoginState{Redirect: redirect, Nonce: nonce}, nil
+}
+
+// NewLoginCode 로그인 후 리다이렉트 URL 에 액세스 토큰 대신 전달하는 일회용 코드. 서버에는 해시(HashToken)만 저장한다.
+func NewLoginCode() (string, error) {
+	return newOAuthRandomValue()
+}
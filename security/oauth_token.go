@@ -0,0 +1,90 @@
+package security
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/golang-jwt/jwt"
+	"github.com/pkg/errors"
+	"strings"
+	"time"
+)
+
+// OAuthAccessTokenPrefix 내부 앱에 발급한 액세스 토큰을 JWT, 개인 액세스 토큰과 구분하기 위한 접두사
+const OAuthAccessTokenPrefix = "boauth_"
+
+// IdTokenType id_token 은 멤버의 액세스 토큰으로 사용할 수 없도록 typ 클레임을 지정한다.
+const IdTokenType = "ID"
+
+const idTokenExpiration = time.Hour
+
+// NewOAuthAccessToken 내부 앱이 /userinfo 를 호출할 때 사용하는 토큰 원문을 생성한다. 서버에는 해시(HashToken)만 저장한다.
+func NewOAuthAccessToken() (string, error) {
+	token, err := newOAuthRandomValue()
+	if err != nil {
+		return "", err
+	}
+
+	return OAuthAccessTokenPrefix + token, nil
+}
+
+func IsOAuthAccessToken(token string) bool {
+	return strings.HasPrefix(token, OAuthAccessTokenPrefix)
+}
+
+// NewOAuthAuthorizationCode 인가 코드와 클라이언트 시크릿도 원문은 한 번만 전달하고 서버에는 해시만 저장한다.
+func NewOAuthAuthorizationCode() (string, error) {
+	return newOAuthRandomValue()
+}
+
+func NewOAuthClientSecret() (string, error) {
+	return newOAuthRandomValue()
+}
+
+func newOAuthRandomValue() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", errors.Wrap(err, "oauth random value error")
+	}
+
+	return hex.EncodeToString(bytes), nil
+}
+
+// GenerateIdToken https://openid.net/specs/openid-connect-core-1_0.html#IDToken
+// 내부 앱이 JWKS 로 서명을 검증할 수 있도록 액세스 토큰과 같은 서명 키를 사용한다.
+// 내부 앱에 JwtSecret 을 공유할 수 없으므로 비대칭 서명 키가 없으면 발급하지 않는다.
+func (j JwtAuthentication) GenerateIdToken(claims map[string]any) (string, error) {
+	if j.HasAsymmetricSigningKey() == false {
+		return "", errors.New("asymmetric jwt signing key is required for id token")
+	}
+
+	idTokenClaims := jwt.MapClaims{}
+	for key, value := range claims {
+		idTokenClaims[key] = value
+	}
+
+	issuedAt := time.Now()
+	idTokenClaims["iat"] = issuedAt.Unix()
+	idTokenClaims["exp"] = issuedAt.Add(idTokenExpiration).Unix()
+	idTokenClaims["typ"] = IdTokenType
+
+	idToken, err := signToken(idTokenClaims)
+	if err != nil {
+		return "", errors.Wrap(err, "create id token error")
+	}
+
+	return idToken, nil
+}
+
+// HasAsymmetricSigningKey OpenID Connect 제공자는 비대칭 서명 키(JwtSigning.ActiveKeyId)가 설정된 경우에만 사용할 수 있다.
+func (JwtAuthentication) HasAsymmetricSigningKey() bool {
+	return signingKeys.activeKey != nil
+}
+
+// GetSigningAlgorithm 디스커버리 문서에 공개할 id_token 서명 알고리즘
+func (JwtAuthentication) GetSigningAlgorithm() string {
+	if signingKeys.activeKey == nil {
+		return ""
+	}
+
+	return signingKeys.activeKey.method.Alg()
+}
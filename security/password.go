@@ -0,0 +1,61 @@
+package security
+
+import (
+	"crypto/rand"
+	"github.com/pkg/errors"
+	"math/big"
+)
+
+// GenerateTemporaryPassword 관리자가 비밀번호를 초기화할 때 사용할 임시 비밀번호를 생성한다.
+// 비밀번호 정책의 문자 종류 조건을 항상 만족하도록 대문자, 소문자, 숫자, 특수 문자를 하나 이상 포함한다.
+func GenerateTemporaryPassword(length int) (string, error) {
+	// 헷갈리기 쉬운 문자(0, O, 1, l, I)는 제외한다.
+	charsets := []string{
+		"ABCDEFGHJKLMNPQRSTUVWXYZ",
+		"abcdefghijkmnopqrstuvwxyz",
+		"23456789",
+		"!@#$%^*-_=+",
+	}
+	if length < len(charsets) {
+		length = len(charsets)
+	}
+
+	all := ""
+	for _, charset := range charsets {
+		all += charset
+	}
+
+	password := make([]byte, length)
+	for i := range password {
+		charset := all
+		if i < len(charsets) {
+			charset = charsets[i]
+		}
+
+		c, err := randomChar(charset)
+		if err != nil {
+			return "", err
+		}
+		password[i] = c
+	}
+
+	// 문자 종류별로 앞에 고정된 문자의 위치를 섞는다.
+	for i := len(password) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", errors.Wrap(err, "temporary password error")
+		}
+		password[i], password[j.Int64()] = password[j.Int64()], password[i]
+	}
+
+	return string(password), nil
+}
+
+func randomChar(charset string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+	if err != nil {
+		return 0, errors.Wrap(err, "temporary password error")
+	}
+
+	return charset[n.Int64()], nil
+}
@@ -0,0 +1,29 @@
+package security
+
+import (
+	"github.com/pkg/errors"
+	"time"
+)
+
+// 비밀번호 변경 대기 토큰은 비밀번호 인증만 통과한 상태를 나타내며, 로그인 과정의 비밀번호 변경 API 외에는 사용할 수 없다.
+const tokenTypePasswordChange = "password-change"
+
+var InvalidPasswordChangeToken = errors.New("invalid password change token")
+
+func (JwtAuthentication) GeneratePasswordChangeToken(memberId uint) (string, error) {
+	token, err := generatePendingToken(memberId, tokenTypePasswordChange, time.Minute*10)
+	if err != nil {
+		return "", errors.Wrap(err, "create password change token error")
+	}
+
+	return token, nil
+}
+
+func (JwtAuthentication) ConvertPasswordChangeTokenMemberId(token string) (uint, error) {
+	memberId, ok := convertPendingTokenMemberId(token, tokenTypePasswordChange)
+	if !ok {
+		return 0, InvalidPasswordChangeToken
+	}
+
+	return memberId, nil
+}
@@ -0,0 +1,20 @@
+package security
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestGenerateTemporaryPassword(t *testing.T) {
+	// when
+	password, err := GenerateTemporaryPassword(16)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 16, len(password))
+	assert.True(t, strings.ContainsAny(password, "ABCDEFGHJKLMNPQRSTUVWXYZ"))
+	assert.True(t, strings.ContainsAny(password, "abcdefghijkmnopqrstuvwxyz"))
+	assert.True(t, strings.ContainsAny(password, "23456789"))
+	assert.True(t, strings.ContainsAny(password, "!@#$%^*-_=+"))
+}
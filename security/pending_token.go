@@ -0,0 +1,42 @@
+package security
+
+import (
+	"github.com/golang-jwt/jwt"
+	"time"
+)
+
+// 로그인 대기 토큰은 로그인 과정 중 일부만 통과한 상태를 나타내며, typ 클레임이 있으므로 API 호출에 사용할 수 없다.
+func generatePendingToken(memberId uint, tokenType string, expiresIn time.Duration) (string, error) {
+	tokenId, err := NewRandomId()
+	if err != nil {
+		return "", err
+	}
+
+	issuedAt := time.Now()
+	return signToken(jwt.MapClaims{
+		"id":  memberId,
+		"typ": tokenType,
+		"jti": tokenId,
+		"iat": issuedAt.Unix(),
+		"exp": issuedAt.Add(expiresIn).Unix(),
+	})
+}
+
+func convertPendingTokenMemberId(token string, tokenType string) (uint, bool) {
+	parsedToken, err := jwt.Parse(token, findVerificationKey)
+	if err != nil || !parsedToken.Valid {
+		return 0, false
+	}
+
+	claimInfo, ok := parsedToken.Claims.(jwt.MapClaims)
+	if !ok || claimInfo["typ"] != tokenType {
+		return 0, false
+	}
+
+	memberId, ok := claimInfo["id"].(float64)
+	if !ok {
+		return 0, false
+	}
+
+	return uint(memberId), true
+}
@@ -0,0 +1,25 @@
+package security
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+// PersonalAccessTokenPrefix 개인 액세스 토큰을 JWT 와 구분하기 위한 접두사
+const PersonalAccessTokenPrefix = "bat_"
+
+// NewPersonalAccessToken 개인 액세스 토큰 원문을 생성한다. 원문은 발급할 때 한 번만 보여주고 서버에는 해시(HashToken)만 저장한다.
+func NewPersonalAccessToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", errors.Wrap(err, "personal access token error")
+	}
+
+	return PersonalAccessTokenPrefix + hex.EncodeToString(bytes), nil
+}
+
+func IsPersonalAccessToken(token string) bool {
+	return strings.HasPrefix(token, PersonalAccessTokenPrefix)
+}
@@ -0,0 +1,24 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"github.com/pkg/errors"
+)
+
+// NewPkceCodeVerifier https://datatracker.ietf.org/doc/html/rfc7636#section-4.1
+func NewPkceCodeVerifier() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.Wrap(err, "generate pkce code verifier error")
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// GetPkceS256CodeChallenge https://datatracker.ietf.org/doc/html/rfc7636#section-4.2
+func GetPkceS256CodeChallenge(codeVerifier string) string {
+	hash := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
@@ -0,0 +1,22 @@
+package security
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetPkceS256CodeChallenge_RFC7636_테스트_벡터(t *testing.T) {
+	// https://datatracker.ietf.org/doc/html/rfc7636#appendix-B
+	assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+		GetPkceS256CodeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"))
+}
+
+func TestNewPkceCodeVerifier(t *testing.T) {
+	// when
+	codeVerifier, err := NewPkceCodeVerifier()
+
+	// then
+	assert.NoError(t, err)
+	// 43 ~ 128 자
+	assert.Equal(t, 43, len(codeVerifier))
+}
@@ -0,0 +1,26 @@
+package security
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"github.com/pkg/errors"
+	"strings"
+)
+
+// ScimTokenPrefix SCIM 토큰을 JWT, 개인 액세스 토큰과 구분하기 위한 접두사
+const ScimTokenPrefix = "bscim_"
+
+// NewScimToken 인사 시스템, IdP 가 SCIM API 를 호출할 때 사용하는 토큰 원문을 생성한다.
+// 원문은 발급할 때 한 번만 보여주고 서버에는 해시(HashToken)만 저장한다.
+func NewScimToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", errors.Wrap(err, "scim token error")
+	}
+
+	return ScimTokenPrefix + hex.EncodeToString(bytes), nil
+}
+
+func IsScimToken(token string) bool {
+	return strings.HasPrefix(token, ScimTokenPrefix)
+}
@@ -0,0 +1,24 @@
+package security
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/pkg/errors"
+)
+
+// HashToken 토큰 원문 대신 DB에 저장할 SHA-256 해시 값을 반환한다.
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// NewRandomId 토큰 ID(jti), 토큰 패밀리 ID 등에 사용할 임의의 값을 생성한다.
+func NewRandomId() (string, error) {
+	bytes := make([]byte, 16)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", errors.Wrap(err, "random id error")
+	}
+
+	return hex.EncodeToString(bytes), nil
+}
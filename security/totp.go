@@ -0,0 +1,119 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"github.com/pkg/errors"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RFC 6238 TOTP (HMAC-SHA1, 6자리, 30초)
+const (
+	totpDigits     = 6
+	totpPeriod     = 30
+	totpSkewSteps  = 1
+	recoveryLength = 10
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func GenerateTotpSecret() (string, error) {
+	secret := make([]byte, 20)
+	if _, err := rand.Read(secret); err != nil {
+		return "", errors.Wrap(err, "totp secret error")
+	}
+
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// GetTotpUri 인증 앱(Google Authenticator 등)에 등록할 수 있는 otpauth URI 를 반환한다.
+func GetTotpUri(issuer, accountName, secret string) string {
+	label := url.PathEscape(fmt.Sprintf("%v:%v", issuer, accountName))
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(totpDigits))
+	query.Set("period", fmt.Sprint(totpPeriod))
+
+	return fmt.Sprintf("otpauth://totp/%v?%v", label, query.Encode())
+}
+
+// ValidateTotpCode 코드가 유효하면 코드가 생성된 시간 스텝을 반환한다.
+// 시계 오차를 고려하여 앞뒤 한 스텝까지 허용하며, 이미 사용된 스텝(lastUsedStep) 이하의 코드는 재사용으로 보고 거부한다.
+func ValidateTotpCode(secret, code string, now time.Time, lastUsedStep int64) (int64, bool) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil || len(code) != totpDigits {
+		return 0, false
+	}
+
+	currentStep := now.Unix() / totpPeriod
+	for step := currentStep - totpSkewSteps; step <= currentStep+totpSkewSteps; step++ {
+		if step <= lastUsedStep {
+			continue
+		}
+
+		if subtle.ConstantTimeCompare([]byte(generateTotpCode(key, step, totpDigits)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// GenerateTotpCode 주어진 시각의 코드를 생성한다.(인증 앱과 동일)
+func GenerateTotpCode(secret string, now time.Time) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", errors.Wrap(err, "totp secret decode error")
+	}
+
+	return generateTotpCode(key, now.Unix()/totpPeriod, totpDigits), nil
+}
+
+func generateTotpCode(key []byte, step int64, digits int) string {
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < digits; i++ {
+		modulo *= 10
+	}
+
+	return fmt.Sprintf("%0*d", digits, value%modulo)
+}
+
+// GenerateRecoveryCodes 인증 앱을 사용할 수 없을 때 한 번만 사용할 수 있는 복구 코드를 생성한다.
+func GenerateRecoveryCodes(count int) ([]string, error) {
+	const alphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+	codes := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		random := make([]byte, recoveryLength)
+		if _, err := rand.Read(random); err != nil {
+			return nil, errors.Wrap(err, "recovery code error")
+		}
+
+		code := make([]byte, recoveryLength)
+		for j, b := range random {
+			code[j] = alphabet[int(b)%len(alphabet)]
+		}
+		codes = append(codes, fmt.Sprintf("%v-%v", string(code[:5]), string(code[5:])))
+	}
+
+	return codes, nil
+}
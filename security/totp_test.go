@@ -0,0 +1,67 @@
+package security
+
+import (
+	"encoding/base32"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateTotpCode_RFC6238_테스트_벡터(t *testing.T) {
+	// https://datatracker.ietf.org/doc/html/rfc6238#appendix-B (SHA1)
+	key := []byte("12345678901234567890")
+	testVectors := map[int64]string{
+		59:          "94287082",
+		1111111109:  "07081804",
+		1111111111:  "14050471",
+		1234567890:  "89005924",
+		2000000000:  "69279037",
+		20000000000: "65353130",
+	}
+
+	for unixTime, expected := range testVectors {
+		assert.Equal(t, expected, generateTotpCode(key, unixTime/30, 8))
+	}
+}
+
+func TestValidateTotpCode(t *testing.T) {
+	// given
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+	now := time.Unix(59, 0)
+
+	// when
+	step, valid := ValidateTotpCode(secret, "287082", now, 0)
+
+	// then
+	assert.True(t, valid)
+	assert.Equal(t, int64(1), step)
+
+	// 이미 사용된 스텝의 코드는 재사용할 수 없다.
+	_, valid = ValidateTotpCode(secret, "287082", now, step)
+	assert.False(t, valid)
+
+	_, valid = ValidateTotpCode(secret, "000000", now, 0)
+	assert.False(t, valid)
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	// when
+	codes, err := GenerateRecoveryCodes(10)
+
+	// then
+	assert.NoError(t, err)
+	assert.Equal(t, 10, len(codes))
+	for _, code := range codes {
+		assert.Equal(t, 11, len(code))
+		assert.True(t, strings.Contains(code, "-"))
+	}
+}
+
+func TestGetTotpUri(t *testing.T) {
+	// when
+	uri := GetTotpUri("better ADMIN", "siteadm", "JBSWY3DPEHPK3PXP")
+
+	// then
+	assert.Equal(t, "otpauth://totp/better%20ADMIN:siteadm?algorithm=SHA1&digits=6&issuer=better+ADMIN&period=30&secret=JBSWY3DPEHPK3PXP", uri)
+}
@@ -0,0 +1,29 @@
+package security
+
+import (
+	"github.com/pkg/errors"
+	"time"
+)
+
+// 2단계 인증 대기 토큰은 비밀번호 인증만 통과한 상태를 나타내며, 2단계 인증 API 외에는 사용할 수 없다.
+const tokenTypeTwoFactor = "two-factor"
+
+var InvalidTwoFactorToken = errors.New("invalid two-factor token")
+
+func (JwtAuthentication) GenerateTwoFactorToken(memberId uint) (string, error) {
+	token, err := generatePendingToken(memberId, tokenTypeTwoFactor, time.Minute*5)
+	if err != nil {
+		return "", errors.Wrap(err, "create two-factor token error")
+	}
+
+	return token, nil
+}
+
+func (JwtAuthentication) ConvertTwoFactorTokenMemberId(token string) (uint, error) {
+	memberId, ok := convertPendingTokenMemberId(token, tokenTypeTwoFactor)
+	if !ok {
+		return 0, InvalidTwoFactorToken
+	}
+
+	return memberId, nil
+}
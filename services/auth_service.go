@@ -2,79 +2,533 @@ package services
 
 import (
 	"better-admin-backend-service/adapters"
+	authDomain "better-admin-backend-service/auth/domain"
+	authRepository "better-admin-backend-service/auth/repository"
 	"better-admin-backend-service/constants"
 	"better-admin-backend-service/dtos"
 	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
 	memberDomain "better-admin-backend-service/member/domain"
 	"better-admin-backend-service/security"
 	"context"
+	"crypto/subtle"
 	"github.com/mitchellh/mapstructure"
 	pkgerrors "github.com/pkg/errors"
+	"strings"
 )
 
 type AuthService struct {
-	memberService       *MemberService
-	organizationService *OrganizationService
-	siteService         *SiteService
+	memberService             *MemberService
+	organizationService       *OrganizationService
+	siteService               *SiteService
+	tokenRevocationService    *TokenRevocationService
+	webAuthnService           *WebAuthnService
+	refreshTokenRepository    *authRepository.RefreshTokenRepository
+	oidcAuthSessionRepository *authRepository.OidcAuthSessionRepository
+	loginProtectionService    *LoginProtectionService
+	memberAccessLogService    *MemberAccessLogService
+	sessionRepository         *authRepository.SessionRepository
+	memberProvisioningService *MemberProvisioningService
+	loginCodeRepository       *authRepository.LoginCodeRepository
 }
 
 func NewAuthService(
 	memberService *MemberService,
 	organizationService *OrganizationService,
-	siteService *SiteService) *AuthService {
+	siteService *SiteService,
+	tokenRevocationService *TokenRevocationService,
+	webAuthnService *WebAuthnService,
+	refreshTokenRepository *authRepository.RefreshTokenRepository,
+	oidcAuthSessionRepository *authRepository.OidcAuthSessionRepository,
+	loginProtectionService *LoginProtectionService,
+	memberAccessLogService *MemberAccessLogService,
+	sessionRepository *authRepository.SessionRepository,
+	memberProvisioningService *MemberProvisioningService,
+	loginCodeRepository *authRepository.LoginCodeRepository) *AuthService {
 
 	return &AuthService{
-		memberService:       memberService,
-		organizationService: organizationService,
-		siteService:         siteService,
+		memberService:             memberService,
+		organizationService:       organizationService,
+		siteService:               siteService,
+		tokenRevocationService:    tokenRevocationService,
+		webAuthnService:           webAuthnService,
+		refreshTokenRepository:    refreshTokenRepository,
+		oidcAuthSessionRepository: oidcAuthSessionRepository,
+		loginProtectionService:    loginProtectionService,
+		memberAccessLogService:    memberAccessLogService,
+		sessionRepository:         sessionRepository,
+		memberProvisioningService: memberProvisioningService,
+		loginCodeRepository:       loginCodeRepository,
 	}
 }
 
-func (s AuthService) AuthWithSignIdPassword(ctx context.Context, signIn dtos.MemberSignIn) (security.JwtToken, error) {
-	memberEntity, err := s.memberService.GetMemberBySignId(ctx, signIn.Id)
+func (s AuthService) AuthWithSignIdPassword(ctx context.Context, signIn dtos.MemberSignIn) (token security.JwtToken, err error) {
+	var memberEntity memberDomain.MemberEntity
+	defer func() {
+		err = s.logLoginFailure(ctx, memberEntity.ID, signIn.Id, constants.TypeMemberSite, err)
+	}()
+
+	if err := s.loginProtectionService.CheckLoginAllowed(ctx, constants.TypeMemberSite, signIn.Id); err != nil {
+		return security.JwtToken{}, err
+	}
+
+	memberEntity, err = s.memberService.GetMemberBySignId(ctx, signIn.Id)
 	if err != nil {
+		if err == errors.ErrNotFound {
+			// 존재하지 않는 아이디도 실패로 기록하여 아이디를 대입하는 공격을 막는다.
+			return security.JwtToken{}, s.loginFailed(ctx, constants.TypeMemberSite, signIn.Id, err)
+		}
 		return security.JwtToken{}, err
 	}
 
 	err = memberEntity.ValidatePassword(signIn.Password)
 	if err != nil {
-		return security.JwtToken{}, errors.ErrAuthentication
+		return security.JwtToken{}, s.loginFailed(ctx, constants.TypeMemberSite, signIn.Id, errors.ErrAuthentication)
+	}
+
+	if err := memberEntity.ValidateLoginStatus(); err != nil {
+		return security.JwtToken{}, err
+	}
+
+	passwordPolicy, err := s.siteService.GetPasswordPolicy(ctx)
+	if err != nil {
+		return security.JwtToken{}, err
+	}
+
+	if err := s.memberService.UpgradePasswordHash(ctx, &memberEntity, signIn.Password, passwordPolicy); err != nil {
+		return security.JwtToken{}, err
+	}
+
+	if memberEntity.IsPasswordChangeRequired(passwordPolicy) {
+		passwordChangeToken, err := security.JwtAuthentication{}.GeneratePasswordChangeToken(memberEntity.ID)
+		if err != nil {
+			return security.JwtToken{}, err
+		}
+
+		return security.JwtToken{}, &errors.ErrPasswordChangeRequired{PasswordChangeToken: passwordChangeToken}
+	}
+
+	if err := s.requireTwoFactor(ctx, memberEntity); err != nil {
+		return security.JwtToken{}, err
+	}
+
+	// 비밀번호 변경, 2단계 인증까지 모두 통과한 경우에만 실패 기록을 초기화한다.
+	if err := s.loginProtectionService.RecordLoginSuccess(ctx, constants.TypeMemberSite, signIn.Id); err != nil {
+		return security.JwtToken{}, err
+	}
+
+	return s.generateJwtTokenAndLogMemberAccess(ctx, memberEntity, constants.TypeMemberSite)
+}
+
+// loginFailed 로그인 실패를 기록한 뒤 원래의 에러를 반환한다.
+func (s AuthService) loginFailed(ctx context.Context, loginType, loginId string, err error) error {
+	if recordErr := s.loginProtectionService.RecordLoginFailure(ctx, loginType, loginId); recordErr != nil {
+		return recordErr
+	}
+
+	return err
+}
+
+// logLoginFailure 로그인에 실패한 경우 접근 기록을 남긴 뒤 원래의 에러를 반환한다.
+// 2단계 인증 또는 비밀번호 변경을 기다리는 경우는 실패로 기록하지 않는다.
+func (s AuthService) logLoginFailure(ctx context.Context, memberId uint, loginId, authType string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	switch err.(type) {
+	case *errors.ErrTwoFactorRequired, *errors.ErrPasswordChangeRequired:
+		return err
+	}
+
+	if logErr := s.memberAccessLogService.LogMemberAccess(ctx, memberId, loginId,
+		constants.MemberAccessTypeLogin, authType, err); logErr != nil {
+		return logErr
+	}
+
+	return err
+}
+
+// AuthWithPasswordChange 로그인 과정에서 비밀번호를 변경한 뒤 이어서 로그인(2단계 인증 포함)한다.
+func (s AuthService) AuthWithPasswordChange(ctx context.Context, signIn dtos.MemberPasswordChangeSignIn) (token security.JwtToken, err error) {
+	var memberEntity memberDomain.MemberEntity
+	defer func() {
+		err = s.logLoginFailure(ctx, memberEntity.ID, memberEntity.SignId, constants.TypeMemberSite, err)
+	}()
+
+	memberId, err := security.JwtAuthentication{}.ConvertPasswordChangeTokenMemberId(signIn.PasswordChangeToken)
+	if err != nil {
+		return security.JwtToken{}, err
+	}
+
+	memberEntity, err = s.memberService.ChangeRequiredPassword(ctx, memberId, signIn.NewPassword)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return security.JwtToken{}, security.InvalidPasswordChangeToken
+		}
+		return security.JwtToken{}, err
 	}
 
-	approved := memberEntity.IsApproved()
-	if approved == false {
-		return security.JwtToken{}, errors.ErrUnApproved
+	if err := memberEntity.ValidateLoginStatus(); err != nil {
+		return security.JwtToken{}, err
+	}
+
+	if err := s.requireTwoFactor(ctx, memberEntity); err != nil {
+		return security.JwtToken{}, err
+	}
+
+	if err := s.loginProtectionService.RecordLoginSuccess(ctx, constants.TypeMemberSite, memberEntity.SignId); err != nil {
+		return security.JwtToken{}, err
+	}
+
+	return s.generateJwtTokenAndLogMemberAccess(ctx, memberEntity, constants.TypeMemberSite)
+}
+
+// requireTwoFactor 2단계 인증이 필요한 경우 2단계 인증 대기 토큰을 담은 errors.ErrTwoFactorRequired 를 반환한다.
+func (s AuthService) requireTwoFactor(ctx context.Context, memberEntity memberDomain.MemberEntity) error {
+	enrollmentRequired := false
+	if memberEntity.TwoFactorEnabled == false {
+		required, err := s.isTwoFactorRequired(ctx, memberEntity)
+		if err != nil {
+			return err
+		}
+
+		if required == false {
+			return nil
+		}
+
+		// 2단계 인증이 필수이지만 아직 등록하지 않은 경우 로그인 과정에서 등록하도록 한다.
+		enrollmentRequired = true
 	}
 
-	return s.generateJwtTokenAndLogMemberAccess(ctx, memberEntity)
+	twoFactorToken, err := security.JwtAuthentication{}.GenerateTwoFactorToken(memberEntity.ID)
+	if err != nil {
+		return err
+	}
+
+	return &errors.ErrTwoFactorRequired{
+		TwoFactorToken:     twoFactorToken,
+		EnrollmentRequired: enrollmentRequired,
+	}
 }
 
-func (s AuthService) generateJwtTokenAndLogMemberAccess(ctx context.Context, memberEntity memberDomain.MemberEntity) (token security.JwtToken, err error) {
+func (s AuthService) isTwoFactorRequired(ctx context.Context, memberEntity memberDomain.MemberEntity) (bool, error) {
+	if memberEntity.Type != constants.TypeMemberSite {
+		return false, nil
+	}
+
+	twoFactorAuthSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyTwoFactorAuth)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+
+	var settings dtos.TwoFactorAuthSetting
+	if err = mapstructure.Decode(twoFactorAuthSetting, &settings); err != nil {
+		return false, err
+	}
+
+	if settings.RequiredForAllPermissions == nil || *settings.RequiredForAllPermissions == false {
+		return false, nil
+	}
+
+	memberAssignedAllRoleAndPermission, err := s.organizationService.GetMemberAssignedAllRoleAndPermission(ctx, memberEntity)
+	if err != nil {
+		return false, err
+	}
+
+	for _, permission := range memberAssignedAllRoleAndPermission.Permissions {
+		if strings.HasSuffix(permission, ".all") {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// AuthWithTwoFactor 2단계 인증을 완료하고 토큰을 발급한다.
+// 로그인 과정에서 2단계 인증을 처음 등록한 경우 새로 발급된 복구 코드를 함께 반환한다.
+func (s AuthService) AuthWithTwoFactor(ctx context.Context, signIn dtos.MemberTwoFactorSignIn) (token security.JwtToken, recoveryCodes []string, err error) {
+	var memberEntity memberDomain.MemberEntity
+	defer func() {
+		err = s.logLoginFailure(ctx, memberEntity.ID, memberEntity.SignId, constants.TypeMemberSite, err)
+	}()
+
+	memberId, err := security.JwtAuthentication{}.ConvertTwoFactorTokenMemberId(signIn.TwoFactorToken)
+	if err != nil {
+		return security.JwtToken{}, nil, err
+	}
+
+	memberEntity, err = s.memberService.GetMemberById(ctx, memberId)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return security.JwtToken{}, nil, security.InvalidTwoFactorToken
+		}
+		return security.JwtToken{}, nil, err
+	}
+
+	if err := memberEntity.ValidateLoginStatus(); err != nil {
+		return security.JwtToken{}, nil, err
+	}
+
+	// 2단계 인증 코드의 대입도 같은 아이디의 로그인 실패로 기록한다.
+	if err := s.loginProtectionService.CheckLoginAllowed(ctx, constants.TypeMemberSite, memberEntity.SignId); err != nil {
+		return security.JwtToken{}, nil, err
+	}
+
+	if memberEntity.TwoFactorEnabled {
+		err = s.memberService.VerifyTwoFactorCode(ctx, memberId, signIn.Code)
+	} else {
+		recoveryCodes, err = s.memberService.ActivateTwoFactor(ctx, memberId, signIn.Code)
+	}
+	if err != nil {
+		if err == errors.ErrInvalidTwoFactorCode {
+			return security.JwtToken{}, nil, s.loginFailed(ctx, constants.TypeMemberSite, memberEntity.SignId, err)
+		}
+		return security.JwtToken{}, nil, err
+	}
+
+	if err := s.loginProtectionService.RecordLoginSuccess(ctx, constants.TypeMemberSite, memberEntity.SignId); err != nil {
+		return security.JwtToken{}, nil, err
+	}
+
+	token, err = s.generateJwtTokenAndLogMemberAccess(ctx, memberEntity, constants.TypeMemberSite)
+	if err != nil {
+		return security.JwtToken{}, nil, err
+	}
+
+	return token, recoveryCodes, nil
+}
+
+func (s AuthService) StartTwoFactorEnrollment(ctx context.Context, twoFactorToken string) (dtos.MemberTwoFactorEnrollment, error) {
+	memberId, err := security.JwtAuthentication{}.ConvertTwoFactorTokenMemberId(twoFactorToken)
+	if err != nil {
+		return dtos.MemberTwoFactorEnrollment{}, err
+	}
+
+	enrollment, err := s.memberService.StartTwoFactorEnrollment(ctx, memberId)
+	if err == errors.ErrNotFound {
+		return dtos.MemberTwoFactorEnrollment{}, security.InvalidTwoFactorToken
+	}
+
+	return enrollment, err
+}
+
+// AuthWithPasskey 패스키는 소유(인증기)와 사용자 확인(생체 인증, PIN)을 함께 검증하므로 2단계 인증을 추가로 요구하지 않는다.
+func (s AuthService) AuthWithPasskey(ctx context.Context, assertion dtos.WebAuthnAssertion) (token security.JwtToken, err error) {
+	var memberEntity memberDomain.MemberEntity
+	defer func() {
+		err = s.logLoginFailure(ctx, memberEntity.ID, memberEntity.GetCandidateId(), constants.AuthTypePasskey, err)
+	}()
+
+	memberEntity, err = s.webAuthnService.FinishLogin(ctx, assertion)
+	if err != nil {
+		return security.JwtToken{}, err
+	}
+
+	if err := memberEntity.ValidateLoginStatus(); err != nil {
+		return security.JwtToken{}, err
+	}
+
+	return s.generateJwtTokenAndLogMemberAccess(ctx, memberEntity, constants.AuthTypePasskey)
+}
+
+func (s AuthService) generateJwtTokenAndLogMemberAccess(ctx context.Context, memberEntity memberDomain.MemberEntity, authType string) (token security.JwtToken, err error) {
 	memberAssignedAllRoleAndPermission, err := s.organizationService.GetMemberAssignedAllRoleAndPermission(ctx, memberEntity)
 	if err != nil {
 		return
 	}
 
+	// 로그인 할 때마다 새로운 리프레시 토큰 패밀리(세션)가 시작된다.
+	familyId, err := security.NewRandomId()
+	if err != nil {
+		return
+	}
+
 	token, err = security.JwtAuthentication{}.GenerateJwtToken(security.UserClaim{
-		Id:          memberEntity.ID,
-		Roles:       memberAssignedAllRoleAndPermission.Roles,
-		Permissions: memberAssignedAllRoleAndPermission.Permissions,
+		Id:                memberEntity.ID,
+		Roles:             memberAssignedAllRoleAndPermission.Roles,
+		Permissions:       memberAssignedAllRoleAndPermission.Permissions,
+		TokenEpoch:        memberEntity.TokenEpoch,
+		PermissionVersion: memberEntity.PermissionVersion,
+		SessionId:         familyId,
 	})
+	if err != nil {
+		return
+	}
+
+	if err = s.saveRefreshToken(ctx, memberEntity.ID, familyId, token); err != nil {
+		return
+	}
+
+	sessionEntity := authDomain.NewSessionEntity(ctx, memberEntity.ID, familyId, authType, token.RefreshTokenExpires)
+	if err = s.sessionRepository.Create(ctx, &sessionEntity); err != nil {
+		return
+	}
 
-	err = s.logMemberAccessAt(ctx, memberEntity.ID)
+	err = s.logMemberAccess(ctx, memberEntity, constants.MemberAccessTypeLogin, authType)
 	return
 }
 
-func (s AuthService) logMemberAccessAt(ctx context.Context, memberId uint) error {
-	err := s.memberService.UpdateMemberLastAccessAt(ctx, memberId)
+func (s AuthService) saveRefreshToken(ctx context.Context, memberId uint, familyId string, token security.JwtToken) error {
+	refreshTokenEntity := authDomain.NewRefreshTokenEntity(memberId, familyId, token)
+	return s.refreshTokenRepository.Create(ctx, &refreshTokenEntity)
+}
+
+func (s AuthService) RefreshAccessToken(ctx context.Context, refreshToken string) (token security.JwtToken, err error) {
+	var memberId uint
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		if logErr := s.memberAccessLogService.LogMemberAccess(ctx, memberId, "",
+			constants.MemberAccessTypeTokenRefresh, "", err); logErr != nil {
+			err = logErr
+		}
+	}()
+
+	userClaim, err := security.JwtAuthentication{}.ConvertRefreshTokenUserClaim(refreshToken)
+	if err != nil {
+		return security.JwtToken{}, errors.ErrInvalidRefreshToken
+	}
+	memberId = userClaim.Id
+
+	refreshTokenEntity, err := s.refreshTokenRepository.FindByTokenHash(ctx, security.HashToken(refreshToken))
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return security.JwtToken{}, errors.ErrInvalidRefreshToken
+		}
+		return security.JwtToken{}, err
+	}
+
+	if refreshTokenEntity.IsRotated() {
+		return security.JwtToken{}, s.revokeReusedRefreshToken(ctx, refreshTokenEntity)
+	}
+
+	if refreshTokenEntity.IsRevoked() || refreshTokenEntity.IsExpired() || refreshTokenEntity.MemberId != userClaim.Id {
+		return security.JwtToken{}, errors.ErrInvalidRefreshToken
+	}
+
+	// 권한 버전이 변경된 경우에도 갱신은 허용한다.(아래에서 역할과 권한을 다시 계산한다.)
+	if err := s.tokenRevocationService.ValidateToken(ctx, *userClaim); err != nil && err != security.AccessTokenPermissionChanged {
+		if err == security.AccessTokenRevoked {
+			return security.JwtToken{}, errors.ErrInvalidRefreshToken
+		}
+		return security.JwtToken{}, err
+	}
+
+	// 리프레시 토큰의 클레임을 그대로 사용하지 않고, 멤버의 현재 역할과 권한으로 토큰을 다시 발급한다.
+	memberEntity, err := s.memberService.GetMemberById(ctx, refreshTokenEntity.MemberId)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return security.JwtToken{}, errors.ErrInvalidRefreshToken
+		}
+		return security.JwtToken{}, err
+	}
+
+	if memberEntity.IsApproved() == false {
+		return security.JwtToken{}, errors.ErrInvalidRefreshToken
+	}
+
+	memberAssignedAllRoleAndPermission, err := s.organizationService.GetMemberAssignedAllRoleAndPermission(ctx, memberEntity)
+	if err != nil {
+		return security.JwtToken{}, err
+	}
+
+	sessionEntity, err := s.sessionRepository.FindBySessionId(ctx, refreshTokenEntity.FamilyId)
+	sessionExists := err == nil
+	if err != nil && err != errors.ErrNotFound {
+		return security.JwtToken{}, err
+	}
+
+	if sessionExists && sessionEntity.IsRevoked() {
+		return security.JwtToken{}, errors.ErrInvalidRefreshToken
+	}
+
+	// 조회한 뒤 다른 요청이 먼저 회전한 경우에도 재사용으로 본다.
+	rotated, err := s.refreshTokenRepository.Rotate(ctx, &refreshTokenEntity)
+	if err != nil {
+		return security.JwtToken{}, err
+	}
+	if rotated == false {
+		return security.JwtToken{}, s.revokeReusedRefreshToken(ctx, refreshTokenEntity)
+	}
+
+	token, err = security.JwtAuthentication{}.GenerateJwtToken(security.UserClaim{
+		Id:                memberEntity.ID,
+		Roles:             memberAssignedAllRoleAndPermission.Roles,
+		Permissions:       memberAssignedAllRoleAndPermission.Permissions,
+		TokenEpoch:        memberEntity.TokenEpoch,
+		PermissionVersion: memberEntity.PermissionVersion,
+		SessionId:         userClaim.SessionId,
+	})
+	if err != nil {
+		return security.JwtToken{}, err
+	}
+
+	if err := s.saveRefreshToken(ctx, refreshTokenEntity.MemberId, refreshTokenEntity.FamilyId, token); err != nil {
+		return security.JwtToken{}, err
+	}
+
+	// 세션 기능 이전에 발급된 리프레시 토큰에는 세션이 없다.
+	if sessionExists {
+		sessionEntity.Touch(ctx, token.RefreshTokenExpires)
+		if err := s.sessionRepository.Save(ctx, &sessionEntity); err != nil {
+			return security.JwtToken{}, err
+		}
+	}
+
+	if err := s.logMemberAccess(ctx, memberEntity, constants.MemberAccessTypeTokenRefresh, memberEntity.Type); err != nil {
+		return security.JwtToken{}, err
+	}
+
+	return token, nil
+}
+
+// revokeReusedRefreshToken 이미 회전된 토큰이 다시 사용되었다는 것은 토큰이 탈취되었을 수 있다는 의미이므로 토큰 패밀리 전체를 폐기한다.
+func (s AuthService) revokeReusedRefreshToken(ctx context.Context, refreshTokenEntity authDomain.RefreshTokenEntity) error {
+	if err := s.tokenRevocationService.RevokeRefreshTokenFamily(ctx, refreshTokenEntity.FamilyId); err != nil {
+		return err
+	}
+
+	return errors.ErrRefreshTokenReused
+}
+
+func (s AuthService) Logout(ctx context.Context, refreshToken string) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := s.tokenRevocationService.RevokeAccessToken(ctx, *userClaim); err != nil {
+		return err
+	}
+
+	if len(refreshToken) == 0 {
+		return nil
+	}
+
+	return s.tokenRevocationService.RevokeRefreshToken(ctx, userClaim.Id, refreshToken)
+}
+
+// logMemberAccess 마지막 접근 시각을 갱신하고 접근 기록을 남긴다.
+func (s AuthService) logMemberAccess(ctx context.Context, memberEntity memberDomain.MemberEntity, accessType, authType string) error {
+	err := s.memberService.UpdateMemberLastAccessAt(ctx, memberEntity.ID)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return s.memberAccessLogService.LogMemberAccess(ctx, memberEntity.ID, memberEntity.GetCandidateId(), accessType, authType, nil)
 }
 
-func (s AuthService) AuthWithDoorayIdAndPassword(ctx context.Context, signIn dtos.MemberSignIn) (security.JwtToken, error) {
+func (s AuthService) AuthWithDoorayIdAndPassword(ctx context.Context, signIn dtos.MemberSignIn) (token security.JwtToken, err error) {
+	defer func() {
+		err = s.logLoginFailure(ctx, 0, signIn.Id, constants.TypeMemberDooray, err)
+	}()
+
 	doorayLoginSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyDoorayLogin)
 	if err != nil {
 		return security.JwtToken{}, err
@@ -90,8 +544,19 @@ func (s AuthService) AuthWithDoorayIdAndPassword(ctx context.Context, signIn dto
 		return security.JwtToken{}, err
 	}
 
+	if err := s.loginProtectionService.CheckLoginAllowed(ctx, constants.TypeMemberDooray, signIn.Id); err != nil {
+		return security.JwtToken{}, err
+	}
+
 	doorayMember, err := adapters.DoorayAdapter{}.Authenticate(settings.Domain, settings.AuthorizationToken, signIn.Id, signIn.Password)
 	if err != nil {
+		if err == errors.ErrAuthentication {
+			return security.JwtToken{}, s.loginFailed(ctx, constants.TypeMemberDooray, signIn.Id, err)
+		}
+		return security.JwtToken{}, err
+	}
+
+	if err := s.loginProtectionService.RecordLoginSuccess(ctx, constants.TypeMemberDooray, signIn.Id); err != nil {
 		return security.JwtToken{}, err
 	}
 
@@ -104,73 +569,433 @@ func (s AuthService) AuthWithDoorayIdAndPassword(ctx context.Context, signIn dto
 				return security.JwtToken{}, err
 			}
 
-			memberAssignedAllRoleAndPermission, err := s.organizationService.GetMemberAssignedAllRoleAndPermission(ctx, newMemberEntity)
+			newMemberEntity, err = s.memberProvisioningService.ProvisionMember(ctx, newMemberEntity.ID)
 			if err != nil {
 				return security.JwtToken{}, err
 			}
 
-			return security.JwtAuthentication{}.GenerateJwtToken(security.UserClaim{
-				Id:          newMemberEntity.ID,
-				Roles:       memberAssignedAllRoleAndPermission.Roles,
-				Permissions: memberAssignedAllRoleAndPermission.Permissions,
-			})
+			return s.generateJwtTokenAndLogMemberAccess(ctx, newMemberEntity, constants.TypeMemberDooray)
 		}
 		return security.JwtToken{}, err
 	}
 
-	return s.generateJwtTokenAndLogMemberAccess(ctx, memberEntity)
+	memberEntity, err = s.memberService.UpdateMemberFromDoorayMember(ctx, memberEntity.ID, doorayMember)
+	if err != nil {
+		return security.JwtToken{}, err
+	}
+
+	// SCIM 으로 비활성화되거나 정지된 멤버에 연결된 로그인인 경우
+	if err := memberEntity.ValidateLoginStatus(); err != nil {
+		return security.JwtToken{}, err
+	}
+
+	return s.generateJwtTokenAndLogMemberAccess(ctx, memberEntity, constants.TypeMemberDooray)
 }
 
-func (s AuthService) AuthWithGoogleWorkspaceAccount(ctx context.Context, code string) (security.JwtToken, error) {
-	googleWorkspaceLoginSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyGoogleWorkspaceLogin)
+func (s AuthService) AuthWithLdap(ctx context.Context, signIn dtos.MemberSignIn) (token security.JwtToken, err error) {
+	// LDAP 아이디는 대소문자를 구분하지 않는다.
+	loginId := strings.ToLower(signIn.Id)
+	var memberEntity memberDomain.MemberEntity
+	defer func() {
+		err = s.logLoginFailure(ctx, memberEntity.ID, loginId, constants.TypeMemberLdap, err)
+	}()
+
+	ldapLoginSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyLdapLogin)
 	if err != nil {
+		if err == errors.ErrNotFound {
+			return security.JwtToken{}, errors.ErrNotSupportedLdap
+		}
 		return security.JwtToken{}, err
 	}
 
-	var settings dtos.GoogleWorkspaceLoginSetting
-	if err = mapstructure.Decode(googleWorkspaceLoginSetting, &settings); err != nil {
+	var settings dtos.LdapLoginSetting
+	if err = mapstructure.Decode(ldapLoginSetting, &settings); err != nil {
 		return security.JwtToken{}, err
 	}
 
-	if *settings.Used == false {
-		err = pkgerrors.New("not supported google workspace login")
+	if settings.Used == nil || *settings.Used == false {
+		return security.JwtToken{}, errors.ErrNotSupportedLdap
+	}
+
+	if err := s.loginProtectionService.CheckLoginAllowed(ctx, constants.TypeMemberLdap, loginId); err != nil {
 		return security.JwtToken{}, err
 	}
 
-	googleMember, err := adapters.GoogleOAuthAdapter{}.Authenticate(code, settings)
+	ldapMember, err := adapters.LdapAdapter{}.Authenticate(settings, signIn.Id, signIn.Password)
+	if err != nil {
+		if err == errors.ErrAuthentication {
+			return security.JwtToken{}, s.loginFailed(ctx, constants.TypeMemberLdap, loginId, err)
+		}
+		return security.JwtToken{}, err
+	}
 
+	if err := s.loginProtectionService.RecordLoginSuccess(ctx, constants.TypeMemberLdap, loginId); err != nil {
+		return security.JwtToken{}, err
+	}
+
+	memberEntity, err = s.memberService.GetMemberByLdapUserId(ctx, ldapMember.UserId)
 	if err != nil {
+		if err != errors.ErrNotFound {
+			return security.JwtToken{}, err
+		}
+
+		memberEntity = memberDomain.NewMemberEntityFromLdapMember(ldapMember, settings.AutoApproval)
+		if err = s.memberService.CreateMember(ctx, &memberEntity); err != nil {
+			return security.JwtToken{}, err
+		}
+
+		memberEntity, err = s.memberProvisioningService.ProvisionMember(ctx, memberEntity.ID)
+		if err != nil {
+			return security.JwtToken{}, err
+		}
+	} else {
+		memberEntity, err = s.memberService.UpdateMemberFromLdapMember(ctx, memberEntity.ID, ldapMember)
+		if err != nil {
+			return security.JwtToken{}, err
+		}
+	}
+
+	if err := memberEntity.ValidateLoginStatus(); err != nil {
 		return security.JwtToken{}, err
 	}
 
-	if googleMember.Hd != settings.Domain {
-		return security.JwtToken{}, &errors.ErrInvalidGoogleWorkspaceAccount{
-			Domain: settings.Domain,
+	return s.generateJwtTokenAndLogMemberAccess(ctx, memberEntity, constants.TypeMemberLdap)
+}
+
+// BeginGoogleWorkspaceLogin 구글 인가 URI 와 로그인을 시작한 브라우저에 보관할 nonce 를 반환한다.
+// 로그인 후 돌아갈 주소는 허용 목록에 있는 경우에만 서명한 state 에 담아 구글에 전달한다.
+func (s AuthService) BeginGoogleWorkspaceLogin(ctx context.Context, redirect string) (string, string, error) {
+	settings, err := s.getGoogleWorkspaceLoginSetting(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	if settings.IsAllowedRedirectUrl(redirect) == false {
+		return "", "", errors.ErrNotAllowedRedirectUrl
+	}
+
+	state, nonce, err := security.JwtAuthentication{}.GenerateLoginState(redirect)
+	if err != nil {
+		return "", "", err
+	}
+
+	return settings.GetOAuthUri(state), nonce, nil
+}
+
+// AuthWithGoogleWorkspaceAccount 구글에서 돌아온 인가 코드로 인증한다. state 에 담아 둔 redirect 를 함께 반환한다.
+// nonce 는 로그인을 시작한 브라우저의 쿠키에 보관한 값이다.
+func (s AuthService) AuthWithGoogleWorkspaceAccount(ctx context.Context, state, nonce, code string) (token security.JwtToken, redirect string, err error) {
+	var googleMember dtos.GoogleMember
+	defer func() {
+		err = s.logLoginFailure(ctx, 0, googleMember.Email, constants.TypeMemberGoogle, err)
+	}()
+
+	loginState, err := security.JwtAuthentication{}.ConvertLoginState(state)
+	if err != nil || subtle.ConstantTimeCompare([]byte(loginState.Nonce), []byte(nonce)) != 1 {
+		return security.JwtToken{}, "", errors.ErrInvalidLoginState
+	}
+
+	settings, err := s.getGoogleWorkspaceLoginSetting(ctx)
+	if err != nil {
+		return security.JwtToken{}, "", err
+	}
+
+	// 로그인을 시작한 뒤 허용 목록에서 제외된 주소로는 돌아가지 않는다.
+	if settings.IsAllowedRedirectUrl(loginState.Redirect) == false {
+		return security.JwtToken{}, "", errors.ErrInvalidLoginState
+	}
+
+	redirect = loginState.Redirect
+	googleMember, err = adapters.GoogleOAuthAdapter{}.Authenticate(code, settings)
+
+	if err != nil {
+		return security.JwtToken{}, redirect, err
+	}
+
+	domain, ok := settings.FindDomain(googleMember.Hd)
+	if ok == false {
+		return security.JwtToken{}, redirect, &errors.ErrInvalidGoogleWorkspaceAccount{
+			Domain: strings.Join(settings.GetDomainNames(), ", "),
 		}
 	}
 
 	memberEntity, err := s.memberService.GetMemberByGoogleId(ctx, googleMember.Id)
 	if err != nil {
-		if err == errors.ErrNotFound {
-			newMemberEntity := memberDomain.NewMemberEntityFromGoogleMember(googleMember)
+		if err != errors.ErrNotFound {
+			return security.JwtToken{}, redirect, err
+		}
 
-			if err = s.memberService.CreateMember(ctx, &newMemberEntity); err != nil {
-				return security.JwtToken{}, err
+		// 구글 워크스페이스 계정의 메일 주소는 확인된 주소이므로 SCIM 으로 프로비저닝된 멤버가 있으면 로그인을 연결한다.
+		memberEntity, err = s.memberService.LinkScimMember(ctx, googleMember.Email,
+			memberDomain.NewMemberIdentityEntity(constants.TypeMemberGoogle, "", googleMember.Id, googleMember.Email))
+		if err != nil {
+			if err != errors.ErrNotFound {
+				return security.JwtToken{}, redirect, err
 			}
 
-			memberAssignedAllRoleAndPermission, err := s.organizationService.GetMemberAssignedAllRoleAndPermission(ctx, newMemberEntity)
+			newMemberEntity, err := s.createGoogleMember(ctx, googleMember, domain)
 			if err != nil {
-				return security.JwtToken{}, err
+				return security.JwtToken{}, redirect, err
 			}
 
-			return security.JwtAuthentication{}.GenerateJwtToken(security.UserClaim{
-				Id:          newMemberEntity.ID,
-				Roles:       memberAssignedAllRoleAndPermission.Roles,
-				Permissions: memberAssignedAllRoleAndPermission.Permissions,
-			})
+			token, err = s.generateJwtTokenAndLogMemberAccess(ctx, newMemberEntity, constants.TypeMemberGoogle)
+			return token, redirect, err
+		}
+	} else {
+		memberEntity, err = s.memberService.UpdateMemberFromGoogleMember(ctx, memberEntity.ID, googleMember)
+		if err != nil {
+			return security.JwtToken{}, redirect, err
+		}
+	}
+
+	// SCIM 으로 비활성화되거나 정지된 멤버 등
+	if err := memberEntity.ValidateLoginStatus(); err != nil {
+		return security.JwtToken{}, redirect, err
+	}
+
+	token, err = s.generateJwtTokenAndLogMemberAccess(ctx, memberEntity, constants.TypeMemberGoogle)
+	return token, redirect, err
+}
+
+// IssueLoginCode 리다이렉트 URL 에 액세스 토큰 대신 전달할 일회용 코드를 발급한다.
+func (s AuthService) IssueLoginCode(ctx context.Context, token security.JwtToken) (string, error) {
+	code, err := security.NewLoginCode()
+	if err != nil {
+		return "", err
+	}
+
+	codeEntity := authDomain.NewLoginCodeEntity(code, token)
+	if err := s.loginCodeRepository.Create(ctx, &codeEntity); err != nil {
+		return "", err
+	}
+
+	return code, nil
+}
+
+// ExchangeLoginCode 코드는 성공 여부와 관계없이 한 번만 교환할 수 있다.
+func (s AuthService) ExchangeLoginCode(ctx context.Context, code string) (security.JwtToken, error) {
+	codeEntity, err := s.loginCodeRepository.FindByCodeHash(ctx, security.HashToken(code))
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return security.JwtToken{}, errors.ErrInvalidLoginCode
+		}
+		return security.JwtToken{}, err
+	}
+
+	// 삭제한 요청만 토큰을 받는다.
+	if err := s.loginCodeRepository.Delete(ctx, codeEntity); err != nil {
+		if err == errors.ErrNotFound {
+			return security.JwtToken{}, errors.ErrInvalidLoginCode
 		}
 		return security.JwtToken{}, err
 	}
 
-	return s.generateJwtTokenAndLogMemberAccess(ctx, memberEntity)
+	if codeEntity.IsExpired() {
+		return security.JwtToken{}, errors.ErrInvalidLoginCode
+	}
+
+	return codeEntity.GetJwtToken(), nil
+}
+
+func (s AuthService) getGoogleWorkspaceLoginSetting(ctx context.Context) (dtos.GoogleWorkspaceLoginSetting, error) {
+	googleWorkspaceLoginSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyGoogleWorkspaceLogin)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return dtos.GoogleWorkspaceLoginSetting{}, errors.ErrNotSupportedGoogleWorkspace
+		}
+		return dtos.GoogleWorkspaceLoginSetting{}, err
+	}
+
+	var settings dtos.GoogleWorkspaceLoginSetting
+	if err := mapstructure.Decode(googleWorkspaceLoginSetting, &settings); err != nil {
+		return dtos.GoogleWorkspaceLoginSetting{}, err
+	}
+
+	if settings.Used == nil || *settings.Used == false {
+		return dtos.GoogleWorkspaceLoginSetting{}, errors.ErrNotSupportedGoogleWorkspace
+	}
+
+	return settings, nil
+}
+
+// createGoogleMember 처음 로그인한 멤버는 도메인에 지정된 기본 역할을 할당하고 조직에 추가한다.
+func (s AuthService) createGoogleMember(ctx context.Context, googleMember dtos.GoogleMember,
+	domain dtos.GoogleWorkspaceDomain) (memberDomain.MemberEntity, error) {
+
+	memberEntity := memberDomain.NewMemberEntityFromGoogleMember(googleMember)
+	if err := s.memberService.CreateMemberWithDefaultRoles(ctx, &memberEntity, domain.RoleIds); err != nil {
+		return memberDomain.MemberEntity{}, err
+	}
+
+	if domain.OrganizationId > 0 {
+		// 설정한 뒤 삭제된 조직은 무시한다.
+		if err := s.organizationService.AddMember(ctx, domain.OrganizationId, memberEntity); err != nil && err != errors.ErrNotFound {
+			return memberDomain.MemberEntity{}, err
+		}
+	}
+
+	// 조직, 역할이 추가되면서 권한 버전이 변경될 수 있으므로 자동 할당 규칙을 적용한 뒤 다시 조회한 멤버를 사용한다.
+	return s.memberProvisioningService.ProvisionMember(ctx, memberEntity.ID)
+}
+
+// BeginOidcLogin IdP 의 인가 엔드포인트 URI 를 반환한다. IdP 에서 돌아올 때 검증할 state, nonce, PKCE code verifier 는 세션으로 보관한다.
+// 로그인 후 돌아갈 주소는 허용 목록에 있어야 한다.
+func (s AuthService) BeginOidcLogin(ctx context.Context, redirect string) (string, error) {
+	settings, err := s.getOidcLoginSetting(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if settings.IsAllowedRedirectUrl(redirect) == false {
+		return "", errors.ErrNotAllowedRedirectUrl
+	}
+
+	state, err := security.NewRandomId()
+	if err != nil {
+		return "", err
+	}
+
+	nonce, err := security.NewRandomId()
+	if err != nil {
+		return "", err
+	}
+
+	codeVerifier, err := security.NewPkceCodeVerifier()
+	if err != nil {
+		return "", err
+	}
+
+	authorizationUri, err := adapters.OidcAdapter{}.GetAuthorizationUri(settings, state, nonce, security.GetPkceS256CodeChallenge(codeVerifier))
+	if err != nil {
+		return "", err
+	}
+
+	sessionEntity := authDomain.NewOidcAuthSessionEntity(state, nonce, codeVerifier, redirect)
+	if err := s.oidcAuthSessionRepository.Create(ctx, &sessionEntity); err != nil {
+		return "", err
+	}
+
+	return authorizationUri, nil
+}
+
+// AuthWithOidc IdP 에서 돌아온 인가 코드로 인증한다. 로그인을 시작할 때 전달받은 redirect 를 함께 반환한다.
+func (s AuthService) AuthWithOidc(ctx context.Context, state, code string) (token security.JwtToken, redirect string, err error) {
+	var oidcMember dtos.OidcMember
+	var memberEntity memberDomain.MemberEntity
+	defer func() {
+		loginId := oidcMember.Email
+		if len(loginId) == 0 {
+			loginId = oidcMember.Subject
+		}
+		err = s.logLoginFailure(ctx, memberEntity.ID, loginId, constants.TypeMemberOidc, err)
+	}()
+
+	sessionEntity, err := s.oidcAuthSessionRepository.FindByState(ctx, state)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return security.JwtToken{}, "", errors.ErrInvalidOidcState
+		}
+		return security.JwtToken{}, "", err
+	}
+
+	// 세션은 성공 여부와 관계없이 한 번만 사용할 수 있다.
+	// 콜백은 GET 요청이라 트랜잭션 없이 처리되므로 세션을 삭제한 요청만 로그인을 진행한다.
+	if err := s.oidcAuthSessionRepository.Delete(ctx, sessionEntity); err != nil {
+		if err == errors.ErrNotFound {
+			return security.JwtToken{}, "", errors.ErrInvalidOidcState
+		}
+		return security.JwtToken{}, "", err
+	}
+
+	if sessionEntity.IsExpired() {
+		return security.JwtToken{}, "", errors.ErrInvalidOidcState
+	}
+
+	settings, err := s.getOidcLoginSetting(ctx)
+	if err != nil {
+		return security.JwtToken{}, "", err
+	}
+
+	// 로그인을 시작한 뒤 허용 목록에서 제외된 주소로는 돌아가지 않는다.
+	if settings.IsAllowedRedirectUrl(sessionEntity.Redirect) == false {
+		return security.JwtToken{}, "", errors.ErrInvalidOidcState
+	}
+
+	redirect = sessionEntity.Redirect
+	if len(code) == 0 {
+		// 사용자가 IdP 에서 인증을 거부한 경우 등
+		return security.JwtToken{}, redirect, errors.ErrAuthentication
+	}
+
+	oidcMember, err = adapters.OidcAdapter{}.Authenticate(code, sessionEntity.CodeVerifier, sessionEntity.Nonce, settings)
+	if err != nil {
+		return security.JwtToken{}, redirect, err
+	}
+
+	memberEntity, err = s.memberService.GetMemberByOidcSubject(ctx, oidcMember.Issuer, oidcMember.Subject)
+	if err != nil {
+		if err != errors.ErrNotFound {
+			return security.JwtToken{}, redirect, err
+		}
+
+		memberEntity, err = s.linkOidcScimMember(ctx, oidcMember)
+		if err != nil {
+			if err != errors.ErrNotFound {
+				return security.JwtToken{}, redirect, err
+			}
+
+			memberEntity = memberDomain.NewMemberEntityFromOidcMember(oidcMember, settings.AutoApproval)
+			if err = s.memberService.CreateMember(ctx, &memberEntity); err != nil {
+				return security.JwtToken{}, redirect, err
+			}
+
+			memberEntity, err = s.memberProvisioningService.ProvisionMember(ctx, memberEntity.ID)
+			if err != nil {
+				return security.JwtToken{}, redirect, err
+			}
+		}
+	} else {
+		memberEntity, err = s.memberService.UpdateMemberFromOidcMember(ctx, memberEntity.ID, oidcMember)
+		if err != nil {
+			return security.JwtToken{}, redirect, err
+		}
+	}
+
+	if err := memberEntity.ValidateLoginStatus(); err != nil {
+		return security.JwtToken{}, redirect, err
+	}
+
+	token, err = s.generateJwtTokenAndLogMemberAccess(ctx, memberEntity, constants.TypeMemberOidc)
+	return token, redirect, err
+}
+
+// linkOidcScimMember IdP 가 확인한 메일 주소인 경우에만 SCIM 으로 프로비저닝된 멤버에 로그인을 연결한다.
+func (s AuthService) linkOidcScimMember(ctx context.Context, oidcMember dtos.OidcMember) (memberDomain.MemberEntity, error) {
+	if oidcMember.EmailVerified == false {
+		return memberDomain.MemberEntity{}, errors.ErrNotFound
+	}
+
+	return s.memberService.LinkScimMember(ctx, oidcMember.Email,
+		memberDomain.NewMemberIdentityEntity(constants.TypeMemberOidc, oidcMember.Issuer, oidcMember.Subject, oidcMember.Email))
+}
+
+func (s AuthService) getOidcLoginSetting(ctx context.Context) (dtos.OidcLoginSetting, error) {
+	oidcLoginSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyOidcLogin)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return dtos.OidcLoginSetting{}, errors.ErrNotSupportedOidc
+		}
+		return dtos.OidcLoginSetting{}, err
+	}
+
+	var settings dtos.OidcLoginSetting
+	if err = mapstructure.Decode(oidcLoginSetting, &settings); err != nil {
+		return dtos.OidcLoginSetting{}, err
+	}
+
+	if settings.Used == nil || *settings.Used == false {
+		return dtos.OidcLoginSetting{}, errors.ErrNotSupportedOidc
+	}
+
+	return settings, nil
 }
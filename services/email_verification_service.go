@@ -0,0 +1,107 @@
+package services
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	memberDomain "better-admin-backend-service/member/domain"
+	memberRepository "better-admin-backend-service/member/repository"
+	"better-admin-backend-service/security"
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+const emailVerificationTokenExpiration = time.Hour * 24
+
+// EmailVerificationService 멤버의 이메일로 인증 메일을 보내고, 메일의 토큰으로 이메일을 인증한다.
+type EmailVerificationService struct {
+	memberService                    *MemberService
+	emailVerificationTokenRepository *memberRepository.EmailVerificationTokenRepository
+}
+
+func NewEmailVerificationService(memberService *MemberService,
+	emailVerificationTokenRepository *memberRepository.EmailVerificationTokenRepository) *EmailVerificationService {
+
+	return &EmailVerificationService{
+		memberService:                    memberService,
+		emailVerificationTokenRepository: emailVerificationTokenRepository,
+	}
+}
+
+func (s EmailVerificationService) SendEmailVerification(ctx context.Context, memberEntity memberDomain.MemberEntity) error {
+	if len(memberEntity.Email) == 0 {
+		return errors.ErrEmailNotRegistered
+	}
+
+	if memberEntity.IsEmailVerified() {
+		return errors.ErrEmailAlreadyVerified
+	}
+
+	token, err := security.NewRandomId()
+	if err != nil {
+		return err
+	}
+
+	entity := memberDomain.NewEmailVerificationTokenEntity(memberEntity.ID, memberEntity.Email, token,
+		time.Now().Add(emailVerificationTokenExpiration))
+	if err := s.emailVerificationTokenRepository.Create(ctx, &entity); err != nil {
+		return err
+	}
+
+	return adapters.MailAdapter().Send(dtos.Mail{
+		To:      []string{memberEntity.Email},
+		Subject: "이메일 주소를 인증해 주세요",
+		Body: fmt.Sprintf("%s 님, 아래 링크에서 이메일 주소를 인증해 주세요.(24시간 동안 유효합니다.)\n\n%s/email-verification?token=%s",
+			memberEntity.Name, config.Config.Mail.WebUrl, url.QueryEscape(token)),
+	})
+}
+
+// ChangeEmail 변경한 이메일로 인증 메일을 보낸다.
+func (s EmailVerificationService) ChangeEmail(ctx context.Context, memberId uint, email string) error {
+	memberEntity, err := s.memberService.ChangeEmail(ctx, memberId, email)
+	if err != nil {
+		return err
+	}
+
+	if memberEntity.IsEmailVerified() {
+		return nil
+	}
+
+	return s.SendEmailVerification(ctx, memberEntity)
+}
+
+func (s EmailVerificationService) ResendEmailVerification(ctx context.Context, memberId uint) error {
+	memberEntity, err := s.memberService.GetMemberById(ctx, memberId)
+	if err != nil {
+		return err
+	}
+
+	return s.SendEmailVerification(ctx, memberEntity)
+}
+
+func (s EmailVerificationService) VerifyEmail(ctx context.Context, token string) error {
+	entity, err := s.emailVerificationTokenRepository.FindByTokenHash(ctx, security.HashToken(token))
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return errors.ErrInvalidEmailVerification
+		}
+		return err
+	}
+
+	if entity.IsAvailable() == false {
+		return errors.ErrInvalidEmailVerification
+	}
+
+	if err := s.memberService.VerifyEmail(ctx, entity.MemberId, entity.Email); err != nil {
+		if err == errors.ErrNotFound {
+			return errors.ErrInvalidEmailVerification
+		}
+		return err
+	}
+
+	entity.Use()
+	return s.emailVerificationTokenRepository.Save(ctx, &entity)
+}
@@ -0,0 +1,134 @@
+package services
+
+import (
+	authDomain "better-admin-backend-service/auth/domain"
+	authRepository "better-admin-backend-service/auth/repository"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"better-admin-backend-service/security"
+	"context"
+	"time"
+)
+
+// ImpersonationService 관리자가 멤버가 보는 화면을 그대로 확인할 수 있도록 멤버의 역할과 권한으로 대리 로그인 토큰을 발급한다.
+// 대리 로그인의 시작과 종료는 모두 기록된다.
+type ImpersonationService struct {
+	memberService           *MemberService
+	organizationService     *OrganizationService
+	tokenRevocationService  *TokenRevocationService
+	impersonationRepository *authRepository.ImpersonationRepository
+}
+
+func NewImpersonationService(memberService *MemberService,
+	organizationService *OrganizationService,
+	tokenRevocationService *TokenRevocationService,
+	impersonationRepository *authRepository.ImpersonationRepository) *ImpersonationService {
+
+	return &ImpersonationService{
+		memberService:           memberService,
+		organizationService:     organizationService,
+		tokenRevocationService:  tokenRevocationService,
+		impersonationRepository: impersonationRepository,
+	}
+}
+
+func (s ImpersonationService) StartImpersonation(ctx context.Context, memberId uint, start dtos.ImpersonationStart) (string, int64, error) {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return "", 0, err
+	}
+
+	// 대리 로그인 중에 다시 다른 멤버로 대리 로그인할 수 없다.
+	if userClaim.IsImpersonation() {
+		return "", 0, errors.ErrImpersonation
+	}
+
+	if userClaim.Id == memberId {
+		return "", 0, errors.ErrSelfImpersonation
+	}
+
+	memberEntity, err := s.memberService.GetMemberById(ctx, memberId)
+	if err != nil {
+		return "", 0, err
+	}
+
+	if memberEntity.IsApproved() == false {
+		return "", 0, errors.ErrUnApproved
+	}
+
+	memberAssignedAllRoleAndPermission, err := s.organizationService.GetMemberAssignedAllRoleAndPermission(ctx, memberEntity)
+	if err != nil {
+		return "", 0, err
+	}
+
+	// 대리 로그인 토큰은 멤버의 권한을 그대로 가지므로 자신이 가지지 않은 권한을 가진 멤버로는 대리 로그인할 수 없다.
+	impersonatorEntity, err := s.memberService.GetMemberById(ctx, userClaim.Id)
+	if err != nil {
+		return "", 0, err
+	}
+
+	impersonatorAssignedAllRoleAndPermission, err := s.organizationService.GetMemberAssignedAllRoleAndPermission(ctx, impersonatorEntity)
+	if err != nil {
+		return "", 0, err
+	}
+
+	for _, permission := range memberAssignedAllRoleAndPermission.Permissions {
+		if hasPermission(impersonatorAssignedAllRoleAndPermission.Permissions, permission) == false {
+			return "", 0, errors.ErrImpersonationNotAllowed
+		}
+	}
+
+	jti, err := security.NewRandomId()
+	if err != nil {
+		return "", 0, err
+	}
+
+	accessToken, expiresAt, err := security.JwtAuthentication{}.GenerateImpersonationToken(security.UserClaim{
+		Id:                memberEntity.ID,
+		Roles:             memberAssignedAllRoleAndPermission.Roles,
+		Permissions:       memberAssignedAllRoleAndPermission.Permissions,
+		Jti:               jti,
+		TokenEpoch:        memberEntity.TokenEpoch,
+		PermissionVersion: memberEntity.PermissionVersion,
+		Impersonator:      &security.ImpersonatorClaim{Id: impersonatorEntity.ID, TokenEpoch: impersonatorEntity.TokenEpoch},
+	})
+	if err != nil {
+		return "", 0, err
+	}
+
+	entity := authDomain.NewImpersonationEntity(ctx, userClaim.Id, memberEntity.ID, jti, start.Reason, time.Unix(expiresAt, 0))
+	if err := s.impersonationRepository.Create(ctx, &entity); err != nil {
+		return "", 0, err
+	}
+
+	return accessToken, expiresAt, nil
+}
+
+// EndImpersonation 대리 로그인 토큰을 폐기하고 종료 시각을 기록한다.
+func (s ImpersonationService) EndImpersonation(ctx context.Context) error {
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return err
+	}
+
+	if userClaim.IsImpersonation() == false {
+		return errors.ErrNotImpersonating
+	}
+
+	entity, err := s.impersonationRepository.FindByJti(ctx, userClaim.Jti)
+	if err != nil {
+		return err
+	}
+
+	entity.End()
+	if err := s.impersonationRepository.Save(ctx, &entity); err != nil {
+		return err
+	}
+
+	return s.tokenRevocationService.RevokeAccessToken(ctx, *userClaim)
+}
+
+func (s ImpersonationService) GetImpersonations(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]authDomain.ImpersonationEntity, int64, error) {
+	return s.impersonationRepository.FindAll(ctx, filters, pageable)
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	authDomain "better-admin-backend-service/auth/domain"
+	authRepository "better-admin-backend-service/auth/repository"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	"time"
+)
+
+// LoginProtectionService 아이디와 클라이언트 IP 별로 연속된 로그인 실패를 기록하여 무차별 대입 공격을 막는다.
+type LoginProtectionService struct {
+	memberService          *MemberService
+	siteService            *SiteService
+	loginFailureRepository *authRepository.LoginFailureRepository
+}
+
+func NewLoginProtectionService(memberService *MemberService,
+	siteService *SiteService,
+	loginFailureRepository *authRepository.LoginFailureRepository) *LoginProtectionService {
+
+	return &LoginProtectionService{
+		memberService:          memberService,
+		siteService:            siteService,
+		loginFailureRepository: loginFailureRepository,
+	}
+}
+
+// CheckLoginAllowed 잠겨 있거나 대기 시간이 남은 경우 인증을 시도하지 않고 errors.ErrLoginLocked 또는 errors.ErrLoginThrottled 를 반환한다.
+func (s LoginProtectionService) CheckLoginAllowed(ctx context.Context, loginType, loginId string) error {
+	for _, key := range s.getLoginFailureKeys(ctx, loginType, loginId) {
+		loginFailureEntity, err := s.loginFailureRepository.FindByLogin(ctx, key.LoginType, key.LoginId)
+		if err != nil {
+			if err == errors.ErrNotFound {
+				continue
+			}
+			return err
+		}
+
+		retryAfter := loginFailureEntity.GetRetryAfter()
+		if retryAfter <= 0 {
+			continue
+		}
+
+		if loginFailureEntity.IsLocked() {
+			return &errors.ErrLoginLocked{RetryAfter: retryAfter}
+		}
+		return &errors.ErrLoginThrottled{RetryAfter: retryAfter}
+	}
+
+	return nil
+}
+
+func (s LoginProtectionService) RecordLoginFailure(ctx context.Context, loginType, loginId string) error {
+	setting, err := s.siteService.GetLoginProtection(ctx)
+	if err != nil {
+		return err
+	}
+
+	lockout := time.Minute * time.Duration(setting.LockoutMinutes)
+	for _, key := range s.getLoginFailureKeys(ctx, loginType, loginId) {
+		loginFailureEntity, err := s.loginFailureRepository.FindByLogin(ctx, key.LoginType, key.LoginId)
+		if err != nil {
+			if err != errors.ErrNotFound {
+				return err
+			}
+			loginFailureEntity = key
+		}
+
+		maxFailures := setting.MaxFailures
+		if key.LoginType == authDomain.LoginFailureTypeIp {
+			maxFailures = setting.IpMaxFailures
+		}
+
+		loginFailureEntity.Fail(maxFailures, lockout)
+		if err := s.loginFailureRepository.Save(ctx, &loginFailureEntity); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RecordLoginSuccess 아이디의 실패 기록만 초기화한다.
+// IP 의 실패 기록은 공격자가 자신의 계정으로 로그인하여 초기화할 수 없도록 잠금 시간이 지나야 초기화된다.
+func (s LoginProtectionService) RecordLoginSuccess(ctx context.Context, loginType, loginId string) error {
+	key := authDomain.NewLoginFailureEntity(loginType, loginId)
+	return s.loginFailureRepository.DeleteByLogin(ctx, key.LoginType, key.LoginId)
+}
+
+// UnlockMember 멤버의 로그인 아이디(사이트, 두레이, LDAP)에 대한 잠금을 해제한다.
+func (s LoginProtectionService) UnlockMember(ctx context.Context, memberId uint) error {
+	memberEntity, err := s.memberService.GetMemberById(ctx, memberId)
+	if err != nil {
+		return err
+	}
+
+	loginIds := map[string]string{
+		constants.TypeMemberSite:   memberEntity.SignId,
+		constants.TypeMemberDooray: memberEntity.DoorayUserCode,
+		constants.TypeMemberLdap:   memberEntity.LdapUserId,
+	}
+
+	for loginType, loginId := range loginIds {
+		if len(loginId) == 0 {
+			continue
+		}
+
+		if err := s.RecordLoginSuccess(ctx, loginType, loginId); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s LoginProtectionService) getLoginFailureKeys(ctx context.Context, loginType, loginId string) []authDomain.LoginFailureEntity {
+	keys := []authDomain.LoginFailureEntity{authDomain.NewLoginFailureEntity(loginType, loginId)}
+
+	if clientIp := helpers.ContextHelper().GetClientIp(ctx); len(clientIp) > 0 {
+		keys = append(keys, authDomain.NewLoginFailureEntity(authDomain.LoginFailureTypeIp, clientIp))
+	}
+
+	return keys
+}
@@ -0,0 +1,42 @@
+package services
+
+import (
+	"better-admin-backend-service/dtos"
+	memberDomain "better-admin-backend-service/member/domain"
+	memberRepository "better-admin-backend-service/member/repository"
+	"context"
+)
+
+// MemberAccessLogService 로그인, 토큰 갱신의 성공/실패를 사이트 설정(member-access-log)에 따라 기록한다.
+type MemberAccessLogService struct {
+	siteService               *SiteService
+	memberAccessLogRepository *memberRepository.MemberAccessLogRepository
+}
+
+func NewMemberAccessLogService(siteService *SiteService,
+	memberAccessLogRepository *memberRepository.MemberAccessLogRepository) *MemberAccessLogService {
+
+	return &MemberAccessLogService{
+		siteService:               siteService,
+		memberAccessLogRepository: memberAccessLogRepository,
+	}
+}
+
+// LogMemberAccess failure 가 nil 이면 성공으로 기록한다.
+func (s MemberAccessLogService) LogMemberAccess(ctx context.Context, memberId uint, loginId, accessType, authType string, failure error) error {
+	setting, err := s.siteService.GetMemberAccessLog(ctx)
+	if err != nil {
+		return err
+	}
+
+	if setting.IsUsed(accessType, failure == nil) == false {
+		return nil
+	}
+
+	entity := memberDomain.NewMemberAccessLogEntity(ctx, memberId, loginId, accessType, authType, failure)
+	return s.memberAccessLogRepository.Create(ctx, &entity)
+}
+
+func (s MemberAccessLogService) GetMemberAccessLogs(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]memberDomain.MemberAccessLogEntity, int64, error) {
+	return s.memberAccessLogRepository.FindAll(ctx, filters, pageable)
+}
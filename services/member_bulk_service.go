@@ -0,0 +1,123 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	"context"
+	pkgerrors "github.com/pkg/errors"
+	"gorm.io/gorm"
+)
+
+var errMemberBulkRolledBack = pkgerrors.New("member bulk operation rolled back")
+
+// MemberBulkService 여러 멤버를 한 번에 승인, 거부, 정지하거나 역할, 조직을 할당한다.
+type MemberBulkService struct {
+	memberService             *MemberService
+	memberProvisioningService *MemberProvisioningService
+	organizationService       *OrganizationService
+}
+
+func NewMemberBulkService(memberService *MemberService,
+	memberProvisioningService *MemberProvisioningService,
+	organizationService *OrganizationService) *MemberBulkService {
+
+	return &MemberBulkService{
+		memberService:             memberService,
+		memberProvisioningService: memberProvisioningService,
+		organizationService:       organizationService,
+	}
+}
+
+func (s MemberBulkService) ApproveMembers(ctx context.Context, bulk dtos.MemberBulkOperation) (dtos.MemberBulkResult, error) {
+	return s.run(ctx, bulk, s.memberProvisioningService.ApproveMember)
+}
+
+func (s MemberBulkService) RejectMembers(ctx context.Context, bulk dtos.MemberBulkOperation) (dtos.MemberBulkResult, error) {
+	return s.run(ctx, bulk, s.memberService.RejectMember)
+}
+
+// AssignRoles 존재하지 않는 역할이 있으면 멤버를 변경하지 않고 errors.ErrRoleNotFound 를 반환한다.
+func (s MemberBulkService) AssignRoles(ctx context.Context, bulk dtos.MemberBulkAssignRole) (dtos.MemberBulkResult, error) {
+	if err := s.memberService.ValidateRoleIds(ctx, bulk.RoleIds); err != nil {
+		return dtos.MemberBulkResult{}, err
+	}
+
+	return s.run(ctx, bulk.MemberBulkOperation, func(ctx context.Context, memberId uint) error {
+		return s.memberService.AssignRole(ctx, memberId, dtos.MemberAssignRole{RoleIds: bulk.RoleIds})
+	})
+}
+
+// AddToOrganization 조직의 기존 멤버는 유지한다. 조직이 없으면 errors.ErrNotFound 를 반환한다.
+func (s MemberBulkService) AddToOrganization(ctx context.Context, bulk dtos.MemberBulkAddOrganization) (dtos.MemberBulkResult, error) {
+	if _, err := s.organizationService.GetOrganization(ctx, bulk.OrganizationId); err != nil {
+		return dtos.MemberBulkResult{}, err
+	}
+
+	return s.run(ctx, bulk.MemberBulkOperation, func(ctx context.Context, memberId uint) error {
+		memberEntity, err := s.memberService.GetMemberById(ctx, memberId)
+		if err != nil {
+			return err
+		}
+
+		return s.organizationService.AddMember(ctx, bulk.OrganizationId, memberEntity)
+	})
+}
+
+func (s MemberBulkService) SuspendMembers(ctx context.Context, bulk dtos.MemberBulkStatusChange) (dtos.MemberBulkResult, error) {
+	return s.run(ctx, bulk.MemberBulkOperation, func(ctx context.Context, memberId uint) error {
+		return s.memberService.ChangeMemberStatus(ctx, memberId, constants.StatusMemberSuspended, bulk.Reason)
+	})
+}
+
+// run 멤버마다 중첩 트랜잭션(세이브포인트)에서 수행하므로 실패한 멤버의 변경은 항상 취소된다.
+// 트랜잭션 모드에서 실패한 멤버가 있으면 성공한 멤버의 변경도 모두 취소한다.
+// 멤버의 상태로 인한 실패가 아닌 오류(DB 오류 등)는 모드와 관계없이 모든 변경을 취소하고 반환한다.
+func (s MemberBulkService) run(ctx context.Context, bulk dtos.MemberBulkOperation,
+	operation func(ctx context.Context, memberId uint) error) (dtos.MemberBulkResult, error) {
+
+	result := dtos.MemberBulkResult{
+		Mode:    bulk.GetMode(),
+		Results: make([]dtos.MemberBulkItemResult, 0),
+	}
+
+	db := helpers.ContextHelper().GetDB(ctx)
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, memberId := range bulk.MemberIds {
+			err := tx.Transaction(func(memberTx *gorm.DB) error {
+				return operation(helpers.ContextHelper().SetDB(ctx, memberTx), memberId)
+			})
+
+			if err != nil && isMemberBulkFailure(err) == false {
+				return err
+			}
+
+			itemResult := dtos.MemberBulkItemResult{MemberId: memberId, Success: err == nil}
+			if err != nil {
+				itemResult.Error = err.Error()
+				result.FailureCount++
+			} else {
+				result.SuccessCount++
+			}
+			result.Results = append(result.Results, itemResult)
+		}
+
+		if result.FailureCount > 0 && result.Mode == constants.MemberBulkModeTransaction {
+			result.RolledBack = true
+			return errMemberBulkRolledBack
+		}
+
+		return nil
+	})
+
+	if err != nil && err != errMemberBulkRolledBack {
+		return dtos.MemberBulkResult{}, err
+	}
+
+	return result, nil
+}
+
+func isMemberBulkFailure(err error) bool {
+	return err == errors.ErrNotFound || err == errors.ErrAlreadyApproved || err == errors.ErrInvalidStatusTransition
+}
@@ -0,0 +1,214 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	memberDomain "better-admin-backend-service/member/domain"
+	memberRepository "better-admin-backend-service/member/repository"
+	"better-admin-backend-service/security"
+	"context"
+	"time"
+)
+
+const memberIdentityLinkTokenExpiration = time.Minute * 10
+
+// MemberIdentityService 한 사람이 여러 로그인(사이트, 두레이, 구글 등)으로 가입한 멤버를 하나의 멤버로 연결한다.
+type MemberIdentityService struct {
+	memberService                     *MemberService
+	organizationService               *OrganizationService
+	tokenRevocationService            *TokenRevocationService
+	memberIdentityRepository          *memberRepository.MemberIdentityRepository
+	memberIdentityLinkTokenRepository *memberRepository.MemberIdentityLinkTokenRepository
+}
+
+func NewMemberIdentityService(memberService *MemberService,
+	organizationService *OrganizationService,
+	tokenRevocationService *TokenRevocationService,
+	memberIdentityRepository *memberRepository.MemberIdentityRepository,
+	memberIdentityLinkTokenRepository *memberRepository.MemberIdentityLinkTokenRepository) *MemberIdentityService {
+
+	return &MemberIdentityService{
+		memberService:                     memberService,
+		organizationService:               organizationService,
+		tokenRevocationService:            tokenRevocationService,
+		memberIdentityRepository:          memberIdentityRepository,
+		memberIdentityLinkTokenRepository: memberIdentityLinkTokenRepository,
+	}
+}
+
+func (s MemberIdentityService) GetIdentities(ctx context.Context, memberId uint) ([]dtos.MemberIdentity, error) {
+	memberEntity, err := s.memberService.GetMemberById(ctx, memberId)
+	if err != nil {
+		return nil, err
+	}
+
+	identities := []dtos.MemberIdentity{{
+		Type:      memberEntity.Type,
+		TypeName:  memberEntity.GetTypeName(),
+		DisplayId: memberEntity.GetCandidateId(),
+		Primary:   true,
+	}}
+
+	identityEntities, err := s.memberIdentityRepository.FindAllByMemberId(ctx, memberId)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, identityEntity := range identityEntities {
+		linkedAt := identityEntity.CreatedAt
+		identities = append(identities, dtos.MemberIdentity{
+			Id:        identityEntity.ID,
+			Type:      identityEntity.Type,
+			TypeName:  identityEntity.GetTypeName(),
+			DisplayId: identityEntity.DisplayId,
+			LinkedAt:  &linkedAt,
+		})
+	}
+
+	return identities, nil
+}
+
+// CreateIdentityLink 다른 로그인을 연결하기 위한 토큰을 발급한다.
+// 멤버는 연결할 로그인으로 다시 로그인한 뒤 토큰을 제출한다.
+func (s MemberIdentityService) CreateIdentityLink(ctx context.Context, memberId uint) (dtos.MemberIdentityLink, error) {
+	token, err := security.NewRandomId()
+	if err != nil {
+		return dtos.MemberIdentityLink{}, err
+	}
+
+	entity := memberDomain.NewMemberIdentityLinkTokenEntity(memberId, token, time.Now().Add(memberIdentityLinkTokenExpiration))
+	if err := s.memberIdentityLinkTokenRepository.Create(ctx, &entity); err != nil {
+		return dtos.MemberIdentityLink{}, err
+	}
+
+	return dtos.MemberIdentityLink{
+		LinkToken: token,
+		ExpiresAt: entity.ExpiresAt,
+	}, nil
+}
+
+// LinkIdentity 현재 로그인한 멤버를 토큰을 발급한 멤버에 연결한다.
+// 현재 로그인한 멤버는 삭제되고, 역할과 조직은 옮기지 않는다.(역할과 조직까지 합치려면 관리자가 병합해야 한다.)
+func (s MemberIdentityService) LinkIdentity(ctx context.Context, memberId uint, linkToken string) error {
+	entity, err := s.memberIdentityLinkTokenRepository.FindByTokenHash(ctx, security.HashToken(linkToken))
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return errors.ErrInvalidIdentityLink
+		}
+		return err
+	}
+
+	if entity.IsAvailable() == false || entity.MemberId == memberId {
+		return errors.ErrInvalidIdentityLink
+	}
+
+	if _, err := s.memberService.GetMemberById(ctx, entity.MemberId); err != nil {
+		if err == errors.ErrNotFound {
+			return errors.ErrInvalidIdentityLink
+		}
+		return err
+	}
+
+	if err := s.absorbMember(ctx, entity.MemberId, memberId); err != nil {
+		return err
+	}
+
+	entity.Use(memberId)
+	return s.memberIdentityLinkTokenRepository.Save(ctx, &entity)
+}
+
+// UnlinkIdentity 연결을 해제한 로그인은 다음에 로그인할 때 새로운 멤버로 가입한다.
+func (s MemberIdentityService) UnlinkIdentity(ctx context.Context, memberId uint, identityId uint) error {
+	identityEntity, err := s.memberIdentityRepository.FindById(ctx, identityId)
+	if err != nil {
+		return err
+	}
+
+	if identityEntity.MemberId != memberId {
+		return errors.ErrNotFound
+	}
+
+	return s.memberIdentityRepository.Delete(ctx, identityEntity)
+}
+
+// MergeMembers 중복 가입한 멤버(source)의 로그인, 역할, 조직을 대상 멤버(target)로 합치고 source 멤버를 삭제한다.
+func (s MemberIdentityService) MergeMembers(ctx context.Context, targetMemberId uint, sourceMemberId uint) error {
+	if targetMemberId == sourceMemberId {
+		return errors.ErrSelfMerge
+	}
+
+	targetMemberEntity, err := s.memberService.GetMemberById(ctx, targetMemberId)
+	if err != nil {
+		return err
+	}
+
+	sourceMemberEntity, err := s.memberService.GetMemberById(ctx, sourceMemberId)
+	if err != nil {
+		return err
+	}
+
+	if sourceMemberEntity.Type == constants.TypeMemberSite {
+		return errors.ErrSiteMemberCannotBeLinked
+	}
+
+	roleIds := make([]uint, 0)
+	for _, roleEntity := range sourceMemberEntity.Roles {
+		roleIds = append(roleIds, roleEntity.ID)
+	}
+
+	if len(roleIds) > 0 {
+		if err := s.memberService.AddRoles(ctx, targetMemberId, roleIds); err != nil {
+			return err
+		}
+	}
+
+	organizationEntities, err := s.organizationService.GetAllOrganizations(ctx, map[string]interface{}{"memberId": sourceMemberId})
+	if err != nil {
+		return err
+	}
+
+	for _, organizationEntity := range organizationEntities {
+		if err := s.organizationService.AddMember(ctx, organizationEntity.ID, targetMemberEntity); err != nil {
+			return err
+		}
+	}
+
+	return s.absorbMember(ctx, targetMemberId, sourceMemberId)
+}
+
+// absorbMember source 멤버의 모든 로그인을 target 멤버로 옮기고 source 멤버를 삭제한다.
+func (s MemberIdentityService) absorbMember(ctx context.Context, targetMemberId uint, sourceMemberId uint) error {
+	sourceMemberEntity, err := s.memberService.GetMemberById(ctx, sourceMemberId)
+	if err != nil {
+		return err
+	}
+
+	// 사이트 멤버의 비밀번호는 다른 멤버로 옮길 수 없으므로 사이트 멤버는 항상 연결 받는 쪽이어야 한다.
+	identityEntity, ok := memberDomain.NewMemberIdentityEntityFromMember(targetMemberId, sourceMemberEntity)
+	if ok == false {
+		return errors.ErrSiteMemberCannotBeLinked
+	}
+
+	if err := s.memberIdentityRepository.Create(ctx, &identityEntity); err != nil {
+		return err
+	}
+
+	linkedIdentityEntities, err := s.memberIdentityRepository.FindAllByMemberId(ctx, sourceMemberId)
+	if err != nil {
+		return err
+	}
+
+	for _, linkedIdentityEntity := range linkedIdentityEntities {
+		linkedIdentityEntity.MemberId = targetMemberId
+		if err := s.memberIdentityRepository.Save(ctx, &linkedIdentityEntity); err != nil {
+			return err
+		}
+	}
+
+	if err := s.tokenRevocationService.RevokeAllTokensOfMember(ctx, sourceMemberId); err != nil {
+		return err
+	}
+
+	return s.memberService.DeleteMember(ctx, sourceMemberId)
+}
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	memberDomain "better-admin-backend-service/member/domain"
+	"context"
+)
+
+// MemberProvisioningService 사이트 설정의 자동 할당 규칙에 따라 새 멤버에게 역할과 조직을 할당한다.
+type MemberProvisioningService struct {
+	memberService       *MemberService
+	organizationService *OrganizationService
+	siteService         *SiteService
+}
+
+func NewMemberProvisioningService(memberService *MemberService,
+	organizationService *OrganizationService,
+	siteService *SiteService) *MemberProvisioningService {
+
+	return &MemberProvisioningService{
+		memberService:       memberService,
+		organizationService: organizationService,
+		siteService:         siteService,
+	}
+}
+
+// ProvisionMember 역할과 조직이 추가되면 권한 버전이 바뀌므로 다시 조회한 멤버를 반환한다.
+// 메일 주소 조건은 확인된 메일 주소로만 평가하고 LDAP 그룹 조건은 로그인할 때 디렉터리에서 조회한 그룹으로 평가한다.
+func (s MemberProvisioningService) ProvisionMember(ctx context.Context, memberId uint) (memberDomain.MemberEntity, error) {
+	memberEntity, err := s.memberService.GetMemberById(ctx, memberId)
+	if err != nil {
+		return memberDomain.MemberEntity{}, err
+	}
+
+	setting, err := s.siteService.GetMemberProvisioning(ctx)
+	if err != nil {
+		return memberDomain.MemberEntity{}, err
+	}
+
+	result := s.evaluate(setting, memberEntity.Type, memberEntity.GetVerifiedEmails(), memberEntity.GetLdapGroups())
+	if len(result.MatchedRules) == 0 {
+		return memberEntity, nil
+	}
+
+	if len(result.RoleIds) > 0 {
+		if err := s.memberService.AddRoles(ctx, memberId, result.RoleIds); err != nil {
+			return memberDomain.MemberEntity{}, err
+		}
+	}
+
+	for _, organizationId := range result.OrganizationIds {
+		// 규칙을 설정한 뒤 삭제된 조직은 무시한다.
+		if err := s.organizationService.AddMember(ctx, organizationId, memberEntity); err != nil && err != errors.ErrNotFound {
+			return memberDomain.MemberEntity{}, err
+		}
+	}
+
+	return s.memberService.GetMemberById(ctx, memberId)
+}
+
+// ApproveMember 승인한 멤버에게 자동 할당 규칙을 적용한다.
+func (s MemberProvisioningService) ApproveMember(ctx context.Context, memberId uint) error {
+	if err := s.memberService.ApproveMember(ctx, memberId); err != nil {
+		return err
+	}
+
+	_, err := s.ProvisionMember(ctx, memberId)
+	return err
+}
+
+// DryRun 역할, 조직을 할당하지 않고 어떤 규칙이 적용되는지만 확인한다.
+func (s MemberProvisioningService) DryRun(ctx context.Context, dryRun dtos.MemberProvisioningDryRun) (dtos.MemberProvisioningDryRunResult, error) {
+	setting := dtos.MemberProvisioningSetting{Rules: dryRun.Rules}
+	if dryRun.Rules == nil {
+		savedSetting, err := s.siteService.GetMemberProvisioning(ctx)
+		if err != nil {
+			return dtos.MemberProvisioningDryRunResult{}, err
+		}
+		setting = savedSetting
+	}
+
+	memberType, emails, ldapGroups := dryRun.MemberType, []string{}, dryRun.LdapGroups
+	if len(dryRun.Email) > 0 {
+		emails = append(emails, dryRun.Email)
+	}
+
+	if dryRun.MemberId > 0 {
+		memberEntity, err := s.memberService.GetMemberById(ctx, dryRun.MemberId)
+		if err != nil {
+			return dtos.MemberProvisioningDryRunResult{}, err
+		}
+		memberType, emails, ldapGroups = memberEntity.Type, memberEntity.GetVerifiedEmails(), memberEntity.GetLdapGroups()
+	}
+
+	return s.evaluate(setting, memberType, emails, ldapGroups), nil
+}
+
+func (s MemberProvisioningService) evaluate(setting dtos.MemberProvisioningSetting, memberType string, emails []string,
+	ldapGroups []string) dtos.MemberProvisioningDryRunResult {
+
+	result := dtos.MemberProvisioningDryRunResult{
+		MatchedRules:    setting.FindMatchedRules(memberType, emails, ldapGroups),
+		RoleIds:         []uint{},
+		OrganizationIds: []uint{},
+	}
+
+	for _, rule := range result.MatchedRules {
+		result.RoleIds = appendUniqueIds(result.RoleIds, rule.RoleIds)
+		result.OrganizationIds = appendUniqueIds(result.OrganizationIds, rule.OrganizationIds)
+	}
+
+	return result
+}
+
+func appendUniqueIds(ids []uint, newIds []uint) []uint {
+	for _, newId := range newIds {
+		exists := false
+		for _, id := range ids {
+			if id == newId {
+				exists = true
+				break
+			}
+		}
+		if exists == false {
+			ids = append(ids, newId)
+		}
+	}
+	return ids
+}
@@ -1,24 +1,36 @@
 package services
 
 import (
+	"better-admin-backend-service/constants"
 	"better-admin-backend-service/dtos"
 	"better-admin-backend-service/errors"
 	"better-admin-backend-service/helpers"
 	"better-admin-backend-service/member/domain"
 	"better-admin-backend-service/member/repository"
+	rbacDomain "better-admin-backend-service/rbac/domain"
+	"better-admin-backend-service/security"
 	"context"
 )
 
 type MemberService struct {
-	rbacService      *RoleBasedAccessControlService
-	memberRepository *repository.MemberRepository
+	rbacService                   *RoleBasedAccessControlService
+	siteService                   *SiteService
+	memberRepository              *repository.MemberRepository
+	memberIdentityRepository      *repository.MemberIdentityRepository
+	memberStatusHistoryRepository *repository.MemberStatusHistoryRepository
 }
 
 func NewMemberService(rbacService *RoleBasedAccessControlService,
-	memberRepository *repository.MemberRepository) *MemberService {
+	siteService *SiteService,
+	memberRepository *repository.MemberRepository,
+	memberIdentityRepository *repository.MemberIdentityRepository,
+	memberStatusHistoryRepository *repository.MemberStatusHistoryRepository) *MemberService {
 	return &MemberService{
-		rbacService:      rbacService,
-		memberRepository: memberRepository,
+		rbacService:                   rbacService,
+		siteService:                   siteService,
+		memberRepository:              memberRepository,
+		memberIdentityRepository:      memberIdentityRepository,
+		memberStatusHistoryRepository: memberStatusHistoryRepository,
 	}
 }
 
@@ -27,7 +39,20 @@ func (s MemberService) GetMemberBySignId(ctx context.Context, signId string) (do
 }
 
 func (s MemberService) GetMemberByDoorayId(ctx context.Context, doorayId string) (domain.MemberEntity, error) {
-	return s.memberRepository.FindByDoorayId(ctx, doorayId)
+	memberEntity, err := s.memberRepository.FindByDoorayId(ctx, doorayId)
+	if err == errors.ErrNotFound {
+		return s.getLinkedMember(ctx, constants.TypeMemberDooray, "", doorayId)
+	}
+	return memberEntity, err
+}
+
+// getLinkedMember 멤버에 연결된 로그인으로 멤버를 찾는다.
+func (s MemberService) getLinkedMember(ctx context.Context, identityType, issuer, subject string) (domain.MemberEntity, error) {
+	identityEntity, err := s.memberIdentityRepository.FindByIdentity(ctx, identityType, issuer, subject)
+	if err != nil {
+		return domain.MemberEntity{}, err
+	}
+	return s.memberRepository.FindById(ctx, identityEntity.MemberId)
 }
 
 func (s MemberService) CreateMember(ctx context.Context, entity *domain.MemberEntity) error {
@@ -64,27 +89,139 @@ func (s MemberService) AssignRole(ctx context.Context, memberId uint, assignRole
 	return s.memberRepository.Save(ctx, &memberEntity)
 }
 
+func (s MemberService) IncreasePermissionVersion(ctx context.Context, memberIds []uint) error {
+	return s.memberRepository.IncreasePermissionVersion(ctx, memberIds)
+}
+
 func (s MemberService) GetMember(ctx context.Context, memberId uint) (domain.MemberEntity, error) {
 	return s.memberRepository.FindById(ctx, memberId)
 }
 
-func (s MemberService) SignUpMember(ctx context.Context, signUp dtos.MemberSignUp) error {
+// SignUpMember 초대를 받아 가입하는 경우(invitation) 초대할 때 지정한 역할로 바로 승인된다.
+func (s MemberService) SignUpMember(ctx context.Context, signUp dtos.MemberSignUp,
+	invitation *domain.MemberInvitationEntity) (domain.MemberEntity, error) {
+
 	_, err := s.memberRepository.FindBySignId(ctx, signUp.SignId)
 	if err != nil {
 		if err == errors.ErrNotFound {
 			// signId 가 중복이 없을 때만 가입
-			newMember, err := domain.NewMemberEntityFromSignUp(signUp)
+			passwordPolicy, err := s.siteService.GetPasswordPolicy(ctx)
+			if err != nil {
+				return domain.MemberEntity{}, err
+			}
+
+			newMember, err := domain.NewMemberEntityFromSignUp(signUp, passwordPolicy)
 			if err != nil {
-				return err
+				return domain.MemberEntity{}, err
+			}
+
+			if invitation != nil {
+				roleEntities, err := s.getRoles(ctx, invitation.GetRoleIds())
+				if err != nil {
+					return domain.MemberEntity{}, err
+				}
+
+				newMember.AcceptInvitation(invitation.Email, roleEntities)
 			}
 
-			return s.memberRepository.Create(ctx, &newMember)
+			if err := s.memberRepository.Create(ctx, &newMember); err != nil {
+				return domain.MemberEntity{}, err
+			}
+
+			return newMember, nil
+		}
+
+		return domain.MemberEntity{}, err
+	}
+
+	return domain.MemberEntity{}, errors.ErrDuplicated
+}
+
+// CreateMemberWithDefaultRoles 외부 인증으로 처음 로그인한 멤버를 기본 역할과 함께 생성한다.
+func (s MemberService) CreateMemberWithDefaultRoles(ctx context.Context, memberEntity *domain.MemberEntity, roleIds []uint) error {
+	roleEntities, err := s.getRoles(ctx, roleIds)
+	if err != nil {
+		return err
+	}
+
+	memberEntity.AssignDefaultRoles(roleEntities)
+	return s.memberRepository.Create(ctx, memberEntity)
+}
+
+func (s MemberService) AddRoles(ctx context.Context, memberId uint, roleIds []uint) error {
+	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
+	if err != nil {
+		return err
+	}
+
+	roleEntities, err := s.getRoles(ctx, roleIds)
+	if err != nil {
+		return err
+	}
+
+	memberEntity.AddRoles(roleEntities)
+	return s.memberRepository.Save(ctx, &memberEntity)
+}
+
+// ValidateRoleIds 존재하지 않는 역할이 있으면 errors.ErrRoleNotFound 를 반환한다.
+func (s MemberService) ValidateRoleIds(ctx context.Context, roleIds []uint) error {
+	roleEntities, err := s.getRoles(ctx, roleIds)
+	if err != nil {
+		return err
+	}
+
+	for _, roleId := range roleIds {
+		exists := false
+		for _, roleEntity := range roleEntities {
+			if roleEntity.ID == roleId {
+				exists = true
+				break
+			}
 		}
+		if exists == false {
+			return errors.ErrRoleNotFound
+		}
+	}
+	return nil
+}
+
+func (s MemberService) getRoles(ctx context.Context, roleIds []uint) ([]rbacDomain.RoleEntity, error) {
+	if len(roleIds) == 0 {
+		return []rbacDomain.RoleEntity{}, nil
+	}
+
+	filters := map[string]interface{}{}
+	filters["roleIds"] = roleIds
+
+	roleEntities, _, err := s.rbacService.GetRoles(ctx, filters, dtos.Pageable{Page: 0})
+	return roleEntities, err
+}
 
+func (s MemberService) ChangeEmail(ctx context.Context, memberId uint, email string) (domain.MemberEntity, error) {
+	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
+	if err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	memberEntity.ChangeEmail(email)
+	if err := s.memberRepository.Save(ctx, &memberEntity); err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	return memberEntity, nil
+}
+
+func (s MemberService) VerifyEmail(ctx context.Context, memberId uint, email string) error {
+	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
+	if err != nil {
 		return err
 	}
 
-	return errors.ErrDuplicated
+	if err := memberEntity.VerifyEmail(email); err != nil {
+		return err
+	}
+
+	return s.memberRepository.Save(ctx, &memberEntity)
 }
 
 func (s MemberService) ApproveMember(ctx context.Context, memberId uint) error {
@@ -93,18 +230,242 @@ func (s MemberService) ApproveMember(ctx context.Context, memberId uint) error {
 		return err
 	}
 
+	fromStatus := memberEntity.Status
 	if err := memberEntity.Approve(ctx); err != nil {
 		return err
 	}
 
-	return s.memberRepository.Save(ctx, &memberEntity)
+	return s.saveMemberStatus(ctx, &memberEntity, fromStatus)
+}
+
+// ChangeMemberStatus 관리자가 멤버를 정지, 잠금, 탈퇴, 만료 처리하거나 재활성화한다.
+func (s MemberService) ChangeMemberStatus(ctx context.Context, memberId uint, status, reason string) error {
+	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
+	if err != nil {
+		return err
+	}
+
+	fromStatus := memberEntity.Status
+	if err := memberEntity.ChangeStatus(ctx, status, reason); err != nil {
+		return err
+	}
+
+	return s.saveMemberStatus(ctx, &memberEntity, fromStatus)
+}
+
+func (s MemberService) GetMemberStatusHistories(ctx context.Context, memberId uint) ([]domain.MemberStatusHistoryEntity, error) {
+	if _, err := s.memberRepository.FindById(ctx, memberId); err != nil {
+		return nil, err
+	}
+
+	return s.memberStatusHistoryRepository.FindAllByMemberId(ctx, memberId)
+}
+
+// saveMemberStatus 상태가 변경된 경우 변경 기록을 남긴다.
+func (s MemberService) saveMemberStatus(ctx context.Context, memberEntity *domain.MemberEntity, fromStatus string) error {
+	if err := s.memberRepository.Save(ctx, memberEntity); err != nil {
+		return err
+	}
+
+	if memberEntity.Status == fromStatus {
+		return nil
+	}
+
+	historyEntity := domain.NewMemberStatusHistoryEntity(*memberEntity, fromStatus)
+	return s.memberStatusHistoryRepository.Create(ctx, &historyEntity)
 }
 
 func (s MemberService) GetMemberByGoogleId(ctx context.Context, googleId string) (domain.MemberEntity, error) {
-	return s.memberRepository.FindByGoogleId(ctx, googleId)
+	memberEntity, err := s.memberRepository.FindByGoogleId(ctx, googleId)
+	if err == errors.ErrNotFound {
+		return s.getLinkedMember(ctx, constants.TypeMemberGoogle, "", googleId)
+	}
+	return memberEntity, err
 }
 
-func (s MemberService) RejectMember(ctx context.Context, memberId uint) error {
+func (s MemberService) GetMemberByOidcSubject(ctx context.Context, issuer, subject string) (domain.MemberEntity, error) {
+	memberEntity, err := s.memberRepository.FindByOidcSubject(ctx, issuer, subject)
+	if err == errors.ErrNotFound {
+		return s.getLinkedMember(ctx, constants.TypeMemberOidc, issuer, subject)
+	}
+	return memberEntity, err
+}
+
+func (s MemberService) GetMemberByLdapUserId(ctx context.Context, ldapUserId string) (domain.MemberEntity, error) {
+	memberEntity, err := s.memberRepository.FindByLdapUserId(ctx, ldapUserId)
+	if err == errors.ErrNotFound {
+		return s.getLinkedMember(ctx, constants.TypeMemberLdap, "", ldapUserId)
+	}
+	return memberEntity, err
+}
+
+func (s MemberService) UpdateMemberFromLdapMember(ctx context.Context, memberId uint, ldapMember dtos.LdapMember) (domain.MemberEntity, error) {
+	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
+	if err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	memberEntity.UpdateFromLdapMember(ldapMember)
+
+	if err := s.memberRepository.Save(ctx, &memberEntity); err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	return memberEntity, nil
+}
+
+func (s MemberService) UpdateMemberFromDoorayMember(ctx context.Context, memberId uint, doorayMember dtos.DoorayMember) (domain.MemberEntity, error) {
+	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
+	if err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	memberEntity.UpdateFromDoorayMember(doorayMember)
+
+	if err := s.memberRepository.Save(ctx, &memberEntity); err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	return memberEntity, nil
+}
+
+func (s MemberService) UpdateMemberFromGoogleMember(ctx context.Context, memberId uint, googleMember dtos.GoogleMember) (domain.MemberEntity, error) {
+	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
+	if err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	memberEntity.UpdateFromGoogleMember(googleMember)
+
+	if err := s.memberRepository.Save(ctx, &memberEntity); err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	return memberEntity, nil
+}
+
+func (s MemberService) UpdateMemberFromOidcMember(ctx context.Context, memberId uint, oidcMember dtos.OidcMember) (domain.MemberEntity, error) {
+	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
+	if err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	memberEntity.UpdateFromOidcMember(oidcMember)
+
+	if err := s.memberRepository.Save(ctx, &memberEntity); err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	return memberEntity, nil
+}
+
+func (s MemberService) UpdateProfile(ctx context.Context, memberId uint, profileUpdate dtos.MemberProfileUpdate) (domain.MemberEntity, error) {
+	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
+	if err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	if err := memberEntity.UpdateProfile(ctx, profileUpdate); err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	if err := s.memberRepository.Save(ctx, &memberEntity); err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	return memberEntity, nil
+}
+
+func (s MemberService) CreateScimMember(ctx context.Context, scimUser dtos.ScimUser) (domain.MemberEntity, error) {
+	if err := s.checkScimUserNameDuplicated(ctx, scimUser.UserName, 0); err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	memberEntity := domain.NewMemberEntityFromScimUser(scimUser)
+	if err := s.memberRepository.Create(ctx, &memberEntity); err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	return memberEntity, nil
+}
+
+func (s MemberService) UpdateMemberFromScimUser(ctx context.Context, memberId uint, scimUser dtos.ScimUser) (domain.MemberEntity, error) {
+	if err := s.checkScimUserNameDuplicated(ctx, scimUser.UserName, memberId); err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
+	if err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	fromStatus := memberEntity.Status
+	if err := memberEntity.UpdateFromScimUser(ctx, scimUser); err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	if err := s.saveMemberStatus(ctx, &memberEntity, fromStatus); err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	return memberEntity, nil
+}
+
+// checkScimUserNameDuplicated SCIM 사용자 이름은 대소문자를 구분하지 않고 중복될 수 없다.
+func (s MemberService) checkScimUserNameDuplicated(ctx context.Context, userName string, memberId uint) error {
+	memberEntities, _, err := s.memberRepository.FindAll(ctx, map[string]interface{}{
+		"types":        []string{constants.TypeMemberScim},
+		"scimUserName": userName,
+	}, dtos.Pageable{Page: 0})
+	if err != nil {
+		return err
+	}
+
+	for _, memberEntity := range memberEntities {
+		if memberEntity.ID != memberId {
+			return errors.ErrDuplicated
+		}
+	}
+
+	return nil
+}
+
+func (s MemberService) DeactivateMember(ctx context.Context, memberId uint) error {
+	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
+	if err != nil {
+		return err
+	}
+
+	fromStatus := memberEntity.Status
+	if err := memberEntity.Deactivate(ctx); err != nil {
+		return err
+	}
+
+	return s.saveMemberStatus(ctx, &memberEntity, fromStatus)
+}
+
+// LinkScimMember SCIM 으로 프로비저닝된 멤버가 메일이 확인된 외부 로그인으로 처음 로그인하면 로그인을 멤버에 연결한다.
+// 연결할 멤버가 없으면 errors.ErrNotFound 를 반환한다.
+func (s MemberService) LinkScimMember(ctx context.Context, email string, identityEntity domain.MemberIdentityEntity) (domain.MemberEntity, error) {
+	if len(email) == 0 {
+		return domain.MemberEntity{}, errors.ErrNotFound
+	}
+
+	memberEntity, err := s.memberRepository.FindScimMemberByEmail(ctx, email)
+	if err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	identityEntity.MemberId = memberEntity.ID
+	if err := s.memberIdentityRepository.Create(ctx, &identityEntity); err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	return memberEntity, nil
+}
+
+// DeleteMember 다른 멤버에 연결되거나 병합된 멤버를 삭제한다.
+func (s MemberService) DeleteMember(ctx context.Context, memberId uint) error {
 	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
 	if err != nil {
 		return err
@@ -119,6 +480,11 @@ func (s MemberService) RejectMember(ctx context.Context, memberId uint) error {
 	return s.memberRepository.Delete(ctx, memberEntity)
 }
 
+// RejectMember 가입 신청한 멤버만 거부할 수 있다. 삭제하지 않고 거부 상태로 변경하여 변경 기록을 남긴다.
+func (s MemberService) RejectMember(ctx context.Context, memberId uint) error {
+	return s.ChangeMemberStatus(ctx, memberId, constants.StatusMemberRejected, "")
+}
+
 func (s MemberService) UpdateMemberLastAccessAt(ctx context.Context, memberId uint) error {
 	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
 	if err != nil {
@@ -129,3 +495,147 @@ func (s MemberService) UpdateMemberLastAccessAt(ctx context.Context, memberId ui
 
 	return s.memberRepository.Save(ctx, &memberEntity)
 }
+
+func (s MemberService) StartTwoFactorEnrollment(ctx context.Context, memberId uint) (dtos.MemberTwoFactorEnrollment, error) {
+	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
+	if err != nil {
+		return dtos.MemberTwoFactorEnrollment{}, err
+	}
+
+	secret, err := memberEntity.StartTwoFactorEnrollment()
+	if err != nil {
+		return dtos.MemberTwoFactorEnrollment{}, err
+	}
+
+	if err := s.memberRepository.Save(ctx, &memberEntity); err != nil {
+		return dtos.MemberTwoFactorEnrollment{}, err
+	}
+
+	return dtos.MemberTwoFactorEnrollment{
+		Secret:     secret,
+		OtpAuthUri: security.GetTotpUri(constants.TwoFactorAuthIssuer, memberEntity.SignId, secret),
+	}, nil
+}
+
+func (s MemberService) ActivateTwoFactor(ctx context.Context, memberId uint, code string) ([]string, error) {
+	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, err := memberEntity.ActivateTwoFactor(code)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.memberRepository.Save(ctx, &memberEntity); err != nil {
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
+
+func (s MemberService) VerifyTwoFactorCode(ctx context.Context, memberId uint, code string) error {
+	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
+	if err != nil {
+		return err
+	}
+
+	if err := memberEntity.VerifyTwoFactorCode(code); err != nil {
+		return err
+	}
+
+	// 사용된 코드를 다시 사용할 수 없도록 저장한다.
+	return s.memberRepository.Save(ctx, &memberEntity)
+}
+
+func (s MemberService) DeactivateTwoFactor(ctx context.Context, memberId uint, code string) error {
+	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
+	if err != nil {
+		return err
+	}
+
+	if err := memberEntity.DeactivateTwoFactor(code); err != nil {
+		return err
+	}
+
+	return s.memberRepository.Save(ctx, &memberEntity)
+}
+
+func (s MemberService) ChangePassword(ctx context.Context, memberId uint, passwordChange dtos.MemberPasswordChange) error {
+	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
+	if err != nil {
+		return err
+	}
+
+	passwordPolicy, err := s.siteService.GetPasswordPolicy(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := memberEntity.ChangePassword(passwordChange.CurrentPassword, passwordChange.NewPassword, passwordPolicy); err != nil {
+		return err
+	}
+
+	return s.memberRepository.Save(ctx, &memberEntity)
+}
+
+// ChangeRequiredPassword 비밀번호를 변경해야 하는 멤버만 변경할 수 있으므로 비밀번호 변경 대기 토큰은 한 번만 사용할 수 있다.
+func (s MemberService) ChangeRequiredPassword(ctx context.Context, memberId uint, newPassword string) (domain.MemberEntity, error) {
+	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
+	if err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	passwordPolicy, err := s.siteService.GetPasswordPolicy(ctx)
+	if err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	if memberEntity.IsPasswordChangeRequired(passwordPolicy) == false {
+		return domain.MemberEntity{}, security.InvalidPasswordChangeToken
+	}
+
+	if err := memberEntity.ChangeRequiredPassword(newPassword, passwordPolicy); err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	if err := s.memberRepository.Save(ctx, &memberEntity); err != nil {
+		return domain.MemberEntity{}, err
+	}
+
+	return memberEntity, nil
+}
+
+func (s MemberService) ResetPassword(ctx context.Context, memberId uint) (string, error) {
+	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
+	if err != nil {
+		return "", err
+	}
+
+	passwordPolicy, err := s.siteService.GetPasswordPolicy(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	temporaryPassword, err := memberEntity.ResetPassword(ctx, passwordPolicy)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.memberRepository.Save(ctx, &memberEntity); err != nil {
+		return "", err
+	}
+
+	return temporaryPassword, nil
+}
+
+// UpgradePasswordHash 로그인 할 때 비밀번호 정책의 bcrypt 비용보다 낮은 비용으로 저장된 비밀번호를 다시 해시한다.
+func (s MemberService) UpgradePasswordHash(ctx context.Context, memberEntity *domain.MemberEntity, password string, passwordPolicy dtos.PasswordPolicySetting) error {
+	upgraded, err := memberEntity.UpgradePasswordHash(password, passwordPolicy.GetHashCost())
+	if err != nil || upgraded == false {
+		return err
+	}
+
+	return s.memberRepository.Save(ctx, memberEntity)
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	authDomain "better-admin-backend-service/auth/domain"
+	authRepository "better-admin-backend-service/auth/repository"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/security"
+	"context"
+	"net/url"
+	"strings"
+)
+
+// OAuthClientService 멤버 계정으로 로그인(OpenID Connect)하는 내부 앱을 등록하고 관리한다.
+type OAuthClientService struct {
+	oauthClientRepository      *authRepository.OAuthClientRepository
+	oauthConsentRepository     *authRepository.OAuthConsentRepository
+	oauthAccessTokenRepository *authRepository.OAuthAccessTokenRepository
+}
+
+func NewOAuthClientService(oauthClientRepository *authRepository.OAuthClientRepository,
+	oauthConsentRepository *authRepository.OAuthConsentRepository,
+	oauthAccessTokenRepository *authRepository.OAuthAccessTokenRepository) *OAuthClientService {
+	return &OAuthClientService{
+		oauthClientRepository:      oauthClientRepository,
+		oauthConsentRepository:     oauthConsentRepository,
+		oauthAccessTokenRepository: oauthAccessTokenRepository,
+	}
+}
+
+func (s OAuthClientService) GetOAuthClients(ctx context.Context) ([]authDomain.OAuthClientEntity, error) {
+	return s.oauthClientRepository.FindAll(ctx)
+}
+
+func (s OAuthClientService) GetOAuthClient(ctx context.Context, clientId string) (authDomain.OAuthClientEntity, error) {
+	return s.oauthClientRepository.FindByClientId(ctx, clientId)
+}
+
+// CreateOAuthClient 기밀 클라이언트의 시크릿 원문은 반환한 뒤 다시 조회할 수 없다.
+func (s OAuthClientService) CreateOAuthClient(ctx context.Context, information dtos.OAuthClientInformation) (authDomain.OAuthClientEntity, string, error) {
+	if err := validateOAuthRedirectUris(information.RedirectUris); err != nil {
+		return authDomain.OAuthClientEntity{}, "", err
+	}
+
+	clientId, err := security.NewRandomId()
+	if err != nil {
+		return authDomain.OAuthClientEntity{}, "", err
+	}
+
+	clientSecret := ""
+	if information.Public == false {
+		clientSecret, err = security.NewOAuthClientSecret()
+		if err != nil {
+			return authDomain.OAuthClientEntity{}, "", err
+		}
+	}
+
+	entity, err := authDomain.NewOAuthClientEntity(ctx, clientId, clientSecret, information)
+	if err != nil {
+		return authDomain.OAuthClientEntity{}, "", err
+	}
+
+	if err := s.oauthClientRepository.Create(ctx, &entity); err != nil {
+		return authDomain.OAuthClientEntity{}, "", err
+	}
+
+	return entity, clientSecret, nil
+}
+
+func (s OAuthClientService) UpdateOAuthClient(ctx context.Context, clientId string, information dtos.OAuthClientInformation) error {
+	if err := validateOAuthRedirectUris(information.RedirectUris); err != nil {
+		return err
+	}
+
+	entity, err := s.oauthClientRepository.FindByClientId(ctx, clientId)
+	if err != nil {
+		return err
+	}
+
+	if err := entity.Update(ctx, information); err != nil {
+		return err
+	}
+
+	return s.oauthClientRepository.Save(ctx, &entity)
+}
+
+// RenewOAuthClientSecret 이전 시크릿은 바로 사용할 수 없게 된다. 공개 클라이언트는 시크릿이 없다.
+func (s OAuthClientService) RenewOAuthClientSecret(ctx context.Context, clientId string) (authDomain.OAuthClientEntity, string, error) {
+	entity, err := s.oauthClientRepository.FindByClientId(ctx, clientId)
+	if err != nil {
+		return authDomain.OAuthClientEntity{}, "", err
+	}
+
+	if entity.IsPublic() {
+		return authDomain.OAuthClientEntity{}, "", errors.ErrNonChangeable
+	}
+
+	clientSecret, err := security.NewOAuthClientSecret()
+	if err != nil {
+		return authDomain.OAuthClientEntity{}, "", err
+	}
+
+	if err := entity.ChangeClientSecret(ctx, clientSecret); err != nil {
+		return authDomain.OAuthClientEntity{}, "", err
+	}
+
+	if err := s.oauthClientRepository.Save(ctx, &entity); err != nil {
+		return authDomain.OAuthClientEntity{}, "", err
+	}
+
+	return entity, clientSecret, nil
+}
+
+// DeleteOAuthClient 클라이언트에 대한 멤버의 동의와 발급한 액세스 토큰도 함께 삭제한다.
+func (s OAuthClientService) DeleteOAuthClient(ctx context.Context, clientId string) error {
+	entity, err := s.oauthClientRepository.FindByClientId(ctx, clientId)
+	if err != nil {
+		return err
+	}
+
+	if err := s.oauthConsentRepository.DeleteAllByClientId(ctx, entity.ClientId); err != nil {
+		return err
+	}
+
+	if err := s.oauthAccessTokenRepository.DeleteAllByClientId(ctx, entity.ClientId); err != nil {
+		return err
+	}
+
+	return s.oauthClientRepository.Delete(ctx, entity)
+}
+
+// validateOAuthRedirectUris 리다이렉트 URI 는 fragment 가 없는 절대 URI 여야 한다.(RFC 6749 3.1.2)
+func validateOAuthRedirectUris(redirectUris []string) error {
+	for _, redirectUri := range redirectUris {
+		parsedUri, err := url.Parse(redirectUri)
+		// 공백으로 구분해서 저장하므로 공백이 포함된 URI 는 등록할 수 없다.
+		if err != nil || len(parsedUri.Scheme) == 0 || len(parsedUri.Host) == 0 || len(parsedUri.Fragment) > 0 ||
+			strings.ContainsAny(redirectUri, " \t\r\n") {
+			return errors.ErrInvalidOAuthRedirectUri
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,460 @@
+package services
+
+import (
+	authDomain "better-admin-backend-service/auth/domain"
+	authRepository "better-admin-backend-service/auth/repository"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/helpers"
+	memberDomain "better-admin-backend-service/member/domain"
+	"better-admin-backend-service/security"
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// 내부 앱이 요청할 수 있는 scope. 역할과 권한은 scope 와 관계없이 항상 제공한다.
+var supportedOAuthScopes = []string{constants.OAuthScopeOpenId, constants.OAuthScopeProfile, constants.OAuthScopeEmail}
+
+// OAuthProviderService 내부 앱에 OpenID Connect 제공자(인가 코드 + PKCE)로 멤버의 신원과 역할, 권한을 제공한다.
+type OAuthProviderService struct {
+	memberService                    *MemberService
+	organizationService              *OrganizationService
+	oauthClientRepository            *authRepository.OAuthClientRepository
+	oauthAuthorizationCodeRepository *authRepository.OAuthAuthorizationCodeRepository
+	oauthConsentRepository           *authRepository.OAuthConsentRepository
+	oauthAccessTokenRepository       *authRepository.OAuthAccessTokenRepository
+}
+
+func NewOAuthProviderService(memberService *MemberService, organizationService *OrganizationService,
+	oauthClientRepository *authRepository.OAuthClientRepository,
+	oauthAuthorizationCodeRepository *authRepository.OAuthAuthorizationCodeRepository,
+	oauthConsentRepository *authRepository.OAuthConsentRepository,
+	oauthAccessTokenRepository *authRepository.OAuthAccessTokenRepository) *OAuthProviderService {
+	return &OAuthProviderService{
+		memberService:                    memberService,
+		organizationService:              organizationService,
+		oauthClientRepository:            oauthClientRepository,
+		oauthAuthorizationCodeRepository: oauthAuthorizationCodeRepository,
+		oauthConsentRepository:           oauthConsentRepository,
+		oauthAccessTokenRepository:       oauthAccessTokenRepository,
+	}
+}
+
+// ValidateAuthorizationRequest 클라이언트와 리다이렉트 URI 가 올바르지 않으면 앱으로 돌려보낼 수 없으므로
+// ErrInvalidOAuthClient, ErrInvalidOAuthRedirectUri 를, 그 밖의 오류는 앱에 전달할 ErrOAuth 를 반환한다.
+func (s OAuthProviderService) ValidateAuthorizationRequest(ctx context.Context,
+	request dtos.OAuthAuthorizationRequest) (authDomain.OAuthClientEntity, []string, error) {
+	clientEntity, err := s.oauthClientRepository.FindByClientId(ctx, request.ClientId)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return authDomain.OAuthClientEntity{}, nil, errors.ErrInvalidOAuthClient
+		}
+		return authDomain.OAuthClientEntity{}, nil, err
+	}
+
+	if clientEntity.HasRedirectUri(request.RedirectUri) == false {
+		return authDomain.OAuthClientEntity{}, nil, errors.ErrInvalidOAuthRedirectUri
+	}
+
+	if request.ResponseType != constants.OAuthResponseTypeCode {
+		return authDomain.OAuthClientEntity{}, nil, &errors.ErrOAuth{Code: constants.OAuthErrorUnsupportedResponseType}
+	}
+
+	scopes, err := parseOAuthScopes(request.Scope)
+	if err != nil {
+		return authDomain.OAuthClientEntity{}, nil, err
+	}
+
+	// 인가 코드를 가로채더라도 토큰으로 교환할 수 없도록 모든 클라이언트에 PKCE(S256)를 요구한다.
+	if len(request.CodeChallenge) == 0 || request.CodeChallengeMethod != constants.OAuthCodeChallengeMethodS256 {
+		return authDomain.OAuthClientEntity{}, nil, &errors.ErrOAuth{Code: constants.OAuthErrorInvalidRequest,
+			Description: "code_challenge with S256 method required"}
+	}
+
+	return clientEntity, scopes, nil
+}
+
+// BeginAuthorization 요청이 올바르면 멤버가 로그인하고 동의하는 웹 화면으로, 올바르지 않으면 앱으로 오류를 돌려보낸다.
+// 웹 화면은 전달받은 쿼리(rawQuery)를 그대로 동의 API 에 전달한다.
+func (s OAuthProviderService) BeginAuthorization(ctx context.Context, request dtos.OAuthAuthorizationRequest, rawQuery string) (string, error) {
+	if _, _, err := s.ValidateAuthorizationRequest(ctx, request); err != nil {
+		if e, ok := err.(*errors.ErrOAuth); ok {
+			return request.NewRedirectUri(newOAuthErrorParams(e)), nil
+		}
+		return "", err
+	}
+
+	return config.Config.OidcProvider.ConsentPageUrl + "?" + rawQuery, nil
+}
+
+// GetConsent 동의 화면에 보여줄 앱과 scope, 동의가 필요한지 여부를 반환한다.
+func (s OAuthProviderService) GetConsent(ctx context.Context, request dtos.OAuthAuthorizationRequest) (dtos.OAuthConsent, error) {
+	clientEntity, scopes, err := s.ValidateAuthorizationRequest(ctx, request)
+	if err != nil {
+		return dtos.OAuthConsent{}, err
+	}
+
+	consentRequired, _, err := s.isConsentRequired(ctx, clientEntity, scopes)
+	if err != nil {
+		return dtos.OAuthConsent{}, err
+	}
+
+	return dtos.OAuthConsent{
+		ClientId:        clientEntity.ClientId,
+		ClientName:      clientEntity.Name,
+		Scopes:          scopes,
+		ConsentRequired: consentRequired,
+	}, nil
+}
+
+// Authorize 로그인한 멤버의 동의 결과로 인가 코드(또는 오류)를 붙인 앱의 리다이렉트 URI 를 반환한다.
+func (s OAuthProviderService) Authorize(ctx context.Context, decision dtos.OAuthConsentDecision) (string, error) {
+	clientEntity, scopes, err := s.ValidateAuthorizationRequest(ctx, decision.OAuthAuthorizationRequest)
+	if err != nil {
+		if e, ok := err.(*errors.ErrOAuth); ok {
+			return decision.NewRedirectUri(newOAuthErrorParams(e)), nil
+		}
+		return "", err
+	}
+
+	if decision.Approved == false {
+		return decision.NewRedirectUri(newOAuthErrorParams(&errors.ErrOAuth{Code: constants.OAuthErrorAccessDenied})), nil
+	}
+
+	consentRequired, consentEntity, err := s.isConsentRequired(ctx, clientEntity, scopes)
+	if err != nil {
+		return "", err
+	}
+
+	if consentRequired {
+		consentEntity.Grant(scopes)
+		if err := s.oauthConsentRepository.Save(ctx, &consentEntity); err != nil {
+			return "", err
+		}
+	}
+
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	code, err := security.NewOAuthAuthorizationCode()
+	if err != nil {
+		return "", err
+	}
+
+	codeEntity := authDomain.NewOAuthAuthorizationCodeEntity(code, clientEntity.ClientId, userClaim.Id,
+		decision.RedirectUri, strings.Join(scopes, " "), decision.Nonce, decision.CodeChallenge)
+	if err := s.oauthAuthorizationCodeRepository.Create(ctx, &codeEntity); err != nil {
+		return "", err
+	}
+
+	return decision.NewRedirectUri(url.Values{"code": {code}}), nil
+}
+
+// isConsentRequired 동의가 필요하면 동의를 기록할 엔티티(이전 동의 또는 새 동의)를 함께 반환한다.
+func (s OAuthProviderService) isConsentRequired(ctx context.Context, clientEntity authDomain.OAuthClientEntity,
+	scopes []string) (bool, authDomain.OAuthConsentEntity, error) {
+	if clientEntity.SkipConsent {
+		return false, authDomain.OAuthConsentEntity{}, nil
+	}
+
+	userClaim, err := helpers.ContextHelper().GetUserClaim(ctx)
+	if err != nil {
+		return false, authDomain.OAuthConsentEntity{}, err
+	}
+
+	consentEntity, err := s.oauthConsentRepository.FindByMemberIdAndClientId(ctx, userClaim.Id, clientEntity.ClientId)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return true, authDomain.NewOAuthConsentEntity(userClaim.Id, clientEntity.ClientId, []string{}), nil
+		}
+		return false, authDomain.OAuthConsentEntity{}, err
+	}
+
+	return consentEntity.Covers(scopes) == false, consentEntity, nil
+}
+
+// ExchangeAuthorizationCode https://datatracker.ietf.org/doc/html/rfc6749#section-4.1.3
+// basicClientId, basicClientSecret 은 Authorization 헤더(client_secret_basic)로 전달된 값이다.
+func (s OAuthProviderService) ExchangeAuthorizationCode(ctx context.Context, tokenRequest dtos.OAuthTokenRequest,
+	basicClientId, basicClientSecret string) (dtos.OAuthToken, error) {
+	if tokenRequest.GrantType != constants.OAuthGrantTypeAuthorizationCode {
+		return dtos.OAuthToken{}, &errors.ErrOAuth{Code: constants.OAuthErrorUnsupportedGrantType}
+	}
+
+	clientId, clientSecret := tokenRequest.ClientId, tokenRequest.ClientSecret
+	if len(basicClientId) > 0 {
+		clientId, clientSecret = basicClientId, basicClientSecret
+	}
+
+	clientEntity, err := s.authenticateClient(ctx, clientId, clientSecret)
+	if err != nil {
+		return dtos.OAuthToken{}, err
+	}
+
+	if len(tokenRequest.Code) == 0 {
+		return dtos.OAuthToken{}, &errors.ErrOAuth{Code: constants.OAuthErrorInvalidRequest, Description: "code required"}
+	}
+
+	invalidGrant := &errors.ErrOAuth{Code: constants.OAuthErrorInvalidGrant}
+	codeEntity, err := s.oauthAuthorizationCodeRepository.FindByCodeHash(ctx, security.HashToken(tokenRequest.Code))
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return dtos.OAuthToken{}, invalidGrant
+		}
+		return dtos.OAuthToken{}, err
+	}
+
+	if codeEntity.ClientId != clientEntity.ClientId {
+		return dtos.OAuthToken{}, invalidGrant
+	}
+
+	if codeEntity.IsUsed() {
+		return dtos.OAuthToken{}, s.revokeReusedAuthorizationCode(ctx, codeEntity)
+	}
+
+	if codeEntity.IsExpired() || codeEntity.RedirectUri != tokenRequest.RedirectUri ||
+		codeEntity.VerifyCodeVerifier(tokenRequest.CodeVerifier) == false {
+		return dtos.OAuthToken{}, invalidGrant
+	}
+
+	used, err := s.oauthAuthorizationCodeRepository.Use(ctx, &codeEntity)
+	if err != nil {
+		return dtos.OAuthToken{}, err
+	}
+	if used == false {
+		// 동시에 요청한 다른 요청이 먼저 인가 코드를 사용했다.
+		return dtos.OAuthToken{}, s.revokeReusedAuthorizationCode(ctx, codeEntity)
+	}
+
+	memberEntity, err := s.getApprovedMember(ctx, codeEntity.MemberId)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return dtos.OAuthToken{}, invalidGrant
+		}
+		return dtos.OAuthToken{}, err
+	}
+
+	scopes := strings.Fields(codeEntity.Scope)
+	idTokenClaims, err := s.getMemberClaims(ctx, memberEntity, scopes)
+	if err != nil {
+		return dtos.OAuthToken{}, err
+	}
+	idTokenClaims["iss"] = config.Config.OidcProvider.Issuer
+	idTokenClaims["aud"] = clientEntity.ClientId
+	if len(codeEntity.Nonce) > 0 {
+		idTokenClaims["nonce"] = codeEntity.Nonce
+	}
+
+	idToken, err := security.JwtAuthentication{}.GenerateIdToken(idTokenClaims)
+	if err != nil {
+		return dtos.OAuthToken{}, err
+	}
+
+	accessToken, err := security.NewOAuthAccessToken()
+	if err != nil {
+		return dtos.OAuthToken{}, err
+	}
+
+	accessTokenEntity := authDomain.NewOAuthAccessTokenEntity(accessToken, codeEntity)
+	if err := s.oauthAccessTokenRepository.Create(ctx, &accessTokenEntity); err != nil {
+		return dtos.OAuthToken{}, err
+	}
+
+	return dtos.OAuthToken{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(authDomain.OAuthAccessTokenExpiration.Seconds()),
+		IdToken:     idToken,
+		Scope:       codeEntity.Scope,
+	}, nil
+}
+
+// authenticateClient 기밀 클라이언트는 시크릿이 일치해야 하고, 공개 클라이언트는 시크릿 없이 PKCE 로만 인증한다.
+// revokeReusedAuthorizationCode 인가 코드가 유출되었을 수 있으므로 이 코드로 발급한 토큰을 폐기한다.(RFC 6749 4.1.2)
+func (s OAuthProviderService) revokeReusedAuthorizationCode(ctx context.Context, codeEntity authDomain.OAuthAuthorizationCodeEntity) error {
+	if err := s.oauthAccessTokenRepository.DeleteAllByAuthorizationCodeId(ctx, codeEntity.ID); err != nil {
+		return err
+	}
+	return &errors.ErrOAuth{Code: constants.OAuthErrorInvalidGrant}
+}
+
+func (s OAuthProviderService) authenticateClient(ctx context.Context, clientId, clientSecret string) (authDomain.OAuthClientEntity, error) {
+	invalidClient := &errors.ErrOAuth{Code: constants.OAuthErrorInvalidClient}
+	if len(clientId) == 0 {
+		return authDomain.OAuthClientEntity{}, invalidClient
+	}
+
+	clientEntity, err := s.oauthClientRepository.FindByClientId(ctx, clientId)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return authDomain.OAuthClientEntity{}, invalidClient
+		}
+		return authDomain.OAuthClientEntity{}, err
+	}
+
+	if clientEntity.IsPublic() {
+		if len(clientSecret) > 0 {
+			return authDomain.OAuthClientEntity{}, invalidClient
+		}
+		return clientEntity, nil
+	}
+
+	if clientEntity.MatchClientSecret(clientSecret) == false {
+		return authDomain.OAuthClientEntity{}, invalidClient
+	}
+
+	return clientEntity, nil
+}
+
+// GetUserInfo https://openid.net/specs/openid-connect-core-1_0.html#UserInfo
+func (s OAuthProviderService) GetUserInfo(ctx context.Context, accessToken string) (map[string]any, error) {
+	invalidToken := &errors.ErrOAuth{Code: constants.OAuthErrorInvalidToken}
+	if security.IsOAuthAccessToken(accessToken) == false {
+		return nil, invalidToken
+	}
+
+	accessTokenEntity, err := s.oauthAccessTokenRepository.FindByTokenHash(ctx, security.HashToken(accessToken))
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, invalidToken
+		}
+		return nil, err
+	}
+
+	if accessTokenEntity.IsExpired() {
+		return nil, invalidToken
+	}
+
+	memberEntity, err := s.getApprovedMember(ctx, accessTokenEntity.MemberId)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, invalidToken
+		}
+		return nil, err
+	}
+
+	return s.getMemberClaims(ctx, memberEntity, accessTokenEntity.GetScopes())
+}
+
+// getApprovedMember 토큰을 발급한 뒤 승인이 취소(비활성화)된 멤버는 찾을 수 없는 것으로 처리한다.
+func (s OAuthProviderService) getApprovedMember(ctx context.Context, memberId uint) (memberDomain.MemberEntity, error) {
+	memberEntity, err := s.memberService.GetMemberById(ctx, memberId)
+	if err != nil {
+		return memberDomain.MemberEntity{}, err
+	}
+
+	if memberEntity.IsApproved() == false {
+		return memberDomain.MemberEntity{}, errors.ErrNotFound
+	}
+
+	return memberEntity, nil
+}
+
+// getMemberClaims id_token 과 /userinfo 에 공통으로 제공하는 클레임. 이름, 이메일은 동의한 scope 에 따라 제공한다.
+func (s OAuthProviderService) getMemberClaims(ctx context.Context, memberEntity memberDomain.MemberEntity,
+	scopes []string) (map[string]any, error) {
+	assignedAllRoleAndPermission, err := s.organizationService.GetMemberAssignedAllRoleAndPermission(ctx, memberEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := map[string]any{
+		"sub":         strconv.FormatUint(uint64(memberEntity.ID), 10),
+		"roles":       assignedAllRoleAndPermission.Roles,
+		"permissions": assignedAllRoleAndPermission.Permissions,
+	}
+
+	for _, scope := range scopes {
+		switch scope {
+		case constants.OAuthScopeProfile:
+			claims["name"] = memberEntity.Name
+			if len(memberEntity.Picture) > 0 {
+				claims["picture"] = memberEntity.Picture
+			}
+		case constants.OAuthScopeEmail:
+			if len(memberEntity.Email) > 0 {
+				claims["email"] = memberEntity.Email
+				claims["email_verified"] = memberEntity.IsEmailVerified()
+			}
+		}
+	}
+
+	return claims, nil
+}
+
+// GetOpenIdConfiguration https://openid.net/specs/openid-connect-discovery-1_0.html#ProviderMetadata
+func (OAuthProviderService) GetOpenIdConfiguration() dtos.OpenIdConfiguration {
+	issuer := config.Config.OidcProvider.Issuer
+
+	return dtos.OpenIdConfiguration{
+		Issuer:                            issuer,
+		AuthorizationEndpoint:             issuer + "/api/oauth/authorize",
+		TokenEndpoint:                     issuer + "/api/oauth/token",
+		UserinfoEndpoint:                  issuer + "/api/oauth/userinfo",
+		JwksUri:                           issuer + "/.well-known/jwks.json",
+		ScopesSupported:                   supportedOAuthScopes,
+		ResponseTypesSupported:            []string{constants.OAuthResponseTypeCode},
+		GrantTypesSupported:               []string{constants.OAuthGrantTypeAuthorizationCode},
+		SubjectTypesSupported:             []string{"public"},
+		IdTokenSigningAlgValuesSupported:  []string{security.JwtAuthentication{}.GetSigningAlgorithm()},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{constants.OAuthCodeChallengeMethodS256},
+		ClaimsSupported: []string{"iss", "sub", "aud", "exp", "iat", "nonce", "name", "picture", "email",
+			"email_verified", "roles", "permissions"},
+	}
+}
+
+// parseOAuthScopes openid 는 반드시 포함해야 하며 지원하지 않는 scope 는 요청할 수 없다.
+func parseOAuthScopes(scope string) ([]string, error) {
+	scopes := make([]string, 0)
+	hasOpenId := false
+	for _, requested := range strings.Fields(scope) {
+		supported := false
+		for _, supportedScope := range supportedOAuthScopes {
+			if requested == supportedScope {
+				supported = true
+				break
+			}
+		}
+
+		if supported == false {
+			return nil, &errors.ErrOAuth{Code: constants.OAuthErrorInvalidScope, Description: "not supported scope: " + requested}
+		}
+
+		if requested == constants.OAuthScopeOpenId {
+			hasOpenId = true
+		}
+
+		duplicated := false
+		for _, scope := range scopes {
+			if scope == requested {
+				duplicated = true
+				break
+			}
+		}
+		if duplicated == false {
+			scopes = append(scopes, requested)
+		}
+	}
+
+	if hasOpenId == false {
+		return nil, &errors.ErrOAuth{Code: constants.OAuthErrorInvalidScope, Description: "openid scope required"}
+	}
+
+	return scopes, nil
+}
+
+func newOAuthErrorParams(err *errors.ErrOAuth) url.Values {
+	params := url.Values{"error": {err.Code}}
+	if len(err.Description) > 0 {
+		params.Set("error_description", err.Description)
+	}
+
+	return params
+}
@@ -28,12 +28,32 @@ func NewOrganizationService(
 	}
 }
 
-func (s OrganizationService) CreateOrganization(ctx context.Context, information dtos.OrganizationInformation) error {
+func (s OrganizationService) CreateOrganization(ctx context.Context, information dtos.OrganizationInformation) (domain.OrganizationEntity, error) {
 	organizationEntity, err := domain.NewOrganizationEntity(ctx, information)
 	if err != nil {
-		return err
+		return domain.OrganizationEntity{}, err
+	}
+
+	if err := s.organizationRepository.Create(ctx, &organizationEntity); err != nil {
+		return domain.OrganizationEntity{}, err
+	}
+
+	return organizationEntity, nil
+}
+
+// CreateScimOrganization SCIM 으로 생성한 조직은 SCIM 으로만 변경, 삭제할 수 있도록 표시한다.
+func (s OrganizationService) CreateScimOrganization(ctx context.Context, name string) (domain.OrganizationEntity, error) {
+	organizationEntity, err := domain.NewOrganizationEntity(ctx, dtos.OrganizationInformation{Name: name})
+	if err != nil {
+		return domain.OrganizationEntity{}, err
+	}
+	organizationEntity.ScimManaged = true
+
+	if err := s.organizationRepository.Create(ctx, &organizationEntity); err != nil {
+		return domain.OrganizationEntity{}, err
 	}
-	return s.organizationRepository.Create(ctx, organizationEntity)
+
+	return organizationEntity, nil
 }
 
 func (s OrganizationService) GetAllOrganizations(ctx context.Context, filters map[string]interface{}) ([]domain.OrganizationEntity, error) {
@@ -88,15 +108,22 @@ func (s OrganizationService) DeleteOrganization(ctx context.Context, organizatio
 		return err
 	}
 
+	memberIds := organizationEntity.GetMemberIds()
 	for _, childEntity := range childEntities {
 		childEntity.UpdatedBy = userClaim.Id
 		if err := s.organizationRepository.Delete(ctx, childEntity); err != nil {
 			return err
 		}
+		memberIds = append(memberIds, childEntity.GetMemberIds()...)
 	}
 
 	organizationEntity.UpdatedBy = userClaim.Id
-	return s.organizationRepository.Delete(ctx, organizationEntity)
+	if err := s.organizationRepository.Delete(ctx, organizationEntity); err != nil {
+		return err
+	}
+
+	// 조직에서 받은 역할이 사라지므로 소속 멤버의 권한 버전을 올린다.
+	return s.memberService.IncreasePermissionVersion(ctx, memberIds)
 }
 
 func (s OrganizationService) AssignRoles(ctx context.Context, organizationId uint, assignRole dtos.OrganizationAssignRole) error {
@@ -118,7 +145,11 @@ func (s OrganizationService) AssignRoles(ctx context.Context, organizationId uin
 		return err
 	}
 
-	return s.organizationRepository.Save(ctx, &organizationEntity)
+	if err := s.organizationRepository.Save(ctx, &organizationEntity); err != nil {
+		return err
+	}
+
+	return s.memberService.IncreasePermissionVersion(ctx, organizationEntity.GetMemberIds())
 }
 
 func (s OrganizationService) AssignMembers(ctx context.Context, organizationId uint, assignMember dtos.OrganizationAssignMember) error {
@@ -135,12 +166,42 @@ func (s OrganizationService) AssignMembers(ctx context.Context, organizationId u
 		return err
 	}
 
+	// 조직에서 빠지는 멤버와 새로 들어오는 멤버 모두 권한이 바뀐다.
+	memberIds := organizationEntity.GetMemberIds()
+
 	err = organizationEntity.AssignMember(ctx, findMemberEntities)
 	if err != nil {
 		return err
 	}
 
-	return s.organizationRepository.Save(ctx, &organizationEntity)
+	if err := s.organizationRepository.Save(ctx, &organizationEntity); err != nil {
+		return err
+	}
+
+	return s.memberService.IncreasePermissionVersion(ctx, append(memberIds, organizationEntity.GetMemberIds()...))
+}
+
+// AddMember 조직의 기존 멤버는 유지하고 멤버를 추가한다.
+func (s OrganizationService) AddMember(ctx context.Context, organizationId uint, memberEntity memberDomain.MemberEntity) error {
+	organizationEntity, err := s.organizationRepository.FindById(ctx, organizationId)
+	if err != nil {
+		return err
+	}
+
+	if organizationEntity.ExistMember(memberEntity.ID) {
+		return nil
+	}
+
+	err = organizationEntity.AssignMember(ctx, append(organizationEntity.Members, memberEntity))
+	if err != nil {
+		return err
+	}
+
+	if err := s.organizationRepository.Save(ctx, &organizationEntity); err != nil {
+		return err
+	}
+
+	return s.memberService.IncreasePermissionVersion(ctx, []uint{memberEntity.ID})
 }
 
 func (s OrganizationService) ChangeOrganizationName(ctx context.Context, organizationId uint, organizationName string) error {
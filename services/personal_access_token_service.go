@@ -0,0 +1,161 @@
+package services
+
+import (
+	authDomain "better-admin-backend-service/auth/domain"
+	authRepository "better-admin-backend-service/auth/repository"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/security"
+	"context"
+	"strings"
+	"time"
+)
+
+// PersonalAccessTokenService 멤버가 가진 권한 중 일부만 사용할 수 있는 개인 액세스 토큰을 발급하고 인증한다.
+type PersonalAccessTokenService struct {
+	memberService                 *MemberService
+	organizationService           *OrganizationService
+	personalAccessTokenRepository *authRepository.PersonalAccessTokenRepository
+}
+
+func NewPersonalAccessTokenService(memberService *MemberService,
+	organizationService *OrganizationService,
+	personalAccessTokenRepository *authRepository.PersonalAccessTokenRepository) *PersonalAccessTokenService {
+
+	return &PersonalAccessTokenService{
+		memberService:                 memberService,
+		organizationService:           organizationService,
+		personalAccessTokenRepository: personalAccessTokenRepository,
+	}
+}
+
+// CreatePersonalAccessToken 토큰 원문은 반환한 뒤 다시 조회할 수 없다.
+func (s PersonalAccessTokenService) CreatePersonalAccessToken(ctx context.Context, memberId uint,
+	creation dtos.PersonalAccessTokenCreation) (authDomain.PersonalAccessTokenEntity, string, error) {
+
+	memberPermissions, tokenEpoch, err := s.getMemberPermissions(ctx, memberId)
+	if err != nil {
+		return authDomain.PersonalAccessTokenEntity{}, "", err
+	}
+
+	for _, permission := range creation.Permissions {
+		if hasPermission(memberPermissions, permission) == false {
+			return authDomain.PersonalAccessTokenEntity{}, "", errors.ErrNotAllowedPermission
+		}
+	}
+
+	token, err := security.NewPersonalAccessToken()
+	if err != nil {
+		return authDomain.PersonalAccessTokenEntity{}, "", err
+	}
+
+	expiresAt := time.Now().AddDate(0, 0, creation.ExpiresInDays)
+	entity := authDomain.NewPersonalAccessTokenEntity(memberId, tokenEpoch, creation.Name, token, creation.Permissions, expiresAt)
+	if err := s.personalAccessTokenRepository.Create(ctx, &entity); err != nil {
+		return authDomain.PersonalAccessTokenEntity{}, "", err
+	}
+
+	return entity, token, nil
+}
+
+func (s PersonalAccessTokenService) GetPersonalAccessTokens(ctx context.Context, memberId uint) ([]authDomain.PersonalAccessTokenEntity, error) {
+	return s.personalAccessTokenRepository.FindAllByMemberId(ctx, memberId)
+}
+
+func (s PersonalAccessTokenService) DeletePersonalAccessToken(ctx context.Context, memberId, id uint) error {
+	entity, err := s.personalAccessTokenRepository.FindByIdAndMemberId(ctx, id, memberId)
+	if err != nil {
+		return err
+	}
+
+	return s.personalAccessTokenRepository.Delete(ctx, entity)
+}
+
+// Authenticate 토큰의 권한 중 멤버가 지금도 가지고 있는 권한만 담은 UserClaim 을 반환한다.
+// 멤버가 승인 상태가 아니거나 멤버의 모든 토큰이 폐기된 경우 security.AccessTokenRevoked 를 반환한다.
+func (s PersonalAccessTokenService) Authenticate(ctx context.Context, token string) (*security.UserClaim, error) {
+	entity, err := s.personalAccessTokenRepository.FindByTokenHash(ctx, security.HashToken(token))
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, security.InvalidAccessToken
+		}
+		return nil, err
+	}
+
+	if entity.IsExpired() {
+		return nil, security.AccessTokenExpired
+	}
+
+	memberEntity, err := s.memberService.GetMemberById(ctx, entity.MemberId)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, security.AccessTokenRevoked
+		}
+		return nil, err
+	}
+
+	if memberEntity.IsApproved() == false || memberEntity.IsTokenEpochExpired(entity.TokenEpoch) {
+		return nil, security.AccessTokenRevoked
+	}
+
+	memberAssignedAllRoleAndPermission, err := s.organizationService.GetMemberAssignedAllRoleAndPermission(ctx, memberEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions := make([]string, 0)
+	for _, permission := range entity.GetPermissions() {
+		if hasPermission(memberAssignedAllRoleAndPermission.Permissions, permission) {
+			permissions = append(permissions, permission)
+		}
+	}
+
+	entity.Use()
+	if err := s.personalAccessTokenRepository.Save(ctx, &entity); err != nil {
+		return nil, err
+	}
+
+	return &security.UserClaim{
+		Id:                    entity.MemberId,
+		Roles:                 []string{},
+		Permissions:           permissions,
+		PersonalAccessTokenId: entity.ID,
+	}, nil
+}
+
+func (s PersonalAccessTokenService) getMemberPermissions(ctx context.Context, memberId uint) ([]string, uint, error) {
+	memberEntity, err := s.memberService.GetMemberById(ctx, memberId)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	memberAssignedAllRoleAndPermission, err := s.organizationService.GetMemberAssignedAllRoleAndPermission(ctx, memberEntity)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return memberAssignedAllRoleAndPermission.Permissions, memberEntity.TokenEpoch, nil
+}
+
+// hasPermission 권한 정책(policy.rego)과 같이 "member.all" 은 "member.read" 등 같은 대상의 모든 권한을 포함한다.
+// 본인 정보 관리 범위(member.self)는 모든 멤버가 가진다.
+func hasPermission(permissions []string, permission string) bool {
+	if permission == constants.PermissionScopeMemberSelf {
+		return true
+	}
+
+	for _, p := range permissions {
+		if p == permission {
+			return true
+		}
+
+		details := strings.Split(p, ".")
+		requiredDetails := strings.Split(permission, ".")
+		if len(details) == 2 && len(requiredDetails) == 2 && details[1] == "all" && details[0] == requiredDetails[0] {
+			return true
+		}
+	}
+
+	return false
+}
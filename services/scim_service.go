@@ -0,0 +1,379 @@
+package services
+
+import (
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	memberDomain "better-admin-backend-service/member/domain"
+	organizationDomain "better-admin-backend-service/organization/domain"
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+const (
+	scimDefaultCount = 100
+	scimMaxCount     = 100
+)
+
+// ScimService 인사 시스템, IdP(Okta, Azure AD 등)가 SCIM 2.0 으로 멤버와 조직을 프로비저닝한다.
+// SCIM 사용자는 SCIM 으로 생성한 멤버이고, SCIM 그룹은 조직이다.
+type ScimService struct {
+	memberService       *MemberService
+	organizationService *OrganizationService
+}
+
+func NewScimService(memberService *MemberService, organizationService *OrganizationService) *ScimService {
+	return &ScimService{
+		memberService:       memberService,
+		organizationService: organizationService,
+	}
+}
+
+// GetUsers startIndex 는 1 부터 시작한다. 실제로 조회한 시작 위치를 함께 반환한다.
+func (s ScimService) GetUsers(ctx context.Context, filter string, startIndex, count int) ([]memberDomain.MemberEntity, int64, int, error) {
+	filters := map[string]interface{}{
+		"types": []string{constants.TypeMemberScim},
+	}
+
+	if len(filter) > 0 {
+		scimFilter, err := dtos.ParseScimFilter(filter)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+
+		switch strings.ToLower(scimFilter.Attribute) {
+		case "username":
+			filters["scimUserName"] = scimFilter.Value
+		case "externalid":
+			filters["scimExternalId"] = scimFilter.Value
+		case "emails", "emails.value":
+			filters["email"] = scimFilter.Value
+		case "id":
+			filters["memberIds"] = []uint{}
+			if id, err := strconv.ParseUint(scimFilter.Value, 10, 64); err == nil {
+				filters["memberIds"] = []uint{uint(id)}
+			}
+		default:
+			return nil, 0, 0, errors.ErrInvalidScimFilter
+		}
+	}
+
+	pageable := newScimPageable(startIndex, count)
+	memberEntities, totalCount, err := s.memberService.GetMembers(ctx, filters, pageable)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	return memberEntities, totalCount, pageable.GetOffset() + 1, nil
+}
+
+// newScimPageable SCIM 의 startIndex 는 1부터 시작하는 항목 위치이므로 startIndex 번째 항목부터 조회한다.(RFC 7644 3.4.2.4)
+func newScimPageable(startIndex, count int) dtos.Pageable {
+	if count <= 0 {
+		count = scimDefaultCount
+	}
+
+	if count > scimMaxCount {
+		count = scimMaxCount
+	}
+
+	if startIndex < 1 {
+		startIndex = 1
+	}
+
+	return dtos.NewOffsetPageable(startIndex-1, count)
+}
+
+// GetUser SCIM 으로 생성하지 않은 멤버는 찾을 수 없는 것으로 처리한다.
+func (s ScimService) GetUser(ctx context.Context, id string) (memberDomain.MemberEntity, error) {
+	memberId, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return memberDomain.MemberEntity{}, errors.ErrNotFound
+	}
+
+	memberEntity, err := s.memberService.GetMemberById(ctx, uint(memberId))
+	if err != nil {
+		return memberDomain.MemberEntity{}, err
+	}
+
+	if memberEntity.Type != constants.TypeMemberScim {
+		return memberDomain.MemberEntity{}, errors.ErrNotFound
+	}
+
+	return memberEntity, nil
+}
+
+func (s ScimService) CreateUser(ctx context.Context, scimUser dtos.ScimUser) (memberDomain.MemberEntity, error) {
+	return s.memberService.CreateScimMember(ctx, scimUser)
+}
+
+func (s ScimService) ReplaceUser(ctx context.Context, id string, scimUser dtos.ScimUser) (memberDomain.MemberEntity, error) {
+	memberEntity, err := s.GetUser(ctx, id)
+	if err != nil {
+		return memberDomain.MemberEntity{}, err
+	}
+
+	return s.memberService.UpdateMemberFromScimUser(ctx, memberEntity.ID, scimUser)
+}
+
+func (s ScimService) PatchUser(ctx context.Context, id string, patchRequest dtos.ScimPatchRequest) (memberDomain.MemberEntity, error) {
+	memberEntity, err := s.GetUser(ctx, id)
+	if err != nil {
+		return memberDomain.MemberEntity{}, err
+	}
+
+	resource, err := toScimResource(memberEntity.GetScimUser())
+	if err != nil {
+		return memberDomain.MemberEntity{}, err
+	}
+
+	if err := patchRequest.ApplyTo(resource); err != nil {
+		return memberDomain.MemberEntity{}, err
+	}
+
+	// Azure AD 는 active 를 "False" 처럼 문자열로 보낸다.
+	for key, value := range resource {
+		if active, ok := value.(string); ok && strings.EqualFold(key, "active") {
+			resource[key] = strings.EqualFold(active, "true")
+		}
+	}
+
+	var scimUser dtos.ScimUser
+	if err := fromScimResource(resource, &scimUser); err != nil {
+		return memberDomain.MemberEntity{}, err
+	}
+
+	if len(scimUser.UserName) == 0 {
+		return memberDomain.MemberEntity{}, errors.ErrInvalidScimValue
+	}
+
+	return s.memberService.UpdateMemberFromScimUser(ctx, memberEntity.ID, scimUser)
+}
+
+// DeactivateUser 인사 시스템에서 삭제한 사용자도 이력을 남기기 위해 멤버를 삭제하지 않고 비활성화한다.
+func (s ScimService) DeactivateUser(ctx context.Context, id string) error {
+	memberEntity, err := s.GetUser(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.memberService.DeactivateMember(ctx, memberEntity.ID)
+}
+
+// GetGroups 조직 수가 많지 않으므로 필터와 페이징은 조회한 뒤에 적용한다.
+func (s ScimService) GetGroups(ctx context.Context, filter string, startIndex, count int) ([]organizationDomain.OrganizationEntity, int64, int, error) {
+	var scimFilter *dtos.ScimFilter
+	if len(filter) > 0 {
+		parsedFilter, err := dtos.ParseScimFilter(filter)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+
+		attribute := strings.ToLower(parsedFilter.Attribute)
+		if attribute != "displayname" && attribute != "id" {
+			return nil, 0, 0, errors.ErrInvalidScimFilter
+		}
+		scimFilter = &parsedFilter
+	}
+
+	organizationEntities, err := s.organizationService.GetAllOrganizations(ctx, nil)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	matchedEntities := make([]organizationDomain.OrganizationEntity, 0)
+	for _, organizationEntity := range organizationEntities {
+		if scimFilter != nil {
+			resource, err := toScimResource(organizationEntity.GetScimGroup())
+			if err != nil {
+				return nil, 0, 0, err
+			}
+
+			if scimFilter.Matches(resource) == false {
+				continue
+			}
+		}
+		matchedEntities = append(matchedEntities, organizationEntity)
+	}
+
+	pageable := newScimPageable(startIndex, count)
+	start := pageable.GetOffset()
+	if start > len(matchedEntities) {
+		start = len(matchedEntities)
+	}
+	end := start + pageable.PageSize
+	if end > len(matchedEntities) {
+		end = len(matchedEntities)
+	}
+
+	return matchedEntities[start:end], int64(len(matchedEntities)), start + 1, nil
+}
+
+func (s ScimService) GetGroup(ctx context.Context, id string) (organizationDomain.OrganizationEntity, error) {
+	organizationId, err := strconv.ParseUint(id, 10, 64)
+	if err != nil {
+		return organizationDomain.OrganizationEntity{}, errors.ErrNotFound
+	}
+
+	return s.organizationService.GetOrganization(ctx, uint(organizationId))
+}
+
+// getScimManagedGroup SCIM 으로 생성하지 않은 조직은 변경할 수 없으므로 찾을 수 없는 것으로 처리한다.
+func (s ScimService) getScimManagedGroup(ctx context.Context, id string) (organizationDomain.OrganizationEntity, error) {
+	organizationEntity, err := s.GetGroup(ctx, id)
+	if err != nil {
+		return organizationDomain.OrganizationEntity{}, err
+	}
+
+	if !organizationEntity.ScimManaged {
+		return organizationDomain.OrganizationEntity{}, errors.ErrNotFound
+	}
+
+	return organizationEntity, nil
+}
+
+func (s ScimService) CreateGroup(ctx context.Context, scimGroup dtos.ScimGroup) (organizationDomain.OrganizationEntity, error) {
+	memberIds, err := s.getGroupMemberIds(ctx, scimGroup)
+	if err != nil {
+		return organizationDomain.OrganizationEntity{}, err
+	}
+
+	organizationEntity, err := s.organizationService.CreateScimOrganization(ctx, scimGroup.DisplayName)
+	if err != nil {
+		return organizationDomain.OrganizationEntity{}, err
+	}
+
+	if err := s.organizationService.AssignMembers(ctx, organizationEntity.ID, dtos.OrganizationAssignMember{MemberIds: memberIds}); err != nil {
+		return organizationDomain.OrganizationEntity{}, err
+	}
+
+	return s.organizationService.GetOrganization(ctx, organizationEntity.ID)
+}
+
+// ReplaceGroup 그룹의 멤버로 조직의 멤버를 모두 교체한다.
+func (s ScimService) ReplaceGroup(ctx context.Context, id string, scimGroup dtos.ScimGroup) (organizationDomain.OrganizationEntity, error) {
+	organizationEntity, err := s.getScimManagedGroup(ctx, id)
+	if err != nil {
+		return organizationDomain.OrganizationEntity{}, err
+	}
+
+	memberIds, err := s.getGroupMemberIds(ctx, scimGroup)
+	if err != nil {
+		return organizationDomain.OrganizationEntity{}, err
+	}
+
+	if organizationEntity.Name != scimGroup.DisplayName {
+		if err := s.organizationService.ChangeOrganizationName(ctx, organizationEntity.ID, scimGroup.DisplayName); err != nil {
+			return organizationDomain.OrganizationEntity{}, err
+		}
+	}
+
+	if err := s.organizationService.AssignMembers(ctx, organizationEntity.ID, dtos.OrganizationAssignMember{MemberIds: memberIds}); err != nil {
+		return organizationDomain.OrganizationEntity{}, err
+	}
+
+	return s.organizationService.GetOrganization(ctx, organizationEntity.ID)
+}
+
+func (s ScimService) PatchGroup(ctx context.Context, id string, patchRequest dtos.ScimPatchRequest) (organizationDomain.OrganizationEntity, error) {
+	organizationEntity, err := s.getScimManagedGroup(ctx, id)
+	if err != nil {
+		return organizationDomain.OrganizationEntity{}, err
+	}
+
+	resource, err := toScimResource(organizationEntity.GetScimGroup())
+	if err != nil {
+		return organizationDomain.OrganizationEntity{}, err
+	}
+
+	if err := patchRequest.ApplyTo(resource); err != nil {
+		return organizationDomain.OrganizationEntity{}, err
+	}
+
+	var scimGroup dtos.ScimGroup
+	if err := fromScimResource(resource, &scimGroup); err != nil {
+		return organizationDomain.OrganizationEntity{}, err
+	}
+
+	if len(scimGroup.DisplayName) == 0 {
+		return organizationDomain.OrganizationEntity{}, errors.ErrInvalidScimValue
+	}
+
+	return s.ReplaceGroup(ctx, id, scimGroup)
+}
+
+func (s ScimService) DeleteGroup(ctx context.Context, id string) error {
+	organizationEntity, err := s.getScimManagedGroup(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.organizationService.DeleteOrganization(ctx, organizationEntity.ID)
+}
+
+// getGroupMemberIds 존재하지 않는 멤버를 지정하면 errors.ErrInvalidScimValue 를 반환한다.
+func (s ScimService) getGroupMemberIds(ctx context.Context, scimGroup dtos.ScimGroup) ([]uint, error) {
+	memberIds := make([]uint, 0)
+	for _, member := range scimGroup.Members {
+		memberId, err := strconv.ParseUint(member.Value, 10, 64)
+		if err != nil {
+			return nil, errors.ErrInvalidScimValue
+		}
+		memberIds = append(memberIds, uint(memberId))
+	}
+
+	if len(memberIds) == 0 {
+		return memberIds, nil
+	}
+
+	memberEntities, _, err := s.memberService.GetMembers(ctx, map[string]interface{}{"memberIds": memberIds}, dtos.Pageable{Page: 0})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, memberId := range memberIds {
+		exists := false
+		for _, memberEntity := range memberEntities {
+			if memberEntity.ID == memberId {
+				exists = true
+				break
+			}
+		}
+
+		if exists == false {
+			return nil, errors.ErrInvalidScimValue
+		}
+	}
+
+	return memberIds, nil
+}
+
+// toScimResource PATCH 와 필터를 적용하기 위해 리소스를 JSON 객체(map)로 변환한다.
+func toScimResource(value any) (map[string]any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	resource := map[string]any{}
+	if err := json.Unmarshal(data, &resource); err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+func fromScimResource(resource map[string]any, value any) error {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, value); err != nil {
+		return errors.ErrInvalidScimValue
+	}
+
+	return nil
+}
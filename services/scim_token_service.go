@@ -0,0 +1,78 @@
+package services
+
+import (
+	authDomain "better-admin-backend-service/auth/domain"
+	authRepository "better-admin-backend-service/auth/repository"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/security"
+	"context"
+)
+
+// ScimTokenService SCIM API 전용 토큰을 발급하고 인증한다.
+type ScimTokenService struct {
+	scimTokenRepository *authRepository.ScimTokenRepository
+}
+
+func NewScimTokenService(scimTokenRepository *authRepository.ScimTokenRepository) *ScimTokenService {
+	return &ScimTokenService{
+		scimTokenRepository: scimTokenRepository,
+	}
+}
+
+// CreateScimToken 토큰 원문은 반환한 뒤 다시 조회할 수 없다.
+func (s ScimTokenService) CreateScimToken(ctx context.Context, name string) (authDomain.ScimTokenEntity, string, error) {
+	token, err := security.NewScimToken()
+	if err != nil {
+		return authDomain.ScimTokenEntity{}, "", err
+	}
+
+	entity, err := authDomain.NewScimTokenEntity(ctx, name, token)
+	if err != nil {
+		return authDomain.ScimTokenEntity{}, "", err
+	}
+
+	if err := s.scimTokenRepository.Create(ctx, &entity); err != nil {
+		return authDomain.ScimTokenEntity{}, "", err
+	}
+
+	return entity, token, nil
+}
+
+func (s ScimTokenService) GetScimTokens(ctx context.Context) ([]authDomain.ScimTokenEntity, error) {
+	return s.scimTokenRepository.FindAll(ctx)
+}
+
+func (s ScimTokenService) DeleteScimToken(ctx context.Context, id uint) error {
+	entity, err := s.scimTokenRepository.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return s.scimTokenRepository.Delete(ctx, entity)
+}
+
+// Authenticate SCIM API 를 호출한 시스템을 나타내는 UserClaim 을 반환한다.
+func (s ScimTokenService) Authenticate(ctx context.Context, token string) (*security.UserClaim, error) {
+	if security.IsScimToken(token) == false {
+		return nil, security.InvalidAccessToken
+	}
+
+	entity, err := s.scimTokenRepository.FindByTokenHash(ctx, security.HashToken(token))
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, security.InvalidAccessToken
+		}
+		return nil, err
+	}
+
+	entity.Use()
+	if err := s.scimTokenRepository.Save(ctx, &entity); err != nil {
+		return nil, err
+	}
+
+	return &security.UserClaim{
+		Roles:       []string{},
+		Permissions: []string{},
+		ScimTokenId: entity.ID,
+	}, nil
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	authDomain "better-admin-backend-service/auth/domain"
+	authRepository "better-admin-backend-service/auth/repository"
+	"better-admin-backend-service/errors"
+	"better-admin-backend-service/security"
+	"context"
+	"time"
+)
+
+// recentLoginDuration 패스키 등록 등 인증 수단을 추가하려면 이 시간 안에 다시 로그인해야 한다.
+const recentLoginDuration = 5 * time.Minute
+
+// SessionService 멤버가 로그인한 세션을 조회하고 세션 단위로 폐기한다.
+type SessionService struct {
+	tokenRevocationService *TokenRevocationService
+	sessionRepository      *authRepository.SessionRepository
+}
+
+func NewSessionService(tokenRevocationService *TokenRevocationService,
+	sessionRepository *authRepository.SessionRepository) *SessionService {
+
+	return &SessionService{
+		tokenRevocationService: tokenRevocationService,
+		sessionRepository:      sessionRepository,
+	}
+}
+
+func (s SessionService) GetActiveSessions(ctx context.Context, memberId uint) ([]authDomain.SessionEntity, error) {
+	return s.sessionRepository.FindAllActiveByMemberId(ctx, memberId)
+}
+
+// RevokeSession 세션의 리프레시 토큰 패밀리를 폐기한다. 세션의 액세스 토큰은 즉시, 리프레시 토큰은 다음 갱신부터 사용할 수 없다.
+func (s SessionService) RevokeSession(ctx context.Context, memberId, id uint) error {
+	sessionEntity, err := s.sessionRepository.FindByIdAndMemberId(ctx, id, memberId)
+	if err != nil {
+		return err
+	}
+
+	return s.tokenRevocationService.RevokeRefreshTokenFamily(ctx, sessionEntity.SessionId)
+}
+
+// RequireRecentLogin 토큰의 세션이 최근(recentLoginDuration)에 로그인한 세션이 아니면 errors.ErrReauthenticationRequired 를 반환한다.
+// 탈취된 액세스 토큰만으로 인증 수단을 추가할 수 없도록 다시 로그인하게 한다.
+func (s SessionService) RequireRecentLogin(ctx context.Context, userClaim *security.UserClaim) error {
+	if len(userClaim.SessionId) == 0 {
+		return errors.ErrReauthenticationRequired
+	}
+
+	sessionEntity, err := s.sessionRepository.FindBySessionId(ctx, userClaim.SessionId)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return errors.ErrReauthenticationRequired
+		}
+		return err
+	}
+
+	if sessionEntity.MemberId != userClaim.Id || sessionEntity.IsActive() == false ||
+		time.Since(sessionEntity.CreatedAt) > recentLoginDuration {
+		return errors.ErrReauthenticationRequired
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package services
+
+import (
+	"better-admin-backend-service/adapters"
+	"better-admin-backend-service/config"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	memberDomain "better-admin-backend-service/member/domain"
+	memberRepository "better-admin-backend-service/member/repository"
+	"better-admin-backend-service/security"
+	"context"
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"net/url"
+)
+
+// SignUpService 사이트 가입 방식(sign-up) 설정에 따라 가입을 처리하고, 가입 초대를 관리한다.
+type SignUpService struct {
+	memberService              *MemberService
+	organizationService        *OrganizationService
+	siteService                *SiteService
+	emailVerificationService   *EmailVerificationService
+	memberProvisioningService  *MemberProvisioningService
+	memberInvitationRepository *memberRepository.MemberInvitationRepository
+}
+
+func NewSignUpService(memberService *MemberService,
+	organizationService *OrganizationService,
+	siteService *SiteService,
+	emailVerificationService *EmailVerificationService,
+	memberProvisioningService *MemberProvisioningService,
+	memberInvitationRepository *memberRepository.MemberInvitationRepository) *SignUpService {
+
+	return &SignUpService{
+		memberService:              memberService,
+		organizationService:        organizationService,
+		siteService:                siteService,
+		emailVerificationService:   emailVerificationService,
+		memberProvisioningService:  memberProvisioningService,
+		memberInvitationRepository: memberInvitationRepository,
+	}
+}
+
+// SignUpMember 초대 토큰이 있으면 초대로 가입(바로 승인)하고, 없으면 가입 신청한다.
+// 가입이 중지(disabled)된 경우 초대를 받았더라도 가입할 수 없다.
+func (s SignUpService) SignUpMember(ctx context.Context, signUp dtos.MemberSignUp) error {
+	setting, err := s.siteService.GetSignUp(ctx)
+	if err != nil {
+		return err
+	}
+
+	if setting.Mode == constants.SignUpModeDisabled {
+		return errors.ErrSignUpNotAllowed
+	}
+
+	if len(signUp.InvitationToken) > 0 {
+		return s.signUpWithInvitation(ctx, signUp)
+	}
+
+	if setting.Mode != constants.SignUpModeOpen {
+		return errors.ErrSignUpNotAllowed
+	}
+
+	memberEntity, err := s.memberService.SignUpMember(ctx, signUp, nil)
+	if err != nil {
+		return err
+	}
+
+	if len(memberEntity.Email) == 0 {
+		return nil
+	}
+
+	// 인증 메일은 나중에 다시 보낼 수 있으므로 메일 발송에 실패해도 가입은 진행한다.
+	if err := s.emailVerificationService.SendEmailVerification(ctx, memberEntity); err != nil {
+		log.Error("send email verification error: ", err)
+	}
+
+	return nil
+}
+
+func (s SignUpService) signUpWithInvitation(ctx context.Context, signUp dtos.MemberSignUp) error {
+	invitationEntity, err := s.memberInvitationRepository.FindByTokenHash(ctx, security.HashToken(signUp.InvitationToken))
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return errors.ErrInvalidInvitation
+		}
+		return err
+	}
+
+	if invitationEntity.IsAvailable() == false {
+		return errors.ErrInvalidInvitation
+	}
+
+	memberEntity, err := s.memberService.SignUpMember(ctx, signUp, &invitationEntity)
+	if err != nil {
+		return err
+	}
+
+	for _, organizationId := range invitationEntity.GetOrganizationIds() {
+		// 초대한 뒤 삭제된 조직은 무시한다.
+		if err := s.organizationService.AddMember(ctx, organizationId, memberEntity); err != nil && err != errors.ErrNotFound {
+			return err
+		}
+	}
+
+	// 초대로 가입한 멤버는 바로 승인되므로 외부 인증으로 처음 로그인한 멤버와 같이 자동 할당 규칙을 적용한다.
+	if _, err := s.memberProvisioningService.ProvisionMember(ctx, memberEntity.ID); err != nil {
+		return err
+	}
+
+	invitationEntity.Accept(memberEntity.ID)
+	return s.memberInvitationRepository.Save(ctx, &invitationEntity)
+}
+
+// CreateInvitation 초대 메일을 보낸다. 토큰 원문은 메일로만 전달한다.
+func (s SignUpService) CreateInvitation(ctx context.Context, creation dtos.MemberInvitationCreation) (memberDomain.MemberInvitationEntity, error) {
+	token, err := security.NewRandomId()
+	if err != nil {
+		return memberDomain.MemberInvitationEntity{}, err
+	}
+
+	entity, err := memberDomain.NewMemberInvitationEntity(ctx, token, creation)
+	if err != nil {
+		return memberDomain.MemberInvitationEntity{}, err
+	}
+
+	if err := s.memberInvitationRepository.Create(ctx, &entity); err != nil {
+		return memberDomain.MemberInvitationEntity{}, err
+	}
+
+	if err := adapters.MailAdapter().Send(dtos.Mail{
+		To:      []string{entity.Email},
+		Subject: "가입 초대",
+		Body: fmt.Sprintf("아래 링크에서 가입해 주세요.(%s 까지 유효합니다.)\n\n%s/sign-up?invitationToken=%s",
+			entity.ExpiresAt.Format("2006-01-02 15:04"), config.Config.Mail.WebUrl, url.QueryEscape(token)),
+	}); err != nil {
+		return memberDomain.MemberInvitationEntity{}, err
+	}
+
+	return entity, nil
+}
+
+func (s SignUpService) GetInvitations(ctx context.Context, filters map[string]interface{}, pageable dtos.Pageable) ([]memberDomain.MemberInvitationEntity, int64, error) {
+	return s.memberInvitationRepository.FindAll(ctx, filters, pageable)
+}
+
+// CancelInvitation 이미 수락한 초대는 취소할 수 없다.
+func (s SignUpService) CancelInvitation(ctx context.Context, invitationId uint) error {
+	entity, err := s.memberInvitationRepository.FindById(ctx, invitationId)
+	if err != nil {
+		return err
+	}
+
+	if entity.IsAccepted() {
+		return errors.ErrInvalidInvitation
+	}
+
+	return s.memberInvitationRepository.Delete(ctx, entity)
+}
@@ -82,6 +82,60 @@ func (s SiteService) GetAppVersion(ctx context.Context) (dtos.AppVersionSetting,
 	return appVersion, nil
 }
 
+// GetPasswordPolicy 비밀번호 정책을 설정하지 않은 경우 기본 정책(비밀번호 필수, bcrypt.DefaultCost)을 반환한다.
+func (s SiteService) GetPasswordPolicy(ctx context.Context) (dtos.PasswordPolicySetting, error) {
+	settingEntity, err := s.siteSettingRepository.FindByKey(ctx, constants.SettingKeyPasswordPolicy)
+	if err != nil {
+		if pkgerrors.Is(err, errors.ErrNotFound) {
+			return dtos.PasswordPolicySetting{}, nil
+		}
+		return dtos.PasswordPolicySetting{}, err
+	}
+
+	var passwordPolicy dtos.PasswordPolicySetting
+	if err = mapstructure.Decode(settingEntity.ValueObject, &passwordPolicy); err != nil {
+		return dtos.PasswordPolicySetting{}, err
+	}
+
+	return passwordPolicy, nil
+}
+
+// GetLoginProtection 로그인 제한을 설정하지 않은 경우 기본 설정(아이디 5회, IP 20회 실패 시 15분 잠금)을 반환한다.
+func (s SiteService) GetLoginProtection(ctx context.Context) (dtos.LoginProtectionSetting, error) {
+	settingEntity, err := s.siteSettingRepository.FindByKey(ctx, constants.SettingKeyLoginProtection)
+	if err != nil {
+		if pkgerrors.Is(err, errors.ErrNotFound) {
+			return dtos.NewLoginProtectionSetting(), nil
+		}
+		return dtos.LoginProtectionSetting{}, err
+	}
+
+	var loginProtection dtos.LoginProtectionSetting
+	if err = mapstructure.Decode(settingEntity.ValueObject, &loginProtection); err != nil {
+		return dtos.LoginProtectionSetting{}, err
+	}
+
+	return loginProtection, nil
+}
+
+// GetMemberAccessLog 접근 기록을 설정하지 않은 경우 모든 로그인, 토큰 갱신을 기록한다.
+func (s SiteService) GetMemberAccessLog(ctx context.Context) (dtos.MemberAccessLogSetting, error) {
+	settingEntity, err := s.siteSettingRepository.FindByKey(ctx, constants.SettingKeyMemberAccessLog)
+	if err != nil {
+		if pkgerrors.Is(err, errors.ErrNotFound) {
+			return dtos.NewMemberAccessLogSetting(), nil
+		}
+		return dtos.MemberAccessLogSetting{}, err
+	}
+
+	var memberAccessLog dtos.MemberAccessLogSetting
+	if err = mapstructure.Decode(settingEntity.ValueObject, &memberAccessLog); err != nil {
+		return dtos.MemberAccessLogSetting{}, err
+	}
+
+	return memberAccessLog, nil
+}
+
 func (s SiteService) IncreaseAppVersion(ctx context.Context) error {
 	appVersion, err := s.GetAppVersion(ctx)
 	if err != nil {
@@ -91,3 +145,39 @@ func (s SiteService) IncreaseAppVersion(ctx context.Context) error {
 
 	return s.SetSettingWithKey(ctx, constants.SettingKeyAppVersion, appVersion)
 }
+
+// GetSignUp 가입 방식을 설정하지 않은 경우 누구나 가입 신청할 수 있다.
+func (s SiteService) GetSignUp(ctx context.Context) (dtos.SignUpSetting, error) {
+	settingEntity, err := s.siteSettingRepository.FindByKey(ctx, constants.SettingKeySignUp)
+	if err != nil {
+		if pkgerrors.Is(err, errors.ErrNotFound) {
+			return dtos.NewSignUpSetting(), nil
+		}
+		return dtos.SignUpSetting{}, err
+	}
+
+	var signUp dtos.SignUpSetting
+	if err = mapstructure.Decode(settingEntity.ValueObject, &signUp); err != nil {
+		return dtos.SignUpSetting{}, err
+	}
+
+	return signUp, nil
+}
+
+// GetMemberProvisioning 자동 할당 규칙을 설정하지 않은 경우 규칙이 없는 설정을 반환한다.
+func (s SiteService) GetMemberProvisioning(ctx context.Context) (dtos.MemberProvisioningSetting, error) {
+	settingEntity, err := s.siteSettingRepository.FindByKey(ctx, constants.SettingKeyMemberProvisioning)
+	if err != nil {
+		if pkgerrors.Is(err, errors.ErrNotFound) {
+			return dtos.NewMemberProvisioningSetting(), nil
+		}
+		return dtos.MemberProvisioningSetting{}, err
+	}
+
+	var memberProvisioning dtos.MemberProvisioningSetting
+	if err = mapstructure.Decode(settingEntity.ValueObject, &memberProvisioning); err != nil {
+		return dtos.MemberProvisioningSetting{}, err
+	}
+
+	return memberProvisioning, nil
+}
@@ -0,0 +1,193 @@
+package services
+
+import (
+	authDomain "better-admin-backend-service/auth/domain"
+	authRepository "better-admin-backend-service/auth/repository"
+	"better-admin-backend-service/errors"
+	memberRepository "better-admin-backend-service/member/repository"
+	"better-admin-backend-service/security"
+	"context"
+)
+
+type TokenRevocationService struct {
+	memberRepository             *memberRepository.MemberRepository
+	refreshTokenRepository       *authRepository.RefreshTokenRepository
+	revokedAccessTokenRepository *authRepository.RevokedAccessTokenRepository
+	sessionRepository            *authRepository.SessionRepository
+}
+
+func NewTokenRevocationService(
+	memberRepository *memberRepository.MemberRepository,
+	refreshTokenRepository *authRepository.RefreshTokenRepository,
+	revokedAccessTokenRepository *authRepository.RevokedAccessTokenRepository,
+	sessionRepository *authRepository.SessionRepository) *TokenRevocationService {
+
+	return &TokenRevocationService{
+		memberRepository:             memberRepository,
+		refreshTokenRepository:       refreshTokenRepository,
+		revokedAccessTokenRepository: revokedAccessTokenRepository,
+		sessionRepository:            sessionRepository,
+	}
+}
+
+// ValidateToken 로그인을 통해 발급된 토큰이 폐기되었는지 확인한다.
+// 토큰이 직접 폐기(로그아웃)되었거나, 세션이 폐기되었거나, 멤버의 토큰 에포크가 변경되었거나, 멤버가 더 이상 승인 상태가 아니면 폐기된 토큰으로 본다.
+// 멤버의 권한 버전이 변경되었으면 AccessTokenPermissionChanged 를 반환한다.(토큰을 갱신하면 변경된 권한으로 다시 발급된다.)
+func (s TokenRevocationService) ValidateToken(ctx context.Context, userClaim security.UserClaim) error {
+	if userClaim.IsIssuedBySignIn() == false {
+		return nil
+	}
+
+	revoked, err := s.revokedAccessTokenRepository.ExistsByJti(ctx, userClaim.Jti)
+	if err != nil {
+		return err
+	}
+
+	if revoked {
+		return security.AccessTokenRevoked
+	}
+
+	if len(userClaim.SessionId) > 0 {
+		sessionEntity, err := s.sessionRepository.FindBySessionId(ctx, userClaim.SessionId)
+		if err != nil {
+			if err == errors.ErrNotFound {
+				return security.AccessTokenRevoked
+			}
+			return err
+		}
+
+		if sessionEntity.IsRevoked() {
+			return security.AccessTokenRevoked
+		}
+	}
+
+	memberEntity, err := s.memberRepository.FindById(ctx, userClaim.Id)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return security.AccessTokenRevoked
+		}
+		return err
+	}
+
+	if memberEntity.IsApproved() == false || memberEntity.IsTokenEpochExpired(userClaim.TokenEpoch) {
+		return security.AccessTokenRevoked
+	}
+
+	// 대리 로그인 토큰은 대리 로그인한 관리자의 토큰이 폐기되었거나 관리자가 더 이상 승인 상태가 아니어도 폐기된 토큰으로 본다.
+	if userClaim.IsImpersonation() {
+		impersonatorEntity, err := s.memberRepository.FindById(ctx, userClaim.Impersonator.Id)
+		if err != nil {
+			if err == errors.ErrNotFound {
+				return security.AccessTokenRevoked
+			}
+			return err
+		}
+
+		if impersonatorEntity.IsApproved() == false || impersonatorEntity.IsTokenEpochExpired(userClaim.Impersonator.TokenEpoch) {
+			return security.AccessTokenRevoked
+		}
+	}
+
+	if memberEntity.IsPermissionVersionExpired(userClaim.PermissionVersion) {
+		return security.AccessTokenPermissionChanged
+	}
+
+	return nil
+}
+
+func (s TokenRevocationService) RevokeAccessToken(ctx context.Context, userClaim security.UserClaim) error {
+	if userClaim.IsIssuedBySignIn() == false {
+		return nil
+	}
+
+	revokedAccessTokenEntity := authDomain.NewRevokedAccessTokenEntity(userClaim)
+	return s.revokedAccessTokenRepository.Create(ctx, &revokedAccessTokenEntity)
+}
+
+func (s TokenRevocationService) RevokeRefreshToken(ctx context.Context, memberId uint, refreshToken string) error {
+	refreshTokenEntity, err := s.refreshTokenRepository.FindByTokenHash(ctx, security.HashToken(refreshToken))
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil
+		}
+		return err
+	}
+
+	if refreshTokenEntity.MemberId != memberId {
+		return nil
+	}
+
+	return s.RevokeRefreshTokenFamily(ctx, refreshTokenEntity.FamilyId)
+}
+
+// RevokeRefreshTokenFamily 토큰 패밀리와 함께 같은 아이디의 세션도 폐기한다.
+func (s TokenRevocationService) RevokeRefreshTokenFamily(ctx context.Context, familyId string) error {
+	refreshTokenEntities, err := s.refreshTokenRepository.FindAllByFamilyId(ctx, familyId)
+	if err != nil {
+		return err
+	}
+
+	for i := range refreshTokenEntities {
+		refreshTokenEntities[i].Revoke()
+		if err := s.refreshTokenRepository.Save(ctx, &refreshTokenEntities[i]); err != nil {
+			return err
+		}
+	}
+
+	sessionEntity, err := s.sessionRepository.FindBySessionId(ctx, familyId)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil
+		}
+		return err
+	}
+
+	sessionEntity.Revoke()
+	return s.sessionRepository.Save(ctx, &sessionEntity)
+}
+
+func (s TokenRevocationService) RevokeAllTokensOfMember(ctx context.Context, memberId uint) error {
+	memberEntity, err := s.memberRepository.FindById(ctx, memberId)
+	if err != nil {
+		return err
+	}
+
+	if err := memberEntity.RevokeAllTokens(ctx); err != nil {
+		return err
+	}
+
+	if err := s.memberRepository.Save(ctx, &memberEntity); err != nil {
+		return err
+	}
+
+	return s.RevokeSessionsOfMember(ctx, memberId)
+}
+
+// RevokeSessionsOfMember 멤버의 모든 리프레시 토큰과 세션을 폐기한다. 액세스 토큰은 멤버의 토큰 에포크로 무효화한다.
+func (s TokenRevocationService) RevokeSessionsOfMember(ctx context.Context, memberId uint) error {
+	refreshTokenEntities, err := s.refreshTokenRepository.FindAllByMemberId(ctx, memberId)
+	if err != nil {
+		return err
+	}
+
+	for i := range refreshTokenEntities {
+		refreshTokenEntities[i].Revoke()
+		if err := s.refreshTokenRepository.Save(ctx, &refreshTokenEntities[i]); err != nil {
+			return err
+		}
+	}
+
+	sessionEntities, err := s.sessionRepository.FindAllActiveByMemberId(ctx, memberId)
+	if err != nil {
+		return err
+	}
+
+	for i := range sessionEntities {
+		sessionEntities[i].Revoke()
+		if err := s.sessionRepository.Save(ctx, &sessionEntities[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,323 @@
+package services
+
+import (
+	authDomain "better-admin-backend-service/auth/domain"
+	authRepository "better-admin-backend-service/auth/repository"
+	"better-admin-backend-service/constants"
+	"better-admin-backend-service/dtos"
+	"better-admin-backend-service/errors"
+	memberDomain "better-admin-backend-service/member/domain"
+	memberRepository "better-admin-backend-service/member/repository"
+	"better-admin-backend-service/security"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/mitchellh/mapstructure"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"time"
+)
+
+type WebAuthnService struct {
+	memberService                *MemberService
+	siteService                  *SiteService
+	webAuthnCredentialRepository *memberRepository.WebAuthnCredentialRepository
+	webAuthnSessionRepository    *authRepository.WebAuthnSessionRepository
+}
+
+func NewWebAuthnService(
+	memberService *MemberService,
+	siteService *SiteService,
+	webAuthnCredentialRepository *memberRepository.WebAuthnCredentialRepository,
+	webAuthnSessionRepository *authRepository.WebAuthnSessionRepository) *WebAuthnService {
+
+	return &WebAuthnService{
+		memberService:                memberService,
+		siteService:                  siteService,
+		webAuthnCredentialRepository: webAuthnCredentialRepository,
+		webAuthnSessionRepository:    webAuthnSessionRepository,
+	}
+}
+
+func (s WebAuthnService) BeginRegistration(ctx context.Context, memberId uint) (dtos.WebAuthnCeremony, error) {
+	webAuthn, err := s.newWebAuthn(ctx)
+	if err != nil {
+		return dtos.WebAuthnCeremony{}, err
+	}
+
+	user, err := s.getWebAuthnUser(ctx, memberId)
+	if err != nil {
+		return dtos.WebAuthnCeremony{}, err
+	}
+
+	// 이미 등록된 패스키는 다시 등록하지 않도록 제외하고, 아이디 입력 없이 로그인 할 수 있도록 discoverable 자격 증명을 요구한다.
+	excludeCredentials := make([]protocol.CredentialDescriptor, 0)
+	for _, credential := range user.credentials {
+		excludeCredentials = append(excludeCredentials, credential.Descriptor())
+	}
+
+	creation, sessionData, err := webAuthn.BeginRegistration(user,
+		webauthn.WithExclusions(excludeCredentials),
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementRequired),
+		webauthn.WithConveyancePreference(protocol.PreferNoAttestation))
+	if err != nil {
+		return dtos.WebAuthnCeremony{}, err
+	}
+
+	sessionId, err := s.saveSession(ctx, memberId, authDomain.WebAuthnCeremonyRegistration, *sessionData)
+	if err != nil {
+		return dtos.WebAuthnCeremony{}, err
+	}
+
+	return dtos.WebAuthnCeremony{
+		SessionId: sessionId,
+		Options:   creation,
+	}, nil
+}
+
+func (s WebAuthnService) FinishRegistration(ctx context.Context, memberId uint, registration dtos.WebAuthnCredentialRegistration) (memberDomain.WebAuthnCredentialEntity, error) {
+	webAuthn, err := s.newWebAuthn(ctx)
+	if err != nil {
+		return memberDomain.WebAuthnCredentialEntity{}, err
+	}
+
+	sessionData, err := s.consumeSession(ctx, registration.SessionId, memberId, authDomain.WebAuthnCeremonyRegistration)
+	if err != nil {
+		return memberDomain.WebAuthnCredentialEntity{}, err
+	}
+
+	user, err := s.getWebAuthnUser(ctx, memberId)
+	if err != nil {
+		return memberDomain.WebAuthnCredentialEntity{}, err
+	}
+
+	parsedResponse, err := protocol.ParseCredentialCreationResponseBody(bytes.NewReader(registration.Credential))
+	if err != nil {
+		log.Error("webauthn registration parse error: ", err)
+		return memberDomain.WebAuthnCredentialEntity{}, errors.ErrInvalidWebAuthnCredential
+	}
+
+	credential, err := webAuthn.CreateCredential(user, sessionData, parsedResponse)
+	if err != nil {
+		log.Error("webauthn registration error: ", err)
+		return memberDomain.WebAuthnCredentialEntity{}, errors.ErrInvalidWebAuthnCredential
+	}
+
+	credentialEntity := memberDomain.NewWebAuthnCredentialEntity(memberId, registration.Name, *credential)
+	if err := s.webAuthnCredentialRepository.Create(ctx, &credentialEntity); err != nil {
+		return memberDomain.WebAuthnCredentialEntity{}, err
+	}
+
+	return credentialEntity, nil
+}
+
+func (s WebAuthnService) BeginLogin(ctx context.Context) (dtos.WebAuthnCeremony, error) {
+	webAuthn, err := s.newWebAuthn(ctx)
+	if err != nil {
+		return dtos.WebAuthnCeremony{}, err
+	}
+
+	// 패스키 로그인은 비밀번호를 대신하므로 사용자 확인(생체 인증, PIN 등)을 필수로 한다.
+	assertion, sessionData, err := webAuthn.BeginDiscoverableLogin(webauthn.WithUserVerification(protocol.VerificationRequired))
+	if err != nil {
+		return dtos.WebAuthnCeremony{}, err
+	}
+
+	sessionId, err := s.saveSession(ctx, 0, authDomain.WebAuthnCeremonyLogin, *sessionData)
+	if err != nil {
+		return dtos.WebAuthnCeremony{}, err
+	}
+
+	return dtos.WebAuthnCeremony{
+		SessionId: sessionId,
+		Options:   assertion,
+	}, nil
+}
+
+// FinishLogin 서명을 검증하고 패스키의 주인인 멤버를 반환한다.
+func (s WebAuthnService) FinishLogin(ctx context.Context, assertion dtos.WebAuthnAssertion) (memberDomain.MemberEntity, error) {
+	webAuthn, err := s.newWebAuthn(ctx)
+	if err != nil {
+		return memberDomain.MemberEntity{}, err
+	}
+
+	sessionData, err := s.consumeSession(ctx, assertion.SessionId, 0, authDomain.WebAuthnCeremonyLogin)
+	if err != nil {
+		return memberDomain.MemberEntity{}, err
+	}
+
+	parsedResponse, err := protocol.ParseCredentialRequestResponseBody(bytes.NewReader(assertion.Credential))
+	if err != nil {
+		log.Error("webauthn assertion parse error: ", err)
+		return memberDomain.MemberEntity{}, errors.ErrInvalidWebAuthnCredential
+	}
+
+	var user *webAuthnUser
+	credential, err := webAuthn.ValidateDiscoverableLogin(func(rawID, userHandle []byte) (webauthn.User, error) {
+		memberId, err := strconv.ParseUint(string(userHandle), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		user, err = s.getWebAuthnUser(ctx, uint(memberId))
+		return user, err
+	}, sessionData, parsedResponse)
+	if err != nil {
+		log.Error("webauthn assertion error: ", err)
+		return memberDomain.MemberEntity{}, errors.ErrInvalidWebAuthnCredential
+	}
+
+	if credential.Authenticator.CloneWarning {
+		// 서명 카운터가 증가하지 않았다면 복제된 인증기일 수 있다.
+		log.Error("webauthn sign count did not increase: member ", user.member.ID)
+		return memberDomain.MemberEntity{}, errors.ErrInvalidWebAuthnCredential
+	}
+
+	credentialEntity, err := s.webAuthnCredentialRepository.FindByCredentialId(ctx, base64.RawURLEncoding.EncodeToString(credential.ID))
+	if err != nil {
+		return memberDomain.MemberEntity{}, err
+	}
+
+	credentialEntity.UpdateUsage(*credential)
+	if err := s.webAuthnCredentialRepository.Save(ctx, &credentialEntity); err != nil {
+		return memberDomain.MemberEntity{}, err
+	}
+
+	return user.member, nil
+}
+
+func (s WebAuthnService) GetCredentials(ctx context.Context, memberId uint) ([]memberDomain.WebAuthnCredentialEntity, error) {
+	return s.webAuthnCredentialRepository.FindAllByMemberId(ctx, memberId)
+}
+
+func (s WebAuthnService) DeleteCredential(ctx context.Context, memberId uint, credentialId uint) error {
+	credentialEntity, err := s.webAuthnCredentialRepository.FindByMemberIdAndId(ctx, memberId, credentialId)
+	if err != nil {
+		return err
+	}
+
+	return s.webAuthnCredentialRepository.Delete(ctx, credentialEntity)
+}
+
+func (s WebAuthnService) newWebAuthn(ctx context.Context) (*webauthn.WebAuthn, error) {
+	webAuthnLoginSetting, err := s.siteService.GetSettingWithKey(ctx, constants.SettingKeyWebAuthnLogin)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return nil, errors.ErrNotSupportedWebAuthn
+		}
+		return nil, err
+	}
+
+	var settings dtos.WebAuthnLoginSetting
+	if err = mapstructure.Decode(webAuthnLoginSetting, &settings); err != nil {
+		return nil, err
+	}
+
+	if settings.Used == nil || *settings.Used == false {
+		return nil, errors.ErrNotSupportedWebAuthn
+	}
+
+	// 세션(챌린지)은 제한 시간 내에만 사용할 수 있다.
+	timeout := webauthn.TimeoutConfig{Enforce: true, Timeout: time.Minute * 5, TimeoutUVD: time.Minute * 5}
+	return webauthn.New(&webauthn.Config{
+		RPID:          settings.RpId,
+		RPDisplayName: settings.RpDisplayName,
+		RPOrigins:     settings.RpOrigins,
+		Timeouts: webauthn.TimeoutsConfig{
+			Login:        timeout,
+			Registration: timeout,
+		},
+	})
+}
+
+func (s WebAuthnService) saveSession(ctx context.Context, memberId uint, ceremony string, sessionData webauthn.SessionData) (string, error) {
+	sessionId, err := security.NewRandomId()
+	if err != nil {
+		return "", err
+	}
+
+	sessionEntity, err := authDomain.NewWebAuthnSessionEntity(sessionId, memberId, ceremony, sessionData)
+	if err != nil {
+		return "", err
+	}
+
+	if err := s.webAuthnSessionRepository.Create(ctx, &sessionEntity); err != nil {
+		return "", err
+	}
+
+	return sessionId, nil
+}
+
+func (s WebAuthnService) consumeSession(ctx context.Context, sessionId string, memberId uint, ceremony string) (webauthn.SessionData, error) {
+	sessionEntity, err := s.webAuthnSessionRepository.FindBySessionId(ctx, sessionId)
+	if err != nil {
+		if err == errors.ErrNotFound {
+			return webauthn.SessionData{}, errors.ErrInvalidWebAuthnSession
+		}
+		return webauthn.SessionData{}, err
+	}
+
+	if err := s.webAuthnSessionRepository.Delete(ctx, sessionEntity); err != nil {
+		return webauthn.SessionData{}, err
+	}
+
+	if sessionEntity.IsExpired() || sessionEntity.MemberId != memberId || sessionEntity.Ceremony != ceremony {
+		return webauthn.SessionData{}, errors.ErrInvalidWebAuthnSession
+	}
+
+	return sessionEntity.GetSessionData()
+}
+
+func (s WebAuthnService) getWebAuthnUser(ctx context.Context, memberId uint) (*webAuthnUser, error) {
+	memberEntity, err := s.memberService.GetMemberById(ctx, memberId)
+	if err != nil {
+		return nil, err
+	}
+
+	credentialEntities, err := s.webAuthnCredentialRepository.FindAllByMemberId(ctx, memberId)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials := make([]webauthn.Credential, 0)
+	for _, credentialEntity := range credentialEntities {
+		credentials = append(credentials, credentialEntity.ToCredential())
+	}
+
+	return &webAuthnUser{
+		member:      memberEntity,
+		credentials: credentials,
+	}, nil
+}
+
+// webAuthnUser webauthn.User 구현체
+type webAuthnUser struct {
+	member      memberDomain.MemberEntity
+	credentials []webauthn.Credential
+}
+
+func (u webAuthnUser) WebAuthnID() []byte {
+	// 사용자 핸들(user handle)에는 개인 정보가 포함되지 않아야 하므로 멤버 아이디를 사용한다.
+	return []byte(strconv.FormatUint(uint64(u.member.ID), 10))
+}
+
+func (u webAuthnUser) WebAuthnName() string {
+	if len(u.member.GetCandidateId()) > 0 {
+		return u.member.GetCandidateId()
+	}
+	return u.member.Name
+}
+
+func (u webAuthnUser) WebAuthnDisplayName() string {
+	return u.member.Name
+}
+
+func (u webAuthnUser) WebAuthnCredentials() []webauthn.Credential {
+	return u.credentials
+}
+
+func (u webAuthnUser) WebAuthnIcon() string {
+	return ""
+}
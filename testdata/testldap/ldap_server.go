@@ -0,0 +1,274 @@
+package testldap
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"math/big"
+	"net"
+	"strings"
+	"time"
+)
+
+const startTlsOid = "1.3.6.1.4.1.1466.20037"
+
+type Entry struct {
+	Dn         string
+	Password   string
+	Attributes map[string][]string
+}
+
+// LdapServer 테스트를 위한 LDAP 서버. 단순 바인드, 검색(and, or, not, 같음, 존재 필터), StartTLS 만 지원한다.
+type LdapServer struct {
+	listener  net.Listener
+	entries   []Entry
+	tlsConfig *tls.Config
+}
+
+func NewLdapServer(entries ...Entry) *LdapServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		panic(err)
+	}
+
+	s := &LdapServer{
+		listener: listener,
+		entries:  entries,
+	}
+
+	go s.serve()
+	return s
+}
+
+func (s *LdapServer) Url() string {
+	return "ldap://" + s.listener.Addr().String()
+}
+
+func (s *LdapServer) Close() {
+	s.listener.Close()
+}
+
+// EnableStartTls 자체 서명 인증서로 StartTLS 를 지원하고 인증서(PEM)를 반환한다.
+func (s *LdapServer) EnableStartTls() string {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "better-admin test ldap"},
+		NotBefore:             time.Now().Add(-time.Minute),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		DNSNames:              []string{"localhost"},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		panic(err)
+	}
+
+	s.tlsConfig = &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: privateKey}},
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func (s *LdapServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+
+		go s.handle(conn)
+	}
+}
+
+func (s *LdapServer) handle(conn net.Conn) {
+	defer func() { conn.Close() }()
+
+	boundDn := ""
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+
+		messageId, _ := packet.Children[0].Value.(int64)
+		request := packet.Children[1]
+
+		switch request.Tag {
+		case ldap.ApplicationBindRequest:
+			dn := stringValue(request.Children[1])
+			password := stringValue(request.Children[2])
+
+			resultCode := ldap.LDAPResultInvalidCredentials
+			if len(password) == 0 {
+				// 인증되지 않은 바인드(RFC 4513 5.1.2)
+				resultCode, boundDn = ldap.LDAPResultSuccess, ""
+			} else if entry, ok := s.findEntry(dn); ok && entry.Password == password {
+				resultCode, boundDn = ldap.LDAPResultSuccess, entry.Dn
+			}
+
+			s.write(conn, newResult(messageId, ldap.ApplicationBindResponse, resultCode))
+		case ldap.ApplicationSearchRequest:
+			if len(boundDn) == 0 {
+				s.write(conn, newResult(messageId, ldap.ApplicationSearchResultDone, ldap.LDAPResultInsufficientAccessRights))
+				continue
+			}
+
+			s.search(conn, messageId, request)
+		case ldap.ApplicationExtendedRequest:
+			if stringValue(request.Children[0]) != startTlsOid || s.tlsConfig == nil {
+				s.write(conn, newResult(messageId, ldap.ApplicationExtendedResponse, ldap.LDAPResultProtocolError))
+				continue
+			}
+
+			s.write(conn, newResult(messageId, ldap.ApplicationExtendedResponse, ldap.LDAPResultSuccess))
+			conn = tls.Server(conn, s.tlsConfig)
+		case ldap.ApplicationUnbindRequest:
+			return
+		default:
+			s.write(conn, newResult(messageId, ldap.ApplicationExtendedResponse, ldap.LDAPResultUnwillingToPerform))
+		}
+	}
+}
+
+func (s *LdapServer) search(conn net.Conn, messageId int64, request *ber.Packet) {
+	baseDn := strings.ToLower(stringValue(request.Children[0]))
+	sizeLimit, _ := request.Children[3].Value.(int64)
+	filter := request.Children[6]
+
+	found := 0
+	for _, entry := range s.entries {
+		if !strings.HasSuffix(strings.ToLower(entry.Dn), baseDn) || !matches(entry, filter) {
+			continue
+		}
+
+		if sizeLimit > 0 && int64(found) >= sizeLimit {
+			s.write(conn, newResult(messageId, ldap.ApplicationSearchResultDone, ldap.LDAPResultSizeLimitExceeded))
+			return
+		}
+
+		s.write(conn, newSearchResultEntry(messageId, entry))
+		found++
+	}
+
+	s.write(conn, newResult(messageId, ldap.ApplicationSearchResultDone, ldap.LDAPResultSuccess))
+}
+
+func (s *LdapServer) findEntry(dn string) (Entry, bool) {
+	for _, entry := range s.entries {
+		if strings.EqualFold(entry.Dn, dn) {
+			return entry, true
+		}
+	}
+
+	return Entry{}, false
+}
+
+func (s *LdapServer) write(conn net.Conn, packet *ber.Packet) {
+	conn.Write(packet.Bytes())
+}
+
+func matches(entry Entry, filter *ber.Packet) bool {
+	switch filter.Tag {
+	case ldap.FilterAnd:
+		for _, child := range filter.Children {
+			if !matches(entry, child) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterOr:
+		for _, child := range filter.Children {
+			if matches(entry, child) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterNot:
+		return !matches(entry, filter.Children[0])
+	case ldap.FilterEqualityMatch:
+		for _, value := range attributeValues(entry, stringValue(filter.Children[0])) {
+			if strings.EqualFold(value, stringValue(filter.Children[1])) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterPresent:
+		return len(attributeValues(entry, stringValue(filter))) > 0
+	default:
+		return false
+	}
+}
+
+func attributeValues(entry Entry, name string) []string {
+	for attributeName, values := range entry.Attributes {
+		if strings.EqualFold(attributeName, name) {
+			return values
+		}
+	}
+
+	return nil
+}
+
+func newResult(messageId int64, tag ber.Tag, resultCode int) *ber.Packet {
+	response := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Response")
+	response.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(resultCode), "Result Code"))
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+
+	return newMessage(messageId, response)
+}
+
+func newSearchResultEntry(messageId int64, entry Entry) *ber.Packet {
+	response := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, entry.Dn, "Object Name"))
+
+	attributes := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+	for name, values := range entry.Attributes {
+		attribute := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+		attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Type"))
+
+		attributeValues := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		for _, value := range values {
+			attributeValues.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, "Value"))
+		}
+
+		attribute.AppendChild(attributeValues)
+		attributes.AppendChild(attribute)
+	}
+	response.AppendChild(attributes)
+
+	return newMessage(messageId, response)
+}
+
+func newMessage(messageId int64, response *ber.Packet) *ber.Packet {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageId, "Message ID"))
+	packet.AppendChild(response)
+
+	return packet
+}
+
+func stringValue(packet *ber.Packet) string {
+	if value, ok := packet.Value.(string); ok {
+		return value
+	}
+
+	return packet.Data.String()
+}
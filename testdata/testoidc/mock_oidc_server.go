@@ -0,0 +1,182 @@
+package testoidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"github.com/golang-jwt/jwt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+)
+
+const keyId = "mock-oidc-key"
+
+// MockOidcServer 테스트를 위한 OpenID Connect IdP. 디스커버리, 토큰(PKCE 검증), JWKS 엔드포인트를 제공한다.
+type MockOidcServer struct {
+	server         *httptest.Server
+	clientId       string
+	privateKey     *rsa.PrivateKey
+	mutex          sync.Mutex
+	authorizations map[string]authorization
+}
+
+type authorization struct {
+	clientId      string
+	redirectUri   string
+	codeChallenge string
+	claims        jwt.MapClaims
+}
+
+func NewMockOidcServer(clientId string) *MockOidcServer {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+
+	s := &MockOidcServer{
+		clientId:       clientId,
+		privateKey:     privateKey,
+		authorizations: map[string]authorization{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", s.handleDiscovery)
+	mux.HandleFunc("/token", s.handleToken)
+	mux.HandleFunc("/jwks", s.handleJwks)
+	s.server = httptest.NewServer(mux)
+
+	return s
+}
+
+func (s *MockOidcServer) Close() {
+	s.server.Close()
+}
+
+func (s *MockOidcServer) Issuer() string {
+	return s.server.URL
+}
+
+// Authorize 사용자가 IdP 에서 로그인하고 동의한 것처럼 인가 코드를 발급한다.
+// claims 는 발급할 id_token 에 담기며, 같은 이름의 표준 클레임(nonce 등)을 덮어쓸 수 있다.
+func (s *MockOidcServer) Authorize(authorizationUri string, claims map[string]any) (code string, state string) {
+	parsedUri, err := url.Parse(authorizationUri)
+	if err != nil {
+		panic(err)
+	}
+
+	query := parsedUri.Query()
+	if query.Get("response_type") != "code" || query.Get("code_challenge_method") != "S256" || query.Get("client_id") != s.clientId {
+		panic("invalid authorization request: " + authorizationUri)
+	}
+
+	idTokenClaims := jwt.MapClaims{
+		"iss":   s.Issuer(),
+		"aud":   s.clientId,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Minute * 5).Unix(),
+		"nonce": query.Get("nonce"),
+	}
+	for name, value := range claims {
+		idTokenClaims[name] = value
+	}
+
+	code = newRandomString()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.authorizations[code] = authorization{
+		clientId:      query.Get("client_id"),
+		redirectUri:   query.Get("redirect_uri"),
+		codeChallenge: query.Get("code_challenge"),
+		claims:        idTokenClaims,
+	}
+
+	return code, query.Get("state")
+}
+
+func (s *MockOidcServer) handleDiscovery(w http.ResponseWriter, _ *http.Request) {
+	writeJson(w, http.StatusOK, map[string]any{
+		"issuer":                                s.Issuer(),
+		"authorization_endpoint":                s.Issuer() + "/authorize",
+		"token_endpoint":                        s.Issuer() + "/token",
+		"jwks_uri":                              s.Issuer() + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (s *MockOidcServer) handleToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJson(w, http.StatusBadRequest, map[string]any{"error": "invalid_request"})
+		return
+	}
+
+	s.mutex.Lock()
+	code := r.PostForm.Get("code")
+	auth, ok := s.authorizations[code]
+	// 인가 코드는 한 번만 사용할 수 있다.
+	delete(s.authorizations, code)
+	s.mutex.Unlock()
+
+	codeVerifierHash := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !ok || auth.clientId != r.PostForm.Get("client_id") || auth.redirectUri != r.PostForm.Get("redirect_uri") ||
+		auth.codeChallenge != base64.RawURLEncoding.EncodeToString(codeVerifierHash[:]) {
+		writeJson(w, http.StatusBadRequest, map[string]any{"error": "invalid_grant"})
+		return
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, auth.claims)
+	token.Header["kid"] = keyId
+	idToken, err := token.SignedString(s.privateKey)
+	if err != nil {
+		panic(err)
+	}
+
+	writeJson(w, http.StatusOK, map[string]any{
+		"access_token": newRandomString(),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     idToken,
+	})
+}
+
+func (s *MockOidcServer) handleJwks(w http.ResponseWriter, _ *http.Request) {
+	writeJson(w, http.StatusOK, map[string]any{
+		"keys": []map[string]any{
+			{
+				"kty": "RSA",
+				"kid": keyId,
+				"use": "sig",
+				"alg": "RS256",
+				"n":   base64.RawURLEncoding.EncodeToString(s.privateKey.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.privateKey.E)).Bytes()),
+			},
+		},
+	})
+}
+
+func writeJson(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		panic(err)
+	}
+}
+
+func newRandomString() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,147 @@
+package testwebauthn
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"github.com/go-webauthn/webauthn/protocol/webauthncbor"
+)
+
+// SoftwareAuthenticator 테스트를 위한 소프트웨어 인증기(ES256, attestation "none")
+type SoftwareAuthenticator struct {
+	rpId         string
+	origin       string
+	privateKey   *ecdsa.PrivateKey
+	credentialId []byte
+	userHandle   []byte
+	signCount    uint32
+}
+
+func NewSoftwareAuthenticator(rpId, origin string) *SoftwareAuthenticator {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+
+	credentialId := make([]byte, 16)
+	if _, err := rand.Read(credentialId); err != nil {
+		panic(err)
+	}
+
+	return &SoftwareAuthenticator{
+		rpId:         rpId,
+		origin:       origin,
+		privateKey:   privateKey,
+		credentialId: credentialId,
+	}
+}
+
+// CreateCredential navigator.credentials.create() 처럼 등록 옵션({"publicKey": {...}})으로 PublicKeyCredential JSON 을 만든다.
+func (a *SoftwareAuthenticator) CreateCredential(options map[string]any) (json.RawMessage, error) {
+	publicKey := options["publicKey"].(map[string]any)
+	userHandle, err := base64.RawURLEncoding.DecodeString(publicKey["user"].(map[string]any)["id"].(string))
+	if err != nil {
+		return nil, err
+	}
+	a.userHandle = userHandle
+
+	clientDataJSON, err := a.clientDataJSON("webauthn.create", publicKey["challenge"].(string))
+	if err != nil {
+		return nil, err
+	}
+
+	coseKey, err := webauthncbor.Marshal(map[int]any{
+		1:  2,  // kty: EC2
+		3:  -7, // alg: ES256
+		-1: 1,  // crv: P-256
+		-2: a.privateKey.X.FillBytes(make([]byte, 32)),
+		-3: a.privateKey.Y.FillBytes(make([]byte, 32)),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// flags: UP(0x01) | UV(0x04) | AT(0x40)
+	authData := a.authenticatorData(0x45)
+	authData = append(authData, make([]byte, 16)...) // AAGUID
+	credentialIdLength := make([]byte, 2)
+	binary.BigEndian.PutUint16(credentialIdLength, uint16(len(a.credentialId)))
+	authData = append(authData, credentialIdLength...)
+	authData = append(authData, a.credentialId...)
+	authData = append(authData, coseKey...)
+
+	attestationObject, err := webauthncbor.Marshal(map[string]any{
+		"fmt":      "none",
+		"attStmt":  map[string]any{},
+		"authData": authData,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return a.publicKeyCredential(map[string]any{
+		"clientDataJSON":    base64.RawURLEncoding.EncodeToString(clientDataJSON),
+		"attestationObject": base64.RawURLEncoding.EncodeToString(attestationObject),
+	})
+}
+
+// GetAssertion navigator.credentials.get() 처럼 로그인 옵션({"publicKey": {...}})에 서명한 PublicKeyCredential JSON 을 만든다.
+func (a *SoftwareAuthenticator) GetAssertion(options map[string]any) (json.RawMessage, error) {
+	publicKey := options["publicKey"].(map[string]any)
+
+	clientDataJSON, err := a.clientDataJSON("webauthn.get", publicKey["challenge"].(string))
+	if err != nil {
+		return nil, err
+	}
+
+	a.signCount++
+	// flags: UP(0x01) | UV(0x04)
+	authData := a.authenticatorData(0x05)
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	digest := sha256.Sum256(append(authData, clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.privateKey, digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	return a.publicKeyCredential(map[string]any{
+		"clientDataJSON":    base64.RawURLEncoding.EncodeToString(clientDataJSON),
+		"authenticatorData": base64.RawURLEncoding.EncodeToString(authData),
+		"signature":         base64.RawURLEncoding.EncodeToString(signature),
+		"userHandle":        base64.RawURLEncoding.EncodeToString(a.userHandle),
+	})
+}
+
+func (a *SoftwareAuthenticator) clientDataJSON(ceremonyType, challenge string) ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"type":        ceremonyType,
+		"challenge":   challenge,
+		"origin":      a.origin,
+		"crossOrigin": false,
+	})
+}
+
+func (a *SoftwareAuthenticator) authenticatorData(flags byte) []byte {
+	rpIdHash := sha256.Sum256([]byte(a.rpId))
+
+	signCount := make([]byte, 4)
+	binary.BigEndian.PutUint32(signCount, a.signCount)
+
+	authData := append(rpIdHash[:], flags)
+	return append(authData, signCount...)
+}
+
+func (a *SoftwareAuthenticator) publicKeyCredential(response map[string]any) (json.RawMessage, error) {
+	credentialId := base64.RawURLEncoding.EncodeToString(a.credentialId)
+	return json.Marshal(map[string]any{
+		"id":       credentialId,
+		"rawId":    credentialId,
+		"type":     "public-key",
+		"response": response,
+	})
+}